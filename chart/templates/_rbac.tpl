@@ -18,6 +18,7 @@
     (not (empty (include "vcluster.rbac.clusterRoleExtraRules" . )))
     (not (empty (include "vcluster.plugin.clusterRoleExtraRules" . )))
     (not (empty (include "vcluster.generic.clusterRoleExtraRules" . )))
+    (not (empty (include "vcluster.customResources.clusterRoleExtraRules" . )))
     .Values.networking.replicateServices.fromHost
     .Values.pro
     .Values.sync.toHost.storageClasses.enabled
@@ -25,15 +26,21 @@
     .Values.sync.toHost.persistentVolumes.enabled
     .Values.sync.toHost.priorityClasses.enabled
     .Values.sync.toHost.volumeSnapshots.enabled
+    .Values.sync.toHost.gatewayAPI.enabled
     .Values.controlPlane.advanced.virtualScheduler.enabled
     .Values.sync.fromHost.ingressClasses.enabled
     (eq (toString .Values.sync.fromHost.storageClasses.enabled) "true")
     (eq (toString .Values.sync.fromHost.csiNodes.enabled) "true")
     (eq (toString .Values.sync.fromHost.csiDrivers.enabled) "true")
     (eq (toString .Values.sync.fromHost.csiStorageCapacities.enabled) "true")
+    .Values.sync.fromHost.resourceClasses.enabled
+    .Values.sync.fromHost.resourceSlices.enabled
     .Values.sync.fromHost.nodes.enabled
+    .Values.sync.fromHost.configMaps.enabled
+    .Values.sync.fromHost.secrets.enabled
     .Values.integrations.kubeVirt.enabled
     (and .Values.integrations.metricsServer.enabled .Values.integrations.metricsServer.nodes)
+    .Values.sync.toHost.namespaces.enabled
     .Values.experimental.multiNamespaceMode.enabled -}}
 {{- true -}}
 {{- end -}}
@@ -133,6 +140,50 @@
 {{- end }}
 {{- end -}}
 
+{{/*
+  Role rules defined by sync.toHost.customResources
+*/}}
+{{- define "vcluster.customResources.roleExtraRules" -}}
+{{- range $key, $customResource := .Values.sync.toHost.customResources }}
+{{- if $customResource.enabled }}
+{{- $groupResource := splitList "." (first (splitList "/" $key)) }}
+- apiGroups: [{{ rest $groupResource | join "." | quote }}]
+  resources: [{{ first $groupResource | quote }}, {{ printf "%s/status" (first $groupResource) | quote }}]
+  verbs: ["create", "delete", "patch", "update", "get", "list", "watch"]
+{{- end }}
+{{- end }}
+{{- end -}}
+
+{{/*
+  Cluster role rules defined by sync.toHost.customResources and sync.fromHost.customResources
+*/}}
+{{- define "vcluster.customResources.clusterRoleExtraRules" -}}
+{{- $enabled := false }}
+{{- range $key, $customResource := .Values.sync.toHost.customResources }}
+{{- if $customResource.enabled }}
+{{- $enabled = true }}
+{{- end }}
+{{- end }}
+{{- range $key, $customResource := .Values.sync.fromHost.customResources }}
+{{- if $customResource.enabled }}
+{{- $enabled = true }}
+{{- $groupResource := splitList "." (first (splitList "/" $key)) }}
+- apiGroups: [{{ rest $groupResource | join "." | quote }}]
+  resources: [{{ first $groupResource | quote }}, {{ printf "%s/status" (first $groupResource) | quote }}]
+  {{- if or $customResource.reversePatches $customResource.conflictRules }}
+  verbs: ["patch", "update", "get", "list", "watch"]
+  {{- else }}
+  verbs: ["get", "list", "watch"]
+  {{- end }}
+{{- end }}
+{{- end }}
+{{- if $enabled }}
+- apiGroups: ["apiextensions.k8s.io"]
+  resources: ["customresourcedefinitions"]
+  verbs: ["get", "list", "watch"]
+{{- end }}
+{{- end -}}
+
 {{/*
   Cluster Role rules defined on global level
 */}}
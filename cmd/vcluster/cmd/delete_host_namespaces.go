@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/vcluster/pkg/lifecycle"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+type DeleteHostNamespacesOptions struct {
+	Name      string
+	Namespace string
+}
+
+func NewDeleteHostNamespacesCommand() *cobra.Command {
+	options := &DeleteHostNamespacesOptions{}
+	cmd := &cobra.Command{
+		Use:   "delete-host-namespaces",
+		Short: "Delete the host namespaces of a multi-namespace mode vCluster before it is uninstalled",
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, _ []string) (err error) {
+			return ExecuteDeleteHostNamespaces(cobraCmd.Context(), options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.Name, "name", os.Getenv("VCLUSTER_NAME"), "The name of the vCluster")
+	cmd.Flags().StringVar(&options.Namespace, "namespace", os.Getenv("POD_NAMESPACE"), "The host namespace of the vCluster")
+	return cmd
+}
+
+func ExecuteDeleteHostNamespaces(ctx context.Context, options *DeleteHostNamespacesOptions) error {
+	if options.Name == "" || options.Namespace == "" {
+		return fmt.Errorf("--name and --namespace are required")
+	}
+
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return fmt.Errorf("get kube config: %w", err)
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("create kubernetes client: %w", err)
+	}
+
+	// the vCluster would recreate the host namespaces of its virtual namespaces, so it is scaled down first. This
+	// doesn't fail the uninstall, as the vCluster might not be running anymore.
+	logger := log.GetInstance()
+	err = lifecycle.PauseVCluster(ctx, kubeClient, options.Name, options.Namespace, logger)
+	if err != nil {
+		logger.Warnf("Error pausing vcluster %s/%s: %v", options.Namespace, options.Name, err)
+	}
+
+	return lifecycle.DeleteMultiNamespaceVClusterNamespaces(ctx, kubeClient, options.Name, options.Namespace, logger)
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/loft-sh/vcluster/pkg/snapshot"
+	"github.com/spf13/cobra"
+)
+
+type RestoreOptions struct {
+	Config string
+
+	Input string
+}
+
+func NewRestoreCommand() *cobra.Command {
+	options := &RestoreOptions{}
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore the vCluster backing store from a snapshot",
+		Long: `Restore the vCluster backing store from a snapshot. The vCluster needs to be paused,
+as the backing store is started by this command. Secrets within the snapshot are not restored.`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, _ []string) (err error) {
+			return ExecuteRestore(cobraCmd.Context(), options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.Config, "config", "/var/vcluster/config.yaml", "The path where to find the vCluster config to load")
+	cmd.Flags().StringVar(&options.Input, "input", "-", "Where to read the snapshot from, either - for stdin, file://path or s3://bucket/key")
+	return cmd
+}
+
+func ExecuteRestore(ctx context.Context, options *RestoreOptions) error {
+	vConfig, _, err := parseSnapshotConfig(options.Config)
+	if err != nil {
+		return err
+	}
+
+	var reader io.Reader = os.Stdin
+	if options.Input != "-" {
+		store, err := snapshot.NewStore(options.Input)
+		if err != nil {
+			return err
+		}
+
+		readCloser, err := store.Reader(ctx)
+		if err != nil {
+			return err
+		}
+		defer readCloser.Close()
+		reader = readCloser
+	}
+
+	// start the backing store of the paused vCluster
+	etcdClient, err := snapshot.StartBackingStore(ctx, vConfig)
+	if err != nil {
+		return fmt.Errorf("start backing store: %w", err)
+	}
+	defer etcdClient.Close()
+
+	return snapshot.Restore(ctx, snapshot.OptionsFromConfig(vConfig), etcdClient, reader)
+}
@@ -57,5 +57,9 @@ func BuildRoot() *cobra.Command {
 	// add top level commands
 	rootCmd.AddCommand(NewStartCommand())
 	rootCmd.AddCommand(NewCpCommand())
+	rootCmd.AddCommand(NewSnapshotCommand())
+	rootCmd.AddCommand(NewRestoreCommand())
+	rootCmd.AddCommand(NewWakeupProxyCommand())
+	rootCmd.AddCommand(NewDeleteHostNamespacesCommand())
 	return rootCmd
 }
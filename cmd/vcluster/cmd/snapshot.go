@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/loft-sh/vcluster/pkg/config"
+	"github.com/loft-sh/vcluster/pkg/etcd"
+	"github.com/loft-sh/vcluster/pkg/pro"
+	"github.com/loft-sh/vcluster/pkg/snapshot"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+type SnapshotOptions struct {
+	Config string
+
+	Output string
+}
+
+func NewSnapshotCommand() *cobra.Command {
+	options := &SnapshotOptions{}
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Snapshot the vCluster backing store and secrets",
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, _ []string) (err error) {
+			return ExecuteSnapshot(cobraCmd.Context(), options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.Config, "config", "/var/vcluster/config.yaml", "The path where to find the vCluster config to load")
+	cmd.Flags().StringVar(&options.Output, "output", "-", "Where to write the snapshot to, either - for stdout, file://path or s3://bucket/key")
+	return cmd
+}
+
+func ExecuteSnapshot(ctx context.Context, options *SnapshotOptions) error {
+	vConfig, kubeClient, err := parseSnapshotConfig(options.Config)
+	if err != nil {
+		return err
+	}
+
+	// connect to the running backing store
+	etcdClient, err := etcd.NewFromConfig(ctx, vConfig)
+	if err != nil {
+		return fmt.Errorf("create etcd client: %w", err)
+	}
+	defer etcdClient.Close()
+
+	snapshotOptions := snapshot.OptionsFromConfig(vConfig)
+	if options.Output == "-" {
+		return snapshot.Create(ctx, snapshotOptions, etcdClient, kubeClient, os.Stdout)
+	}
+
+	store, err := snapshot.NewStore(options.Output)
+	if err != nil {
+		return err
+	}
+
+	return store.Write(ctx, func(w io.Writer) error {
+		return snapshot.Create(ctx, snapshotOptions, etcdClient, kubeClient, w)
+	})
+}
+
+// parseSnapshotConfig parses the vCluster config and returns it together with a client for the control plane namespace
+func parseSnapshotConfig(configPath string) (*config.VirtualClusterConfig, kubernetes.Interface, error) {
+	vConfig, err := config.ParseConfig(configPath, os.Getenv("VCLUSTER_NAME"), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vConfig.ControlPlaneConfig, vConfig.ControlPlaneNamespace, vConfig.ControlPlaneService, vConfig.WorkloadConfig, vConfig.WorkloadNamespace, vConfig.WorkloadService, err = pro.GetRemoteClient(vConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(vConfig.ControlPlaneConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return vConfig, kubeClient, nil
+}
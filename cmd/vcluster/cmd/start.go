@@ -24,6 +24,8 @@ type StartOptions struct {
 	Config string
 
 	SetValues []string
+
+	SyncDryRun bool
 }
 
 func NewStartCommand() *cobra.Command {
@@ -39,6 +41,7 @@ func NewStartCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&startOptions.Config, "config", "/var/vcluster/config.yaml", "The path where to find the vCluster config to load")
+	cmd.Flags().BoolVar(&startOptions.SyncDryRun, "sync-dry-run", false, "If enabled, the syncer will only record the changes it would apply to the host cluster instead of applying them")
 
 	// Should only be used for development
 	cmd.Flags().StringArrayVar(&startOptions.SetValues, "set", []string{}, "Set values for the config. E.g. --set 'exportKubeConfig.secret.name=my-name'")
@@ -52,6 +55,9 @@ func ExecuteStart(ctx context.Context, options *StartOptions) error {
 	if err != nil {
 		return err
 	}
+	if options.SyncDryRun {
+		vConfig.Experimental.SyncSettings.DryRun.Enabled = true
+	}
 
 	// get current namespace
 	vConfig.ControlPlaneConfig, vConfig.ControlPlaneNamespace, vConfig.ControlPlaneService, vConfig.WorkloadConfig, vConfig.WorkloadNamespace, vConfig.WorkloadService, err = pro.GetRemoteClient(vConfig)
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/loft-sh/vcluster/pkg/sleepmode"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+type WakeupProxyOptions struct {
+	Name      string
+	Namespace string
+	Service   string
+
+	Port           int
+	HealthPort     int
+	TargetPort     int
+	Timeout        time.Duration
+	MultiNamespace bool
+}
+
+func NewWakeupProxyCommand() *cobra.Command {
+	options := &WakeupProxyOptions{}
+	cmd := &cobra.Command{
+		Use:   "wakeup-proxy",
+		Short: "Wake up a sleeping vCluster on incoming requests",
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, _ []string) (err error) {
+			return ExecuteWakeupProxy(cobraCmd.Context(), options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.Name, "name", os.Getenv("VCLUSTER_NAME"), "The name of the vCluster to wake up")
+	cmd.Flags().StringVar(&options.Namespace, "namespace", os.Getenv("POD_NAMESPACE"), "The host namespace of the vCluster")
+	cmd.Flags().StringVar(&options.Service, "service", "", "The name of the vCluster service. Defaults to the name of the vCluster")
+	cmd.Flags().IntVar(&options.Port, "port", 8443, "The port to listen on")
+	cmd.Flags().IntVar(&options.HealthPort, "health-port", 8080, "The port to serve the health endpoint on, which doesn't wake up the vCluster")
+	cmd.Flags().IntVar(&options.TargetPort, "target-port", 8443, "The port of the vCluster pods to forward connections to")
+	cmd.Flags().DurationVar(&options.Timeout, "timeout", 5*time.Minute, "How long to wait for the vCluster to become ready")
+	cmd.Flags().BoolVar(&options.MultiNamespace, "multi-namespace", false, "If the workloads of the vCluster are synced to multiple host namespaces")
+	return cmd
+}
+
+func ExecuteWakeupProxy(ctx context.Context, options *WakeupProxyOptions) error {
+	if options.Name == "" || options.Namespace == "" {
+		return fmt.Errorf("--name and --namespace are required")
+	}
+	if options.Service == "" {
+		options.Service = options.Name
+	}
+
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return fmt.Errorf("get kube config: %w", err)
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("create kubernetes client: %w", err)
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", options.Port))
+	if err != nil {
+		return fmt.Errorf("listen on port %d: %w", options.Port, err)
+	}
+
+	// the health endpoint is served separately, as every connection to the proxy port might wake up the vCluster
+	healthServer := &http.Server{
+		Addr:              fmt.Sprintf(":%d", options.HealthPort),
+		ReadHeaderTimeout: 10 * time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	}
+	go func() {
+		<-ctx.Done()
+		_ = healthServer.Close()
+	}()
+	go func() {
+		err := healthServer.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			klog.FromContext(ctx).Error(err, "serve health endpoint")
+		}
+	}()
+
+	proxy := &sleepmode.WakeupProxy{
+		KubeClient:     kubeClient,
+		Name:           options.Name,
+		Namespace:      options.Namespace,
+		Service:        options.Service,
+		TargetPort:     options.TargetPort,
+		Timeout:        options.Timeout,
+		MultiNamespace: options.MultiNamespace,
+	}
+	return proxy.Serve(ctx, listener)
+}
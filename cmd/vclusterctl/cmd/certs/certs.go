@@ -0,0 +1,22 @@
+package certs
+
+import (
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/spf13/cobra"
+)
+
+func NewCertsCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	certsCmd := &cobra.Command{
+		Use:   "certs",
+		Short: "Manage virtual cluster certificates",
+		Long: `#######################################################
+################### vcluster certs ####################
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+	}
+
+	certsCmd.AddCommand(NewCheckCmd(globalFlags))
+	certsCmd.AddCommand(NewRotateCmd(globalFlags))
+	return certsCmd
+}
@@ -0,0 +1,55 @@
+package certs
+
+import (
+	"context"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/vcluster/pkg/cli"
+	"github.com/loft-sh/vcluster/pkg/cli/completion"
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/loft-sh/vcluster/pkg/cli/util"
+	"github.com/spf13/cobra"
+)
+
+// CheckCmd holds the cmd flags
+type CheckCmd struct {
+	*flags.GlobalFlags
+
+	Log log.Logger
+}
+
+// NewCheckCmd creates a new command
+func NewCheckCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &CheckCmd{
+		GlobalFlags: globalFlags,
+		Log:         log.GetInstance(),
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "check" + util.VClusterNameOnlyUseLine,
+		Short: "Shows the expiry of the virtual cluster certificates",
+		Long: `#######################################################
+################ vcluster certs check #################
+#######################################################
+Check prints the certificates of the virtual cluster
+control plane together with their expiry date and shows
+the phase of an ongoing CA rotation.
+
+Example:
+vcluster certs check test
+#######################################################
+	`,
+		Args:              util.VClusterNameOnlyValidator,
+		ValidArgsFunction: completion.NewValidVClusterNameFunc(globalFlags),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+	}
+
+	return cobraCmd
+}
+
+// Run executes the functionality
+func (cmd *CheckCmd) Run(ctx context.Context, args []string) error {
+	return cli.CertsCheckHelm(ctx, cmd.GlobalFlags, args[0], cmd.Log)
+}
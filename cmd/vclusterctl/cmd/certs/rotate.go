@@ -0,0 +1,69 @@
+package certs
+
+import (
+	"context"
+	"time"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/vcluster/pkg/cli"
+	"github.com/loft-sh/vcluster/pkg/cli/completion"
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/loft-sh/vcluster/pkg/cli/util"
+	"github.com/spf13/cobra"
+)
+
+// RotateCmd holds the cmd flags
+type RotateCmd struct {
+	*flags.GlobalFlags
+	cli.CertsRotateOptions
+
+	Log log.Logger
+}
+
+// NewRotateCmd creates a new command
+func NewRotateCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &RotateCmd{
+		GlobalFlags: globalFlags,
+		Log:         log.GetInstance(),
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "rotate" + util.VClusterNameOnlyUseLine,
+		Short: "Renews the virtual cluster certificates",
+		Long: `#######################################################
+################ vcluster certs rotate ################
+#######################################################
+Rotate re-issues the API server, front-proxy, etcd and
+kubelet client certificates from the existing CAs and
+restarts the virtual cluster to load them.
+
+With --ca new CAs are created. The CA rotation runs in
+phases of the given transition period: first the old and
+new CAs are trusted, then certificates are signed by the
+new CAs and finally the old CAs are removed. The virtual
+cluster advances the phases automatically if certificate
+rotation is enabled, otherwise run the command again
+after each transition period. Kube configs need to be
+updated with 'vcluster connect' after the rotation.
+
+Example:
+vcluster certs rotate test
+vcluster certs rotate test --ca --transition-period 48h
+#######################################################
+	`,
+		Args:              util.VClusterNameOnlyValidator,
+		ValidArgsFunction: completion.NewValidVClusterNameFunc(globalFlags),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+	}
+
+	cobraCmd.Flags().BoolVar(&cmd.CA, "ca", false, "If enabled, rotates the certificate authorities as well. Not supported with a deployed etcd")
+	cobraCmd.Flags().DurationVar(&cmd.TransitionPeriod, "transition-period", 24*time.Hour, "The duration of each CA rotation phase in which the old CAs are still trusted")
+	return cobraCmd
+}
+
+// Run executes the functionality
+func (cmd *RotateCmd) Run(ctx context.Context, args []string) error {
+	return cli.CertsRotateHelm(ctx, &cmd.CertsRotateOptions, cmd.GlobalFlags, args[0], cmd.Log)
+}
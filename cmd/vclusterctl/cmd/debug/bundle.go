@@ -0,0 +1,63 @@
+package debug
+
+import (
+	"context"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/vcluster/pkg/cli"
+	"github.com/loft-sh/vcluster/pkg/cli/completion"
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/loft-sh/vcluster/pkg/cli/util"
+	"github.com/spf13/cobra"
+)
+
+// BundleCmd holds the cmd flags
+type BundleCmd struct {
+	*flags.GlobalFlags
+	cli.DebugBundleOptions
+
+	Log log.Logger
+}
+
+// NewBundleCmd creates a new command
+func NewBundleCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &BundleCmd{
+		GlobalFlags: globalFlags,
+		Log:         log.GetInstance(),
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "bundle" + util.VClusterNameOnlyUseLine,
+		Short: "Collects logs, config and state of a virtual cluster into a support archive",
+		Long: `#######################################################
+################ vcluster debug bundle ################
+#######################################################
+Bundle collects the logs of the vCluster, distro and
+coredns containers, the vCluster config, the host objects
+managed by the virtual cluster, the virtual cluster
+objects, the events of both sides and the pprof profiles
+of the syncer if it runs with DEBUG=true into a tar.gz
+archive. Secrets are redacted by default.
+
+Example:
+vcluster debug bundle test --output bundle.tar.gz
+#######################################################
+	`,
+		Args:              util.VClusterNameOnlyValidator,
+		ValidArgsFunction: completion.NewValidVClusterNameFunc(globalFlags),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+	}
+
+	cobraCmd.Flags().StringVarP(&cmd.Output, "output", "o", "", "The file to write the debug bundle to, defaults to vcluster-<name>-debug-<timestamp>.tar.gz")
+	cobraCmd.Flags().BoolVar(&cmd.Redact, "redact", true, "If false, the values of secrets and credentials within the config are included in the debug bundle")
+	cobraCmd.Flags().IntVar(&cmd.ProfileSeconds, "profile-seconds", 10, "The duration of the cpu profile in seconds, 0 to skip it. Profiles are only collected if pprof is enabled for the syncer")
+
+	return cobraCmd
+}
+
+// Run executes the functionality
+func (cmd *BundleCmd) Run(ctx context.Context, args []string) error {
+	return cli.DebugBundleHelm(ctx, &cmd.DebugBundleOptions, cmd.GlobalFlags, args[0], cmd.Log)
+}
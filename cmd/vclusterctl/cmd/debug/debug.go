@@ -0,0 +1,21 @@
+package debug
+
+import (
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/spf13/cobra"
+)
+
+func NewDebugCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	debugCmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Debug virtual clusters",
+		Long: `#######################################################
+################### vcluster debug ####################
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+	}
+
+	debugCmd.AddCommand(NewBundleCmd(globalFlags))
+	return debugCmd
+}
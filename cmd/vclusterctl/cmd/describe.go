@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/vcluster/pkg/cli"
+	"github.com/loft-sh/vcluster/pkg/cli/completion"
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/loft-sh/vcluster/pkg/cli/util"
+	"github.com/spf13/cobra"
+)
+
+// DescribeCmd holds the describe cmd flags
+type DescribeCmd struct {
+	*flags.GlobalFlags
+	cli.DescribeOptions
+
+	Log log.Logger
+}
+
+// NewDescribeCmd creates a new command
+func NewDescribeCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &DescribeCmd{
+		GlobalFlags: globalFlags,
+		Log:         log.GetInstance(),
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "describe" + util.VClusterNameOnlyUseLine,
+		Short: "Shows a detailed health report of a virtual cluster",
+		Long: `#######################################################
+################## vcluster describe ##################
+#######################################################
+Describe shows the effective config and its changes to
+the chart defaults, the distro, Kubernetes version and
+backing store, the status of the control plane pods and
+volumes, the certificate expiry, the number of synced
+objects and the most recent syncer errors and events
+of a virtual cluster.
+
+Example:
+vcluster describe test
+vcluster describe test --namespace test -o json
+#######################################################
+	`,
+		Args:              util.VClusterNameOnlyValidator,
+		ValidArgsFunction: completion.NewValidVClusterNameFunc(globalFlags),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+	}
+
+	cobraCmd.Flags().StringVarP(&cmd.Output, "output", "o", "text", "Choose the format of the output. [text|json|yaml]")
+
+	return cobraCmd
+}
+
+// Run executes the functionality
+func (cmd *DescribeCmd) Run(ctx context.Context, args []string) error {
+	return cli.DescribeHelm(ctx, &cmd.DescribeOptions, cmd.GlobalFlags, args[0], cmd.Log)
+}
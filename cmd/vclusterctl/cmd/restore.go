@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/vcluster/pkg/cli"
+	"github.com/loft-sh/vcluster/pkg/cli/completion"
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/loft-sh/vcluster/pkg/cli/util"
+	"github.com/spf13/cobra"
+)
+
+// RestoreCmd holds the cmd flags
+type RestoreCmd struct {
+	*flags.GlobalFlags
+	cli.RestoreOptions
+
+	Log log.Logger
+}
+
+// NewRestoreCmd creates a new command
+func NewRestoreCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &RestoreCmd{
+		GlobalFlags: globalFlags,
+		Log:         log.GetInstance(),
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "restore" + util.VClusterNameOnlyUseLine,
+		Short: "Restores a virtual cluster from a snapshot",
+		Long: `#######################################################
+################## vcluster restore ###################
+#######################################################
+Restore will pause the virtual cluster, replace its
+backing store data, config secret and certificates with
+the ones from the snapshot and resume it afterwards.
+
+The snapshot needs to be created with 'vcluster snapshot
+create' from a virtual cluster with the same distro.
+
+Example:
+vcluster restore test --from file://./test.snapshot.gz
+vcluster restore test --from s3://my-bucket/test.snapshot.gz
+#######################################################
+	`,
+		Args:              util.VClusterNameOnlyValidator,
+		ValidArgsFunction: completion.NewValidVClusterNameFunc(globalFlags),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&cmd.From, "from", "", "The location to read the snapshot from, either file://path or s3://bucket/key")
+	_ = cobraCmd.MarkFlagRequired("from")
+	return cobraCmd
+}
+
+// Run executes the functionality
+func (cmd *RestoreCmd) Run(ctx context.Context, args []string) error {
+	return cli.RestoreHelm(ctx, &cmd.RestoreOptions, cmd.GlobalFlags, args[0], cmd.Log)
+}
@@ -11,10 +11,13 @@ import (
 	"github.com/mitchellh/go-homedir"
 
 	"github.com/loft-sh/log"
+	"github.com/loft-sh/vcluster/cmd/vclusterctl/cmd/certs"
 	"github.com/loft-sh/vcluster/cmd/vclusterctl/cmd/convert"
 	"github.com/loft-sh/vcluster/cmd/vclusterctl/cmd/credits"
+	"github.com/loft-sh/vcluster/cmd/vclusterctl/cmd/debug"
 	cmdplatform "github.com/loft-sh/vcluster/cmd/vclusterctl/cmd/platform"
 	"github.com/loft-sh/vcluster/cmd/vclusterctl/cmd/platform/set"
+	"github.com/loft-sh/vcluster/cmd/vclusterctl/cmd/snapshot"
 	cmdtelemetry "github.com/loft-sh/vcluster/cmd/vclusterctl/cmd/telemetry"
 	"github.com/loft-sh/vcluster/cmd/vclusterctl/cmd/use"
 	"github.com/loft-sh/vcluster/pkg/cli/completion"
@@ -116,9 +119,14 @@ func BuildRoot(log log.Logger) (*cobra.Command, *flags.GlobalFlags, error) {
 	rootCmd.AddCommand(NewConnectCmd(globalFlags))
 	rootCmd.AddCommand(NewCreateCmd(globalFlags))
 	rootCmd.AddCommand(NewListCmd(globalFlags))
+	rootCmd.AddCommand(NewDescribeCmd(globalFlags))
 	rootCmd.AddCommand(NewDeleteCmd(globalFlags))
 	rootCmd.AddCommand(NewPauseCmd(globalFlags))
 	rootCmd.AddCommand(NewResumeCmd(globalFlags))
+	rootCmd.AddCommand(snapshot.NewSnapshotCmd(globalFlags))
+	rootCmd.AddCommand(NewRestoreCmd(globalFlags))
+	rootCmd.AddCommand(certs.NewCertsCmd(globalFlags))
+	rootCmd.AddCommand(debug.NewDebugCmd(globalFlags))
 	rootCmd.AddCommand(NewDisconnectCmd(globalFlags))
 	rootCmd.AddCommand(NewUpgradeCmd())
 	rootCmd.AddCommand(use.NewUseCmd(globalFlags))
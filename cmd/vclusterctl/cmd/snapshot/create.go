@@ -0,0 +1,65 @@
+package snapshot
+
+import (
+	"context"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/vcluster/pkg/cli"
+	"github.com/loft-sh/vcluster/pkg/cli/completion"
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/loft-sh/vcluster/pkg/cli/util"
+	"github.com/spf13/cobra"
+)
+
+// CreateCmd holds the cmd flags
+type CreateCmd struct {
+	*flags.GlobalFlags
+	cli.SnapshotOptions
+
+	Log log.Logger
+}
+
+// NewCreateCmd creates a new command
+func NewCreateCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &CreateCmd{
+		GlobalFlags: globalFlags,
+		Log:         log.GetInstance(),
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "create" + util.VClusterNameOnlyUseLine,
+		Short: "Creates a snapshot of a virtual cluster",
+		Long: `#######################################################
+############### vcluster snapshot create ##############
+#######################################################
+Create saves the backing store data (embedded etcd,
+deployed etcd or kine/SQLite), the vcluster config secret
+and the certificates of a virtual cluster to a file or
+an S3 compatible object store.
+
+S3 credentials are read from the AWS_ACCESS_KEY_ID,
+AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment
+variables.
+
+Example:
+vcluster snapshot create test --output file://./test.snapshot.gz
+vcluster snapshot create test --output s3://my-bucket/test.snapshot.gz
+vcluster snapshot create test --output "s3://my-bucket/test.snapshot.gz?endpoint=http://localhost:9000"
+#######################################################
+	`,
+		Args:              util.VClusterNameOnlyValidator,
+		ValidArgsFunction: completion.NewValidVClusterNameFunc(globalFlags),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&cmd.Output, "output", "", "The location to write the snapshot to, either file://path or s3://bucket/key")
+	_ = cobraCmd.MarkFlagRequired("output")
+	return cobraCmd
+}
+
+// Run executes the functionality
+func (cmd *CreateCmd) Run(ctx context.Context, args []string) error {
+	return cli.SnapshotHelm(ctx, &cmd.SnapshotOptions, cmd.GlobalFlags, args[0], cmd.Log)
+}
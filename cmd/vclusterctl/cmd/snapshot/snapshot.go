@@ -0,0 +1,21 @@
+package snapshot
+
+import (
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/spf13/cobra"
+)
+
+func NewSnapshotCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	snapshotCmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Manage virtual cluster snapshots",
+		Long: `#######################################################
+################## vcluster snapshot ##################
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+	}
+
+	snapshotCmd.AddCommand(NewCreateCmd(globalFlags))
+	return snapshotCmd
+}
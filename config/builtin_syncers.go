@@ -0,0 +1,88 @@
+package config
+
+import "strings"
+
+// GatewayAPISyncerSuffix is the suffix of the names of the sync.toHost.gatewayAPI syncers, which are named
+// <kind>/<group>/GatewayAPI
+const GatewayAPISyncerSuffix = "/GatewayAPI"
+
+// BuiltInSyncerConfig is the config of a built-in syncer that can be patched or limited by a selector
+type BuiltInSyncerConfig struct {
+	// Name is the name of the syncer controller
+	Name string
+
+	// Path is the path of the syncer config below sync, e.g. toHost.pods
+	Path string
+
+	// ToHost is true if the syncer syncs objects from the virtual cluster to the host cluster
+	ToHost bool
+
+	// SyncPatches are the patches configured for the syncer
+	SyncPatches SyncPatches
+
+	// Selector is the selector configured for the syncer, which is nil for syncers that sync from the host cluster
+	Selector *SyncToHostSelector
+}
+
+// BuiltInSyncers returns the config of all built-in syncers
+func (s Sync) BuiltInSyncers() []BuiltInSyncerConfig {
+	toHost := func(name, path string, syncPatches SyncPatches, selector SyncToHostSelector) BuiltInSyncerConfig {
+		return BuiltInSyncerConfig{Name: name, Path: "toHost." + path, ToHost: true, SyncPatches: syncPatches, Selector: &selector}
+	}
+	fromHost := func(name, path string, syncPatches SyncPatches) BuiltInSyncerConfig {
+		return BuiltInSyncerConfig{Name: name, Path: "fromHost." + path, SyncPatches: syncPatches}
+	}
+
+	return []BuiltInSyncerConfig{
+		toHost("pod", "pods", s.ToHost.Pods.SyncPatches, s.ToHost.Pods.Selector),
+		toHost("secret", "secrets", s.ToHost.Secrets.SyncPatches, s.ToHost.Secrets.Selector),
+		toHost("configmap", "configMaps", s.ToHost.ConfigMaps.SyncPatches, s.ToHost.ConfigMaps.Selector),
+		toHost("ingress", "ingresses", s.ToHost.Ingresses.SyncPatches, s.ToHost.Ingresses.Selector),
+		toHost("service", "services", s.ToHost.Services.SyncPatches, s.ToHost.Services.Selector),
+		toHost("endpoints", "endpoints", s.ToHost.Endpoints.SyncPatches, s.ToHost.Endpoints.Selector),
+		toHost("endpointslices", "endpointSlices", s.ToHost.EndpointSlices.SyncPatches, s.ToHost.EndpointSlices.Selector),
+		toHost("networkpolicy", "networkPolicies", s.ToHost.NetworkPolicies.SyncPatches, s.ToHost.NetworkPolicies.Selector),
+		toHost("persistent-volume-claim", "persistentVolumeClaims", s.ToHost.PersistentVolumeClaims.SyncPatches, s.ToHost.PersistentVolumeClaims.Selector),
+		toHost("persistentvolume", "persistentVolumes", s.ToHost.PersistentVolumes.SyncPatches, s.ToHost.PersistentVolumes.Selector),
+		toHost("volume-snapshot", "volumeSnapshots", s.ToHost.VolumeSnapshots.SyncPatches, s.ToHost.VolumeSnapshots.Selector),
+		toHost("storageclass", "storageClasses", s.ToHost.StorageClasses.SyncPatches, s.ToHost.StorageClasses.Selector),
+		toHost("serviceaccount", "serviceAccounts", s.ToHost.ServiceAccounts.SyncPatches, s.ToHost.ServiceAccounts.Selector),
+		toHost("podDisruptionBudget", "podDisruptionBudgets", s.ToHost.PodDisruptionBudgets.SyncPatches, s.ToHost.PodDisruptionBudgets.Selector),
+		toHost("priorityclass", "priorityClasses", s.ToHost.PriorityClasses.SyncPatches, s.ToHost.PriorityClasses.Selector),
+		toHost("resourceclaim", "resourceClaims", s.ToHost.ResourceClaims.SyncPatches, s.ToHost.ResourceClaims.Selector),
+		toHost("resourceclaimtemplate", "resourceClaimTemplates", s.ToHost.ResourceClaimTemplates.SyncPatches, s.ToHost.ResourceClaimTemplates.Selector),
+		toHost(GatewayAPISyncerSuffix, "gatewayAPI", s.ToHost.GatewayAPI.SyncPatches, s.ToHost.GatewayAPI.Selector),
+		toHost("namespace", "namespaces", s.ToHost.Namespaces.SyncPatches, s.ToHost.Namespaces.Selector),
+		fromHost("node", "nodes", s.FromHost.Nodes.SyncPatches),
+		fromHost("event", "events", s.FromHost.Events.SyncPatches),
+		fromHost("ingressclass", "ingressClasses", s.FromHost.IngressClasses.SyncPatches),
+		fromHost("host-storageclass", "storageClasses", s.FromHost.StorageClasses.SyncPatches),
+		fromHost("csinode", "csiNodes", s.FromHost.CSINodes.SyncPatches),
+		fromHost("csidriver", "csiDrivers", s.FromHost.CSIDrivers.SyncPatches),
+		fromHost("csistoragecapacity", "csiStorageCapacities", s.FromHost.CSIStorageCapacities.SyncPatches),
+		fromHost("resourceclass", "resourceClasses", s.FromHost.ResourceClasses.SyncPatches),
+		fromHost("resourceslice", "resourceSlices", s.FromHost.ResourceSlices.SyncPatches),
+		fromHost("host-configmap", "configMaps", s.FromHost.ConfigMaps.SyncPatches),
+		fromHost("host-secret", "secrets", s.FromHost.Secrets.SyncPatches),
+	}
+}
+
+// BuiltInSyncer returns the config of the built-in syncer with the given name
+func (s Sync) BuiltInSyncer(name string) (BuiltInSyncerConfig, bool) {
+	if strings.HasSuffix(name, GatewayAPISyncerSuffix) {
+		name = GatewayAPISyncerSuffix
+	}
+
+	for _, syncer := range s.BuiltInSyncers() {
+		if syncer.Name == name {
+			return syncer, true
+		}
+	}
+
+	return BuiltInSyncerConfig{}, false
+}
+
+// IsEmpty returns true if the selector doesn't limit which objects are synced
+func (s SyncToHostSelector) IsEmpty() bool {
+	return len(s.LabelSelector) == 0 && len(s.MatchExpressions) == 0 && len(s.Namespaces.Include) == 0 && len(s.Namespaces.Exclude) == 0
+}
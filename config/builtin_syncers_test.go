@@ -0,0 +1,41 @@
+package config
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestSync_BuiltInSyncer(t *testing.T) {
+	sync := Sync{}
+	sync.ToHost.Secrets.Selector.LabelSelector = map[string]string{"team": "a"}
+	sync.ToHost.GatewayAPI.Selector.LabelSelector = map[string]string{"expose": "true"}
+	sync.FromHost.Secrets.Patches = []*Patch{{Path: "metadata.labels.team"}}
+
+	secret, ok := sync.BuiltInSyncer("secret")
+	assert.Assert(t, ok)
+	assert.Equal(t, secret.Path, "toHost.secrets")
+	assert.Assert(t, secret.ToHost)
+	assert.Equal(t, secret.Selector.LabelSelector["team"], "a")
+
+	hostSecret, ok := sync.BuiltInSyncer("host-secret")
+	assert.Assert(t, ok)
+	assert.Equal(t, hostSecret.Path, "fromHost.secrets")
+	assert.Assert(t, !hostSecret.ToHost && hostSecret.Selector == nil)
+	assert.Equal(t, len(hostSecret.SyncPatches.Patches), 1)
+
+	// all gateway api syncers share the same config
+	httpRoute, ok := sync.BuiltInSyncer("HTTPRoute/gateway.networking.k8s.io" + GatewayAPISyncerSuffix)
+	assert.Assert(t, ok)
+	assert.Equal(t, httpRoute.Path, "toHost.gatewayAPI")
+	assert.Equal(t, httpRoute.Selector.LabelSelector["expose"], "true")
+
+	_, ok = sync.BuiltInSyncer("unknown")
+	assert.Assert(t, !ok)
+
+	names := map[string]bool{}
+	for _, syncer := range sync.BuiltInSyncers() {
+		assert.Assert(t, !names[syncer.Name], syncer.Name)
+		names[syncer.Name] = true
+	}
+}
@@ -57,6 +57,9 @@ type Config struct {
 	// Define which vCluster plugins to load.
 	Plugins map[string]Plugins `json:"plugins,omitempty"`
 
+	// SleepMode puts the virtual cluster to sleep when it is not used and wakes it up again on the next request.
+	SleepMode SleepMode `json:"sleepMode,omitempty"`
+
 	// Experimental features for vCluster. Configuration here might change, so be careful with this.
 	Experimental Experimental `json:"experimental,omitempty"`
 
@@ -126,6 +129,23 @@ type MetricsServer struct {
 
 	// Pods defines if metrics-server pods api should get proxied from host to virtual cluster.
 	Pods bool `json:"pods,omitempty"`
+
+	// CustomMetrics defines if the custom metrics api (custom.metrics.k8s.io) of a host metrics adapter such as
+	// prometheus-adapter should get proxied from host to virtual cluster.
+	CustomMetrics MetricsAdapter `json:"customMetrics,omitempty"`
+
+	// ExternalMetrics defines if the external metrics api (external.metrics.k8s.io) of a host metrics adapter such as
+	// prometheus-adapter should get proxied from host to virtual cluster.
+	ExternalMetrics MetricsAdapter `json:"externalMetrics,omitempty"`
+}
+
+// MetricsAdapter reuses a metrics adapter from the host cluster within the vCluster.
+type MetricsAdapter struct {
+	// Enabled signals the metrics api should get proxied from host to virtual cluster.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// APIService holds information about where to find the metrics adapter service. Defaults to prometheus-adapter/monitoring.
+	APIService APIService `json:"apiService,omitempty"`
 }
 
 // APIService holds configuration related to the api server
@@ -352,48 +372,214 @@ type SyncToHost struct {
 	ConfigMaps SyncAllResource `json:"configMaps,omitempty"`
 
 	// Ingresses defines if ingresses created within the virtual cluster should get synced to the host cluster.
-	Ingresses EnableSwitch `json:"ingresses,omitempty"`
+	Ingresses SyncToHostResource `json:"ingresses,omitempty"`
 
 	// Services defines if services created within the virtual cluster should get synced to the host cluster.
-	Services EnableSwitch `json:"services,omitempty"`
+	Services SyncToHostResource `json:"services,omitempty"`
 
 	// Endpoints defines if endpoints created within the virtual cluster should get synced to the host cluster.
-	Endpoints EnableSwitch `json:"endpoints,omitempty"`
+	Endpoints SyncToHostResource `json:"endpoints,omitempty"`
+
+	// EndpointSlices defines if endpoint slices of services without a selector created within the virtual cluster should get synced to the host cluster.
+	// In contrast to endpoints, endpoint slices are not truncated at 1000 addresses and keep topology hints as well as dual-stack address types.
+	// Synced endpoints are excluded from endpoint slice mirroring in the host cluster, disable sync.toHost.endpoints to only sync endpoint slices.
+	EndpointSlices SyncToHostResource `json:"endpointSlices,omitempty"`
 
 	// NetworkPolicies defines if network policies created within the virtual cluster should get synced to the host cluster.
-	NetworkPolicies EnableSwitch `json:"networkPolicies,omitempty"`
+	NetworkPolicies SyncToHostResource `json:"networkPolicies,omitempty"`
 
 	// PersistentVolumeClaims defines if persistent volume claims created within the virtual cluster should get synced to the host cluster.
-	PersistentVolumeClaims EnableSwitch `json:"persistentVolumeClaims,omitempty"`
+	PersistentVolumeClaims SyncToHostResource `json:"persistentVolumeClaims,omitempty"`
 
 	// PersistentVolumes defines if persistent volumes created within the virtual cluster should get synced to the host cluster.
-	PersistentVolumes EnableSwitch `json:"persistentVolumes,omitempty"`
+	PersistentVolumes SyncToHostResource `json:"persistentVolumes,omitempty"`
 
 	// VolumeSnapshots defines if volume snapshots created within the virtual cluster should get synced to the host cluster.
-	VolumeSnapshots EnableSwitch `json:"volumeSnapshots,omitempty"`
+	VolumeSnapshots SyncToHostResource `json:"volumeSnapshots,omitempty"`
 
 	// StorageClasses defines if storage classes created within the virtual cluster should get synced to the host cluster.
-	StorageClasses EnableSwitch `json:"storageClasses,omitempty"`
+	StorageClasses SyncToHostResource `json:"storageClasses,omitempty"`
 
 	// ServiceAccounts defines if service accounts created within the virtual cluster should get synced to the host cluster.
-	ServiceAccounts EnableSwitch `json:"serviceAccounts,omitempty"`
+	ServiceAccounts SyncToHostResource `json:"serviceAccounts,omitempty"`
 
 	// PodDisruptionBudgets defines if pod disruption budgets created within the virtual cluster should get synced to the host cluster.
-	PodDisruptionBudgets EnableSwitch `json:"podDisruptionBudgets,omitempty"`
+	PodDisruptionBudgets SyncToHostResource `json:"podDisruptionBudgets,omitempty"`
 
 	// PriorityClasses defines if priority classes created within the virtual cluster should get synced to the host cluster.
-	PriorityClasses EnableSwitch `json:"priorityClasses,omitempty"`
+	PriorityClasses SyncToHostResource `json:"priorityClasses,omitempty"`
+
+	// ResourceClaims defines if dynamic resource allocation claims created within the virtual cluster should get synced to the host cluster.
+	// Requires the resource.k8s.io/v1alpha2 API to be enabled in the host and virtual cluster.
+	ResourceClaims SyncToHostResource `json:"resourceClaims,omitempty"`
+
+	// ResourceClaimTemplates defines if resource claim templates created within the virtual cluster should get synced to the host cluster.
+	// Claims for pods that use a template are then generated in the host cluster.
+	ResourceClaimTemplates SyncToHostResource `json:"resourceClaimTemplates,omitempty"`
+
+	// GatewayAPI defines if Gateway API routes and reference grants created within the virtual cluster should get synced to the host cluster.
+	GatewayAPI SyncToHostGatewayAPI `json:"gatewayAPI,omitempty"`
+
+	// Namespaces defines if each virtual namespace should get synced to its own namespace in the host cluster (multi-namespace mode).
+	// Namespaced objects keep their names in the host cluster. This cannot be changed after the vCluster was created.
+	Namespaces SyncToHostNamespaces `json:"namespaces,omitempty"`
+
+	// CustomResources defines what custom resources should get synced from the virtual cluster to the host cluster. The key
+	// is the resource in the form resource.group/version, e.g. certificates.cert-manager.io/v1. vCluster will copy the definition
+	// automatically from the host cluster to the virtual cluster on startup.
+	CustomResources map[string]SyncToHostCustomResource `json:"customResources,omitempty"`
+}
+
+type SyncToHostGatewayAPI struct {
+	// Enabled defines if HTTPRoutes, GRPCRoutes, TLSRoutes and ReferenceGrants should get synced to the host cluster.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// AllowedGateways are the host gateways in the form namespace/name that virtual routes are allowed to attach to.
+	// Parent references to other gateways are removed from the host route.
+	AllowedGateways []string `json:"allowedGateways,omitempty"`
+
+	// Selector defines what virtual routes and reference grants should get synced to the host cluster. If empty, all objects are synced.
+	Selector SyncToHostSelector `json:"selector,omitempty"`
+
+	SyncPatches `json:",inline"`
+}
+
+type SyncToHostNamespaces struct {
+	// Enabled defines if virtual namespaces should get synced to their own host namespaces.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// NameTemplate is a Go template for the name of the host namespace. Available fields are .Name, which holds the name of the
+	// virtual namespace, .VClusterName and .VClusterNamespace. The template must use .Name exactly once together with a prefix or suffix
+	// and must use .VClusterName, e.g. "{{ .VClusterName }}-{{ .Name }}". Names longer than 63 characters are shortened and hashed. Defaults to vcluster-<hash>-<hash>.
+	// The template cannot be changed after the vCluster was created.
+	NameTemplate string `json:"nameTemplate,omitempty"`
+
+	// ExtraLabels are extra labels that will be added by vCluster to each created host namespace.
+	ExtraLabels map[string]string `json:"extraLabels,omitempty"`
+
+	// ResourceQuota is a resource quota that vCluster creates in each host namespace.
+	ResourceQuota NamespaceObjectTemplate `json:"resourceQuota,omitempty"`
+
+	// LimitRange is a limit range that vCluster creates in each host namespace.
+	LimitRange NamespaceObjectTemplate `json:"limitRange,omitempty"`
+
+	// NetworkPolicy is a network policy that vCluster creates in each host namespace.
+	NetworkPolicy NamespaceObjectTemplate `json:"networkPolicy,omitempty"`
+
+	// Selector defines what virtual namespaces should get synced to the host cluster. The namespace lists match the
+	// name of the virtual namespace. If empty, all namespaces are synced.
+	Selector SyncToHostSelector `json:"selector,omitempty"`
+
+	SyncPatches `json:",inline"`
+}
+
+// NamespaceObjectTemplate is an object vCluster creates and keeps up to date in each host namespace.
+type NamespaceObjectTemplate struct {
+	// Enabled defines if the object should get created.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Spec is the spec of the object, e.g. a ResourceQuotaSpec for resource quotas.
+	Spec map[string]interface{} `json:"spec,omitempty"`
+
+	LabelsAndAnnotations `json:",inline"`
 }
 
+type SyncToHostCustomResource struct {
+	// Enabled defines if this option should be enabled.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Optional defines if vCluster should skip this resource instead of failing if it cannot be found in the host cluster.
+	Optional bool `json:"optional,omitempty"`
+
+	// Selector defines what virtual objects should get synced to the host cluster. If empty, all objects are synced.
+	Selector CustomResourceSelector `json:"selector,omitempty"`
+
+	// Patches are the patches to apply on the host object when syncing it from the virtual cluster.
+	Patches []*Patch `json:"patches,omitempty"`
+
+	// ReversePatches are the patches to apply on the virtual object when syncing changes back from the host cluster.
+	ReversePatches []*Patch `json:"reversePatches,omitempty"`
+
+	// StatusSync defines how the status is synced. Can be either HostToVirtual (default), where the status of the
+	// host object is written back to the virtual object, or Disabled.
+	StatusSync StatusSyncMode `json:"statusSync,omitempty"`
+
+	// ConflictRules define which side owns a field if it is changed in the virtual and host cluster. By default,
+	// all fields besides the status are owned by the virtual object.
+	ConflictRules []ConflictRule `json:"conflictRules,omitempty"`
+
+	// ReplaceOnConflict defines if vCluster should recreate the host object if it cannot be updated.
+	ReplaceOnConflict bool `json:"replaceOnConflict,omitempty"`
+}
+
+type SyncFromHostCustomResource struct {
+	// Enabled defines if this option should be enabled.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Optional defines if vCluster should skip this resource instead of failing if it cannot be found in the host cluster.
+	Optional bool `json:"optional,omitempty"`
+
+	// Selector defines what host objects should get synced to the virtual cluster. If empty, all objects are synced.
+	Selector CustomResourceSelector `json:"selector,omitempty"`
+
+	// Mappings defines what host objects should get synced to which virtual namespace and name. Mappings are required for
+	// namespaced resources, cluster scoped resources are synced with the same name.
+	Mappings FromHostMappings `json:"mappings,omitempty"`
+
+	// Patches are the patches to apply on the virtual object when syncing it from the host cluster.
+	Patches []*Patch `json:"patches,omitempty"`
+
+	// ReversePatches are the patches to apply on the host object when syncing changes back from the virtual cluster.
+	ReversePatches []*Patch `json:"reversePatches,omitempty"`
+
+	// StatusSync defines how the status is synced. Can be either HostToVirtual (default), where the status of the
+	// host object is copied to the virtual object, or Disabled.
+	StatusSync StatusSyncMode `json:"statusSync,omitempty"`
+
+	// ConflictRules define which side owns a field if it is changed in the virtual and host cluster. By default,
+	// all fields are owned by the host object.
+	ConflictRules []ConflictRule `json:"conflictRules,omitempty"`
+
+	// ReplaceOnConflict defines if vCluster should recreate the virtual object if it cannot be updated.
+	ReplaceOnConflict bool `json:"replaceOnConflict,omitempty"`
+}
+
+type CustomResourceSelector struct {
+	// LabelSelector are the labels an object needs to have to get synced.
+	LabelSelector map[string]string `json:"labelSelector,omitempty"`
+}
+
+type StatusSyncMode string
+
+const (
+	StatusSyncModeHostToVirtual StatusSyncMode = "HostToVirtual"
+	StatusSyncModeDisabled      StatusSyncMode = "Disabled"
+)
+
+type ConflictRule struct {
+	// Path is the path of the field within the object, e.g. spec.replicas
+	Path string `json:"path,omitempty"`
+
+	// Owner defines which side wins if the field differs. Can be either Virtual or Host.
+	Owner ConflictRuleOwner `json:"owner,omitempty"`
+}
+
+type ConflictRuleOwner string
+
+const (
+	ConflictRuleOwnerVirtual ConflictRuleOwner = "Virtual"
+	ConflictRuleOwnerHost    ConflictRuleOwner = "Host"
+)
+
 type SyncFromHost struct {
 	// Nodes defines if nodes should get synced from the host cluster to the virtual cluster, but not back.
 	Nodes SyncNodes `json:"nodes,omitempty"`
 
 	// Events defines if events should get synced from the host cluster to the virtual cluster, but not back.
-	Events EnableSwitch `json:"events,omitempty"`
+	Events EnableSwitchWithPatches `json:"events,omitempty"`
 
 	// IngressClasses defines if ingress classes should get synced from the host cluster to the virtual cluster, but not back.
-	IngressClasses EnableSwitch `json:"ingressClasses,omitempty"`
+	IngressClasses EnableSwitchWithPatches `json:"ingressClasses,omitempty"`
 
 	// StorageClasses defines if storage classes should get synced from the host cluster to the virtual cluster, but not back. If auto, is automatically enabled when the virtual scheduler is enabled.
 	StorageClasses EnableAutoSwitch `json:"storageClasses,omitempty"`
@@ -406,11 +592,50 @@ type SyncFromHost struct {
 
 	// CSIStorageCapacities defines if csi storage capacities should get synced from the host cluster to the virtual cluster, but not back. If auto, is automatically enabled when the virtual scheduler is enabled.
 	CSIStorageCapacities EnableAutoSwitch `json:"csiStorageCapacities,omitempty"`
+
+	// ResourceClasses defines if dynamic resource allocation classes should get synced from the host cluster to the virtual cluster, but not back.
+	// Resource classes are the device classes of the resource.k8s.io/v1alpha2 API and select the driver for resource claims.
+	ResourceClasses EnableSwitchWithPatches `json:"resourceClasses,omitempty"`
+
+	// ResourceSlices defines if resource slices published by dynamic resource allocation drivers should get synced from the host cluster to the virtual cluster, but not back.
+	ResourceSlices EnableSwitchWithPatches `json:"resourceSlices,omitempty"`
+
+	// ConfigMaps defines if config maps in the host should get synced to the virtual cluster. Synced config maps are not synced back to the host
+	// cluster and changes to them within the virtual cluster are reverted on the next sync.
+	ConfigMaps SyncFromHostResource `json:"configMaps,omitempty"`
+
+	// Secrets defines if secrets in the host should get synced to the virtual cluster. Synced secrets are not synced back to the host
+	// cluster and changes to them within the virtual cluster are reverted on the next sync.
+	Secrets SyncFromHostResource `json:"secrets,omitempty"`
+
+	// CustomResources defines what custom resources should get synced from the host cluster to the virtual cluster. The key
+	// is the resource in the form resource.group/version, e.g. certificates.cert-manager.io/v1. vCluster will copy the definition
+	// automatically from the host cluster to the virtual cluster on startup.
+	CustomResources map[string]SyncFromHostCustomResource `json:"customResources,omitempty"`
+}
+
+type SyncFromHostResource struct {
+	// Enabled defines if this option should be enabled.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Mappings defines what host objects should get synced to which virtual namespace and name.
+	Mappings FromHostMappings `json:"mappings,omitempty"`
+
+	SyncPatches `json:",inline"`
+}
+
+type FromHostMappings struct {
+	// ByName is a map of host-namespace/host-name to virtual-namespace/virtual-name. The host name can be a
+	// wildcard ("*") to sync all objects of the host namespace, in which case the virtual side needs to be a
+	// wildcard as well, e.g. "platform/*": "shared/*". Host namespaces used here need to be accessible by vCluster.
+	ByName map[string]string `json:"byName,omitempty"`
 }
 
 type EnableAutoSwitch struct {
 	// Enabled defines if this option should be enabled.
 	Enabled StrBool `json:"enabled,omitempty" jsonschema:"oneof_type=string;boolean"`
+
+	SyncPatches `json:",inline"`
 }
 
 type EnableSwitch struct {
@@ -418,12 +643,68 @@ type EnableSwitch struct {
 	Enabled bool `json:"enabled,omitempty"`
 }
 
+type EnableSwitchWithPatches struct {
+	// Enabled defines if this option should be enabled.
+	Enabled bool `json:"enabled,omitempty"`
+
+	SyncPatches `json:",inline"`
+}
+
+type SyncToHostResource struct {
+	// Enabled defines if this option should be enabled.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Selector defines what virtual objects should get synced to the host cluster. If empty, all objects are synced.
+	Selector SyncToHostSelector `json:"selector,omitempty"`
+
+	SyncPatches `json:",inline"`
+}
+
+// SyncToHostSelector selects the virtual objects of a built-in syncer that are synced to the host cluster. Host objects
+// of virtual objects that stop matching the selector are deleted.
+type SyncToHostSelector struct {
+	// LabelSelector are the labels a virtual object needs to have to get synced.
+	LabelSelector map[string]string `json:"labelSelector,omitempty"`
+
+	// MatchExpressions are label requirements a virtual object needs to fulfill to get synced, e.g. a DoesNotExist
+	// requirement for the key vcluster.loft.sh/skip-sync.
+	MatchExpressions []LabelSelectorRequirement `json:"matchExpressions,omitempty"`
+
+	// Namespaces defines from which virtual namespaces objects are synced. Cluster scoped objects are not
+	// filtered by namespace.
+	Namespaces SyncToHostSelectorNamespaces `json:"namespaces,omitempty"`
+}
+
+type SyncToHostSelectorNamespaces struct {
+	// Include are the virtual namespaces whose objects are synced. If empty, objects of all namespaces are synced.
+	Include []string `json:"include,omitempty"`
+
+	// Exclude are the virtual namespaces whose objects are never synced. Exclude takes precedence over include.
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// SyncPatches are patches that are applied to the objects of a built-in syncer after the built-in translation.
+type SyncPatches struct {
+	// Patches are the patches to apply on the synced object after the built-in translation. For sync.toHost this is
+	// the host object and for sync.fromHost the virtual object.
+	Patches []*Patch `json:"patches,omitempty"`
+
+	// ReversePatches are the patches to apply on the originating object when syncing changes back. For sync.toHost this
+	// is the virtual object and for sync.fromHost the host object.
+	ReversePatches []*Patch `json:"reversePatches,omitempty"`
+}
+
 type SyncAllResource struct {
 	// Enabled defines if this option should be enabled.
 	Enabled bool `json:"enabled,omitempty"`
 
 	// All defines if all resources of that type should get synced or only the necessary ones that are needed.
 	All bool `json:"all,omitempty"`
+
+	// Selector defines what virtual objects should get synced to the host cluster. If empty, all objects are synced.
+	Selector SyncToHostSelector `json:"selector,omitempty"`
+
+	SyncPatches `json:",inline"`
 }
 
 type SyncPods struct {
@@ -434,6 +715,11 @@ type SyncPods struct {
 	// a certain image that is used within the virtual cluster to be another image on the host cluster
 	TranslateImage map[string]string `json:"translateImage,omitempty"`
 
+	// ImageTranslation defines rules to rewrite the images of synced pods, e.g. to pull them through a registry mirror, and
+	// if image tags should be pinned to digests. Exact matches in translateImage take precedence over the rules. The original
+	// images are recorded in the vcluster.loft.sh/original-images annotation of the host pod.
+	ImageTranslation SyncPodsImageTranslation `json:"imageTranslation,omitempty"`
+
 	// EnforceTolerations will add the specified tolerations to all pods synced by the virtual cluster.
 	EnforceTolerations []string `json:"enforceTolerations,omitempty"`
 
@@ -445,6 +731,50 @@ type SyncPods struct {
 	// a small container to each stateful set pod that will initially rewrite the /etc/hosts file to match the FQDN expected by
 	// the virtual cluster.
 	RewriteHosts SyncRewriteHosts `json:"rewriteHosts,omitempty"`
+
+	// Selector defines what virtual pods should get synced to the host cluster. If empty, all pods are synced.
+	Selector SyncToHostSelector `json:"selector,omitempty"`
+
+	SyncPatches `json:",inline"`
+}
+
+type SyncPodsImageTranslation struct {
+	// Rules rewrite images that match a pattern. Patterns are matched against the image as written and against its fully
+	// qualified form, e.g. docker.io/library/nginx:1.25 for nginx:1.25. Each * in a pattern matches any characters and is
+	// inserted for the corresponding * in the replacement, so a pattern ending with * rewrites by prefix. The first matching
+	// rule is applied.
+	Rules []ImageRewriteRule `json:"rules,omitempty"`
+
+	// RegistryMirrors maps registry hosts to the mirrors that should be used instead, e.g. docker.io to
+	// harbor.example.com/docker-hub. Mirrors are applied after translateImage and the rules.
+	RegistryMirrors map[string]string `json:"registryMirrors,omitempty"`
+
+	// ResolveDigests defines if image tags should be resolved to digests.
+	ResolveDigests ImageDigestResolution `json:"resolveDigests,omitempty"`
+}
+
+type ImageRewriteRule struct {
+	// From is the image pattern, e.g. docker.io/*.
+	From string `json:"from,omitempty"`
+
+	// To is the image the matching images are rewritten to, e.g. cache.example.com/docker/*.
+	To string `json:"to,omitempty"`
+}
+
+type ImageDigestResolution struct {
+	// Enabled defines if vCluster should pin image tags to the digest they currently point to, e.g. nginx:1.25 becomes
+	// nginx:1.25@sha256:.... Only images of the registry mirrors, the rule targets and the registries below are resolved.
+	// Registries are queried anonymously and images that cannot be resolved keep their tag.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Registries are additional registry hosts whose image tags are resolved, e.g. ghcr.io.
+	Registries []string `json:"registries,omitempty"`
+
+	// CacheTTL defines how long a resolved digest is cached, e.g. 30m. Defaults to 1h.
+	CacheTTL string `json:"cacheTTL,omitempty"`
+
+	// InsecureRegistries are registry hosts that are queried via plain http.
+	InsecureRegistries []string `json:"insecureRegistries,omitempty"`
 }
 
 type SyncRewriteHosts struct {
@@ -475,6 +805,8 @@ type SyncNodes struct {
 
 	// Selector can be used to define more granular what nodes should get synced from the host cluster to the virtual cluster.
 	Selector SyncNodeSelector `json:"selector,omitempty"`
+
+	SyncPatches `json:",inline"`
 }
 
 type SyncNodeSelector struct {
@@ -519,6 +851,19 @@ type ReplicateServices struct {
 
 	// FromHost defines the services that should get synced from the host to the virtual cluster.
 	FromHost []ServiceMapping `json:"fromHost,omitempty"`
+
+	// EndpointSlices defines how the endpoints of replicated services without a selector are written.
+	EndpointSlices ReplicateServicesEndpointSlices `json:"endpointSlices,omitempty"`
+}
+
+type ReplicateServicesEndpointSlices struct {
+	// Enabled defines if endpoint slices should be written for replicated services. Endpoint slices support dual-stack
+	// services, keep topology hints and are not truncated at 1000 addresses.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// MirrorEndpoints defines if endpoints should be written in addition to the endpoint slices. Disable this for large
+	// services, as endpoints are truncated at 1000 addresses.
+	MirrorEndpoints bool `json:"mirrorEndpoints,omitempty"`
 }
 
 type ServiceMapping struct {
@@ -893,6 +1238,34 @@ type BackingStore struct {
 
 	// Database defines that a database backend should be used as the backend for the virtual cluster. This uses a project called kine under the hood which is a shim for bridging Kubernetes and relational databases.
 	Database Database `json:"database,omitempty"`
+
+	// Snapshots defines scheduled snapshots of the backing store, the vCluster config and certificates.
+	Snapshots BackingStoreSnapshots `json:"snapshots,omitempty"`
+}
+
+type BackingStoreSnapshots struct {
+	// Enabled defines if scheduled snapshots should be created. Snapshots are only created by the leader.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Schedule is the cron schedule in standard format (e.g. "0 3 * * *" or "@daily") on which snapshots are created.
+	Schedule string `json:"schedule,omitempty"`
+
+	// Destination is where snapshots are stored. Can be either a directory such as file:///data/snapshots, which can also be
+	// an additional persistent volume mounted via controlPlane.statefulSet.persistence.addVolumeMounts, or an S3 compatible
+	// bucket such as s3://bucket/prefix?region=eu-west-1&endpoint=http://minio:9000. S3 credentials are read from the
+	// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables of the control plane.
+	Destination string `json:"destination,omitempty"`
+
+	// Retention defines how many snapshots are kept in the destination.
+	Retention BackingStoreSnapshotsRetention `json:"retention,omitempty"`
+}
+
+type BackingStoreSnapshotsRetention struct {
+	// Count is the number of snapshots to keep. 0 keeps all snapshots.
+	Count int `json:"count,omitempty"`
+
+	// MaxAge is the maximum age of snapshots to keep as a duration such as 168h. Empty keeps all snapshots.
+	MaxAge string `json:"maxAge,omitempty"`
 }
 
 type Database struct {
@@ -1103,6 +1476,36 @@ type ControlPlaneProxy struct {
 
 	// ExtraSANs are extra hostnames to sign the vCluster proxy certificate for.
 	ExtraSANs []string `json:"extraSANs,omitempty"`
+
+	// OIDC configures the vCluster proxy to authenticate users with OpenID Connect tokens.
+	OIDC ControlPlaneProxyOIDC `json:"oidc,omitempty"`
+}
+
+type ControlPlaneProxyOIDC struct {
+	// Enabled defines if OIDC tokens should be accepted by the vCluster proxy.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// IssuerURL is the URL of the OpenID issuer, only HTTPS scheme will be accepted.
+	IssuerURL string `json:"issuerURL,omitempty"`
+
+	// ClientID is the client ID for the OpenID Connect client, tokens must be issued for this audience.
+	ClientID string `json:"clientID,omitempty"`
+
+	// UsernameClaim is the OpenID claim to use as the user name. Defaults to sub.
+	UsernameClaim string `json:"usernameClaim,omitempty"`
+
+	// UsernamePrefix is prepended to the user name. If empty and the username claim is not email, the prefix defaults to
+	// issuerURL#. Use - to disable prefixing.
+	UsernamePrefix string `json:"usernamePrefix,omitempty"`
+
+	// GroupsClaim is the OpenID claim to use as the user groups. The claim value can either be a string or an array of strings.
+	GroupsClaim string `json:"groupsClaim,omitempty"`
+
+	// GroupsPrefix is prepended to the group names.
+	GroupsPrefix string `json:"groupsPrefix,omitempty"`
+
+	// CA is the PEM encoded certificate authority that signed the certificate of the issuer. If empty, the system roots are used.
+	CA string `json:"ca,omitempty"`
 }
 
 type ControlPlaneService struct {
@@ -1170,6 +1573,133 @@ type ControlPlaneAdvanced struct {
 
 	// GlobalMetadata is metadata that will be added to all resources deployed by Helm.
 	GlobalMetadata ControlPlaneGlobalMetadata `json:"globalMetadata,omitempty"`
+
+	// Audit defines audit logging for requests to the vCluster api server proxy.
+	Audit ControlPlaneAudit `json:"audit,omitempty"`
+
+	// CertificateRotation defines if and when vCluster renews the certificates of the control plane.
+	CertificateRotation ControlPlaneCertificateRotation `json:"certificateRotation,omitempty"`
+}
+
+type ControlPlaneCertificateRotation struct {
+	// Enabled defines if vCluster should watch the expiry of the control plane certificates and renew them from the existing
+	// certificate authorities. This is only supported for the k8s and eks distros. Renewed certificates are loaded by restarting the
+	// vCluster container, so the control plane is briefly unavailable whenever certificates are renewed or a ca rotation advances.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// RenewBefore is the duration before the expiry of a certificate when it gets renewed, e.g. 720h.
+	RenewBefore string `json:"renewBefore,omitempty"`
+
+	// CheckInterval is the interval in which the certificates are checked, e.g. 1h.
+	CheckInterval string `json:"checkInterval,omitempty"`
+}
+
+type ControlPlaneAudit struct {
+	// Enabled defines if audit logging should be enabled.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Policy defines which requests are recorded and how much data the audit events include. If no rules are defined,
+	// the metadata of all requests is recorded.
+	Policy AuditPolicy `json:"policy,omitempty"`
+
+	// Log writes the audit events as json lines into a file within the vCluster container.
+	Log AuditLog `json:"log,omitempty"`
+
+	// Webhook sends the audit events to a remote api.
+	Webhook AuditWebhook `json:"webhook,omitempty"`
+
+	// HostBuffer writes the latest audit events into a config map or secret within the vCluster namespace of the host cluster.
+	HostBuffer AuditHostBuffer `json:"hostBuffer,omitempty"`
+}
+
+type AuditPolicy struct {
+	// OmitStages is a list of stages for which no events are created.
+	OmitStages []string `json:"omitStages,omitempty"`
+
+	// Rules specify the audit Level a request should be recorded at. A request may match multiple rules, in which case the FIRST matching rule is used.
+	Rules []AuditPolicyRule `json:"rules,omitempty"`
+}
+
+type AuditPolicyRule struct {
+	// Level that requests matching this rule are recorded at. Can be None, Metadata, Request or RequestResponse.
+	Level string `json:"level,omitempty"`
+
+	// Users (by authenticated user name) this rule applies to.
+	Users []string `json:"users,omitempty"`
+
+	// UserGroups this rule applies to. A user is considered matching if it is a member of any of the UserGroups.
+	UserGroups []string `json:"userGroups,omitempty"`
+
+	// Verbs this rule applies to.
+	Verbs []string `json:"verbs,omitempty"`
+
+	// Resources this rule applies to.
+	Resources []AuditGroupResources `json:"resources,omitempty"`
+
+	// Namespaces this rule applies to. The empty string "" matches non-namespaced resources.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// NonResourceURLs is a set of URL paths that should be audited. Wildcards are allowed, but only as the full, final step in the path.
+	NonResourceURLs []string `json:"nonResourceURLs,omitempty"`
+
+	// OmitStages is a list of stages for which no events are created.
+	OmitStages []string `json:"omitStages,omitempty"`
+
+	// OmitManagedFields indicates whether to omit the managed fields of the request and response bodies from being written to the API audit log.
+	OmitManagedFields *bool `json:"omitManagedFields,omitempty"`
+}
+
+type AuditGroupResources struct {
+	// Group is the name of the API group that contains the resources. The empty string represents the core API group.
+	Group string `json:"group,omitempty"`
+
+	// Resources is a list of resources this rule applies to, e.g. pods or pods/log.
+	Resources []string `json:"resources,omitempty"`
+
+	// ResourceNames is a list of resource instance names that the policy matches.
+	ResourceNames []string `json:"resourceNames,omitempty"`
+}
+
+type AuditLog struct {
+	// Enabled defines if audit events should be written to the file.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Path is the file the audit events are written to. Use "-" to write to stdout. Defaults to /data/audit/audit.log.
+	Path string `json:"path,omitempty"`
+
+	// MaxAge is the maximum number of days to retain old audit log files.
+	MaxAge int `json:"maxAge,omitempty"`
+
+	// MaxBackups is the maximum number of old audit log files to retain.
+	MaxBackups int `json:"maxBackups,omitempty"`
+
+	// MaxSize is the maximum size in megabytes of the audit log file before it gets rotated. Defaults to 100.
+	MaxSize int `json:"maxSize,omitempty"`
+
+	// Compress defines if the rotated audit log files should be compressed using gzip.
+	Compress bool `json:"compress,omitempty"`
+}
+
+type AuditWebhook struct {
+	// Enabled defines if audit events should be sent to the webhook.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Config is a kube config that defines the remote webhook the audit events are sent to.
+	Config string `json:"config,omitempty"`
+}
+
+type AuditHostBuffer struct {
+	// Enabled defines if audit events should be written to the host cluster.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Kind is the kind of the object the audit events are written to. Can be either ConfigMap or Secret. Defaults to ConfigMap.
+	Kind string `json:"kind,omitempty"`
+
+	// Name is the name of the object the audit events are written to. Defaults to vc-audit-NAME.
+	Name string `json:"name,omitempty"`
+
+	// MaxEvents is the number of latest audit events that are kept in the object. Defaults to 100.
+	MaxEvents int `json:"maxEvents,omitempty"`
 }
 
 type ControlPlaneHeadlessService struct {
@@ -1660,10 +2190,12 @@ type Experimental struct {
 	// SyncSettings are advanced settings for the syncer controller.
 	SyncSettings ExperimentalSyncSettings `json:"syncSettings,omitempty"`
 
-	// GenericSync holds options to generically sync resources from virtual cluster to host.
+	// GenericSync holds options to generically sync resources from virtual cluster to host. This is deprecated, please use
+	// sync.toHost.customResources and sync.fromHost.customResources instead.
 	GenericSync ExperimentalGenericSync `json:"genericSync,omitempty"`
 
 	// MultiNamespaceMode tells virtual cluster to sync to multiple namespaces instead of a single one. This will map each virtual cluster namespace to a single namespace in the host cluster.
+	// This is deprecated, please use sync.toHost.namespaces instead.
 	MultiNamespaceMode ExperimentalMultiNamespaceMode `json:"multiNamespaceMode,omitempty"`
 
 	// IsolatedControlPlane is a feature to run the vCluster control plane in a different Kubernetes cluster than the workloads themselves.
@@ -1674,12 +2206,86 @@ type Experimental struct {
 
 	// DenyProxyRequests denies certain requests in the vCluster proxy.
 	DenyProxyRequests []DenyRule `json:"denyProxyRequests,omitempty" product:"pro"`
+
+	// Accounting tracks the host resources used by the vCluster per virtual namespace for cost attribution.
+	Accounting ExperimentalAccounting `json:"accounting,omitempty"`
 }
 
 func (e Experimental) JSONSchemaExtend(base *jsonschema.Schema) {
 	addProToJSONSchema(base, reflect.TypeOf(e))
 }
 
+type ExperimentalAccounting struct {
+	// Enabled defines if the cpu, memory, gpu and storage requests of the pods and persistent volume claims the vCluster created in the
+	// host cluster should get accounted per virtual namespace. Usage is read from the host metrics-server if integrations.metricsServer.pods
+	// is enabled. The results are exposed as vcluster_accounting_* Prometheus metrics of the syncer.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Interval is the interval in which requests and usage are sampled, e.g. 1m.
+	Interval string `json:"interval,omitempty"`
+
+	// GPUResources are the extended resource names of pods that are accounted as gpus.
+	GPUResources []string `json:"gpuResources,omitempty"`
+
+	// Report defines if the accounted resources should be written to a report periodically.
+	Report ExperimentalAccountingReport `json:"report,omitempty"`
+}
+
+type ExperimentalAccountingReport struct {
+	// Enabled defines if reports should be written. Reports are only written by the leader.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Schedule is the cron schedule in standard format (e.g. "0 * * * *" or "@daily") on which a report of the resources accounted since
+	// the previous report is written.
+	Schedule string `json:"schedule,omitempty"`
+
+	// Format is the format of the report, either json or csv.
+	Format string `json:"format,omitempty"`
+
+	// Destination is where reports are written. Can be either a config map in the host namespace of the vCluster such as configmap://my-report,
+	// which only holds the latest report, or a directory such as file:///data/accounting, which keeps every report.
+	// Defaults to the config map vc-accounting-<vcluster-name>.
+	Destination string `json:"destination,omitempty"`
+}
+
+// SleepMode puts the virtual cluster to sleep when it is not used and wakes it up again on the next request.
+type SleepMode struct {
+	// Enabled defines if sleep mode should be enabled. This deploys a small wake-up proxy next to the vCluster that receives the
+	// traffic of the vCluster service while the vCluster is sleeping and resumes it on the next request.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// TimeZone is the time zone the auto sleep schedule is evaluated in, e.g. Europe/Berlin. Defaults to UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// AutoSleep defines when the vCluster should be put to sleep.
+	AutoSleep SleepModeAutoSleep `json:"autoSleep,omitempty"`
+}
+
+type SleepModeAutoSleep struct {
+	// AfterInactivity is the duration without any API requests to the vCluster after which it is put to sleep, e.g. 2h.
+	AfterInactivity string `json:"afterInactivity,omitempty"`
+
+	// Schedule is an optional cron schedule in standard format (e.g. "0 20 * * 1-5") on which the vCluster is put to sleep
+	// regardless of its activity.
+	Schedule string `json:"schedule,omitempty"`
+
+	// Exclude defines which requests should not count as activity.
+	Exclude SleepModeAutoSleepExclude `json:"exclude,omitempty"`
+}
+
+type SleepModeAutoSleepExclude struct {
+	// Kubelet defines if requests of kubelets should be ignored.
+	Kubelet bool `json:"kubelet,omitempty"`
+
+	// Controllers defines if requests of the controllers within the vCluster, such as the controller manager, the scheduler
+	// and service accounts in kube-system, should be ignored.
+	Controllers bool `json:"controllers,omitempty"`
+
+	// Users are user names whose requests should be ignored. A trailing * matches every user name with that prefix,
+	// e.g. system:serviceaccount:monitoring:*.
+	Users []string `json:"users,omitempty"`
+}
+
 type ExperimentalMultiNamespaceMode struct {
 	// Enabled specifies if multi namespace mode should get enabled
 	Enabled bool `json:"enabled,omitempty"`
@@ -1726,6 +2332,71 @@ type ExperimentalSyncSettings struct {
 
 	// VirtualMetricsBindAddress is the bind address for the virtual manager
 	VirtualMetricsBindAddress string `json:"virtualMetricsBindAddress,omitempty"`
+
+	// DryRun configures the syncer to only record the changes it would apply to the host cluster instead of applying them.
+	DryRun ExperimentalSyncSettingsDryRun `json:"dryRun,omitempty"`
+
+	// Controllers allows to tune the individual syncers. The key is the name of the syncer, e.g. pod, secret or configmap.
+	Controllers map[string]ExperimentalSyncSettingsController `json:"controllers,omitempty"`
+
+	// Naming defines how the names of namespaced objects are translated when they are synced to the host cluster.
+	// The naming policy cannot be changed after the vCluster was created.
+	Naming ExperimentalSyncSettingsNaming `json:"naming,omitempty"`
+}
+
+type ExperimentalSyncSettingsNaming struct {
+	// Policy is the naming policy to use. Can be either "hashed" (default), which translates names to <name>-x-<namespace>-x-<vcluster>,
+	// "prefix", which translates names to <vcluster>-<name>, or "template", which uses the given template.
+	Policy NamingPolicy `json:"policy,omitempty"`
+
+	// Template is a Go template that is used to translate the name if the policy is "template". Available fields are
+	// .Name, .Namespace and .VClusterName. Labels are not available, as they can change after an object was synced, which would
+	// orphan the previously synced host object. The result is shortened and hashed if it is longer than 63 characters.
+	Template string `json:"template,omitempty"`
+}
+
+type NamingPolicy string
+
+const (
+	NamingPolicyHashed   NamingPolicy = "hashed"
+	NamingPolicyPrefix   NamingPolicy = "prefix"
+	NamingPolicyTemplate NamingPolicy = "template"
+)
+
+type ExperimentalSyncSettingsController struct {
+	// MaxConcurrentReconciles is the number of objects the syncer reconciles in parallel. Defaults to 10.
+	MaxConcurrentReconciles int `json:"maxConcurrentReconciles,omitempty"`
+
+	// Backoff configures the exponential backoff for objects that failed to sync.
+	Backoff ExperimentalSyncSettingsControllerBackoff `json:"backoff,omitempty"`
+
+	// QPS is the overall number of reconciles per second the syncer is allowed to queue. Defaults to 10.
+	QPS float64 `json:"qps,omitempty"`
+
+	// Burst is the number of reconciles the syncer is allowed to queue at once. Defaults to 100.
+	Burst int `json:"burst,omitempty"`
+
+	// ResyncPeriod is the period after which all objects are reconciled again, e.g. 10m. Disabled by default.
+	ResyncPeriod string `json:"resyncPeriod,omitempty"`
+}
+
+type ExperimentalSyncSettingsControllerBackoff struct {
+	// BaseDelay is the delay after the first failure of an object, e.g. 5ms. Defaults to 5ms.
+	BaseDelay string `json:"baseDelay,omitempty"`
+
+	// MaxDelay is the maximum delay between retries of an object, e.g. 5m. Defaults to 1000s.
+	MaxDelay string `json:"maxDelay,omitempty"`
+}
+
+type ExperimentalSyncSettingsDryRun struct {
+	// Enabled defines if the syncer should run in dry run mode. This can also be enabled through the --sync-dry-run flag of the vCluster binary.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// File is the path of the file the changes are appended to as json lines. If neither file nor configMap is set, the changes are written to stdout.
+	File string `json:"file,omitempty"`
+	// ConfigMap is the name of the config map within the vCluster namespace the latest change of each host object is written to. The config map contains at most 250 objects per syncer and large diffs are truncated, the amount of changes that were not written is counted per syncer in the dropped key.
+	// ConfigMap is the name of the config map within the vCluster namespace the latest change of each host object is written to.
+	ConfigMap string `json:"configMap,omitempty"`
 }
 
 func (e ExperimentalSyncSettings) JSONSchemaExtend(base *jsonschema.Schema) {
@@ -1917,6 +2588,11 @@ type Patch struct {
 	// Value is the new value to be set to the path
 	Value interface{} `json:"value,omitempty" yaml:"value,omitempty"`
 
+	// ValueExpression is a CEL expression whose result is used instead of Value. The expression can access the
+	// patched object as object, the object it is synced from as otherObject, both of them as virtual and host and
+	// the vCluster as vcluster with the fields name, namespace and labels.
+	ValueExpression string `json:"valueExpression,omitempty" yaml:"valueExpression,omitempty"`
+
 	// Regex - is regular expresion used to identify the Name,
 	// and optionally Namespace, parts of the field value that
 	// will be replaced with the rewritten Name and/or Namespace
@@ -1964,6 +2640,10 @@ type PatchCondition struct {
 
 	// Empty means that the path value should be empty or unset
 	Empty *bool `json:"empty,omitempty" yaml:"empty,omitempty"`
+
+	// Expression is a CEL expression that needs to return true for the patch to get executed. It can access the same
+	// variables as the valueExpression of a patch, e.g. host.metadata.labels["team"] == vcluster.labels["team"].
+	Expression string `json:"expression,omitempty" yaml:"expression,omitempty"`
 }
 
 type PatchSync struct {
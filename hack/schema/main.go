@@ -18,6 +18,8 @@ const ValuesOutFile = "chart/values.yaml"
 
 var SkipProperties = map[string]string{
 	"EnableSwitch":              "*",
+	"EnableSwitchWithPatches":   "enabled",
+	"SyncToHostResource":        "enabled",
 	"SyncAllResource":           "enabled",
 	"DistroContainerEnabled":    "enabled",
 	"EtcdDeployService":         "*",
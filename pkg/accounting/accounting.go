@@ -0,0 +1,234 @@
+package accounting
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	metricsv1beta1 "k8s.io/metrics/pkg/client/clientset/versioned/typed/metrics/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Accounted resources
+const (
+	ResourceCPU     = "cpu"
+	ResourceMemory  = "memory"
+	ResourceGPU     = "gpu"
+	ResourceStorage = "storage"
+)
+
+// Resources holds the accounted values by resource, cpu in cores, memory and storage in bytes and gpus in devices
+type Resources map[string]float64
+
+// NamespaceResources are the requested and used resources of a virtual namespace
+type NamespaceResources struct {
+	Requests Resources
+	Usage    Resources
+}
+
+// Sample holds the resources of each virtual namespace at a point in time
+type Sample map[string]*NamespaceResources
+
+func (s Sample) namespace(vNamespace string) *NamespaceResources {
+	if s[vNamespace] == nil {
+		s[vNamespace] = &NamespaceResources{Requests: Resources{}, Usage: Resources{}}
+	}
+
+	return s[vNamespace]
+}
+
+// Accountant samples the pods and persistent volume claims of the vCluster in the host cluster and
+// accumulates their resources over time
+type Accountant struct {
+	HostClient client.Client
+
+	// MetricsClient is used to retrieve the pod usage from the host metrics-server, nil if usage is not accounted
+	MetricsClient metricsv1beta1.PodMetricsesGetter
+
+	// Namespace is the host namespace of the synced objects, empty if they are synced to multiple namespaces
+	Namespace string
+
+	// GPUResources are the extended resources that are accounted as gpus
+	GPUResources []string
+
+	Now func() time.Time
+
+	m          sync.Mutex
+	lastSample time.Time
+	window     *window
+}
+
+// window holds the resources accumulated since the last report in value seconds
+type window struct {
+	start      time.Time
+	namespaces Sample
+}
+
+// Sample retrieves the current requests and usage of the synced objects per virtual namespace
+func (a *Accountant) Sample(ctx context.Context) (Sample, error) {
+	listOptions := []client.ListOption{client.HasLabels{translate.MarkerLabel}}
+	if a.Namespace != "" {
+		listOptions = append(listOptions, client.InNamespace(a.Namespace))
+	}
+
+	sample := Sample{}
+	podList := &corev1.PodList{}
+	err := a.HostClient.List(ctx, podList, listOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("list pods: %w", err)
+	}
+
+	// host pod by namespace and name to virtual namespace
+	pods := map[string]map[string]string{}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		vNamespace := pod.Annotations[translate.NamespaceAnnotation]
+		if vNamespace == "" || !translate.Default.IsManaged(ctx, pod) || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		if pods[pod.Namespace] == nil {
+			pods[pod.Namespace] = map[string]string{}
+		}
+		pods[pod.Namespace][pod.Name] = vNamespace
+
+		requests := podRequests(pod)
+		namespaceResources := sample.namespace(vNamespace)
+		namespaceResources.Requests[ResourceCPU] += requests.Cpu().AsApproximateFloat64()
+		namespaceResources.Requests[ResourceMemory] += requests.Memory().AsApproximateFloat64()
+		for _, gpuResource := range a.GPUResources {
+			if quantity, ok := requests[corev1.ResourceName(gpuResource)]; ok {
+				namespaceResources.Requests[ResourceGPU] += quantity.AsApproximateFloat64()
+			}
+		}
+	}
+
+	if a.MetricsClient != nil {
+		for pNamespace, namespacePods := range pods {
+			podMetricsList, err := a.MetricsClient.PodMetricses(pNamespace).List(ctx, metav1.ListOptions{LabelSelector: translate.MarkerLabel})
+			if err != nil {
+				// usage is only accounted as long as the metrics-server is available
+				klog.FromContext(ctx).Error(err, "list pod metrics", "namespace", pNamespace)
+				continue
+			}
+
+			for _, podMetrics := range podMetricsList.Items {
+				vNamespace, ok := namespacePods[podMetrics.Name]
+				if !ok {
+					continue
+				}
+
+				namespaceResources := sample.namespace(vNamespace)
+				for _, container := range podMetrics.Containers {
+					namespaceResources.Usage[ResourceCPU] += container.Usage.Cpu().AsApproximateFloat64()
+					namespaceResources.Usage[ResourceMemory] += container.Usage.Memory().AsApproximateFloat64()
+				}
+			}
+		}
+	}
+
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	err = a.HostClient.List(ctx, pvcList, listOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("list persistent volume claims: %w", err)
+	}
+	for i := range pvcList.Items {
+		pvc := &pvcList.Items[i]
+		vNamespace := pvc.Annotations[translate.NamespaceAnnotation]
+		if vNamespace == "" || !translate.Default.IsManaged(ctx, pvc) {
+			continue
+		}
+
+		// the capacity of a bound claim is accounted as usage, as the volume is reserved for the claim
+		namespaceResources := sample.namespace(vNamespace)
+		namespaceResources.Requests[ResourceStorage] += pvc.Spec.Resources.Requests.Storage().AsApproximateFloat64()
+		if pvc.Status.Phase == corev1.ClaimBound {
+			namespaceResources.Usage[ResourceStorage] += pvc.Status.Capacity.Storage().AsApproximateFloat64()
+		}
+	}
+
+	return sample, nil
+}
+
+// Record exposes the sample as metrics and accumulates it for the time since the previous sample
+func (a *Accountant) Record(sample Sample, now time.Time) {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	elapsed := 0.0
+	if !a.lastSample.IsZero() {
+		elapsed = now.Sub(a.lastSample).Seconds()
+	}
+	a.lastSample = now
+	if a.window == nil {
+		a.window = &window{start: now, namespaces: Sample{}}
+	}
+
+	// reset the gauges, so deleted namespaces are removed
+	requestsGauge.Reset()
+	usageGauge.Reset()
+	for vNamespace, namespaceResources := range sample {
+		accumulated := a.window.namespaces.namespace(vNamespace)
+		for resource, value := range namespaceResources.Requests {
+			requestsGauge.WithLabelValues(vNamespace, resource).Set(value)
+			requestsSeconds.WithLabelValues(vNamespace, resource).Add(value * elapsed)
+			accumulated.Requests[resource] += value * elapsed
+		}
+		for resource, value := range namespaceResources.Usage {
+			usageGauge.WithLabelValues(vNamespace, resource).Set(value)
+			usageSeconds.WithLabelValues(vNamespace, resource).Add(value * elapsed)
+			accumulated.Usage[resource] += value * elapsed
+		}
+	}
+}
+
+// Report returns the resources accumulated since the previous report and starts a new report window
+func (a *Accountant) Report(vClusterName string, now time.Time) *Report {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	report := &Report{
+		VCluster: vClusterName,
+		Start:    now,
+		End:      now,
+	}
+	if a.window != nil {
+		report.Start = a.window.start
+		report.Entries = newReportEntries(a.window.namespaces)
+	}
+
+	a.window = &window{start: now, namespaces: Sample{}}
+	return report
+}
+
+// podRequests returns the effective requests of the pod, which are the sum of its containers or the
+// highest requests of an init container and the pod overhead
+func podRequests(pod *corev1.Pod) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		for name, quantity := range container.Resources.Requests {
+			sum := requests[name]
+			sum.Add(quantity)
+			requests[name] = sum
+		}
+	}
+	for _, container := range pod.Spec.InitContainers {
+		for name, quantity := range container.Resources.Requests {
+			if current, ok := requests[name]; !ok || quantity.Cmp(current) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	for name, quantity := range pod.Spec.Overhead {
+		sum := requests[name]
+		sum.Add(quantity)
+		requests[name] = sum
+	}
+
+	return requests
+}
@@ -0,0 +1,227 @@
+package accounting
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	generictesting "github.com/loft-sh/vcluster/pkg/controllers/syncer/testing"
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	testingutil "github.com/loft-sh/vcluster/pkg/util/testing"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	metricsv1beta1api "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsv1beta1 "k8s.io/metrics/pkg/client/clientset/versioned/typed/metrics/v1beta1"
+)
+
+func TestSample(t *testing.T) {
+	pClient := testingutil.NewFakeClient(scheme.Scheme)
+	generictesting.NewFakeRegisterContext(generictesting.NewFakeConfig(), pClient, testingutil.NewFakeClient(scheme.Scheme))
+
+	ctx := context.Background()
+	objects := []*corev1.Pod{
+		{
+			ObjectMeta: hostObjectMeta("web", "team-a"),
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "init", Resources: requests("1", "64Mi", "")}},
+				Containers: []corev1.Container{
+					{Name: "app", Resources: requests("250m", "128Mi", "1")},
+					{Name: "sidecar", Resources: requests("250m", "128Mi", "")},
+				},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		{
+			ObjectMeta: hostObjectMeta("job", "team-a"),
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "job", Resources: requests("2", "1Gi", "")}}},
+			Status:     corev1.PodStatus{Phase: corev1.PodSucceeded},
+		},
+		{
+			ObjectMeta: hostObjectMeta("api", "team-b"),
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "api", Resources: requests("500m", "256Mi", "")}}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "unmanaged", Namespace: generictesting.DefaultTestTargetNamespace},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Resources: requests("4", "4Gi", "")}}},
+		},
+	}
+	for _, pod := range objects {
+		assert.NilError(t, pClient.Create(ctx, pod))
+	}
+	assert.NilError(t, pClient.Create(ctx, &corev1.PersistentVolumeClaim{
+		ObjectMeta: hostObjectMeta("data", "team-b"),
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")}},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Phase:    corev1.ClaimBound,
+			Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("2Gi")},
+		},
+	}))
+
+	accountant := &Accountant{
+		HostClient: pClient,
+		MetricsClient: &fakeMetricsClient{podMetrics: []metricsv1beta1api.PodMetrics{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: translate.Default.PhysicalName("web", "team-a"), Namespace: generictesting.DefaultTestTargetNamespace},
+				Containers: []metricsv1beta1api.ContainerMetrics{
+					{Name: "app", Usage: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m"), corev1.ResourceMemory: resource.MustParse("100Mi")}},
+					{Name: "sidecar", Usage: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m"), corev1.ResourceMemory: resource.MustParse("10Mi")}},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "unmanaged", Namespace: generictesting.DefaultTestTargetNamespace},
+				Containers: []metricsv1beta1api.ContainerMetrics{
+					{Name: "app", Usage: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")}},
+				},
+			},
+		}},
+		Namespace:    generictesting.DefaultTestTargetNamespace,
+		GPUResources: []string{"nvidia.com/gpu"},
+	}
+
+	sample, err := accountant.Sample(ctx)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, sample, Sample{
+		"team-a": {
+			Requests: Resources{ResourceCPU: 1, ResourceMemory: 256 * 1024 * 1024, ResourceGPU: 1},
+			Usage:    Resources{ResourceCPU: 0.5, ResourceMemory: 110 * 1024 * 1024},
+		},
+		"team-b": {
+			Requests: Resources{ResourceCPU: 0.5, ResourceMemory: 256 * 1024 * 1024, ResourceStorage: 1024 * 1024 * 1024},
+			Usage:    Resources{ResourceStorage: 2 * 1024 * 1024 * 1024},
+		},
+	})
+}
+
+func TestRecordReport(t *testing.T) {
+	start := time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)
+	accountant := &Accountant{}
+	sample := Sample{
+		"team-a": {
+			Requests: Resources{ResourceCPU: 2},
+			Usage:    Resources{ResourceCPU: 1},
+		},
+	}
+
+	// the first sample only starts the window
+	accountant.Record(sample, start)
+	accountant.Record(sample, start.Add(time.Minute))
+	accountant.Record(sample, start.Add(2*time.Minute))
+
+	report := accountant.Report("my-vcluster", start.Add(2*time.Minute))
+	assert.Equal(t, report.Start, start)
+	assert.Equal(t, report.End, start.Add(2*time.Minute))
+	assert.DeepEqual(t, report.Entries, []ReportEntry{
+		{Resource: ResourceCPU, Unit: "core-seconds", Requests: 240, Usage: 120},
+		{Resource: ResourceGPU, Unit: "gpu-seconds"},
+		{Resource: ResourceMemory, Unit: "byte-seconds"},
+		{Resource: ResourceStorage, Unit: "byte-seconds"},
+		{Namespace: "team-a", Resource: ResourceCPU, Unit: "core-seconds", Requests: 240, Usage: 120},
+	})
+
+	// the next report starts where the previous one ended
+	accountant.Record(sample, start.Add(3*time.Minute))
+	report = accountant.Report("my-vcluster", start.Add(3*time.Minute))
+	assert.Equal(t, report.Start, start.Add(2*time.Minute))
+	assert.Equal(t, report.Entries[len(report.Entries)-1].Requests, 120.0)
+
+	csv, err := report.Encode("csv")
+	assert.NilError(t, err)
+	assert.Equal(t, string(csv), `vcluster,start,end,namespace,resource,unit,requests,usage
+my-vcluster,2024-01-02T03:02:00Z,2024-01-02T03:03:00Z,,cpu,core-seconds,120,60
+my-vcluster,2024-01-02T03:02:00Z,2024-01-02T03:03:00Z,,gpu,gpu-seconds,0,0
+my-vcluster,2024-01-02T03:02:00Z,2024-01-02T03:03:00Z,,memory,byte-seconds,0,0
+my-vcluster,2024-01-02T03:02:00Z,2024-01-02T03:03:00Z,,storage,byte-seconds,0,0
+my-vcluster,2024-01-02T03:02:00Z,2024-01-02T03:03:00Z,team-a,cpu,core-seconds,120,60
+`)
+
+	_, err = report.Encode("xml")
+	assert.ErrorContains(t, err, "unsupported report format")
+}
+
+func TestDestinations(t *testing.T) {
+	ctx := context.Background()
+	report := &Report{VCluster: "my-vcluster", End: time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)}
+	pClient := testingutil.NewFakeClient(scheme.Scheme)
+
+	destination, err := NewDestination("", "json", pClient, "vcluster-ns", "my-vcluster")
+	assert.NilError(t, err)
+	assert.Equal(t, destination.String(), "configmap://vcluster-ns/vc-accounting-my-vcluster")
+	assert.NilError(t, destination.Write(ctx, report, []byte("first")))
+	assert.NilError(t, destination.Write(ctx, report, []byte("second")))
+	configMap := &corev1.ConfigMap{}
+	assert.NilError(t, pClient.Get(ctx, types.NamespacedName{Namespace: "vcluster-ns", Name: "vc-accounting-my-vcluster"}, configMap))
+	assert.DeepEqual(t, configMap.Data, map[string]string{"report.json": "second"})
+
+	dir := t.TempDir()
+	destination, err = NewDestination("file://"+dir, "csv", pClient, "vcluster-ns", "my-vcluster")
+	assert.NilError(t, err)
+	assert.NilError(t, destination.Write(ctx, report, []byte("data")))
+	data, err := os.ReadFile(filepath.Join(dir, "accounting-20240102-030000.csv"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "data")
+
+	_, err = NewDestination("s3://bucket", "csv", pClient, "vcluster-ns", "my-vcluster")
+	assert.ErrorContains(t, err, "unsupported report destination")
+}
+
+func hostObjectMeta(name, vNamespace string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      translate.Default.PhysicalName(name, vNamespace),
+		Namespace: generictesting.DefaultTestTargetNamespace,
+		Annotations: map[string]string{
+			translate.NameAnnotation:      name,
+			translate.NamespaceAnnotation: vNamespace,
+		},
+		Labels: map[string]string{
+			translate.MarkerLabel:    translate.VClusterName,
+			translate.NamespaceLabel: vNamespace,
+		},
+	}
+}
+
+func requests(cpu, memory, gpu string) corev1.ResourceRequirements {
+	resources := corev1.ResourceRequirements{Requests: corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}}
+	if gpu != "" {
+		resources.Requests["nvidia.com/gpu"] = resource.MustParse(gpu)
+	}
+
+	return resources
+}
+
+type fakeMetricsClient struct {
+	podMetrics []metricsv1beta1api.PodMetrics
+}
+
+func (f *fakeMetricsClient) PodMetricses(namespace string) metricsv1beta1.PodMetricsInterface {
+	return &fakePodMetrics{namespace: namespace, podMetrics: f.podMetrics}
+}
+
+type fakePodMetrics struct {
+	metricsv1beta1.PodMetricsInterface
+
+	namespace  string
+	podMetrics []metricsv1beta1api.PodMetrics
+}
+
+func (f *fakePodMetrics) List(_ context.Context, _ metav1.ListOptions) (*metricsv1beta1api.PodMetricsList, error) {
+	list := &metricsv1beta1api.PodMetricsList{}
+	for _, podMetrics := range f.podMetrics {
+		if podMetrics.Namespace == f.namespace {
+			list.Items = append(list.Items, podMetrics)
+		}
+	}
+
+	return list, nil
+}
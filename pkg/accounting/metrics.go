@@ -0,0 +1,34 @@
+package accounting
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	requestsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vcluster_accounting_requests",
+		Help: "Current requests of the host pods and persistent volume claims of a virtual namespace in cores, bytes or gpus.",
+	}, []string{"namespace", "resource"})
+	usageGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vcluster_accounting_usage",
+		Help: "Current usage of the host pods and persistent volume claims of a virtual namespace in cores or bytes.",
+	}, []string{"namespace", "resource"})
+	requestsSeconds = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "vcluster_accounting_requests_seconds_total",
+		Help: "Requests of a virtual namespace accumulated over time in core, byte or gpu seconds.",
+	}, []string{"namespace", "resource"})
+	usageSeconds = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "vcluster_accounting_usage_seconds_total",
+		Help: "Usage of a virtual namespace accumulated over time in core or byte seconds.",
+	}, []string{"namespace", "resource"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		requestsGauge,
+		usageGauge,
+		requestsSeconds,
+		usageSeconds,
+	)
+}
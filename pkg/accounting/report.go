@@ -0,0 +1,196 @@
+package accounting
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var units = map[string]string{
+	ResourceCPU:     "core-seconds",
+	ResourceMemory:  "byte-seconds",
+	ResourceGPU:     "gpu-seconds",
+	ResourceStorage: "byte-seconds",
+}
+
+// Report holds the resources accounted within a time window
+type Report struct {
+	VCluster string    `json:"vcluster"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+
+	// Entries holds the accounted resources per virtual namespace, entries without a namespace are the totals of the vCluster
+	Entries []ReportEntry `json:"entries"`
+}
+
+// ReportEntry holds the requests and usage of a resource accumulated over the report window
+type ReportEntry struct {
+	Namespace string  `json:"namespace,omitempty"`
+	Resource  string  `json:"resource"`
+	Unit      string  `json:"unit"`
+	Requests  float64 `json:"requests"`
+	Usage     float64 `json:"usage"`
+}
+
+func newReportEntries(namespaces Sample) []ReportEntry {
+	totals := &NamespaceResources{Requests: Resources{}, Usage: Resources{}}
+	entries := []ReportEntry{}
+	for vNamespace, namespaceResources := range namespaces {
+		for resource := range units {
+			requests, usage := namespaceResources.Requests[resource], namespaceResources.Usage[resource]
+			if requests == 0 && usage == 0 {
+				continue
+			}
+
+			totals.Requests[resource] += requests
+			totals.Usage[resource] += usage
+			entries = append(entries, ReportEntry{Namespace: vNamespace, Resource: resource, Unit: units[resource], Requests: requests, Usage: usage})
+		}
+	}
+	for resource := range units {
+		entries = append(entries, ReportEntry{Resource: resource, Unit: units[resource], Requests: totals.Requests[resource], Usage: totals.Usage[resource]})
+	}
+
+	slices.SortFunc(entries, func(a, b ReportEntry) int {
+		if a.Namespace != b.Namespace {
+			return strings.Compare(a.Namespace, b.Namespace)
+		}
+		return strings.Compare(a.Resource, b.Resource)
+	})
+	return entries
+}
+
+// Encode encodes the report as json or csv
+func (r *Report) Encode(format string) ([]byte, error) {
+	switch format {
+	case "json":
+		return json.MarshalIndent(r, "", "  ")
+	case "csv":
+		buf := &bytes.Buffer{}
+		writer := csv.NewWriter(buf)
+		records := [][]string{{"vcluster", "start", "end", "namespace", "resource", "unit", "requests", "usage"}}
+		for _, entry := range r.Entries {
+			records = append(records, []string{
+				r.VCluster,
+				r.Start.UTC().Format(time.RFC3339),
+				r.End.UTC().Format(time.RFC3339),
+				entry.Namespace,
+				entry.Resource,
+				entry.Unit,
+				strconv.FormatFloat(entry.Requests, 'f', -1, 64),
+				strconv.FormatFloat(entry.Usage, 'f', -1, 64),
+			})
+		}
+
+		err := writer.WriteAll(records)
+		if err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+	}
+
+	return nil, fmt.Errorf("unsupported report format %q, expected json or csv", format)
+}
+
+// Destination is where reports are written to
+type Destination interface {
+	Write(ctx context.Context, report *Report, data []byte) error
+	String() string
+}
+
+// NewDestination parses the report destination, which defaults to a config map in the host namespace of the vCluster
+func NewDestination(rawURL, format string, hostClient client.Client, hostNamespace, vClusterName string) (Destination, error) {
+	if rawURL == "" {
+		rawURL = "configmap://"
+	}
+
+	scheme, rest, found := strings.Cut(rawURL, "://")
+	if !found {
+		return nil, fmt.Errorf("invalid report destination %q, expected configmap://name or file://path", rawURL)
+	}
+
+	switch scheme {
+	case "configmap":
+		if rest == "" {
+			rest = translate.SafeConcatName("vc-accounting", vClusterName)
+		}
+
+		return &configMapDestination{client: hostClient, namespace: hostNamespace, name: rest, key: "report." + format}, nil
+	case "file":
+		if rest == "" {
+			return nil, fmt.Errorf("invalid report destination %q, path is missing", rawURL)
+		}
+
+		return &fileDestination{dir: rest, extension: format}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported report destination %q, expected configmap://name or file://path", rawURL)
+}
+
+type configMapDestination struct {
+	client    client.Client
+	namespace string
+	name      string
+	key       string
+}
+
+func (c *configMapDestination) Write(ctx context.Context, _ *Report, data []byte) error {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      c.name,
+			Namespace: c.namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrPatch(ctx, c.client, configMap, func() error {
+		configMap.Data = map[string]string{c.key: string(data)}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("write report config map %s/%s: %w", c.namespace, c.name, err)
+	}
+
+	return nil
+}
+
+func (c *configMapDestination) String() string {
+	return "configmap://" + c.namespace + "/" + c.name
+}
+
+type fileDestination struct {
+	dir       string
+	extension string
+}
+
+func (f *fileDestination) Write(_ context.Context, report *Report, data []byte) error {
+	err := os.MkdirAll(f.dir, 0755)
+	if err != nil {
+		return fmt.Errorf("create report directory: %w", err)
+	}
+
+	name := "accounting-" + report.End.UTC().Format("20060102-150405") + "." + f.extension
+	err = os.WriteFile(filepath.Join(f.dir, name), data, 0644)
+	if err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+
+	return nil
+}
+
+func (f *fileDestination) String() string {
+	return "file://" + f.dir
+}
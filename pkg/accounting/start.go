@@ -0,0 +1,100 @@
+package accounting
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/loft-sh/vcluster/pkg/config"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"github.com/robfig/cron/v3"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	metricsv1beta1 "k8s.io/metrics/pkg/client/clientset/versioned/typed/metrics/v1beta1"
+)
+
+// Start samples the resources of the vCluster in the configured interval and writes the scheduled reports. This
+// is only called by the leader.
+func Start(ctx *config.ControllerContext) error {
+	accounting := ctx.Config.Experimental.Accounting
+	interval, err := time.ParseDuration(accounting.Interval)
+	if err != nil {
+		return fmt.Errorf("parse accounting interval: %w", err)
+	}
+
+	accountant := &Accountant{
+		HostClient:   ctx.LocalManager.GetClient(),
+		GPUResources: accounting.GPUResources,
+		Now:          time.Now,
+	}
+	if translate.Default.SingleNamespaceTarget() {
+		accountant.Namespace = ctx.Config.WorkloadTargetNamespace
+	}
+	if ctx.Config.Integrations.MetricsServer.Enabled && ctx.Config.Integrations.MetricsServer.Pods {
+		accountant.MetricsClient, err = metricsv1beta1.NewForConfig(ctx.LocalManager.GetConfig())
+		if err != nil {
+			return fmt.Errorf("create metrics client: %w", err)
+		}
+	}
+
+	logger := klog.FromContext(ctx).WithName("accounting")
+	go wait.UntilWithContext(ctx, func(ctx context.Context) {
+		sample, err := accountant.Sample(ctx)
+		if err != nil {
+			logger.Error(err, "sample resources")
+			return
+		}
+
+		accountant.Record(sample, accountant.Now())
+	}, interval)
+
+	if !accounting.Report.Enabled {
+		return nil
+	}
+
+	destination, err := NewDestination(accounting.Report.Destination, accounting.Report.Format, ctx.WorkloadNamespaceClient, ctx.Config.WorkloadNamespace, ctx.Config.Name)
+	if err != nil {
+		return err
+	}
+
+	cronScheduler := cron.New(cron.WithChain(cron.SkipIfStillRunning(cronLogger{logger: logger})))
+	_, err = cronScheduler.AddFunc(accounting.Report.Schedule, func() {
+		report := accountant.Report(ctx.Config.Name, accountant.Now())
+		data, err := report.Encode(accounting.Report.Format)
+		if err != nil {
+			logger.Error(err, "encode report")
+			return
+		}
+
+		err = destination.Write(ctx, report, data)
+		if err != nil {
+			logger.Error(err, "write report", "destination", destination.String())
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("parse accounting report schedule %q: %w", accounting.Report.Schedule, err)
+	}
+
+	logger.Info("Start accounting reports", "schedule", accounting.Report.Schedule, "destination", destination.String())
+	cronScheduler.Start()
+	go func() {
+		<-ctx.Done()
+		<-cronScheduler.Stop().Done()
+	}()
+
+	return nil
+}
+
+// cronLogger adapts logr to the cron logger interface
+type cronLogger struct {
+	logger logr.Logger
+}
+
+func (c cronLogger) Info(msg string, keysAndValues ...interface{}) {
+	c.logger.V(1).Info(msg, keysAndValues...)
+}
+
+func (c cronLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	c.logger.Error(err, msg, keysAndValues...)
+}
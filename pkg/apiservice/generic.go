@@ -139,7 +139,9 @@ func createOperation(ctrlCtx *config.ControllerContext, serviceName string, host
 	}
 }
 
-func StartAPIServiceProxy(ctx *config.ControllerContext, targetServiceName, targetServiceNamespace string, targetPort, hostPort int) error {
+// StartAPIServiceProxy proxies the discovery paths of the api service to the target service in the host cluster. Other
+// paths are served by the resource handler if it is set and denied otherwise.
+func StartAPIServiceProxy(ctx *config.ControllerContext, targetServiceName, targetServiceNamespace string, targetPort, hostPort int, resourceHandler http.Handler) error {
 	tlsCertFile := ctx.Config.VirtualClusterKubeConfig().ServerCACert
 	tlsKeyFile := ctx.Config.VirtualClusterKubeConfig().ServerCAKey
 
@@ -168,6 +170,11 @@ func StartAPIServiceProxy(ctx *config.ControllerContext, targetServiceName, targ
 		Handler: http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
 			// we only allow traffic to discovery paths
 			if !isAPIServiceProxyPathAllowed(request.Method, request.URL.Path) {
+				if resourceHandler != nil {
+					resourceHandler.ServeHTTP(writer, request)
+					return
+				}
+
 				klog.FromContext(ctx).Info("Denied access to api service proxy at path", "path", request.URL.Path, "method", request.Method)
 				responsewriters.ErrorNegotiated(
 					kerrors.NewForbidden(metav1.SchemeGroupVersion.WithResource("proxy").GroupResource(), "proxy", fmt.Errorf("paths other than discovery paths are not allowed")),
@@ -0,0 +1,74 @@
+package oidcauthenticator
+
+import (
+	"context"
+	"fmt"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"k8s.io/apiserver/pkg/apis/apiserver"
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authentication/request/bearertoken"
+	"k8s.io/apiserver/pkg/server/dynamiccertificates"
+	"k8s.io/apiserver/plugin/pkg/authenticator/token/oidc"
+)
+
+// New creates a new authenticator that validates OIDC tokens of the configured issuer and maps the claims
+// to the user and groups within the virtual cluster. Tokens of other issuers are not authenticated.
+func New(ctx context.Context, config vclusterconfig.ControlPlaneProxyOIDC) (authenticator.Request, error) {
+	options := oidc.Options{
+		JWTAuthenticator: toJWTAuthenticator(config),
+	}
+	if config.CA != "" {
+		caContent, err := dynamiccertificates.NewStaticCAContent("oidc-ca", []byte(config.CA))
+		if err != nil {
+			return nil, fmt.Errorf("parse oidc ca: %w", err)
+		}
+
+		options.CAContentProvider = caContent
+	}
+
+	tokenAuthenticator, err := oidc.New(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("create oidc authenticator: %w", err)
+	}
+
+	return bearertoken.New(tokenAuthenticator), nil
+}
+
+// toJWTAuthenticator converts the vCluster oidc config into the api server jwt authenticator config. Prefixes
+// follow the same rules as the kube-apiserver --oidc-* flags.
+func toJWTAuthenticator(config vclusterconfig.ControlPlaneProxyOIDC) apiserver.JWTAuthenticator {
+	usernameClaim := config.UsernameClaim
+	if usernameClaim == "" {
+		usernameClaim = "sub"
+	}
+
+	usernamePrefix := config.UsernamePrefix
+	if usernamePrefix == "" && usernameClaim != "email" {
+		usernamePrefix = config.IssuerURL + "#"
+	} else if usernamePrefix == "-" {
+		usernamePrefix = ""
+	}
+
+	jwtAuthenticator := apiserver.JWTAuthenticator{
+		Issuer: apiserver.Issuer{
+			URL:       config.IssuerURL,
+			Audiences: []string{config.ClientID},
+		},
+		ClaimMappings: apiserver.ClaimMappings{
+			Username: apiserver.PrefixedClaimOrExpression{
+				Claim:  usernameClaim,
+				Prefix: &usernamePrefix,
+			},
+		},
+	}
+	if config.GroupsClaim != "" {
+		groupsPrefix := config.GroupsPrefix
+		jwtAuthenticator.ClaimMappings.Groups = apiserver.PrefixedClaimOrExpression{
+			Claim:  config.GroupsClaim,
+			Prefix: &groupsPrefix,
+		}
+	}
+
+	return jwtAuthenticator
+}
@@ -0,0 +1,155 @@
+package oidcauthenticator
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	jose "gopkg.in/square/go-jose.v2"
+	"gotest.tools/v3/assert"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/authentication/user"
+)
+
+func TestOIDCAuthenticator(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NilError(t, err)
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, (&jose.SignerOptions{}).WithHeader("kid", "test"))
+	assert.NilError(t, err)
+
+	// fake issuer that serves the discovery document and the signing keys
+	mux := http.NewServeMux()
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                server.URL,
+			"jwks_uri":                              server.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: key.Public(), KeyID: "test", Algorithm: "RS256", Use: "sig"}}})
+	})
+
+	newToken := func(claims map[string]interface{}) string {
+		payload, err := json.Marshal(claims)
+		assert.NilError(t, err)
+		signed, err := signer.Sign(payload)
+		assert.NilError(t, err)
+		token, err := signed.CompactSerialize()
+		assert.NilError(t, err)
+		return token
+	}
+	now := time.Now()
+	validClaims := map[string]interface{}{
+		"iss":    server.URL,
+		"aud":    "vcluster",
+		"sub":    "1234",
+		"email":  "jane@example.com",
+		"groups": []string{"developers", "admins"},
+		"exp":    now.Add(time.Hour).Unix(),
+		"iat":    now.Unix(),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	oidcAuthenticator, err := New(ctx, vclusterconfig.ControlPlaneProxyOIDC{
+		Enabled:       true,
+		IssuerURL:     server.URL,
+		ClientID:      "vcluster",
+		UsernameClaim: "email",
+		GroupsClaim:   "groups",
+		GroupsPrefix:  "oidc:",
+		CA:            string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})),
+	})
+	assert.NilError(t, err)
+
+	authenticate := func(token string) (user.Info, bool, error) {
+		req := httptest.NewRequest(http.MethodGet, "/api", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		response, ok, err := oidcAuthenticator.AuthenticateRequest(req)
+		if response == nil {
+			return nil, ok, err
+		}
+
+		return response.User, ok, err
+	}
+
+	// the verifier is initialized in the background
+	validToken := newToken(validClaims)
+	var info user.Info
+	err = wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, 10*time.Second, true, func(context.Context) (bool, error) {
+		var ok bool
+		info, ok, err = authenticate(validToken)
+		return ok && err == nil, nil
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, info.GetName(), "jane@example.com")
+	assert.DeepEqual(t, info.GetGroups(), []string{"oidc:developers", "oidc:admins"})
+
+	// tokens of other issuers are not authenticated
+	otherClaims := map[string]interface{}{}
+	for k, v := range validClaims {
+		otherClaims[k] = v
+	}
+	otherClaims["iss"] = "https://kubernetes.default.svc"
+	_, ok, err := authenticate(newToken(otherClaims))
+	assert.ErrorContains(t, err, "invalid bearer token")
+	assert.Assert(t, !ok)
+
+	// tokens for other clients are rejected
+	otherClaims["iss"] = server.URL
+	otherClaims["aud"] = "other"
+	_, ok, err = authenticate(newToken(otherClaims))
+	assert.ErrorContains(t, err, "oidc: verify token")
+	assert.Assert(t, !ok)
+}
+
+func TestToJWTAuthenticator(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		config                 vclusterconfig.ControlPlaneProxyOIDC
+		expectedUsernameClaim  string
+		expectedUsernamePrefix string
+	}{
+		{
+			name:                   "default",
+			config:                 vclusterconfig.ControlPlaneProxyOIDC{IssuerURL: "https://dex"},
+			expectedUsernameClaim:  "sub",
+			expectedUsernamePrefix: "https://dex#",
+		},
+		{
+			name:                  "email",
+			config:                vclusterconfig.ControlPlaneProxyOIDC{IssuerURL: "https://dex", UsernameClaim: "email"},
+			expectedUsernameClaim: "email",
+		},
+		{
+			name:                  "disabled prefix",
+			config:                vclusterconfig.ControlPlaneProxyOIDC{IssuerURL: "https://dex", UsernameClaim: "name", UsernamePrefix: "-"},
+			expectedUsernameClaim: "name",
+		},
+		{
+			name:                   "custom prefix",
+			config:                 vclusterconfig.ControlPlaneProxyOIDC{IssuerURL: "https://dex", UsernamePrefix: "dex:"},
+			expectedUsernameClaim:  "sub",
+			expectedUsernamePrefix: "dex:",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			jwtAuthenticator := toJWTAuthenticator(testCase.config)
+			assert.Equal(t, jwtAuthenticator.ClaimMappings.Username.Claim, testCase.expectedUsernameClaim)
+			assert.Equal(t, *jwtAuthenticator.ClaimMappings.Username.Prefix, testCase.expectedUsernamePrefix)
+			assert.Equal(t, jwtAuthenticator.ClaimMappings.Groups.Claim, "")
+		})
+	}
+}
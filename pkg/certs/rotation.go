@@ -0,0 +1,422 @@
+package certs
+
+import (
+	"crypto"
+	"crypto/x509"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/maps"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+)
+
+const (
+	// CARotationPhaseAnnotation holds the phase of an ongoing CA rotation on the certs secret
+	CARotationPhaseAnnotation = "vcluster.loft.sh/ca-rotation-phase"
+	// CARotationTimeAnnotation holds the time the current CA rotation phase started
+	CARotationTimeAnnotation = "vcluster.loft.sh/ca-rotation-time"
+	// CARotationTransitionPeriodAnnotation holds the duration of each CA rotation phase
+	CARotationTransitionPeriodAnnotation = "vcluster.loft.sh/ca-rotation-transition-period"
+
+	// CARotationPhaseTrust means the old and new CAs are trusted, but certificates are still signed by the old CAs
+	CARotationPhaseTrust = "Trust"
+	// CARotationPhaseSign means certificates are signed by the new CAs, but the old CAs are still trusted
+	CARotationPhaseSign = "Sign"
+)
+
+// SecretName returns the name of the secret that holds the certificates of the vCluster
+func SecretName(vClusterName string) string {
+	return vClusterName + "-certs"
+}
+
+// certificateAuthority holds the secret keys of a CA certificate and its private key
+type certificateAuthority struct {
+	cert string
+	key  string
+}
+
+// nextKey is the secret key that holds the private key of the new CA during the trust phase of a CA rotation
+func (c certificateAuthority) nextKey() string {
+	return strings.TrimSuffix(c.key, ".key") + "-next.key"
+}
+
+// leafCertificate holds the secret keys of a certificate, its private key and the CA that signs it
+type leafCertificate struct {
+	cert string
+	key  string
+	ca   certificateAuthority
+}
+
+var (
+	kubernetesCA = certificateAuthority{cert: certMap[CACertName], key: certMap[CAKeyName]}
+	frontProxyCA = certificateAuthority{cert: certMap[FrontProxyCACertName], key: certMap[FrontProxyCAKeyName]}
+	etcdCA       = certificateAuthority{cert: certMap[EtcdCACertName], key: certMap[EtcdCAKeyName]}
+
+	certificateAuthorities = []certificateAuthority{kubernetesCA, frontProxyCA, etcdCA}
+
+	leafCertificates = []leafCertificate{
+		{cert: certMap[APIServerCertName], key: certMap[APIServerKeyName], ca: kubernetesCA},
+		{cert: certMap[APIServerKubeletClientCertName], key: certMap[APIServerKubeletClientKeyName], ca: kubernetesCA},
+		{cert: certMap[FrontProxyClientCertName], key: certMap[FrontProxyClientKeyName], ca: frontProxyCA},
+		{cert: certMap[APIServerEtcdClientCertName], key: certMap[APIServerEtcdClientKeyName], ca: etcdCA},
+		{cert: certMap[EtcdServerCertName], key: certMap[EtcdServerKeyName], ca: etcdCA},
+		{cert: certMap[EtcdPeerCertName], key: certMap[EtcdPeerKeyName], ca: etcdCA},
+		{cert: certMap[EtcdHealthcheckClientCertName], key: certMap[EtcdHealthcheckClientKeyName], ca: etcdCA},
+	}
+
+	// kubeConfigs hold client certificates signed by the kubernetes CA
+	kubeConfigs = []string{
+		certMap[AdminKubeConfigFileName],
+		certMap[ControllerManagerKubeConfigFileName],
+		certMap[SchedulerKubeConfigFileName],
+	}
+)
+
+// CertificateInfo describes a certificate within the certs secret
+type CertificateInfo struct {
+	Name     string
+	Subject  string
+	Issuer   string
+	CA       bool
+	NotAfter time.Time
+}
+
+// CertificateInfos returns the certificates and kube config client certificates within the certs secret data
+// sorted by name. CA bundles return an entry for each certificate.
+func CertificateInfos(data map[string][]byte) ([]CertificateInfo, error) {
+	infos := []CertificateInfo{}
+	keys := maps.Keys(data)
+	slices.Sort(keys)
+	for _, key := range keys {
+		var certs []*x509.Certificate
+		if strings.HasSuffix(key, ".crt") {
+			var err error
+			certs, err = certutil.ParseCertsPEM(data[key])
+			if err != nil {
+				return nil, fmt.Errorf("parse %s: %w", key, err)
+			}
+		} else if strings.HasSuffix(key, ".conf") {
+			kubeConfig, err := clientcmd.Load(data[key])
+			if err != nil {
+				return nil, fmt.Errorf("parse %s: %w", key, err)
+			}
+
+			for _, authInfo := range kubeConfig.AuthInfos {
+				if len(authInfo.ClientCertificateData) == 0 {
+					continue
+				}
+
+				clientCerts, err := certutil.ParseCertsPEM(authInfo.ClientCertificateData)
+				if err != nil {
+					return nil, fmt.Errorf("parse client certificate in %s: %w", key, err)
+				}
+				certs = append(certs, clientCerts...)
+			}
+		}
+
+		for _, cert := range certs {
+			infos = append(infos, CertificateInfo{
+				Name:     key,
+				Subject:  cert.Subject.CommonName,
+				Issuer:   cert.Issuer.CommonName,
+				CA:       cert.IsCA,
+				NotAfter: cert.NotAfter,
+			})
+		}
+	}
+
+	return infos, nil
+}
+
+// NeedsRenewal returns true if a leaf certificate or kube config client certificate within the certs secret data
+// expires before the given time.
+func NeedsRenewal(data map[string][]byte, before time.Time) (bool, error) {
+	infos, err := CertificateInfos(data)
+	if err != nil {
+		return false, err
+	}
+
+	for _, info := range infos {
+		if !info.CA && info.NotAfter.Before(before) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// RenewCertificates re-issues the leaf certificates and kube config client certificates within the certs secret data
+// with the current CAs. The subject, SANs and usages of the existing certificates are kept.
+func RenewCertificates(data map[string][]byte) error {
+	for _, leaf := range leafCertificates {
+		if len(data[leaf.cert]) == 0 {
+			continue
+		}
+
+		caCert, caKey, err := loadCertificateAuthority(data, leaf.ca)
+		if err != nil {
+			return err
+		}
+
+		certPEM, keyPEM, err := renewCertificate(data[leaf.cert], caCert, caKey)
+		if err != nil {
+			return fmt.Errorf("renew %s: %w", leaf.cert, err)
+		}
+
+		data[leaf.cert] = certPEM
+		data[leaf.key] = keyPEM
+	}
+
+	for _, name := range kubeConfigs {
+		if len(data[name]) == 0 {
+			continue
+		}
+
+		caCert, caKey, err := loadCertificateAuthority(data, kubernetesCA)
+		if err != nil {
+			return err
+		}
+
+		kubeConfig, err := clientcmd.Load(data[name])
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", name, err)
+		}
+		for _, authInfo := range kubeConfig.AuthInfos {
+			if len(authInfo.ClientCertificateData) == 0 {
+				continue
+			}
+
+			authInfo.ClientCertificateData, authInfo.ClientKeyData, err = renewCertificate(authInfo.ClientCertificateData, caCert, caKey)
+			if err != nil {
+				return fmt.Errorf("renew client certificate in %s: %w", name, err)
+			}
+		}
+
+		data[name], err = clientcmd.Write(*kubeConfig)
+		if err != nil {
+			return fmt.Errorf("encode %s: %w", name, err)
+		}
+	}
+
+	return updateKubeConfigCAs(data)
+}
+
+// StartCARotation creates new CAs and adds them to the trusted CA bundles within the certs secret. Certificates are
+// still signed by the old CAs until AdvanceCARotation switches to the new CAs after the transition period.
+func StartCARotation(secret *corev1.Secret, transitionPeriod time.Duration, now time.Time) error {
+	if phase := secret.Annotations[CARotationPhaseAnnotation]; phase != "" {
+		return fmt.Errorf("ca rotation is already in progress in phase %s", phase)
+	}
+
+	for _, ca := range certificateAuthorities {
+		if len(secret.Data[ca.cert]) == 0 {
+			continue
+		}
+
+		caCert, _, err := loadCertificateAuthority(secret.Data, ca)
+		if err != nil {
+			return err
+		}
+
+		newCACert, newCAKey, err := NewCertificateAuthority(&CertConfig{
+			Config: certutil.Config{
+				CommonName:   caCert.Subject.CommonName,
+				Organization: caCert.Subject.Organization,
+			},
+			PublicKeyAlgorithm: caCert.PublicKeyAlgorithm,
+		})
+		if err != nil {
+			return fmt.Errorf("create new %s: %w", ca.cert, err)
+		}
+		keyPEM, err := keyutil.MarshalPrivateKeyToPEM(newCAKey)
+		if err != nil {
+			return fmt.Errorf("encode new %s: %w", ca.key, err)
+		}
+
+		secret.Data[ca.cert] = append(EncodeCertPEM(caCert), EncodeCertPEM(newCACert)...)
+		secret.Data[ca.nextKey()] = keyPEM
+	}
+
+	err := updateKubeConfigCAs(secret.Data)
+	if err != nil {
+		return err
+	}
+
+	setCARotationPhase(secret, CARotationPhaseTrust, now)
+	secret.Annotations[CARotationTransitionPeriodAnnotation] = transitionPeriod.String()
+	return nil
+}
+
+// AdvanceCARotation moves an ongoing CA rotation to the next phase if the transition period of the current phase
+// has passed. After the trust phase the certificates are re-issued by the new CAs, after the sign phase the old CAs
+// are removed from the trusted CA bundles. Returns true if the secret was changed.
+func AdvanceCARotation(secret *corev1.Secret, now time.Time) (bool, error) {
+	phase := secret.Annotations[CARotationPhaseAnnotation]
+	if phase == "" {
+		return false, nil
+	}
+
+	next, err := NextCARotationPhaseTime(secret)
+	if err != nil {
+		return false, err
+	} else if now.Before(next) {
+		return false, nil
+	}
+
+	switch phase {
+	case CARotationPhaseTrust:
+		for _, ca := range certificateAuthorities {
+			if len(secret.Data[ca.nextKey()]) == 0 {
+				continue
+			}
+
+			bundle, err := certutil.ParseCertsPEM(secret.Data[ca.cert])
+			if err != nil {
+				return false, fmt.Errorf("parse %s: %w", ca.cert, err)
+			} else if len(bundle) != 2 {
+				return false, fmt.Errorf("expected old and new certificate in %s, got %d certificates", ca.cert, len(bundle))
+			}
+
+			// the first certificate of the bundle is used for signing
+			secret.Data[ca.cert] = append(EncodeCertPEM(bundle[1]), EncodeCertPEM(bundle[0])...)
+			secret.Data[ca.key] = secret.Data[ca.nextKey()]
+			delete(secret.Data, ca.nextKey())
+		}
+
+		err = RenewCertificates(secret.Data)
+		if err != nil {
+			return false, err
+		}
+
+		setCARotationPhase(secret, CARotationPhaseSign, now)
+	case CARotationPhaseSign:
+		for _, ca := range certificateAuthorities {
+			if len(secret.Data[ca.cert]) == 0 {
+				continue
+			}
+
+			caCert, _, err := loadCertificateAuthority(secret.Data, ca)
+			if err != nil {
+				return false, err
+			}
+
+			secret.Data[ca.cert] = EncodeCertPEM(caCert)
+		}
+
+		err = updateKubeConfigCAs(secret.Data)
+		if err != nil {
+			return false, err
+		}
+
+		delete(secret.Annotations, CARotationPhaseAnnotation)
+		delete(secret.Annotations, CARotationTimeAnnotation)
+		delete(secret.Annotations, CARotationTransitionPeriodAnnotation)
+	default:
+		return false, fmt.Errorf("unknown ca rotation phase %s", phase)
+	}
+
+	return true, nil
+}
+
+// NextCARotationPhaseTime returns the time when the ongoing CA rotation moves to the next phase
+func NextCARotationPhaseTime(secret *corev1.Secret) (time.Time, error) {
+	started, err := time.Parse(time.RFC3339, secret.Annotations[CARotationTimeAnnotation])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse annotation %s: %w", CARotationTimeAnnotation, err)
+	}
+	transitionPeriod, err := time.ParseDuration(secret.Annotations[CARotationTransitionPeriodAnnotation])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse annotation %s: %w", CARotationTransitionPeriodAnnotation, err)
+	}
+
+	return started.Add(transitionPeriod), nil
+}
+
+func setCARotationPhase(secret *corev1.Secret, phase string, now time.Time) {
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+
+	secret.Annotations[CARotationPhaseAnnotation] = phase
+	secret.Annotations[CARotationTimeAnnotation] = now.UTC().Format(time.RFC3339)
+}
+
+// updateKubeConfigCAs sets the current kubernetes CA bundle in the kube configs
+func updateKubeConfigCAs(data map[string][]byte) error {
+	for _, name := range kubeConfigs {
+		if len(data[name]) == 0 {
+			continue
+		}
+
+		kubeConfig, err := clientcmd.Load(data[name])
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", name, err)
+		}
+		for _, cluster := range kubeConfig.Clusters {
+			cluster.CertificateAuthorityData = data[kubernetesCA.cert]
+		}
+
+		data[name], err = clientcmd.Write(*kubeConfig)
+		if err != nil {
+			return fmt.Errorf("encode %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// loadCertificateAuthority returns the signing certificate and key of the CA, which is the first certificate of the bundle
+func loadCertificateAuthority(data map[string][]byte, ca certificateAuthority) (*x509.Certificate, crypto.Signer, error) {
+	certs, err := certutil.ParseCertsPEM(data[ca.cert])
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse %s: %w", ca.cert, err)
+	}
+
+	key, err := keyutil.ParsePrivateKeyPEM(data[ca.key])
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse %s: %w", ca.key, err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported private key type in %s", ca.key)
+	}
+
+	return certs[0], signer, nil
+}
+
+// renewCertificate issues a new certificate and key with the subject, SANs and usages of the given certificate
+func renewCertificate(certPEM []byte, caCert *x509.Certificate, caKey crypto.Signer) ([]byte, []byte, error) {
+	certs, err := certutil.ParseCertsPEM(certPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cert := certs[0]
+	newCert, newKey, err := NewCertAndKey(caCert, caKey, &CertConfig{
+		Config: certutil.Config{
+			CommonName:   cert.Subject.CommonName,
+			Organization: cert.Subject.Organization,
+			AltNames: certutil.AltNames{
+				DNSNames: cert.DNSNames,
+				IPs:      cert.IPAddresses,
+			},
+			Usages: cert.ExtKeyUsage,
+		},
+		PublicKeyAlgorithm: cert.PublicKeyAlgorithm,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyPEM, err := keyutil.MarshalPrivateKeyToPEM(newKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return EncodeCertPEM(newCert), keyPEM, nil
+}
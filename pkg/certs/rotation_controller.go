@@ -0,0 +1,149 @@
+package certs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"time"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"golang.org/x/exp/maps"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// RotationController renews the certificates within the certs secret before they expire and advances ongoing
+// CA rotations. As the control plane only reads the certificates on startup, it is reloaded whenever the
+// certificates within the secret change.
+type RotationController struct {
+	Client     kubernetes.Interface
+	Namespace  string
+	SecretName string
+
+	RenewBefore time.Duration
+
+	// Reload restarts the control plane to load the changed certificates
+	Reload func()
+	Now    func() time.Time
+
+	loadedChecksum string
+}
+
+// StartRotationController starts checking the certificates of the vCluster in the given interval. This is called
+// by every replica after the certificates were downloaded, concurrent updates of the secret are prevented by
+// the resource version. reload is called whenever the certificates within the secret change.
+func StartRotationController(ctx context.Context, client kubernetes.Interface, namespace, vClusterName string, rotation vclusterconfig.ControlPlaneCertificateRotation, reload func()) error {
+	renewBefore, err := time.ParseDuration(rotation.RenewBefore)
+	if err != nil {
+		return fmt.Errorf("parse renew before: %w", err)
+	}
+	checkInterval, err := time.ParseDuration(rotation.CheckInterval)
+	if err != nil {
+		return fmt.Errorf("parse check interval: %w", err)
+	}
+
+	controller := &RotationController{
+		Client:      client,
+		Namespace:   namespace,
+		SecretName:  SecretName(vClusterName),
+		RenewBefore: renewBefore,
+		Reload:      reload,
+		Now:         time.Now,
+	}
+	err = controller.Init(ctx)
+	if err != nil {
+		return err
+	}
+
+	go wait.UntilWithContext(ctx, func(ctx context.Context) {
+		err := controller.Check(ctx)
+		if err != nil {
+			klog.Errorf("Error checking certificates: %v", err)
+		}
+	}, checkInterval)
+	return nil
+}
+
+// Init remembers the currently loaded certificates
+func (r *RotationController) Init(ctx context.Context) error {
+	secret, err := r.Client.CoreV1().Secrets(r.Namespace).Get(ctx, r.SecretName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("get certs secret: %w", err)
+	}
+
+	r.loadedChecksum = checksum(secret.Data)
+	return nil
+}
+
+// Check renews the certificates or advances the CA rotation if needed and reloads the control plane if the
+// certificates within the secret differ from the loaded ones.
+func (r *RotationController) Check(ctx context.Context) error {
+	secret, err := r.Client.CoreV1().Secrets(r.Namespace).Get(ctx, r.SecretName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("get certs secret: %w", err)
+	}
+
+	// certificates were changed by another replica or the cli
+	if checksum(secret.Data) != r.loadedChecksum {
+		r.Reload()
+		return nil
+	}
+
+	changed, err := r.rotate(secret)
+	if err != nil {
+		return err
+	} else if !changed {
+		return nil
+	}
+
+	_, err = r.Client.CoreV1().Secrets(r.Namespace).Update(ctx, secret, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("update certs secret: %w", err)
+	}
+
+	r.Reload()
+	return nil
+}
+
+func (r *RotationController) rotate(secret *corev1.Secret) (bool, error) {
+	now := r.Now()
+	advanced, err := AdvanceCARotation(secret, now)
+	if err != nil {
+		return false, fmt.Errorf("advance ca rotation: %w", err)
+	} else if advanced {
+		klog.Infof("Advanced ca rotation to phase %q", secret.Annotations[CARotationPhaseAnnotation])
+		return true, nil
+	}
+
+	needsRenewal, err := NeedsRenewal(secret.Data, now.Add(r.RenewBefore))
+	if err != nil {
+		return false, err
+	} else if !needsRenewal {
+		return false, nil
+	}
+
+	klog.Info("Renewing certificates that expire within " + r.RenewBefore.String())
+	err = RenewCertificates(secret.Data)
+	if err != nil {
+		return false, fmt.Errorf("renew certificates: %w", err)
+	}
+
+	return true, nil
+}
+
+func checksum(data map[string][]byte) string {
+	hash := sha256.New()
+	keys := maps.Keys(data)
+	slices.Sort(keys)
+	for _, key := range keys {
+		hash.Write([]byte(key))
+		hash.Write(data[key])
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
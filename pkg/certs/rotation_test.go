@@ -0,0 +1,177 @@
+package certs
+
+import (
+	"context"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/clientcmd"
+	certutil "k8s.io/client-go/util/cert"
+)
+
+func newTestCertsData(t *testing.T) map[string][]byte {
+	certificateDir := t.TempDir()
+	err := generateCertificates("10.96.0.0/12", "test", certificateDir, "cluster.local", []string{"localhost", "test-etcd"})
+	assert.NilError(t, err)
+
+	data := map[string][]byte{}
+	for fromName, toName := range certMap {
+		data[toName], err = os.ReadFile(filepath.Join(certificateDir, fromName))
+		assert.NilError(t, err)
+	}
+
+	return data
+}
+
+func parseCerts(t *testing.T, data []byte) []*x509.Certificate {
+	certs, err := certutil.ParseCertsPEM(data)
+	assert.NilError(t, err)
+	return certs
+}
+
+func assertSignedBy(t *testing.T, data []byte, ca *x509.Certificate) {
+	cert := parseCerts(t, data)[0]
+	assert.NilError(t, cert.CheckSignatureFrom(ca))
+}
+
+func TestRenewCertificates(t *testing.T) {
+	data := newTestCertsData(t)
+	oldAPIServer := parseCerts(t, data[APIServerCertName])[0]
+
+	infos, err := CertificateInfos(data)
+	assert.NilError(t, err)
+	assert.Equal(t, len(infos), 13)
+
+	needsRenewal, err := NeedsRenewal(data, time.Now())
+	assert.NilError(t, err)
+	assert.Assert(t, !needsRenewal)
+	needsRenewal, err = NeedsRenewal(data, time.Now().Add(CertificateValidity+time.Hour))
+	assert.NilError(t, err)
+	assert.Assert(t, needsRenewal)
+
+	err = RenewCertificates(data)
+	assert.NilError(t, err)
+
+	newAPIServer := parseCerts(t, data[APIServerCertName])[0]
+	assert.Assert(t, newAPIServer.SerialNumber.Cmp(oldAPIServer.SerialNumber) != 0)
+	assert.DeepEqual(t, newAPIServer.DNSNames, oldAPIServer.DNSNames)
+	assert.Equal(t, len(newAPIServer.IPAddresses), len(oldAPIServer.IPAddresses))
+	assert.DeepEqual(t, newAPIServer.ExtKeyUsage, oldAPIServer.ExtKeyUsage)
+
+	caCert := parseCerts(t, data[CACertName])[0]
+	assertSignedBy(t, data[APIServerCertName], caCert)
+	assertSignedBy(t, data[certMap[EtcdServerCertName]], parseCerts(t, data[certMap[EtcdCACertName]])[0])
+
+	kubeConfig, err := clientcmd.Load(data[AdminKubeConfigFileName])
+	assert.NilError(t, err)
+	for _, authInfo := range kubeConfig.AuthInfos {
+		assertSignedBy(t, authInfo.ClientCertificateData, caCert)
+	}
+}
+
+func TestCARotation(t *testing.T) {
+	secret := &corev1.Secret{Data: newTestCertsData(t)}
+	oldCA := parseCerts(t, secret.Data[CACertName])[0]
+	now := time.Now()
+
+	err := StartCARotation(secret, time.Hour, now)
+	assert.NilError(t, err)
+	assert.Equal(t, secret.Annotations[CARotationPhaseAnnotation], CARotationPhaseTrust)
+	bundle := parseCerts(t, secret.Data[CACertName])
+	assert.Equal(t, len(bundle), 2)
+	assert.Assert(t, bundle[0].Equal(oldCA))
+	newCA := bundle[1]
+	assert.Assert(t, len(secret.Data["ca-next.key"]) > 0)
+	assertSignedBy(t, secret.Data[APIServerCertName], oldCA)
+
+	err = StartCARotation(secret, time.Hour, now)
+	assert.ErrorContains(t, err, "ca rotation is already in progress in phase Trust")
+
+	// nothing happens within the transition period
+	advanced, err := AdvanceCARotation(secret, now.Add(time.Minute))
+	assert.NilError(t, err)
+	assert.Assert(t, !advanced)
+
+	// certificates are signed by the new ca
+	advanced, err = AdvanceCARotation(secret, now.Add(time.Hour))
+	assert.NilError(t, err)
+	assert.Assert(t, advanced)
+	assert.Equal(t, secret.Annotations[CARotationPhaseAnnotation], CARotationPhaseSign)
+	bundle = parseCerts(t, secret.Data[CACertName])
+	assert.Equal(t, len(bundle), 2)
+	assert.Assert(t, bundle[0].Equal(newCA))
+	assert.Assert(t, bundle[1].Equal(oldCA))
+	_, ok := secret.Data["ca-next.key"]
+	assert.Assert(t, !ok)
+	assertSignedBy(t, secret.Data[APIServerCertName], newCA)
+	assertSignedBy(t, secret.Data[FrontProxyClientCertName], parseCerts(t, secret.Data[FrontProxyCACertName])[0])
+
+	// the old ca is removed
+	advanced, err = AdvanceCARotation(secret, now.Add(2*time.Hour))
+	assert.NilError(t, err)
+	assert.Assert(t, advanced)
+	_, ok = secret.Annotations[CARotationPhaseAnnotation]
+	assert.Assert(t, !ok)
+	bundle = parseCerts(t, secret.Data[CACertName])
+	assert.Equal(t, len(bundle), 1)
+	assert.Assert(t, bundle[0].Equal(newCA))
+
+	kubeConfig, err := clientcmd.Load(secret.Data[AdminKubeConfigFileName])
+	assert.NilError(t, err)
+	for _, cluster := range kubeConfig.Clusters {
+		assert.Equal(t, string(cluster.CertificateAuthorityData), string(secret.Data[CACertName]))
+	}
+}
+
+func TestRotationController(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: SecretName("test"), Namespace: "test"},
+		Data:       newTestCertsData(t),
+	})
+
+	reloads := 0
+	controller := &RotationController{
+		Client:      client,
+		Namespace:   "test",
+		SecretName:  SecretName("test"),
+		RenewBefore: 720 * time.Hour,
+		Reload:      func() { reloads++ },
+		Now:         time.Now,
+	}
+	err := controller.Init(ctx)
+	assert.NilError(t, err)
+
+	// certificates are valid
+	err = controller.Check(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, reloads, 0)
+
+	// certificates expire soon
+	controller.Now = func() time.Time { return time.Now().Add(CertificateValidity) }
+	err = controller.Check(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, reloads, 1)
+
+	secret, err := client.CoreV1().Secrets("test").Get(ctx, SecretName("test"), metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Assert(t, parseCerts(t, secret.Data[APIServerCertName])[0].NotAfter.After(time.Now().Add(CertificateValidity-time.Hour)))
+
+	// changes by other replicas reload the control plane as well
+	controller.Now = time.Now
+	err = controller.Init(ctx)
+	assert.NilError(t, err)
+	secret.Data[APIServerCertName] = []byte("changed")
+	_, err = client.CoreV1().Secrets("test").Update(ctx, secret, metav1.UpdateOptions{})
+	assert.NilError(t, err)
+	err = controller.Check(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, reloads, 2)
+}
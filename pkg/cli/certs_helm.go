@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/log/table"
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/certs"
+	"github.com/loft-sh/vcluster/pkg/cli/find"
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/loft-sh/vcluster/pkg/lifecycle"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+type CertsRotateOptions struct {
+	CA               bool
+	TransitionPeriod time.Duration
+}
+
+// CertsCheckHelm prints the expiry of the certificates of the given vCluster
+func CertsCheckHelm(ctx context.Context, globalFlags *flags.GlobalFlags, vClusterName string, log log.Logger) error {
+	_, _, secret, err := getCertsSecret(ctx, globalFlags, vClusterName, log)
+	if err != nil {
+		return err
+	}
+
+	infos, err := certs.CertificateInfos(secret.Data)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	values := [][]string{}
+	for _, info := range infos {
+		residualTime := "expired"
+		if info.NotAfter.After(now) {
+			residualTime = duration.HumanDuration(info.NotAfter.Sub(now))
+		}
+
+		values = append(values, []string{info.Name, info.Subject, info.Issuer, fmt.Sprintf("%t", info.CA), info.NotAfter.UTC().Format(time.RFC3339), residualTime})
+	}
+	table.PrintTable(log, []string{"NAME", "SUBJECT", "ISSUER", "CA", "EXPIRES", "RESIDUAL TIME"}, values)
+
+	if phase := secret.Annotations[certs.CARotationPhaseAnnotation]; phase != "" {
+		next, err := certs.NextCARotationPhaseTime(secret)
+		if err != nil {
+			return err
+		}
+
+		log.Infof("CA rotation is in phase %s until %s", phase, next.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// CertsRotateHelm renews the certificates of the given vCluster or starts or advances a rotation of its CAs. The
+// vCluster pods are restarted afterwards to load the new certificates.
+func CertsRotateHelm(ctx context.Context, options *CertsRotateOptions, globalFlags *flags.GlobalFlags, vClusterName string, log log.Logger) error {
+	vCluster, kubeClient, secret, err := getCertsSecret(ctx, globalFlags, vClusterName, log)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	phase := secret.Annotations[certs.CARotationPhaseAnnotation]
+	if options.CA && phase == "" {
+		// the deployed etcd only loads the trusted client CAs on startup and isn't restarted during the rotation,
+		// so it would reject the api server as soon as the client certificates are signed by the new CA
+		deployedEtcd, err := deploysEtcd(ctx, kubeClient, vCluster.Name, vCluster.Namespace)
+		if err != nil {
+			return err
+		} else if deployedEtcd {
+			return fmt.Errorf("rotating the certificate authorities of vcluster %s/%s is not supported with controlPlane.backingStore.etcd.deploy.enabled", vCluster.Namespace, vCluster.Name)
+		}
+
+		err = certs.StartCARotation(secret, options.TransitionPeriod, now)
+		if err != nil {
+			return err
+		}
+
+		log.Infof("Started ca rotation, the old and new CAs are trusted for %s", options.TransitionPeriod.String())
+	} else if phase != "" {
+		advanced, err := certs.AdvanceCARotation(secret, now)
+		if err != nil {
+			return err
+		} else if !advanced {
+			next, err := certs.NextCARotationPhaseTime(secret)
+			if err != nil {
+				return err
+			}
+
+			log.Infof("CA rotation is in phase %s until %s, run the command again afterwards to advance it", phase, next.UTC().Format(time.RFC3339))
+			return nil
+		}
+
+		if newPhase := secret.Annotations[certs.CARotationPhaseAnnotation]; newPhase != "" {
+			log.Infof("Advanced ca rotation to phase %s, certificates are now signed by the new CAs", newPhase)
+		} else {
+			log.Info("Finished ca rotation, the old CAs are not trusted anymore. Please run 'vcluster connect' again to update your kube config")
+		}
+	} else {
+		err = certs.RenewCertificates(secret.Data)
+		if err != nil {
+			return err
+		}
+
+		log.Info("Renewed certificates")
+	}
+
+	_, err = kubeClient.CoreV1().Secrets(vCluster.Namespace).Update(ctx, secret, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("update certs secret: %w", err)
+	}
+
+	// restart the vCluster to load the new certificates
+	if vCluster.Status == find.StatusRunning {
+		err = lifecycle.DeletePods(ctx, kubeClient, "app=vcluster,release="+vCluster.Name, vCluster.Namespace, log)
+		if err != nil {
+			return fmt.Errorf("restart vcluster pods: %w", err)
+		}
+	}
+
+	log.Donef("Successfully rotated certificates of vcluster %s/%s", vCluster.Namespace, vCluster.Name)
+	return nil
+}
+
+// deploysEtcd checks if the vCluster uses a deployed etcd as backing store
+func deploysEtcd(ctx context.Context, kubeClient kubernetes.Interface, vClusterName, namespace string) (bool, error) {
+	secret, err := kubeClient.CoreV1().Secrets(namespace).Get(ctx, "vc-config-"+vClusterName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("get vcluster config: %w", err)
+	}
+
+	vClusterConfig := &vclusterconfig.Config{}
+	err = yaml.Unmarshal(secret.Data["config.yaml"], vClusterConfig)
+	if err != nil {
+		return false, fmt.Errorf("parse vcluster config: %w", err)
+	}
+
+	return vClusterConfig.ControlPlane.BackingStore.Etcd.Deploy.Enabled, nil
+}
+
+func getCertsSecret(ctx context.Context, globalFlags *flags.GlobalFlags, vClusterName string, log log.Logger) (*find.VCluster, *kubernetes.Clientset, *corev1.Secret, error) {
+	vCluster, err := find.GetVCluster(ctx, globalFlags.Context, vClusterName, globalFlags.Namespace, log)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	kubeConfig, err := vCluster.ClientFactory.ClientConfig()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("load kube config: %w", err)
+	}
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	secret, err := kubeClient.CoreV1().Secrets(vCluster.Namespace).Get(ctx, certs.SecretName(vCluster.Name), metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("get certs secret: %w", err)
+	}
+
+	return vCluster, kubeClient, secret, nil
+}
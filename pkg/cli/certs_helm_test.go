@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDeploysEtcd(t *testing.T) {
+	ctx := context.Background()
+	configSecret := func(config string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "vc-config-my-vcluster", Namespace: "test"},
+			Data:       map[string][]byte{"config.yaml": []byte(config)},
+		}
+	}
+
+	deployed, err := deploysEtcd(ctx, fake.NewSimpleClientset(), "my-vcluster", "test")
+	assert.NilError(t, err)
+	assert.Assert(t, !deployed)
+
+	deployed, err = deploysEtcd(ctx, fake.NewSimpleClientset(configSecret("controlPlane:\n  backingStore:\n    etcd:\n      embedded:\n        enabled: true\n")), "my-vcluster", "test")
+	assert.NilError(t, err)
+	assert.Assert(t, !deployed)
+
+	deployed, err = deploysEtcd(ctx, fake.NewSimpleClientset(configSecret("controlPlane:\n  backingStore:\n    etcd:\n      deploy:\n        enabled: true\n")), "my-vcluster", "test")
+	assert.NilError(t, err)
+	assert.Assert(t, deployed)
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
@@ -15,6 +16,7 @@ import (
 	"time"
 
 	"github.com/loft-sh/log"
+	vclusterconfig "github.com/loft-sh/vcluster/config"
 	"github.com/loft-sh/vcluster/pkg/cli/find"
 	"github.com/loft-sh/vcluster/pkg/cli/flags"
 	"github.com/loft-sh/vcluster/pkg/cli/localkubernetes"
@@ -54,6 +56,12 @@ type ConnectOptions struct {
 	BackgroundProxy           bool
 	Insecure                  bool
 
+	OIDC             bool
+	OIDCIssuerURL    string
+	OIDCClientID     string
+	OIDCClientSecret string
+	OIDCExtraScopes  []string
+
 	Project string
 }
 
@@ -416,9 +424,85 @@ func (cmd *connectHelm) getVClusterKubeConfig(ctx context.Context, vclusterName
 		}
 	}
 
+	// we want to use oidc tokens in the kube config
+	if cmd.OIDC {
+		oidcConfig, err := getOIDCConfig(ctx, cmd.kubeClient, vclusterName, cmd.Namespace, cmd.ConnectOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		// set exec credential plugin
+		for k := range kubeConfig.AuthInfos {
+			kubeConfig.AuthInfos[k] = oidcAuthInfo(oidcConfig, cmd.ConnectOptions)
+		}
+	}
+
 	return kubeConfig, nil
 }
 
+// getOIDCConfig returns the oidc config of the vCluster, which can be overridden by the connect flags
+func getOIDCConfig(ctx context.Context, kubeClient kubernetes.Interface, vClusterName, namespace string, options *ConnectOptions) (vclusterconfig.ControlPlaneProxyOIDC, error) {
+	oidcConfig := vclusterconfig.ControlPlaneProxyOIDC{}
+	secret, err := kubeClient.CoreV1().Secrets(namespace).Get(ctx, "vc-config-"+vClusterName, metav1.GetOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return oidcConfig, fmt.Errorf("get vcluster config: %w", err)
+	} else if err == nil {
+		vClusterConfig := &vclusterconfig.Config{}
+		err = vClusterConfig.UnmarshalYAMLStrict(secret.Data["config.yaml"])
+		if err != nil {
+			return oidcConfig, fmt.Errorf("parse vcluster config: %w", err)
+		}
+
+		oidcConfig = vClusterConfig.ControlPlane.Proxy.OIDC
+		if !oidcConfig.Enabled {
+			return oidcConfig, fmt.Errorf("oidc is not enabled for vcluster %s, please enable controlPlane.proxy.oidc", vClusterName)
+		}
+	}
+
+	if options.OIDCIssuerURL != "" {
+		oidcConfig.IssuerURL = options.OIDCIssuerURL
+	}
+	if options.OIDCClientID != "" {
+		oidcConfig.ClientID = options.OIDCClientID
+	}
+	if oidcConfig.IssuerURL == "" || oidcConfig.ClientID == "" {
+		return oidcConfig, fmt.Errorf("couldn't determine oidc issuer url and client id of vcluster %s, please specify --oidc-issuer-url and --oidc-client-id", vClusterName)
+	}
+
+	return oidcConfig, nil
+}
+
+// oidcAuthInfo returns an auth info that retrieves the oidc token via the kubelogin exec credential plugin
+func oidcAuthInfo(oidcConfig vclusterconfig.ControlPlaneProxyOIDC, options *ConnectOptions) *clientcmdapi.AuthInfo {
+	args := []string{
+		"oidc-login",
+		"get-token",
+		"--oidc-issuer-url=" + oidcConfig.IssuerURL,
+		"--oidc-client-id=" + oidcConfig.ClientID,
+	}
+	if options.OIDCClientSecret != "" {
+		args = append(args, "--oidc-client-secret="+options.OIDCClientSecret)
+	}
+	for _, scope := range options.OIDCExtraScopes {
+		args = append(args, "--oidc-extra-scope="+scope)
+	}
+	if oidcConfig.CA != "" {
+		args = append(args, "--certificate-authority-data="+base64.StdEncoding.EncodeToString([]byte(oidcConfig.CA)))
+	}
+
+	return &clientcmdapi.AuthInfo{
+		Exec: &clientcmdapi.ExecConfig{
+			APIVersion:      "client.authentication.k8s.io/v1beta1",
+			Command:         "kubectl",
+			Args:            args,
+			InstallHint:     "kubelogin is required to log in with OIDC, see https://github.com/int128/kubelogin for installation instructions",
+			InteractiveMode: clientcmdapi.IfAvailableExecInteractiveMode,
+		},
+		Extensions:           make(map[string]runtime.Object),
+		ImpersonateUserExtra: make(map[string][]string),
+	}
+}
+
 func (cmd *connectHelm) setServerIfExposed(ctx context.Context, vClusterName string, vClusterConfig *clientcmdapi.Config) error {
 	printedWaiting := false
 	err := wait.PollUntilContextTimeout(ctx, time.Second*2, time.Minute*5, true, func(ctx context.Context) (done bool, err error) {
@@ -1,10 +1,14 @@
 package cli
 
 import (
+	"context"
 	"testing"
 
 	"github.com/loft-sh/vcluster/pkg/cli/flags"
 	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
@@ -99,3 +103,54 @@ func TestExchangeContextName(t *testing.T) {
 		assert.DeepEqual(t, newConfig, testCase.expectedConfig)
 	}
 }
+
+func TestOIDCAuthInfo(t *testing.T) {
+	ctx := context.Background()
+	kubeClient := fake.NewSimpleClientset(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "vc-config-oidc", Namespace: "test"},
+			Data: map[string][]byte{"config.yaml": []byte(`controlPlane:
+  proxy:
+    oidc:
+      enabled: true
+      issuerURL: https://dex.example.com
+      clientID: vcluster
+      ca: ca-data
+`)},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "vc-config-no-oidc", Namespace: "test"},
+			Data:       map[string][]byte{"config.yaml": []byte("controlPlane: {}")},
+		},
+	)
+
+	// oidc config is read from the vCluster config
+	options := &ConnectOptions{OIDC: true, OIDCClientSecret: "secret", OIDCExtraScopes: []string{"email", "groups"}}
+	oidcConfig, err := getOIDCConfig(ctx, kubeClient, "oidc", "test", options)
+	assert.NilError(t, err)
+	authInfo := oidcAuthInfo(oidcConfig, options)
+	assert.Equal(t, authInfo.Exec.Command, "kubectl")
+	assert.DeepEqual(t, authInfo.Exec.Args, []string{
+		"oidc-login",
+		"get-token",
+		"--oidc-issuer-url=https://dex.example.com",
+		"--oidc-client-id=vcluster",
+		"--oidc-client-secret=secret",
+		"--oidc-extra-scope=email",
+		"--oidc-extra-scope=groups",
+		"--certificate-authority-data=Y2EtZGF0YQ==",
+	})
+	assert.Equal(t, len(authInfo.ClientCertificateData), 0)
+
+	// flags override the vCluster config
+	oidcConfig, err = getOIDCConfig(ctx, kubeClient, "oidc", "test", &ConnectOptions{OIDC: true, OIDCIssuerURL: "https://other.example.com"})
+	assert.NilError(t, err)
+	assert.Equal(t, oidcConfig.IssuerURL, "https://other.example.com")
+	assert.Equal(t, oidcConfig.ClientID, "vcluster")
+
+	_, err = getOIDCConfig(ctx, kubeClient, "no-oidc", "test", &ConnectOptions{OIDC: true})
+	assert.ErrorContains(t, err, "oidc is not enabled for vcluster no-oidc")
+
+	_, err = getOIDCConfig(ctx, kubeClient, "missing", "test", &ConnectOptions{OIDC: true, OIDCIssuerURL: "https://dex.example.com"})
+	assert.ErrorContains(t, err, "please specify --oidc-issuer-url and --oidc-client-id")
+}
@@ -0,0 +1,635 @@
+package cli
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/vcluster/pkg/cli/find"
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/loft-sh/vcluster/pkg/util/clihelper"
+	utilkubeconfig "github.com/loft-sh/vcluster/pkg/util/kubeconfig"
+	"github.com/loft-sh/vcluster/pkg/util/portforward"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+)
+
+const redactedValue = "REDACTED"
+
+// debugVirtualResources are the resources that are listed within the virtual cluster
+var debugVirtualResources = append([]schema.GroupVersionResource{
+	corev1.SchemeGroupVersion.WithResource("namespaces"),
+	corev1.SchemeGroupVersion.WithResource("nodes"),
+	corev1.SchemeGroupVersion.WithResource("persistentvolumes"),
+	{Group: "apps", Version: "v1", Resource: "deployments"},
+	{Group: "apps", Version: "v1", Resource: "statefulsets"},
+	{Group: "apps", Version: "v1", Resource: "daemonsets"},
+	{Group: "batch", Version: "v1", Resource: "jobs"},
+	{Group: "storage.k8s.io", Version: "v1", Resource: "storageclasses"},
+	{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"},
+}, describeSyncedResources...)
+
+// debugProfiles are the pprof profiles collected from the syncer
+var debugProfiles = []string{"heap", "goroutine", "allocs", "block", "mutex", "threadcreate"}
+
+// sensitiveConfigPaths are the paths of config values that carry credentials, * matches all elements of a list
+var sensitiveConfigPaths = [][]string{
+	{"controlPlane", "distro", "k3s", "token"},
+	{"controlPlane", "backingStore", "database", "embedded", "dataSource"},
+	{"controlPlane", "backingStore", "database", "external", "dataSource"},
+	{"experimental", "isolatedControlPlane", "kubeConfig"},
+	{"experimental", "virtualClusterKubeConfig", "kubeConfig"},
+	{"experimental", "deploy", "vcluster", "helm", "*", "chart", "password"},
+	{"experimental", "deploy", "vcluster", "helm", "*", "values"},
+}
+
+// manifestConfigPaths are the paths of config values that hold manifests, whose secrets are redacted
+var manifestConfigPaths = [][]string{
+	{"experimental", "deploy", "host", "manifests"},
+	{"experimental", "deploy", "host", "manifestsTemplate"},
+	{"experimental", "deploy", "vcluster", "manifests"},
+	{"experimental", "deploy", "vcluster", "manifestsTemplate"},
+}
+
+type DebugBundleOptions struct {
+	Output         string
+	Redact         bool
+	ProfileSeconds int
+}
+
+// DebugBundleHelm collects logs, config and the state of the given vCluster into a support archive. Information
+// that couldn't be collected is listed in the errors.txt file of the archive.
+func DebugBundleHelm(ctx context.Context, options *DebugBundleOptions, globalFlags *flags.GlobalFlags, vClusterName string, log log.Logger) error {
+	vCluster, err := find.GetVCluster(ctx, globalFlags.Context, vClusterName, globalFlags.Namespace, log)
+	if err != nil {
+		return err
+	}
+
+	kubeConfig, err := vCluster.ClientFactory.ClientConfig()
+	if err != nil {
+		return fmt.Errorf("load kube config: %w", err)
+	}
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return err
+	}
+	dynamicClient, err := dynamic.NewForConfig(kubeConfig)
+	if err != nil {
+		return err
+	}
+
+	output := options.Output
+	if output == "" {
+		output = fmt.Sprintf("vcluster-%s-debug-%s.tar.gz", vCluster.Name, time.Now().UTC().Format("20060102-150405"))
+	}
+
+	bundle := &debugBundle{
+		vCluster:          vCluster,
+		hostClient:        kubeClient,
+		hostDynamicClient: dynamicClient,
+		redact:            options.Redact,
+		profileSeconds:    options.ProfileSeconds,
+	}
+
+	// the virtual cluster is reached through a port forwarding to the vCluster pod
+	if vCluster.Status == find.StatusRunning {
+		log.Infof("Connecting to vcluster %s/%s...", vCluster.Namespace, vCluster.Name)
+		virtualConfig, stopChan, err := portForwardVCluster(ctx, kubeConfig, kubeClient, vCluster, log)
+		if err != nil {
+			bundle.errorf("connect to virtual cluster: %v", err)
+		} else {
+			defer stopPortForwarding(stopChan)
+
+			bundle.virtualClient, err = kubernetes.NewForConfig(virtualConfig)
+			if err != nil {
+				return err
+			}
+			bundle.virtualDynamicClient, err = dynamic.NewForConfig(virtualConfig)
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		bundle.errorf("vcluster is %s, skipping virtual cluster objects", strings.ToLower(string(vCluster.Status)))
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("create debug bundle: %w", err)
+	}
+	defer file.Close()
+
+	log.Infof("Collecting debug information of vcluster %s/%s...", vCluster.Namespace, vCluster.Name)
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+	err = bundle.write(ctx, tarWriter)
+	if err != nil {
+		return err
+	}
+	err = tarWriter.Close()
+	if err != nil {
+		return fmt.Errorf("write debug bundle: %w", err)
+	}
+	err = gzipWriter.Close()
+	if err != nil {
+		return fmt.Errorf("write debug bundle: %w", err)
+	}
+
+	if len(bundle.errors) > 0 {
+		log.Warnf("Some information couldn't be collected, see errors.txt within the debug bundle")
+	}
+	log.Donef("Successfully wrote debug bundle of vcluster %s/%s to %s", vCluster.Namespace, vCluster.Name, output)
+	return nil
+}
+
+type debugBundle struct {
+	vCluster          *find.VCluster
+	hostClient        kubernetes.Interface
+	hostDynamicClient dynamic.Interface
+
+	// virtual clients are nil if the virtual cluster is not reachable
+	virtualClient        kubernetes.Interface
+	virtualDynamicClient dynamic.Interface
+
+	redact         bool
+	profileSeconds int
+
+	tarWriter *tar.Writer
+	errors    []string
+}
+
+func (b *debugBundle) write(ctx context.Context, tarWriter *tar.Writer) error {
+	b.tarWriter = tarWriter
+	for _, collect := range []func(ctx context.Context) error{
+		b.collectConfig,
+		b.collectLogs,
+		b.collectHostObjects,
+		b.collectVirtualObjects,
+		b.collectEvents,
+		b.collectProfiles,
+	} {
+		err := collect(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(b.errors) == 0 {
+		return nil
+	}
+
+	return b.add("errors.txt", []byte(strings.Join(b.errors, "\n")+"\n"))
+}
+
+// errorf records information that couldn't be collected
+func (b *debugBundle) errorf(format string, args ...interface{}) {
+	b.errors = append(b.errors, fmt.Sprintf(format, args...))
+}
+
+func (b *debugBundle) add(name string, data []byte) error {
+	err := b.tarWriter.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("write %s to debug bundle: %w", name, err)
+	}
+
+	_, err = b.tarWriter.Write(data)
+	if err != nil {
+		return fmt.Errorf("write %s to debug bundle: %w", name, err)
+	}
+
+	return nil
+}
+
+func (b *debugBundle) addYAML(name string, obj interface{}) error {
+	out, err := yaml.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("yaml marshal %s: %w", name, err)
+	}
+
+	return b.add(name, out)
+}
+
+// collectConfig adds the vCluster config with redacted credentials
+func (b *debugBundle) collectConfig(ctx context.Context) error {
+	secret, err := b.hostClient.CoreV1().Secrets(b.vCluster.Namespace).Get(ctx, "vc-config-"+b.vCluster.Name, metav1.GetOptions{})
+	if err != nil {
+		b.errorf("get config secret: %v", err)
+		return nil
+	}
+
+	config := secret.Data["config.yaml"]
+	if b.redact {
+		config, err = redactConfig(config)
+		if err != nil {
+			b.errorf("redact config: %v", err)
+			return nil
+		}
+	}
+
+	return b.add("config/vcluster.yaml", config)
+}
+
+// collectLogs adds the logs of the vCluster pods, which include the syncer and the distro, and of the pods
+// synced from the kube-system namespace of the virtual cluster, such as coredns
+func (b *debugBundle) collectLogs(ctx context.Context) error {
+	for _, selector := range []string{
+		"app=vcluster,release=" + b.vCluster.Name,
+		translate.MarkerLabel + "=" + b.vCluster.Name + "," + translate.NamespaceLabel + "=kube-system",
+	} {
+		podList, err := b.hostClient.CoreV1().Pods(b.vCluster.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			b.errorf("list pods %s: %v", selector, err)
+			continue
+		}
+
+		for _, pod := range podList.Items {
+			restarts := map[string]int32{}
+			for _, containerStatus := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+				restarts[containerStatus.Name] = containerStatus.RestartCount
+			}
+
+			for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+				err = b.collectContainerLogs(ctx, &pod, container.Name, false)
+				if err != nil {
+					return err
+				}
+				if restarts[container.Name] > 0 {
+					err = b.collectContainerLogs(ctx, &pod, container.Name, true)
+					if err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (b *debugBundle) collectContainerLogs(ctx context.Context, pod *corev1.Pod, container string, previous bool) error {
+	name := "logs/" + pod.Name + "/" + container + ".log"
+	if previous {
+		name = "logs/" + pod.Name + "/" + container + ".previous.log"
+	}
+
+	logs, err := b.hostClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{Container: container, Previous: previous}).DoRaw(ctx)
+	if err != nil {
+		b.errorf("get logs of %s: %v", strings.TrimPrefix(name, "logs/"), err)
+		return nil
+	}
+
+	return b.add(name, logs)
+}
+
+// collectHostObjects adds the host objects managed by the vCluster and the vCluster pods
+func (b *debugBundle) collectHostObjects(ctx context.Context) error {
+	podList, err := b.hostClient.CoreV1().Pods(b.vCluster.Namespace).List(ctx, metav1.ListOptions{LabelSelector: "app=vcluster,release=" + b.vCluster.Name})
+	if err != nil {
+		b.errorf("list vcluster pods: %v", err)
+	} else {
+		podList.Kind = "PodList"
+		podList.APIVersion = "v1"
+		err = b.addYAML("host/vcluster-pods.yaml", podList)
+		if err != nil {
+			return err
+		}
+	}
+
+	return b.collectObjects(ctx, "host", b.hostDynamicClient, b.vCluster.Namespace, metav1.ListOptions{LabelSelector: translate.MarkerLabel + "=" + b.vCluster.Name}, describeSyncedResources)
+}
+
+// collectVirtualObjects adds the objects of the virtual cluster
+func (b *debugBundle) collectVirtualObjects(ctx context.Context) error {
+	if b.virtualDynamicClient == nil {
+		return nil
+	}
+
+	return b.collectObjects(ctx, "virtual", b.virtualDynamicClient, metav1.NamespaceAll, metav1.ListOptions{}, debugVirtualResources)
+}
+
+func (b *debugBundle) collectObjects(ctx context.Context, dir string, dynamicClient dynamic.Interface, namespace string, listOptions metav1.ListOptions, resources []schema.GroupVersionResource) error {
+	for _, gvr := range resources {
+		var resourceInterface dynamic.ResourceInterface = dynamicClient.Resource(gvr)
+		if namespace != "" && !isClusterScoped(gvr) {
+			resourceInterface = dynamicClient.Resource(gvr).Namespace(namespace)
+		}
+
+		list, err := resourceInterface.List(ctx, listOptions)
+		if kerrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			b.errorf("list %s %s: %v", dir, gvr.Resource, err)
+			continue
+		}
+
+		if b.redact && gvr.Group == "" && gvr.Resource == "secrets" {
+			for i := range list.Items {
+				redactSecret(&list.Items[i])
+			}
+		}
+
+		name := gvr.Resource
+		if gvr.Group != "" {
+			name += "." + gvr.Group
+		}
+		err = b.addYAML(dir+"/"+name+".yaml", list)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// collectEvents adds the events of the vCluster namespace and of the virtual cluster
+func (b *debugBundle) collectEvents(ctx context.Context) error {
+	hostEvents, err := b.hostClient.CoreV1().Events(b.vCluster.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		b.errorf("list host events: %v", err)
+	} else {
+		hostEvents.Kind = "EventList"
+		hostEvents.APIVersion = "v1"
+		err = b.addYAML("events/host.yaml", hostEvents)
+		if err != nil {
+			return err
+		}
+	}
+
+	if b.virtualClient == nil {
+		return nil
+	}
+
+	virtualEvents, err := b.virtualClient.CoreV1().Events(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		b.errorf("list virtual events: %v", err)
+		return nil
+	}
+
+	virtualEvents.Kind = "EventList"
+	virtualEvents.APIVersion = "v1"
+	return b.addYAML("events/virtual.yaml", virtualEvents)
+}
+
+// collectProfiles adds the pprof profiles of the syncer, which are only served if the syncer runs with DEBUG=true
+func (b *debugBundle) collectProfiles(ctx context.Context) error {
+	if b.virtualClient == nil {
+		return nil
+	}
+
+	pod, err := findRunningVClusterPod(ctx, b.hostClient, b.vCluster.Name, b.vCluster.Namespace)
+	if err != nil {
+		b.errorf("find vcluster pod: %v", err)
+		return nil
+	} else if !pprofEnabled(pod) {
+		return nil
+	}
+
+	restClient := b.virtualClient.CoreV1().RESTClient()
+	for _, profile := range debugProfiles {
+		data, err := restClient.Get().AbsPath("/debug/pprof/" + profile).DoRaw(ctx)
+		if err != nil {
+			b.errorf("get %s profile: %v", profile, err)
+			continue
+		}
+
+		err = b.add("pprof/"+profile+".pb.gz", data)
+		if err != nil {
+			return err
+		}
+	}
+
+	if b.profileSeconds <= 0 {
+		return nil
+	}
+
+	data, err := restClient.Get().AbsPath("/debug/pprof/profile").Param("seconds", strconv.Itoa(b.profileSeconds)).Timeout(time.Duration(b.profileSeconds+30) * time.Second).DoRaw(ctx)
+	if err != nil {
+		b.errorf("get cpu profile: %v", err)
+		return nil
+	}
+
+	return b.add("pprof/cpu.pb.gz", data)
+}
+
+// pprofEnabled checks if the syncer serves the pprof endpoints
+func pprofEnabled(pod *corev1.Pod) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Name != "syncer" {
+			continue
+		}
+
+		for _, env := range container.Env {
+			if env.Name == "DEBUG" && env.Value == "true" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func isClusterScoped(gvr schema.GroupVersionResource) bool {
+	switch gvr.Resource {
+	case "namespaces", "nodes", "persistentvolumes", "storageclasses", "customresourcedefinitions":
+		return true
+	}
+
+	return false
+}
+
+// redactSecret replaces the values of the secret with a placeholder, so it can be seen which keys are set
+func redactSecret(secret *unstructured.Unstructured) {
+	for _, field := range []string{"data", "stringData"} {
+		data, found, _ := unstructured.NestedMap(secret.Object, field)
+		if !found {
+			continue
+		}
+
+		for key := range data {
+			data[key] = redactedValue
+		}
+		_ = unstructured.SetNestedMap(secret.Object, data, field)
+	}
+
+	annotations := secret.GetAnnotations()
+	if _, ok := annotations[corev1.LastAppliedConfigAnnotation]; ok {
+		annotations[corev1.LastAppliedConfigAnnotation] = redactedValue
+		secret.SetAnnotations(annotations)
+	}
+}
+
+// redactConfig replaces the values of the sensitive config paths within the vCluster config
+func redactConfig(config []byte) ([]byte, error) {
+	raw := map[string]interface{}{}
+	err := yaml.Unmarshal(config, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	redactConfigValues(raw)
+	return yaml.Marshal(raw)
+}
+
+// redactConfigValues replaces the values of the sensitive config paths within the decoded vCluster config
+func redactConfigValues(config map[string]interface{}) {
+	for _, path := range sensitiveConfigPaths {
+		redactConfigPath(config, path, redactConfigValue)
+	}
+	for _, path := range manifestConfigPaths {
+		redactConfigPath(config, path, redactManifests)
+	}
+}
+
+// redactConfigPath redacts the value at the given path below value with the given function
+func redactConfigPath(value interface{}, path []string, redact func(value interface{}) interface{}) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		child, ok := value[path[0]]
+		if !ok {
+			return
+		} else if len(path) == 1 {
+			value[path[0]] = redact(child)
+			return
+		}
+
+		redactConfigPath(child, path[1:], redact)
+	case []interface{}:
+		if path[0] != "*" {
+			return
+		}
+
+		for i, child := range value {
+			if len(path) == 1 {
+				value[i] = redact(child)
+				continue
+			}
+
+			redactConfigPath(child, path[1:], redact)
+		}
+	}
+}
+
+// redactConfigValue redacts all strings of the value, which can also be an object if the config is invalid
+func redactConfigValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			value[key] = redactConfigValue(child)
+		}
+	case []interface{}:
+		for i, child := range value {
+			value[i] = redactConfigValue(child)
+		}
+	case string:
+		if value != "" {
+			return redactedValue
+		}
+	}
+
+	return value
+}
+
+// redactManifests redacts the secrets within the given manifests. Documents that cannot be parsed, such as
+// templates, are redacted completely, as they could contain secrets as well.
+func redactManifests(value interface{}) interface{} {
+	manifests, ok := value.(string)
+	if !ok {
+		return redactConfigValue(value)
+	}
+
+	documents := strings.Split(manifests, "\n---")
+	for i, document := range documents {
+		obj := map[string]interface{}{}
+		err := yaml.Unmarshal([]byte(document), &obj)
+		if err != nil {
+			documents[i] = "\n" + redactedValue + "\n"
+			continue
+		} else if obj["kind"] != "Secret" {
+			continue
+		}
+
+		redactSecret(&unstructured.Unstructured{Object: obj})
+		out, err := yaml.Marshal(obj)
+		if err != nil {
+			documents[i] = "\n" + redactedValue + "\n"
+			continue
+		}
+
+		documents[i] = "\n" + string(out)
+	}
+
+	return strings.TrimPrefix(strings.Join(documents, "\n---"), "\n")
+}
+
+// portForwardVCluster starts a port forwarding to the vCluster pod and returns the config to access the virtual cluster
+func portForwardVCluster(ctx context.Context, kubeConfig *rest.Config, kubeClient *kubernetes.Clientset, vCluster *find.VCluster, log log.Logger) (*rest.Config, chan struct{}, error) {
+	pod, err := findRunningVClusterPod(ctx, kubeClient, vCluster.Name, vCluster.Namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	virtualKubeConfig, err := utilkubeconfig.ReadKubeConfig(ctx, kubeClient, vCluster.Name, vCluster.Namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	localPort := strconv.Itoa(clihelper.RandomPort())
+	remotePort := "8443"
+	for _, cluster := range virtualKubeConfig.Clusters {
+		if cluster == nil {
+			continue
+		}
+
+		splitted := strings.Split(cluster.Server, ":")
+		if len(splitted) != 3 {
+			return nil, nil, fmt.Errorf("unexpected server in kubeconfig: %s", cluster.Server)
+		}
+
+		remotePort = splitted[2]
+		splitted[2] = localPort
+		cluster.Server = strings.Join(splitted, ":")
+	}
+
+	stopChan, err := portforward.StartPortForwarding(ctx, kubeConfig, kubeClient, "", pod.Name, pod.Namespace, localPort, remotePort, io.Discard, io.Discard, log)
+	if err != nil {
+		return nil, nil, fmt.Errorf("start port forwarding: %w", err)
+	}
+
+	virtualConfig, err := clientcmd.NewDefaultClientConfig(*virtualKubeConfig, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		stopPortForwarding(stopChan)
+		return nil, nil, fmt.Errorf("load virtual cluster kube config: %w", err)
+	}
+
+	return virtualConfig, stopChan, nil
+}
+
+// stopPortForwarding stops the port forwarding, unless it was already stopped because the connection was lost
+func stopPortForwarding(stopChan chan struct{}) {
+	select {
+	case <-stopChan:
+	default:
+		close(stopChan)
+	}
+}
@@ -12,10 +12,10 @@ import (
 	"github.com/loft-sh/vcluster/pkg/cli/flags"
 	"github.com/loft-sh/vcluster/pkg/cli/localkubernetes"
 	"github.com/loft-sh/vcluster/pkg/helm"
+	"github.com/loft-sh/vcluster/pkg/lifecycle"
 	"github.com/loft-sh/vcluster/pkg/platform"
 	"github.com/loft-sh/vcluster/pkg/util/clihelper"
 	"github.com/loft-sh/vcluster/pkg/util/helmdownloader"
-	"github.com/loft-sh/vcluster/pkg/util/translate"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,6 +37,7 @@ type DeleteOptions struct {
 	DeleteContext       bool
 	DeleteConfigMap     bool
 	AutoDeleteNamespace bool
+	KeepHostNamespaces  bool
 	IgnoreNotFound      bool
 }
 
@@ -113,7 +114,13 @@ func DeleteHelm(ctx context.Context, options *DeleteOptions, globalFlags *flags.
 
 	// we have to delete the chart
 	cmd.log.Infof("Delete vcluster %s...", vClusterName)
-	err = helm.NewClient(cmd.rawConfig, cmd.log, helmBinaryPath).Delete(vClusterName, cmd.Namespace)
+	helmClient := helm.NewClient(cmd.rawConfig, cmd.log, helmBinaryPath)
+	if cmd.KeepHostNamespaces {
+		// the pre-delete hook of the chart would delete the host namespaces
+		err = helmClient.DeleteWithoutHooks(vClusterName, cmd.Namespace)
+	} else {
+		err = helmClient.Delete(vClusterName, cmd.Namespace)
+	}
 	if err != nil {
 		return err
 	}
@@ -166,6 +173,16 @@ func DeleteHelm(ctx context.Context, options *DeleteOptions, globalFlags *flags.
 		}
 	}
 
+	// delete the host namespaces created by multi-namespace mode, as they would be orphaned otherwise. These are
+	// usually deleted by the pre-delete hook of the chart already, but not for vClusters deployed with older charts.
+	// This happens before the check below, as these namespaces only belong to this vcluster.
+	if !cmd.KeepHostNamespaces {
+		err = lifecycle.DeleteMultiNamespaceVClusterNamespaces(ctx, cmd.kubeClient, vClusterName, cmd.Namespace, cmd.log)
+		if err != nil {
+			return err
+		}
+	}
+
 	// check if there are any other vclusters in the namespace you are deleting vcluster in.
 	vClusters, err := find.ListVClusters(ctx, cmd.Context, "", cmd.Namespace, cmd.log)
 	if err != nil {
@@ -188,28 +205,6 @@ func DeleteHelm(ctx context.Context, options *DeleteOptions, globalFlags *flags.
 			cmd.log.Donef("Successfully deleted virtual cluster namespace %s", cmd.Namespace)
 		}
 
-		// delete multi namespace mode namespaces
-		namespaces, err := cmd.kubeClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{
-			LabelSelector: translate.MarkerLabel + "=" + translate.SafeConcatName(cmd.Namespace, "x", vClusterName),
-		})
-		if err != nil && !kerrors.IsForbidden(err) {
-			return fmt.Errorf("list namespaces: %w", err)
-		}
-
-		// delete all namespaces
-		if namespaces != nil && len(namespaces.Items) > 0 {
-			for _, namespace := range namespaces.Items {
-				err = cmd.kubeClient.CoreV1().Namespaces().Delete(ctx, namespace.Name, metav1.DeleteOptions{})
-				if err != nil {
-					if !kerrors.IsNotFound(err) {
-						return fmt.Errorf("delete namespace: %w", err)
-					}
-				} else {
-					cmd.log.Donef("Successfully deleted virtual cluster namespace %s", namespace.Name)
-				}
-			}
-		}
-
 		// wait for vcluster deletion
 		if cmd.Wait {
 			cmd.log.Info("Waiting for virtual cluster to be deleted...")
@@ -0,0 +1,548 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/loft-sh/log"
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/certs"
+	"github.com/loft-sh/vcluster/pkg/cli/find"
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	describeMaxEvents       = 10
+	describeMaxSyncerErrors = 10
+	describeSyncerLogLines  = 2000
+
+	// labels of the host namespaces created for the vCluster by the namespace syncer
+	hostNamespaceNameLabel      = "vcluster.loft.sh/vcluster-name"
+	hostNamespaceNamespaceLabel = "vcluster.loft.sh/vcluster-namespace"
+)
+
+// describeSyncedResources are the resources that are counted in the host cluster
+var describeSyncedResources = []schema.GroupVersionResource{
+	corev1.SchemeGroupVersion.WithResource("pods"),
+	corev1.SchemeGroupVersion.WithResource("services"),
+	corev1.SchemeGroupVersion.WithResource("endpoints"),
+	corev1.SchemeGroupVersion.WithResource("configmaps"),
+	corev1.SchemeGroupVersion.WithResource("secrets"),
+	corev1.SchemeGroupVersion.WithResource("serviceaccounts"),
+	corev1.SchemeGroupVersion.WithResource("persistentvolumeclaims"),
+	{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"},
+	{Group: "networking.k8s.io", Version: "v1", Resource: "networkpolicies"},
+	{Group: "policy", Version: "v1", Resource: "poddisruptionbudgets"},
+}
+
+type DescribeOptions struct {
+	Output string
+}
+
+// VClusterDescription holds the detailed state of a virtual cluster
+type VClusterDescription struct {
+	Name              string    `json:"name"`
+	Namespace         string    `json:"namespace"`
+	Status            string    `json:"status"`
+	Created           time.Time `json:"created"`
+	Version           string    `json:"version,omitempty"`
+	Distro            string    `json:"distro,omitempty"`
+	KubernetesVersion string    `json:"kubernetesVersion,omitempty"`
+	BackingStore      string    `json:"backingStore,omitempty"`
+
+	// ConfigDiff holds the changes of the vCluster config compared to the chart defaults with redacted credentials
+	ConfigDiff map[string]interface{} `json:"configDiff,omitempty"`
+
+	Pods                   []DescribePod                   `json:"pods"`
+	PersistentVolumeClaims []DescribePersistentVolumeClaim `json:"persistentVolumeClaims"`
+	Certificates           []DescribeCertificate           `json:"certificates"`
+
+	// SyncedObjects is the number of host objects synced by the vCluster by resource
+	SyncedObjects map[string]int `json:"syncedObjects"`
+
+	SyncerErrors []string        `json:"syncerErrors"`
+	Events       []DescribeEvent `json:"events"`
+
+	// Warnings holds the information that couldn't be retrieved
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+type DescribePod struct {
+	Name     string    `json:"name"`
+	Phase    string    `json:"phase"`
+	Ready    string    `json:"ready"`
+	Restarts int32     `json:"restarts"`
+	Node     string    `json:"node,omitempty"`
+	Created  time.Time `json:"created"`
+}
+
+type DescribePersistentVolumeClaim struct {
+	Name         string `json:"name"`
+	Phase        string `json:"phase"`
+	Capacity     string `json:"capacity,omitempty"`
+	StorageClass string `json:"storageClass,omitempty"`
+}
+
+type DescribeCertificate struct {
+	Name     string    `json:"name"`
+	Subject  string    `json:"subject"`
+	CA       bool      `json:"ca"`
+	NotAfter time.Time `json:"notAfter"`
+}
+
+type DescribeEvent struct {
+	LastSeen time.Time `json:"lastSeen"`
+	Type     string    `json:"type"`
+	Reason   string    `json:"reason"`
+	Object   string    `json:"object"`
+	Message  string    `json:"message"`
+	Count    int32     `json:"count,omitempty"`
+}
+
+// DescribeHelm prints a detailed report about the health of the given vCluster
+func DescribeHelm(ctx context.Context, options *DescribeOptions, globalFlags *flags.GlobalFlags, vClusterName string, log log.Logger) error {
+	vCluster, err := find.GetVCluster(ctx, globalFlags.Context, vClusterName, globalFlags.Namespace, log)
+	if err != nil {
+		return err
+	}
+
+	kubeConfig, err := vCluster.ClientFactory.ClientConfig()
+	if err != nil {
+		return fmt.Errorf("load kube config: %w", err)
+	}
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return err
+	}
+	dynamicClient, err := dynamic.NewForConfig(kubeConfig)
+	if err != nil {
+		return err
+	}
+
+	description, err := describeVCluster(ctx, kubeClient, dynamicClient, vCluster)
+	if err != nil {
+		return err
+	}
+
+	var out []byte
+	switch options.Output {
+	case "json":
+		out, err = json.MarshalIndent(description, "", "    ")
+		if err != nil {
+			return fmt.Errorf("json marshal description: %w", err)
+		}
+		out = append(out, '\n')
+	case "yaml":
+		out, err = yaml.Marshal(description)
+		if err != nil {
+			return fmt.Errorf("yaml marshal description: %w", err)
+		}
+	case "", "text":
+		buf := &bytes.Buffer{}
+		printDescription(buf, description, time.Now())
+		out = buf.Bytes()
+	default:
+		return fmt.Errorf("unsupported output format %q, expected text, json or yaml", options.Output)
+	}
+
+	log.WriteString(logrus.InfoLevel, string(out))
+	return nil
+}
+
+func describeVCluster(ctx context.Context, kubeClient kubernetes.Interface, dynamicClient dynamic.Interface, vCluster *find.VCluster) (*VClusterDescription, error) {
+	description := &VClusterDescription{
+		Name:          vCluster.Name,
+		Namespace:     vCluster.Namespace,
+		Status:        string(vCluster.Status),
+		Created:       vCluster.Created.Time,
+		Version:       vCluster.Version,
+		Pods:          []DescribePod{},
+		Certificates:  []DescribeCertificate{},
+		SyncedObjects: map[string]int{},
+		SyncerErrors:  []string{},
+		Events:        []DescribeEvent{},
+
+		PersistentVolumeClaims: []DescribePersistentVolumeClaim{},
+	}
+
+	// effective config
+	vClusterConfig, err := describeConfig(ctx, kubeClient, description)
+	if err != nil {
+		return nil, err
+	}
+
+	// control plane pods and volumes
+	selector := "app=vcluster,release=" + vCluster.Name
+	podList, err := kubeClient.CoreV1().Pods(vCluster.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("list vcluster pods: %w", err)
+	}
+	for _, pod := range podList.Items {
+		description.Pods = append(description.Pods, describePod(&pod))
+		if description.KubernetesVersion == "" {
+			description.KubernetesVersion = kubernetesVersion(&pod)
+		}
+	}
+	slices.SortFunc(description.Pods, func(a, b DescribePod) int { return strings.Compare(a.Name, b.Name) })
+
+	pvcList, err := kubeClient.CoreV1().PersistentVolumeClaims(vCluster.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("list vcluster persistent volume claims: %w", err)
+	}
+	for _, pvc := range pvcList.Items {
+		describedPVC := DescribePersistentVolumeClaim{
+			Name:         pvc.Name,
+			Phase:        string(pvc.Status.Phase),
+			StorageClass: ptr.Deref(pvc.Spec.StorageClassName, ""),
+		}
+		if capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+			describedPVC.Capacity = capacity.String()
+		}
+		description.PersistentVolumeClaims = append(description.PersistentVolumeClaims, describedPVC)
+	}
+
+	// certificates
+	secret, err := kubeClient.CoreV1().Secrets(vCluster.Namespace).Get(ctx, certs.SecretName(vCluster.Name), metav1.GetOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("get certs secret: %w", err)
+	} else if err == nil {
+		infos, err := certs.CertificateInfos(secret.Data)
+		if err != nil {
+			description.Warnings = append(description.Warnings, fmt.Sprintf("parse certificates: %v", err))
+		}
+		for _, info := range infos {
+			description.Certificates = append(description.Certificates, DescribeCertificate{Name: info.Name, Subject: info.Subject, CA: info.CA, NotAfter: info.NotAfter})
+		}
+	}
+
+	// synced objects
+	err = countSyncedObjects(ctx, kubeClient, dynamicClient, vCluster, vClusterConfig, description)
+	if err != nil {
+		return nil, err
+	}
+
+	// events and syncer errors
+	eventList, err := kubeClient.CoreV1().Events(vCluster.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list events: %w", err)
+	}
+	for _, event := range eventList.Items {
+		if event.Type != corev1.EventTypeWarning {
+			continue
+		}
+
+		lastSeen := event.LastTimestamp.Time
+		if lastSeen.IsZero() {
+			lastSeen = event.EventTime.Time
+		}
+		if lastSeen.IsZero() {
+			lastSeen = event.CreationTimestamp.Time
+		}
+
+		description.Events = append(description.Events, DescribeEvent{
+			LastSeen: lastSeen,
+			Type:     event.Type,
+			Reason:   event.Reason,
+			Object:   strings.ToLower(event.InvolvedObject.Kind) + "/" + event.InvolvedObject.Name,
+			Message:  strings.TrimSpace(event.Message),
+			Count:    event.Count,
+		})
+	}
+	slices.SortStableFunc(description.Events, func(a, b DescribeEvent) int { return b.LastSeen.Compare(a.LastSeen) })
+	if len(description.Events) > describeMaxEvents {
+		description.Events = description.Events[:describeMaxEvents]
+	}
+
+	for _, pod := range podList.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+
+		syncerErrors, err := syncerLogErrors(ctx, kubeClient, &pod)
+		if err != nil {
+			description.Warnings = append(description.Warnings, fmt.Sprintf("retrieve logs of pod %s: %v", pod.Name, err))
+			continue
+		}
+
+		description.SyncerErrors = append(description.SyncerErrors, syncerErrors...)
+	}
+	if len(description.SyncerErrors) > describeMaxSyncerErrors {
+		description.SyncerErrors = description.SyncerErrors[len(description.SyncerErrors)-describeMaxSyncerErrors:]
+	}
+
+	return description, nil
+}
+
+// describeConfig decodes the config secret of the vCluster and diffs it against the chart defaults
+func describeConfig(ctx context.Context, kubeClient kubernetes.Interface, description *VClusterDescription) (*vclusterconfig.Config, error) {
+	secret, err := kubeClient.CoreV1().Secrets(description.Namespace).Get(ctx, "vc-config-"+description.Name, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		description.Warnings = append(description.Warnings, fmt.Sprintf("config secret vc-config-%s not found", description.Name))
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("get vcluster config: %w", err)
+	}
+
+	// the values are applied on top of the defaults and unknown fields are ignored, as the config could
+	// be from a different vCluster version
+	vClusterConfig, err := vclusterconfig.NewDefaultConfig()
+	if err != nil {
+		return nil, fmt.Errorf("get default config: %w", err)
+	}
+	err = yaml.Unmarshal(secret.Data["config.yaml"], vClusterConfig)
+	if err != nil {
+		return nil, fmt.Errorf("parse vcluster config: %w", err)
+	}
+
+	description.Distro = vClusterConfig.Distro()
+	description.BackingStore = string(vClusterConfig.BackingStoreType())
+
+	defaultConfig, err := vclusterconfig.NewDefaultConfig()
+	if err != nil {
+		return nil, fmt.Errorf("get default config: %w", err)
+	}
+	configDiff, err := vclusterconfig.Diff(defaultConfig, vClusterConfig)
+	if err != nil {
+		return nil, fmt.Errorf("diff vcluster config: %w", err)
+	}
+	description.ConfigDiff = map[string]interface{}{}
+	err = yaml.Unmarshal([]byte(configDiff), &description.ConfigDiff)
+	if err != nil {
+		return nil, fmt.Errorf("parse config diff: %w", err)
+	}
+	redactConfigValues(description.ConfigDiff)
+
+	return vClusterConfig, nil
+}
+
+// countSyncedObjects counts the objects that were synced to the host cluster. These are either labeled with the
+// vCluster name in the target namespace or located in the host namespaces that belong to the vCluster.
+func countSyncedObjects(ctx context.Context, kubeClient kubernetes.Interface, dynamicClient dynamic.Interface, vCluster *find.VCluster, vClusterConfig *vclusterconfig.Config, description *VClusterDescription) error {
+	targetNamespaces := []string{vCluster.Namespace}
+	listOptions := metav1.ListOptions{LabelSelector: translate.MarkerLabel + "=" + vCluster.Name}
+	if vClusterConfig != nil && vClusterConfig.Sync.ToHost.Namespaces.Enabled {
+		namespaceList, err := kubeClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{
+			LabelSelector: hostNamespaceNameLabel + "=" + vCluster.Name + "," + hostNamespaceNamespaceLabel + "=" + vCluster.Namespace,
+		})
+		if err != nil {
+			return fmt.Errorf("list vcluster host namespaces: %w", err)
+		}
+
+		targetNamespaces = []string{}
+		for _, namespace := range namespaceList.Items {
+			targetNamespaces = append(targetNamespaces, namespace.Name)
+		}
+		listOptions = metav1.ListOptions{}
+	} else if vClusterConfig != nil && vClusterConfig.Experimental.SyncSettings.TargetNamespace != "" {
+		targetNamespaces = []string{vClusterConfig.Experimental.SyncSettings.TargetNamespace}
+	}
+
+	for _, gvr := range describeSyncedResources {
+		for _, namespace := range targetNamespaces {
+			list, err := dynamicClient.Resource(gvr).Namespace(namespace).List(ctx, listOptions)
+			if kerrors.IsNotFound(err) {
+				break
+			} else if err != nil {
+				description.Warnings = append(description.Warnings, fmt.Sprintf("list %s: %v", gvr.Resource, err))
+				break
+			}
+
+			for _, item := range list.Items {
+				// objects created by the host cluster itself are not synced
+				if item.GetAnnotations()[translate.NameAnnotation] != "" {
+					description.SyncedObjects[gvr.Resource]++
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// syncerLogErrors returns the errors the syncer logged recently
+func syncerLogErrors(ctx context.Context, kubeClient kubernetes.Interface, pod *corev1.Pod) ([]string, error) {
+	tailLines := int64(describeSyncerLogLines)
+	stream, err := kubeClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{Container: "syncer", TailLines: &tailLines}).Stream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	return filterLogErrors(stream)
+}
+
+func filterLogErrors(reader io.Reader) ([]string, error) {
+	errors := []string{}
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.Contains(line, "\tERROR\t") || strings.Contains(line, `"level":"error"`) {
+			errors = append(errors, line)
+		}
+	}
+
+	return errors, scanner.Err()
+}
+
+func describePod(pod *corev1.Pod) DescribePod {
+	ready := 0
+	restarts := int32(0)
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.Ready {
+			ready++
+		}
+		restarts += containerStatus.RestartCount
+	}
+
+	phase := string(pod.Status.Phase)
+	if pod.DeletionTimestamp != nil {
+		phase = "Terminating"
+	}
+
+	return DescribePod{
+		Name:     pod.Name,
+		Phase:    phase,
+		Ready:    fmt.Sprintf("%d/%d", ready, len(pod.Spec.Containers)),
+		Restarts: restarts,
+		Node:     pod.Spec.NodeName,
+		Created:  pod.CreationTimestamp.Time,
+	}
+}
+
+// kubernetesVersion returns the version of the virtual Kubernetes control plane, which is the image tag of
+// the kube-apiserver container for the k8s distro or of the vcluster container for k3s and k0s
+func kubernetesVersion(pod *corev1.Pod) string {
+	for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for _, container := range containers {
+			if container.Name != "kube-apiserver" && container.Name != "vcluster" {
+				continue
+			}
+
+			_, tag, found := strings.Cut(container.Image[strings.LastIndex(container.Image, "/")+1:], ":")
+			if found {
+				return tag
+			}
+		}
+	}
+
+	return ""
+}
+
+func printDescription(out io.Writer, description *VClusterDescription, now time.Time) {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "Name:\t%s\n", description.Name)
+	_, _ = fmt.Fprintf(w, "Namespace:\t%s\n", description.Namespace)
+	_, _ = fmt.Fprintf(w, "Status:\t%s\n", description.Status)
+	_, _ = fmt.Fprintf(w, "Age:\t%s\n", duration.HumanDuration(now.Sub(description.Created)))
+	_, _ = fmt.Fprintf(w, "Version:\t%s\n", orNone(description.Version))
+	_, _ = fmt.Fprintf(w, "Distro:\t%s\n", orNone(description.Distro))
+	_, _ = fmt.Fprintf(w, "Kubernetes Version:\t%s\n", orNone(description.KubernetesVersion))
+	_, _ = fmt.Fprintf(w, "Backing Store:\t%s\n", orNone(description.BackingStore))
+	_ = w.Flush()
+
+	_, _ = fmt.Fprintf(out, "\nConfig (changes to defaults):\n")
+	if len(description.ConfigDiff) == 0 {
+		_, _ = fmt.Fprintf(out, "  <none>\n")
+	} else {
+		configDiff, _ := yaml.Marshal(description.ConfigDiff)
+		for _, line := range strings.Split(strings.TrimSuffix(string(configDiff), "\n"), "\n") {
+			_, _ = fmt.Fprintf(out, "  %s\n", line)
+		}
+	}
+
+	_, _ = fmt.Fprintf(out, "\nPods:\n")
+	printDescriptionTable(out, []string{"NAME", "STATUS", "READY", "RESTARTS", "NODE", "AGE"}, len(description.Pods), func(i int) []string {
+		pod := description.Pods[i]
+		return []string{pod.Name, pod.Phase, pod.Ready, fmt.Sprintf("%d", pod.Restarts), orNone(pod.Node), duration.HumanDuration(now.Sub(pod.Created))}
+	})
+
+	_, _ = fmt.Fprintf(out, "\nPersistent Volume Claims:\n")
+	printDescriptionTable(out, []string{"NAME", "STATUS", "CAPACITY", "STORAGE CLASS"}, len(description.PersistentVolumeClaims), func(i int) []string {
+		pvc := description.PersistentVolumeClaims[i]
+		return []string{pvc.Name, pvc.Phase, orNone(pvc.Capacity), orNone(pvc.StorageClass)}
+	})
+
+	_, _ = fmt.Fprintf(out, "\nCertificates:\n")
+	printDescriptionTable(out, []string{"NAME", "CA", "EXPIRES", "RESIDUAL TIME"}, len(description.Certificates), func(i int) []string {
+		certificate := description.Certificates[i]
+		residualTime := "expired"
+		if certificate.NotAfter.After(now) {
+			residualTime = duration.HumanDuration(certificate.NotAfter.Sub(now))
+		}
+
+		return []string{certificate.Name, fmt.Sprintf("%t", certificate.CA), certificate.NotAfter.UTC().Format(time.RFC3339), residualTime}
+	})
+
+	_, _ = fmt.Fprintf(out, "\nSynced Objects:\n")
+	resources := make([]string, 0, len(description.SyncedObjects))
+	for resource := range description.SyncedObjects {
+		resources = append(resources, resource)
+	}
+	slices.Sort(resources)
+	printDescriptionTable(out, []string{"RESOURCE", "COUNT"}, len(resources), func(i int) []string {
+		return []string{resources[i], fmt.Sprintf("%d", description.SyncedObjects[resources[i]])}
+	})
+
+	_, _ = fmt.Fprintf(out, "\nRecent Syncer Errors:\n")
+	if len(description.SyncerErrors) == 0 {
+		_, _ = fmt.Fprintf(out, "  <none>\n")
+	}
+	for _, syncerError := range description.SyncerErrors {
+		_, _ = fmt.Fprintf(out, "  %s\n", syncerError)
+	}
+
+	_, _ = fmt.Fprintf(out, "\nRecent Events:\n")
+	printDescriptionTable(out, []string{"LAST SEEN", "TYPE", "REASON", "OBJECT", "MESSAGE"}, len(description.Events), func(i int) []string {
+		event := description.Events[i]
+		return []string{duration.HumanDuration(now.Sub(event.LastSeen)), event.Type, event.Reason, event.Object, event.Message}
+	})
+
+	if len(description.Warnings) > 0 {
+		_, _ = fmt.Fprintf(out, "\nWarnings:\n")
+		for _, warning := range description.Warnings {
+			_, _ = fmt.Fprintf(out, "  %s\n", warning)
+		}
+	}
+}
+
+func printDescriptionTable(out io.Writer, header []string, rows int, row func(i int) []string) {
+	if rows == 0 {
+		_, _ = fmt.Fprintf(out, "  <none>\n")
+		return
+	}
+
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "  %s\n", strings.Join(header, "\t"))
+	for i := 0; i < rows; i++ {
+		_, _ = fmt.Fprintf(w, "  %s\n", strings.Join(row(i), "\t"))
+	}
+	_ = w.Flush()
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+
+	return value
+}
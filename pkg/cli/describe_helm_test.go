@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/loft-sh/vcluster/pkg/cli/find"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestDescribeVCluster(t *testing.T) {
+	ctx := context.Background()
+	created := time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)
+	controlPlaneLabels := map[string]string{"app": "vcluster", "release": "my-vcluster"}
+	kubeClient := fake.NewSimpleClientset(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "vc-config-my-vcluster", Namespace: "test"},
+			Data: map[string][]byte{"config.yaml": []byte(`controlPlane:
+  distro:
+    k3s:
+      enabled: true
+      token: my-token
+sync:
+  toHost:
+    ingresses:
+      enabled: true
+`)},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-vcluster-0", Namespace: "test", Labels: controlPlaneLabels, CreationTimestamp: metav1.Time{Time: created}},
+			Spec: corev1.PodSpec{
+				NodeName:       "node-1",
+				InitContainers: []corev1.Container{{Name: "vcluster", Image: "registry.example.com:5000/rancher/k3s:v1.30.2-k3s1"}},
+				Containers:     []corev1.Container{{Name: "syncer", Image: "ghcr.io/loft-sh/vcluster:0.21.0"}},
+			},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodPending,
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "syncer", RestartCount: 3}},
+			},
+		},
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "data-my-vcluster-0", Namespace: "test", Labels: controlPlaneLabels},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Phase:    corev1.ClaimBound,
+				Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")},
+			},
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "event-1", Namespace: "test"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "my-vcluster-0"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "BackOff",
+			Message:        "Back-off restarting failed container",
+			LastTimestamp:  metav1.Time{Time: created.Add(time.Hour)},
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "event-2", Namespace: "test"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "my-vcluster-0"},
+			Type:           corev1.EventTypeNormal,
+			Reason:         "Pulled",
+		},
+	)
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(scheme.Scheme,
+		&corev1.Pod{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "nginx-x-default-x-my-vcluster",
+				Namespace:   "test",
+				Labels:      map[string]string{translate.MarkerLabel: "my-vcluster"},
+				Annotations: map[string]string{translate.NameAnnotation: "nginx"},
+			},
+		},
+		&corev1.Pod{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "nginx-x-default-x-other",
+				Namespace:   "test",
+				Labels:      map[string]string{translate.MarkerLabel: "other"},
+				Annotations: map[string]string{translate.NameAnnotation: "nginx"},
+			},
+		},
+		&corev1.Service{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "kube-dns-x-kube-system-x-my-vcluster",
+				Namespace:   "test",
+				Labels:      map[string]string{translate.MarkerLabel: "my-vcluster"},
+				Annotations: map[string]string{translate.NameAnnotation: "kube-dns"},
+			},
+		},
+	)
+
+	description, err := describeVCluster(ctx, kubeClient, dynamicClient, &find.VCluster{
+		Name:      "my-vcluster",
+		Namespace: "test",
+		Status:    find.StatusRunning,
+		Created:   metav1.Time{Time: created},
+		Version:   "0.21.0",
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, description.Distro, "k3s")
+	assert.Equal(t, description.KubernetesVersion, "v1.30.2-k3s1")
+	assert.Equal(t, description.BackingStore, "embedded-database")
+	assert.DeepEqual(t, description.ConfigDiff, map[string]interface{}{
+		"controlPlane": map[string]interface{}{"distro": map[string]interface{}{"k3s": map[string]interface{}{"enabled": true, "token": "REDACTED"}}},
+		"sync":         map[string]interface{}{"toHost": map[string]interface{}{"ingresses": map[string]interface{}{"enabled": true}}},
+	})
+	assert.DeepEqual(t, description.Pods, []DescribePod{{Name: "my-vcluster-0", Phase: "Pending", Ready: "0/1", Restarts: 3, Node: "node-1", Created: created}})
+	assert.DeepEqual(t, description.PersistentVolumeClaims, []DescribePersistentVolumeClaim{{Name: "data-my-vcluster-0", Phase: "Bound", Capacity: "5Gi"}})
+	assert.DeepEqual(t, description.Certificates, []DescribeCertificate{})
+	assert.DeepEqual(t, description.SyncedObjects, map[string]int{"pods": 1, "services": 1})
+	assert.DeepEqual(t, description.Events, []DescribeEvent{{LastSeen: created.Add(time.Hour), Type: "Warning", Reason: "BackOff", Object: "pod/my-vcluster-0", Message: "Back-off restarting failed container"}})
+
+	out := &bytes.Buffer{}
+	printDescription(out, description, created.Add(2*time.Hour))
+	assert.Assert(t, strings.Contains(out.String(), "Kubernetes Version:  v1.30.2-k3s1\n"), out.String())
+	assert.Assert(t, !strings.Contains(out.String(), "my-token"), out.String())
+	assert.Assert(t, strings.Contains(out.String(), "  sync:\n    toHost:\n      ingresses:\n        enabled: true\n"), out.String())
+	assert.Assert(t, strings.Contains(out.String(), "  pods      1\n"), out.String())
+}
+
+func TestFilterLogErrors(t *testing.T) {
+	errors, err := filterLogErrors(strings.NewReader(`2024-01-02 03:00:00	INFO	syncer	started
+2024-01-02 03:00:01	ERROR	pod.nginx	error syncing
+{"level":"info","msg":"started"}
+{"level":"error","msg":"error syncing"}
+`))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, errors, []string{
+		"2024-01-02 03:00:01\tERROR\tpod.nginx\terror syncing",
+		`{"level":"error","msg":"error syncing"}`,
+	})
+}
@@ -21,6 +21,11 @@ func AddCommonFlags(cmd *cobra.Command, options *cli.ConnectOptions) {
 	cmd.Flags().StringVar(&options.ServiceAccountClusterRole, "cluster-role", "", "If specified, vCluster will create the service account if it does not exist and also add a cluster role binding for the given cluster role to it. Requires --service-account to be set")
 	cmd.Flags().IntVar(&options.ServiceAccountExpiration, "token-expiration", 0, "If specified, vCluster will create the service account token for the given duration in seconds. Defaults to eternal")
 	cmd.Flags().BoolVar(&options.Insecure, "insecure", false, "If specified, vCluster will create the kube config with insecure-skip-tls-verify")
+	cmd.Flags().BoolVar(&options.OIDC, "oidc", false, "If specified, vCluster will create a kube config that logs in via OIDC using the kubelogin exec credential plugin. Requires controlPlane.proxy.oidc to be enabled")
+	cmd.Flags().StringVar(&options.OIDCIssuerURL, "oidc-issuer-url", "", "If specified, overrides the OIDC issuer url of the virtual cluster config. Requires --oidc to be set")
+	cmd.Flags().StringVar(&options.OIDCClientID, "oidc-client-id", "", "If specified, overrides the OIDC client id of the virtual cluster config. Requires --oidc to be set")
+	cmd.Flags().StringVar(&options.OIDCClientSecret, "oidc-client-secret", "", "The OIDC client secret to use for the login. Requires --oidc to be set")
+	cmd.Flags().StringSliceVar(&options.OIDCExtraScopes, "oidc-extra-scope", []string{}, "Extra scopes to request during the OIDC login, e.g. email or groups. Requires --oidc to be set")
 	cmd.Flags().BoolVar(&options.BackgroundProxy, "background-proxy", true, "Try to use a background-proxy to access the vCluster. Only works if docker is installed and reachable")
 
 	// deprecated
@@ -0,0 +1,271 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/vcluster/pkg/cli/find"
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/loft-sh/vcluster/pkg/lifecycle"
+	"github.com/loft-sh/vcluster/pkg/snapshot"
+	"github.com/loft-sh/vcluster/pkg/util/podhelper"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+type RestoreOptions struct {
+	From string
+}
+
+// RestoreHelm restores a snapshot created by SnapshotHelm into the given vCluster. The vCluster is paused
+// during the restore and resumed afterwards.
+func RestoreHelm(ctx context.Context, options *RestoreOptions, globalFlags *flags.GlobalFlags, vClusterName string, log log.Logger) error {
+	store, err := snapshot.NewStore(options.From)
+	if err != nil {
+		return err
+	}
+
+	vCluster, err := find.GetVCluster(ctx, globalFlags.Context, vClusterName, globalFlags.Namespace, log)
+	if err != nil {
+		return err
+	}
+
+	kubeConfig, err := vCluster.ClientFactory.ClientConfig()
+	if err != nil {
+		return fmt.Errorf("load kube config: %w", err)
+	}
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return err
+	}
+
+	// the restore pod is created from the statefulset, so make sure it exists before pausing the vCluster
+	statefulSet, err := kubeClient.AppsV1().StatefulSets(vCluster.Namespace).Get(ctx, vCluster.Name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return fmt.Errorf("restoring vcluster %s/%s is only supported for vClusters deployed as statefulset", vCluster.Namespace, vCluster.Name)
+		}
+
+		return fmt.Errorf("get vcluster statefulset: %w", err)
+	}
+
+	// pause the vCluster
+	if vCluster.Status != find.StatusPaused {
+		log.Infof("Pausing vcluster %s/%s...", vCluster.Namespace, vCluster.Name)
+		err = lifecycle.PauseVCluster(ctx, kubeClient, vCluster.Name, vCluster.Namespace, log)
+		if err != nil {
+			return err
+		}
+
+		err = lifecycle.DeletePods(ctx, kubeClient, "vcluster.loft.sh/managed-by="+vCluster.Name, vCluster.Namespace, log)
+		if err != nil {
+			return fmt.Errorf("delete vcluster workloads: %w", err)
+		}
+
+		err = lifecycle.DeleteMultiNamespaceVClusterWorkloads(ctx, kubeClient, vCluster.Name, vCluster.Namespace, log)
+		if err != nil {
+			return fmt.Errorf("delete vcluster multinamespace workloads: %w", err)
+		}
+
+		err = waitForVClusterPodsDeleted(ctx, kubeClient, vCluster.Name, vCluster.Namespace)
+		if err != nil {
+			return err
+		}
+	}
+
+	// restore the vCluster secrets
+	log.Infof("Restoring vcluster secrets from %s...", store.String())
+	metadata, err := restoreSecrets(ctx, store, kubeClient, vCluster.Name, vCluster.Namespace)
+	if err != nil {
+		return err
+	}
+	log.Infof("Snapshot of vcluster %s/%s was created at %s", metadata.Namespace, metadata.Name, metadata.Created.Format(time.RFC3339))
+
+	// restore the backing store within a temporary pod, as only it has access to the backing store
+	err = restoreBackingStore(ctx, kubeConfig, kubeClient, store, statefulSet.Spec.Template, statefulSet.Spec.VolumeClaimTemplates, vCluster.Name, vCluster.Namespace, log)
+	if err != nil {
+		return fmt.Errorf("%w, vcluster %s/%s stays paused, run 'vcluster resume %s' after resolving the issue", err, vCluster.Namespace, vCluster.Name, vCluster.Name)
+	}
+
+	// resume the vCluster
+	err = lifecycle.ResumeVCluster(ctx, kubeClient, vCluster.Name, vCluster.Namespace, log)
+	if err != nil {
+		return err
+	}
+
+	log.Donef("Successfully restored vcluster %s/%s from %s", vCluster.Namespace, vCluster.Name, store.String())
+	return nil
+}
+
+func restoreSecrets(ctx context.Context, store snapshot.Store, kubeClient kubernetes.Interface, vClusterName, vClusterNamespace string) (*snapshot.Metadata, error) {
+	reader, err := store.Reader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return snapshot.RestoreSecrets(ctx, vClusterName, vClusterNamespace, kubeClient, reader)
+}
+
+func restoreBackingStore(ctx context.Context, kubeConfig *rest.Config, kubeClient kubernetes.Interface, store snapshot.Store, template corev1.PodTemplateSpec, volumeClaimTemplates []corev1.PersistentVolumeClaim, vClusterName, vClusterNamespace string, log log.Logger) error {
+	pod, err := newRestorePod(template, volumeClaimTemplates, vClusterName, vClusterNamespace)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Starting restore pod %s/%s...", vClusterNamespace, pod.Name)
+	pod, err = kubeClient.CoreV1().Pods(vClusterNamespace).Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("create restore pod: %w", err)
+	}
+	defer func() {
+		err := kubeClient.CoreV1().Pods(vClusterNamespace).Delete(context.Background(), pod.Name, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			log.Warnf("Error deleting restore pod %s/%s: %v", vClusterNamespace, pod.Name, err)
+		}
+	}()
+
+	// wait until the pod is running
+	err = waitForPod(ctx, kubeClient, pod.Name, vClusterNamespace, func(pod *corev1.Pod) bool {
+		return pod.Status.Phase != corev1.PodPending
+	})
+	if err != nil {
+		return fmt.Errorf("wait for restore pod to start: %w", err)
+	}
+
+	// stream the snapshot into the pod
+	reader, err := store.Reader(ctx)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	transport, upgrader, err := podhelper.GetUpgraderWrapper(kubeConfig)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Restoring backing store from %s...", store.String())
+	output := &bytes.Buffer{}
+	err = podhelper.ExecStreamWithTransport(ctx, kubeClient, &podhelper.ExecStreamWithTransportOptions{
+		ExecStreamOptions: podhelper.ExecStreamOptions{
+			Pod:       pod.Name,
+			Namespace: vClusterNamespace,
+			Container: "syncer",
+			Stdin:     reader,
+			Stdout:    output,
+			Stderr:    output,
+		},
+		Transport:   transport,
+		Upgrader:    upgrader,
+		SubResource: podhelper.SubResourceAttach,
+	})
+	if err != nil {
+		return fmt.Errorf("stream snapshot into restore pod: %w", err)
+	}
+
+	// wait for the restore to finish
+	err = waitForPod(ctx, kubeClient, pod.Name, vClusterNamespace, func(pod *corev1.Pod) bool {
+		return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+	})
+	if err != nil {
+		return fmt.Errorf("wait for restore pod to finish: %w", err)
+	}
+
+	pod, err = kubeClient.CoreV1().Pods(vClusterNamespace).Get(ctx, pod.Name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("get restore pod: %w", err)
+	} else if pod.Status.Phase != corev1.PodSucceeded {
+		logs, _ := kubeClient.CoreV1().Pods(vClusterNamespace).GetLogs(pod.Name, &corev1.PodLogOptions{Container: "syncer"}).DoRaw(ctx)
+		if len(logs) == 0 {
+			logs = output.Bytes()
+		}
+
+		return fmt.Errorf("restore pod failed: %s", strings.TrimSpace(string(logs)))
+	}
+
+	return nil
+}
+
+// newRestorePod creates a pod from the vCluster statefulset template that runs the restore command
+func newRestorePod(template corev1.PodTemplateSpec, volumeClaimTemplates []corev1.PersistentVolumeClaim, vClusterName, vClusterNamespace string) (*corev1.Pod, error) {
+	podSpec := template.Spec.DeepCopy()
+
+	var syncer *corev1.Container
+	for _, container := range podSpec.Containers {
+		if container.Name == "syncer" {
+			syncer = container.DeepCopy()
+			break
+		}
+	}
+	if syncer == nil {
+		return nil, fmt.Errorf("couldn't find syncer container in vcluster statefulset")
+	}
+
+	syncer.Command = []string{"/vcluster", "restore"}
+	syncer.Args = nil
+	syncer.Stdin = true
+	syncer.StdinOnce = true
+	syncer.LivenessProbe = nil
+	syncer.ReadinessProbe = nil
+	syncer.StartupProbe = nil
+	podSpec.Containers = []corev1.Container{*syncer}
+	podSpec.RestartPolicy = corev1.RestartPolicyNever
+
+	// mount the persistent volume of the first replica
+	for _, volumeClaimTemplate := range volumeClaimTemplates {
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: volumeClaimTemplate.Name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: volumeClaimTemplate.Name + "-" + vClusterName + "-0",
+				},
+			},
+		})
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        vClusterName + "-restore",
+			Namespace:   vClusterNamespace,
+			Annotations: template.Annotations,
+			Labels: map[string]string{
+				"app":     "vcluster-restore",
+				"release": vClusterName,
+			},
+		},
+		Spec: *podSpec,
+	}, nil
+}
+
+func waitForPod(ctx context.Context, kubeClient kubernetes.Interface, name, namespace string, condition func(pod *corev1.Pod) bool) error {
+	return wait.PollUntilContextTimeout(ctx, time.Second, time.Minute*10, true, func(ctx context.Context) (bool, error) {
+		pod, err := kubeClient.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		return condition(pod), nil
+	})
+}
+
+func waitForVClusterPodsDeleted(ctx context.Context, kubeClient kubernetes.Interface, vClusterName, vClusterNamespace string) error {
+	return wait.PollUntilContextTimeout(ctx, time.Second, time.Minute*5, true, func(ctx context.Context) (bool, error) {
+		podList, err := kubeClient.CoreV1().Pods(vClusterNamespace).List(ctx, metav1.ListOptions{
+			LabelSelector: "app=vcluster,release=" + vClusterName,
+		})
+		if err != nil {
+			return false, err
+		}
+
+		return len(podList.Items) == 0, nil
+	})
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/vcluster/pkg/cli/find"
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/loft-sh/vcluster/pkg/snapshot"
+	"github.com/loft-sh/vcluster/pkg/util/podhelper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type SnapshotOptions struct {
+	Output string
+}
+
+// SnapshotHelm creates a snapshot of the backing store and secrets of the given vCluster and writes it to options.Output
+func SnapshotHelm(ctx context.Context, options *SnapshotOptions, globalFlags *flags.GlobalFlags, vClusterName string, log log.Logger) error {
+	store, err := snapshot.NewStore(options.Output)
+	if err != nil {
+		return err
+	}
+
+	vCluster, err := find.GetVCluster(ctx, globalFlags.Context, vClusterName, globalFlags.Namespace, log)
+	if err != nil {
+		return err
+	} else if vCluster.Status != find.StatusRunning {
+		return fmt.Errorf("vcluster %s/%s is not running, please resume it first", vCluster.Namespace, vCluster.Name)
+	}
+
+	kubeConfig, err := vCluster.ClientFactory.ClientConfig()
+	if err != nil {
+		return fmt.Errorf("load kube config: %w", err)
+	}
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return err
+	}
+
+	pod, err := findRunningVClusterPod(ctx, kubeClient, vCluster.Name, vCluster.Namespace)
+	if err != nil {
+		return err
+	}
+
+	// the snapshot is created within the vCluster pod, as only it has access to the backing store
+	log.Infof("Creating snapshot of vcluster %s/%s...", vCluster.Namespace, vCluster.Name)
+	err = store.Write(ctx, func(w io.Writer) error {
+		stderr := &bytes.Buffer{}
+		err := podhelper.ExecStream(ctx, kubeConfig, &podhelper.ExecStreamOptions{
+			Pod:       pod.Name,
+			Namespace: pod.Namespace,
+			Container: "syncer",
+			Command:   []string{"/vcluster", "snapshot"},
+			Stdout:    w,
+			Stderr:    stderr,
+		})
+		if err != nil {
+			return fmt.Errorf("create snapshot in pod %s/%s: %w: %s", pod.Namespace, pod.Name, err, strings.TrimSpace(stderr.String()))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Donef("Successfully created snapshot of vcluster %s/%s at %s", vCluster.Namespace, vCluster.Name, store.String())
+	return nil
+}
+
+func findRunningVClusterPod(ctx context.Context, kubeClient kubernetes.Interface, vClusterName, vClusterNamespace string) (*corev1.Pod, error) {
+	podList, err := kubeClient.CoreV1().Pods(vClusterNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: "app=vcluster,release=" + vClusterName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list vcluster pods: %w", err)
+	}
+
+	for _, pod := range podList.Items {
+		if pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp == nil {
+			return &pod, nil
+		}
+	}
+
+	return nil, fmt.Errorf("couldn't find a running pod for vcluster %s/%s", vClusterNamespace, vClusterName)
+}
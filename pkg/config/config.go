@@ -1,12 +1,15 @@
 package config
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/loft-sh/vcluster/config"
 	"github.com/loft-sh/vcluster/config/legacyconfig"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -179,7 +182,7 @@ func (v VirtualClusterConfig) LegacyOptions() (*legacyconfig.LegacyVirtualCluste
 		MountPhysicalHostPaths:      false,
 		HostMetricsBindAddress:      "0",
 		VirtualMetricsBindAddress:   "0",
-		MultiNamespaceMode:          v.Experimental.MultiNamespaceMode.Enabled,
+		MultiNamespaceMode:          v.Sync.ToHost.Namespaces.Enabled,
 		SyncAllSecrets:              v.Sync.ToHost.Secrets.All,
 		SyncAllConfigMaps:           v.Sync.ToHost.ConfigMaps.All,
 		ProxyMetricsServer:          v.Integrations.MetricsServer.Enabled,
@@ -227,3 +230,53 @@ func findResource(resources *metav1.APIResourceList, resourcePlural string) bool
 
 	return false
 }
+
+// ParseFromHostMappings parses the sync.fromHost mappings into a host to virtual name map. Mappings
+// that target a whole namespace use "*" as name on both sides.
+func ParseFromHostMappings(byName map[string]string) (map[types.NamespacedName]types.NamespacedName, error) {
+	ret := map[types.NamespacedName]types.NamespacedName{}
+	for from, to := range byName {
+		hostName, err := parseFromHostMappingName(from)
+		if err != nil {
+			return nil, fmt.Errorf("invalid host object %q: %w", from, err)
+		}
+
+		virtualName, err := parseFromHostMappingName(to)
+		if err != nil {
+			return nil, fmt.Errorf("invalid virtual object %q: %w", to, err)
+		}
+
+		if (hostName.Name == "*") != (virtualName.Name == "*") {
+			return nil, fmt.Errorf("invalid mapping %q: %q, either both or none of the names need to be a wildcard", from, to)
+		}
+
+		ret[hostName] = virtualName
+	}
+
+	return ret, nil
+}
+
+func parseFromHostMappingName(name string) (types.NamespacedName, error) {
+	namespace, objectName, found := strings.Cut(name, "/")
+	if !found || namespace == "" || objectName == "" {
+		return types.NamespacedName{}, fmt.Errorf("expected format namespace/name or namespace/*")
+	}
+
+	return types.NamespacedName{Namespace: namespace, Name: objectName}, nil
+}
+
+// ParseCustomResourceKey parses a sync.toHost.customResources or sync.fromHost.customResources key in the
+// form resource.group/version
+func ParseCustomResourceKey(key string) (schema.GroupVersionResource, error) {
+	groupResource, version, found := strings.Cut(key, "/")
+	if !found || version == "" || strings.Contains(version, "/") {
+		return schema.GroupVersionResource{}, fmt.Errorf("expected format resource.group/version")
+	}
+
+	resource, group, found := strings.Cut(groupResource, ".")
+	if !found || resource == "" || group == "" {
+		return schema.GroupVersionResource{}, fmt.Errorf("expected format resource.group/version")
+	}
+
+	return schema.GroupVersionResource{Group: group, Version: version, Resource: resource}, nil
+}
@@ -3,9 +3,11 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/loft-sh/vcluster/config"
 	"github.com/loft-sh/vcluster/pkg/strvals"
+	"github.com/loft-sh/vcluster/pkg/util/clienthelper"
 	"github.com/pkg/errors"
 	"sigs.k8s.io/yaml"
 )
@@ -36,11 +38,14 @@ func ParseConfig(path, name string, setValues []string) (*VirtualClusterConfig,
 		return nil, err
 	}
 
-	// build config
+	// build config, the namespace is resolved again once the clients are created, but is already needed
+	// to validate the config
+	namespace, _ := clienthelper.CurrentNamespace()
 	retConfig := &VirtualClusterConfig{
-		Config:              *rawConfig,
-		Name:                name,
-		ControlPlaneService: name,
+		Config:                *rawConfig,
+		Name:                  name,
+		ControlPlaneService:   name,
+		ControlPlaneNamespace: strings.TrimSpace(namespace),
 	}
 
 	// validate config
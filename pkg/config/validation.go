@@ -6,12 +6,23 @@ import (
 	"fmt"
 	"net/url"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/ghodss/yaml"
 	"github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/patches/expression"
+	patchesregex "github.com/loft-sh/vcluster/pkg/patches/regex"
+	"github.com/loft-sh/vcluster/pkg/util/labelselector"
 	"github.com/loft-sh/vcluster/pkg/util/toleration"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"github.com/robfig/cron/v3"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 var allowedPodSecurityStandards = map[string]bool{
@@ -69,8 +80,120 @@ func ValidateConfigAndSetDefaults(config *VirtualClusterConfig) error {
 		return fmt.Errorf("you cannot enable both sync.fromHost.storageClasses.enabled and sync.toHost.storageClasses.enabled at the same time. Choose only one of them")
 	}
 
+	// check if dry run reports to a single destination
+	if config.Experimental.SyncSettings.DryRun.File != "" && config.Experimental.SyncSettings.DryRun.ConfigMap != "" {
+		return fmt.Errorf("experimental.syncSettings.dryRun.file and experimental.syncSettings.dryRun.configMap cannot be set at the same time")
+	}
+
+	// validate audit logging
+	err := validateAudit(config.ControlPlane.Advanced.Audit)
+	if err != nil {
+		return err
+	}
+
+	// validate oidc authentication
+	err = validateProxyOIDC(config.ControlPlane.Proxy.OIDC)
+	if err != nil {
+		return err
+	}
+
+	// validate scheduled snapshots
+	err = validateBackingStoreSnapshots(config.ControlPlane.BackingStore.Snapshots)
+	if err != nil {
+		return err
+	}
+
+	// validate certificate rotation
+	err = validateCertificateRotation(config.ControlPlane.Advanced.CertificateRotation)
+	if err != nil {
+		return err
+	}
+
+	// validate accounting
+	err = validateAccounting(config.Experimental.Accounting)
+	if err != nil {
+		return err
+	}
+
+	// validate sleep mode
+	err = validateSleepMode(config.SleepMode)
+	if err != nil {
+		return err
+	}
+
+	// validate syncer controller settings
+	err = validateSyncControllers(config.Experimental.SyncSettings.Controllers)
+	if err != nil {
+		return err
+	}
+
+	// migrate deprecated experimental.multiNamespaceMode
+	if config.Experimental.MultiNamespaceMode.Enabled {
+		config.Sync.ToHost.Namespaces.Enabled = true
+		for k, v := range config.Experimental.MultiNamespaceMode.NamespaceLabels {
+			if config.Sync.ToHost.Namespaces.ExtraLabels == nil {
+				config.Sync.ToHost.Namespaces.ExtraLabels = map[string]string{}
+			}
+			if _, ok := config.Sync.ToHost.Namespaces.ExtraLabels[k]; !ok {
+				config.Sync.ToHost.Namespaces.ExtraLabels[k] = v
+			}
+		}
+	}
+
+	// validate namespaces
+	err = validateNamespaces(config.Sync.ToHost.Namespaces, config.Name, config.ControlPlaneNamespace)
+	if err != nil {
+		return err
+	}
+
+	// validate naming policy
+	err = validateNaming(config.Experimental.SyncSettings.Naming, config.Sync.ToHost.Namespaces.Enabled)
+	if err != nil {
+		return err
+	}
+
+	// validate sync from host mappings
+	err = validateFromHostMappings(config.Sync.FromHost.ConfigMaps, "configMaps")
+	if err != nil {
+		return err
+	}
+	err = validateFromHostMappings(config.Sync.FromHost.Secrets, "secrets")
+	if err != nil {
+		return err
+	}
+
+	// validate custom resources
+	err = validateCustomResources(config.Sync)
+	if err != nil {
+		return err
+	}
+
+	// validate gateway api
+	err = validateGatewayAPI(config.Sync.ToHost.GatewayAPI)
+	if err != nil {
+		return err
+	}
+
+	// validate patches of the built-in syncers
+	err = validateSyncPatches(config.Sync)
+	if err != nil {
+		return err
+	}
+
+	// validate selectors of the built-in syncers
+	err = validateSyncSelectors(config.Sync)
+	if err != nil {
+		return err
+	}
+
+	// validate image translation
+	err = validateImageTranslation(config.Sync.ToHost.Pods.ImageTranslation)
+	if err != nil {
+		return err
+	}
+
 	// validate central admission control
-	err := validateCentralAdmissionControl(config)
+	err = validateCentralAdmissionControl(config)
 	if err != nil {
 		return err
 	}
@@ -114,6 +237,452 @@ func ValidateConfigAndSetDefaults(config *VirtualClusterConfig) error {
 	return nil
 }
 
+func validateFromHostMappings(resource config.SyncFromHostResource, resourceName string) error {
+	if !resource.Enabled {
+		return nil
+	} else if len(resource.Mappings.ByName) == 0 {
+		return fmt.Errorf("sync.fromHost.%s.mappings.byName is empty, but required if sync.fromHost.%s.enabled is true", resourceName, resourceName)
+	}
+
+	mappings, err := ParseFromHostMappings(resource.Mappings.ByName)
+	if err != nil {
+		return fmt.Errorf("invalid sync.fromHost.%s.mappings.byName: %w", resourceName, err)
+	}
+
+	// make sure no two host objects end up as the same virtual object
+	virtualNames := map[types.NamespacedName]types.NamespacedName{}
+	for hostName, virtualName := range mappings {
+		if otherHostName, ok := virtualNames[virtualName]; ok {
+			return fmt.Errorf("invalid sync.fromHost.%s.mappings.byName: %s and %s are both mapped to %s", resourceName, otherHostName.String(), hostName.String(), virtualName.String())
+		}
+
+		virtualNames[virtualName] = hostName
+	}
+
+	return nil
+}
+
+func validateAudit(audit config.ControlPlaneAudit) error {
+	if !audit.Enabled {
+		return nil
+	}
+
+	if !audit.Log.Enabled && !audit.Webhook.Enabled && !audit.HostBuffer.Enabled {
+		return fmt.Errorf("controlPlane.advanced.audit is enabled, but none of log, webhook or hostBuffer is enabled")
+	}
+	if audit.Webhook.Enabled && audit.Webhook.Config == "" {
+		return fmt.Errorf("controlPlane.advanced.audit.webhook.config is required if the webhook is enabled")
+	}
+	if audit.HostBuffer.Kind != "" && audit.HostBuffer.Kind != "ConfigMap" && audit.HostBuffer.Kind != "Secret" {
+		return fmt.Errorf("invalid controlPlane.advanced.audit.hostBuffer.kind %q, must be either ConfigMap or Secret", audit.HostBuffer.Kind)
+	}
+	if audit.Log.MaxAge < 0 || audit.Log.MaxBackups < 0 || audit.Log.MaxSize < 0 || audit.HostBuffer.MaxEvents < 0 {
+		return fmt.Errorf("controlPlane.advanced.audit: maxAge, maxBackups, maxSize and maxEvents cannot be negative")
+	}
+
+	return nil
+}
+
+func validateProxyOIDC(oidc config.ControlPlaneProxyOIDC) error {
+	if !oidc.Enabled {
+		return nil
+	}
+
+	issuerURL, err := url.Parse(oidc.IssuerURL)
+	if err != nil || issuerURL.Scheme != "https" || issuerURL.Host == "" {
+		return fmt.Errorf("invalid controlPlane.proxy.oidc.issuerURL %q, must be a https url", oidc.IssuerURL)
+	}
+	if oidc.ClientID == "" {
+		return fmt.Errorf("controlPlane.proxy.oidc.clientID is required if oidc is enabled")
+	}
+	if oidc.CA != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(oidc.CA)) {
+		return fmt.Errorf("controlPlane.proxy.oidc.ca is not a valid PEM encoded certificate")
+	}
+
+	return nil
+}
+
+func validateBackingStoreSnapshots(snapshots config.BackingStoreSnapshots) error {
+	if !snapshots.Enabled {
+		return nil
+	}
+
+	_, err := cron.ParseStandard(snapshots.Schedule)
+	if err != nil {
+		return fmt.Errorf("invalid controlPlane.backingStore.snapshots.schedule %q: %w", snapshots.Schedule, err)
+	}
+	if !strings.HasPrefix(snapshots.Destination, "file://") && !strings.HasPrefix(snapshots.Destination, "s3://") {
+		return fmt.Errorf("invalid controlPlane.backingStore.snapshots.destination %q, must be either file://path or s3://bucket/prefix", snapshots.Destination)
+	}
+	if snapshots.Retention.Count < 0 {
+		return fmt.Errorf("controlPlane.backingStore.snapshots.retention.count cannot be negative")
+	}
+	if snapshots.Retention.MaxAge != "" {
+		maxAge, err := time.ParseDuration(snapshots.Retention.MaxAge)
+		if err != nil {
+			return fmt.Errorf("controlPlane.backingStore.snapshots.retention.maxAge: %w", err)
+		} else if maxAge < 0 {
+			return fmt.Errorf("controlPlane.backingStore.snapshots.retention.maxAge cannot be negative")
+		}
+	}
+
+	return nil
+}
+
+func validateCertificateRotation(rotation config.ControlPlaneCertificateRotation) error {
+	if !rotation.Enabled {
+		return nil
+	}
+
+	renewBefore, err := time.ParseDuration(rotation.RenewBefore)
+	if err != nil {
+		return fmt.Errorf("controlPlane.advanced.certificateRotation.renewBefore: %w", err)
+	} else if renewBefore <= 0 {
+		return fmt.Errorf("controlPlane.advanced.certificateRotation.renewBefore must be positive")
+	}
+	checkInterval, err := time.ParseDuration(rotation.CheckInterval)
+	if err != nil {
+		return fmt.Errorf("controlPlane.advanced.certificateRotation.checkInterval: %w", err)
+	} else if checkInterval <= 0 {
+		return fmt.Errorf("controlPlane.advanced.certificateRotation.checkInterval must be positive")
+	}
+
+	return nil
+}
+
+func validateAccounting(accounting config.ExperimentalAccounting) error {
+	if !accounting.Enabled {
+		return nil
+	}
+
+	interval, err := time.ParseDuration(accounting.Interval)
+	if err != nil {
+		return fmt.Errorf("experimental.accounting.interval: %w", err)
+	} else if interval <= 0 {
+		return fmt.Errorf("experimental.accounting.interval must be positive")
+	}
+	if !accounting.Report.Enabled {
+		return nil
+	}
+
+	_, err = cron.ParseStandard(accounting.Report.Schedule)
+	if err != nil {
+		return fmt.Errorf("invalid experimental.accounting.report.schedule %q: %w", accounting.Report.Schedule, err)
+	}
+	if accounting.Report.Format != "json" && accounting.Report.Format != "csv" {
+		return fmt.Errorf("invalid experimental.accounting.report.format %q, must be either json or csv", accounting.Report.Format)
+	}
+	if accounting.Report.Destination != "" && !strings.HasPrefix(accounting.Report.Destination, "configmap://") && !strings.HasPrefix(accounting.Report.Destination, "file://") {
+		return fmt.Errorf("invalid experimental.accounting.report.destination %q, must be either configmap://name or file://path", accounting.Report.Destination)
+	}
+
+	return nil
+}
+
+func validateSleepMode(sleepMode config.SleepMode) error {
+	if !sleepMode.Enabled {
+		return nil
+	}
+
+	_, err := time.LoadLocation(sleepMode.TimeZone)
+	if err != nil {
+		return fmt.Errorf("invalid sleepMode.timeZone %q: %w", sleepMode.TimeZone, err)
+	}
+	if sleepMode.AutoSleep.AfterInactivity == "" && sleepMode.AutoSleep.Schedule == "" {
+		return fmt.Errorf("sleepMode.autoSleep.afterInactivity or sleepMode.autoSleep.schedule is required if sleep mode is enabled")
+	}
+	if sleepMode.AutoSleep.AfterInactivity != "" {
+		afterInactivity, err := time.ParseDuration(sleepMode.AutoSleep.AfterInactivity)
+		if err != nil {
+			return fmt.Errorf("sleepMode.autoSleep.afterInactivity: %w", err)
+		} else if afterInactivity <= 0 {
+			return fmt.Errorf("sleepMode.autoSleep.afterInactivity must be positive")
+		}
+	}
+	if sleepMode.AutoSleep.Schedule != "" {
+		_, err = cron.ParseStandard(sleepMode.AutoSleep.Schedule)
+		if err != nil {
+			return fmt.Errorf("invalid sleepMode.autoSleep.schedule %q: %w", sleepMode.AutoSleep.Schedule, err)
+		}
+	}
+
+	return nil
+}
+
+func validateSyncControllers(controllers map[string]config.ExperimentalSyncSettingsController) error {
+	for name, controller := range controllers {
+		if controller.MaxConcurrentReconciles < 0 || controller.QPS < 0 || controller.Burst < 0 {
+			return fmt.Errorf("experimental.syncSettings.controllers[%s]: maxConcurrentReconciles, qps and burst cannot be negative", name)
+		}
+
+		durations := map[string]string{
+			"backoff.baseDelay": controller.Backoff.BaseDelay,
+			"backoff.maxDelay":  controller.Backoff.MaxDelay,
+			"resyncPeriod":      controller.ResyncPeriod,
+		}
+		for field, value := range durations {
+			if value == "" {
+				continue
+			}
+
+			duration, err := time.ParseDuration(value)
+			if err != nil {
+				return fmt.Errorf("experimental.syncSettings.controllers[%s].%s: %w", name, field, err)
+			} else if duration < 0 {
+				return fmt.Errorf("experimental.syncSettings.controllers[%s].%s cannot be negative", name, field)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateNaming(naming config.ExperimentalSyncSettingsNaming, multiNamespaceMode bool) error {
+	switch naming.Policy {
+	case "", config.NamingPolicyHashed:
+		return nil
+	case config.NamingPolicyPrefix:
+	case config.NamingPolicyTemplate:
+		if naming.Template == "" {
+			return fmt.Errorf("experimental.syncSettings.naming.template is required if policy is %s", config.NamingPolicyTemplate)
+		}
+
+		_, err := translate.NewTemplateNamingPolicy(naming.Template)
+		if err != nil {
+			return fmt.Errorf("invalid experimental.syncSettings.naming.template: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported experimental.syncSettings.naming.policy %q, must be one of %s, %s or %s", naming.Policy, config.NamingPolicyHashed, config.NamingPolicyPrefix, config.NamingPolicyTemplate)
+	}
+
+	if multiNamespaceMode {
+		return fmt.Errorf("experimental.syncSettings.naming.policy %s is not supported in multi namespace mode", naming.Policy)
+	}
+
+	return nil
+}
+
+// validateNamespaces validates the multi namespace mode config. The name template is only validated if the namespace
+// of the vCluster is known, otherwise it is validated when the vCluster starts.
+func validateNamespaces(namespaces config.SyncToHostNamespaces, vClusterName, vClusterNamespace string) error {
+	if !namespaces.Enabled {
+		return nil
+	}
+
+	if namespaces.NameTemplate != "" && vClusterNamespace != "" {
+		_, err := translate.NewNamespaceTemplate(namespaces.NameTemplate, vClusterName, vClusterNamespace)
+		if err != nil {
+			return fmt.Errorf("invalid sync.toHost.namespaces.nameTemplate: %w", err)
+		}
+	}
+
+	templates := []struct {
+		name     string
+		template config.NamespaceObjectTemplate
+		spec     interface{}
+	}{
+		{name: "resourceQuota", template: namespaces.ResourceQuota, spec: &corev1.ResourceQuotaSpec{}},
+		{name: "limitRange", template: namespaces.LimitRange, spec: &corev1.LimitRangeSpec{}},
+		{name: "networkPolicy", template: namespaces.NetworkPolicy, spec: &networkingv1.NetworkPolicySpec{}},
+	}
+	for _, t := range templates {
+		if !t.template.Enabled {
+			continue
+		}
+
+		err := runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(t.template.Spec, t.spec, true)
+		if err != nil {
+			return fmt.Errorf("invalid sync.toHost.namespaces.%s.spec: %w", t.name, err)
+		}
+	}
+
+	return nil
+}
+
+func validateCustomResources(sync config.Sync) error {
+	for key, customResource := range sync.ToHost.CustomResources {
+		if !customResource.Enabled {
+			continue
+		}
+
+		err := validateCustomResource(key, customResource.Patches, customResource.ReversePatches, customResource.StatusSync, customResource.ConflictRules)
+		if err != nil {
+			return fmt.Errorf("invalid sync.toHost.customResources[%s]: %w", key, err)
+		}
+
+		if fromHostResource, ok := sync.FromHost.CustomResources[key]; ok && fromHostResource.Enabled {
+			return fmt.Errorf("sync.toHost.customResources[%s] and sync.fromHost.customResources[%s] cannot be enabled at the same time", key, key)
+		}
+	}
+
+	for key, customResource := range sync.FromHost.CustomResources {
+		if !customResource.Enabled {
+			continue
+		}
+
+		err := validateCustomResource(key, customResource.Patches, customResource.ReversePatches, customResource.StatusSync, customResource.ConflictRules)
+		if err != nil {
+			return fmt.Errorf("invalid sync.fromHost.customResources[%s]: %w", key, err)
+		}
+
+		err = validateFromHostMappings(config.SyncFromHostResource{Enabled: len(customResource.Mappings.ByName) > 0, Mappings: customResource.Mappings}, "customResources["+key+"]")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateGatewayAPI(gatewayAPI config.SyncToHostGatewayAPI) error {
+	for idx, gateway := range gatewayAPI.AllowedGateways {
+		namespace, name, found := strings.Cut(gateway, "/")
+		if !found || namespace == "" || name == "" || strings.Contains(name, "/") {
+			return fmt.Errorf("sync.toHost.gatewayAPI.allowedGateways[%d] %q must be in the form namespace/name", idx, gateway)
+		}
+	}
+
+	return nil
+}
+
+func validateSyncPatches(sync config.Sync) error {
+	for _, syncer := range sync.BuiltInSyncers() {
+		for idx, patch := range syncer.SyncPatches.Patches {
+			err := validateSyncPatch(patch)
+			if err != nil {
+				return fmt.Errorf("invalid sync.%s.patches[%d]: %w", syncer.Path, idx, err)
+			}
+		}
+
+		for idx, patch := range syncer.SyncPatches.ReversePatches {
+			err := validateSyncPatch(patch)
+			if err != nil {
+				return fmt.Errorf("invalid sync.%s.reversePatches[%d]: %w", syncer.Path, idx, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateImageTranslation(imageTranslation config.SyncPodsImageTranslation) error {
+	for idx, rule := range imageTranslation.Rules {
+		if rule.From == "" || rule.To == "" {
+			return fmt.Errorf("sync.toHost.pods.imageTranslation.rules[%d]: from and to are required", idx)
+		} else if strings.Count(rule.To, "*") > strings.Count(rule.From, "*") {
+			return fmt.Errorf("sync.toHost.pods.imageTranslation.rules[%d]: to uses more wildcards than from", idx)
+		}
+	}
+
+	for registry, mirror := range imageTranslation.RegistryMirrors {
+		if registry == "" || mirror == "" || strings.Contains(registry, "/") {
+			return fmt.Errorf("sync.toHost.pods.imageTranslation.registryMirrors: invalid mirror %q for registry %q", mirror, registry)
+		}
+	}
+
+	if imageTranslation.ResolveDigests.CacheTTL != "" {
+		_, err := time.ParseDuration(imageTranslation.ResolveDigests.CacheTTL)
+		if err != nil {
+			return fmt.Errorf("invalid sync.toHost.pods.imageTranslation.resolveDigests.cacheTTL: %w", err)
+		}
+	}
+
+	for idx, registry := range imageTranslation.ResolveDigests.Registries {
+		if registry == "" || strings.Contains(registry, "/") {
+			return fmt.Errorf("sync.toHost.pods.imageTranslation.resolveDigests.registries[%d]: invalid registry host %q", idx, registry)
+		}
+	}
+
+	return nil
+}
+
+func validateSyncSelectors(sync config.Sync) error {
+	for _, syncer := range sync.BuiltInSyncers() {
+		if syncer.Selector == nil {
+			continue
+		}
+
+		err := validateSyncSelector(*syncer.Selector)
+		if err != nil {
+			return fmt.Errorf("invalid sync.%s.selector: %w", syncer.Path, err)
+		}
+	}
+
+	return nil
+}
+
+func validateSyncSelector(selector config.SyncToHostSelector) error {
+	_, err := labelselector.FromConfig(selector)
+	if err != nil {
+		return err
+	}
+
+	for _, namespaces := range [][]string{selector.Namespaces.Include, selector.Namespaces.Exclude} {
+		for _, namespace := range namespaces {
+			errs := validation.ValidateNamespaceName(namespace, false)
+			if len(errs) != 0 {
+				return fmt.Errorf("invalid namespace %q: %v", namespace, errs)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateSyncPatch(patch *config.Patch) error {
+	if patch == nil {
+		return fmt.Errorf("patch is required")
+	} else if patch.Path == "" {
+		return fmt.Errorf("path is required")
+	} else if patch.Sync != nil {
+		return fmt.Errorf("sync is not supported for built-in syncers")
+	}
+
+	if patch.Regex != "" {
+		_, err := patchesregex.PrepareRegex(patch.Regex)
+		if err != nil {
+			return fmt.Errorf("invalid regex: %w", err)
+		}
+	}
+
+	return validatePatch(patch)
+}
+
+func validateCustomResource(key string, patches, reversePatches []*config.Patch, statusSync config.StatusSyncMode, conflictRules []config.ConflictRule) error {
+	_, err := ParseCustomResourceKey(key)
+	if err != nil {
+		return err
+	}
+
+	for idx, patch := range patches {
+		err := validatePatch(patch)
+		if err != nil {
+			return fmt.Errorf("invalid patches[%d]: %w", idx, err)
+		}
+	}
+
+	for idx, patch := range reversePatches {
+		err := validatePatch(patch)
+		if err != nil {
+			return fmt.Errorf("invalid reversePatches[%d]: %w", idx, err)
+		}
+	}
+
+	if statusSync != "" && statusSync != config.StatusSyncModeHostToVirtual && statusSync != config.StatusSyncModeDisabled {
+		return fmt.Errorf("invalid statusSync %q, must be one of: %s, %s", statusSync, config.StatusSyncModeHostToVirtual, config.StatusSyncModeDisabled)
+	}
+
+	for idx, rule := range conflictRules {
+		if rule.Path == "" {
+			return fmt.Errorf("conflictRules[%d].path is required", idx)
+		} else if rule.Owner != config.ConflictRuleOwnerVirtual && rule.Owner != config.ConflictRuleOwnerHost {
+			return fmt.Errorf("invalid conflictRules[%d].owner %q, must be one of: %s, %s", idx, rule.Owner, config.ConflictRuleOwnerVirtual, config.ConflictRuleOwnerHost)
+		}
+	}
+
+	return nil
+}
+
 func validateDistro(config *VirtualClusterConfig) error {
 	enabledDistros := 0
 	if config.Config.ControlPlane.Distro.K3S.Enabled {
@@ -238,6 +807,11 @@ func validateGenericSyncConfig(config config.ExperimentalGenericSync) error {
 }
 
 func validatePatch(patch *config.Patch) error {
+	err := validatePatchExpressions(patch)
+	if err != nil {
+		return err
+	}
+
 	switch patch.Operation {
 	case config.PatchTypeRemove, config.PatchTypeReplace, config.PatchTypeAdd:
 		if patch.FromPath != "" {
@@ -258,6 +832,36 @@ func validatePatch(patch *config.Patch) error {
 	}
 }
 
+// validatePatchExpressions type-checks and compiles the expressions of the patch, so that invalid expressions
+// fail at startup instead of during sync. Compiled expressions are cached for the patches.
+func validatePatchExpressions(patch *config.Patch) error {
+	if patch.ValueExpression != "" {
+		if patch.Operation != config.PatchTypeReplace && patch.Operation != config.PatchTypeAdd {
+			return fmt.Errorf("valueExpression is not supported for this operation")
+		} else if patch.Value != nil {
+			return fmt.Errorf("value and valueExpression cannot be used together")
+		}
+
+		_, err := expression.CompileValue(patch.ValueExpression)
+		if err != nil {
+			return fmt.Errorf("invalid valueExpression: %w", err)
+		}
+	}
+
+	for idx, condition := range patch.Conditions {
+		if condition == nil || condition.Expression == "" {
+			continue
+		}
+
+		_, err := expression.CompileCondition(condition.Expression)
+		if err != nil {
+			return fmt.Errorf("invalid conditions[%d].expression: %w", idx, err)
+		}
+	}
+
+	return nil
+}
+
 func validateVerb(verb string) error {
 	if !slices.Contains(verbs, verb) {
 		return fmt.Errorf("invalid verb \"%s\"; expected on of %q", verb, verbs)
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/loft-sh/vcluster/config"
@@ -125,3 +126,690 @@ func mutHook(clientCfg config.ValidatingWebhookClientConfig) config.MutatingWebh
 	}
 	return hook
 }
+
+func TestValidateCustomResources(t *testing.T) {
+	testCases := []struct {
+		name    string
+		sync    config.Sync
+		wantErr string
+	}{
+		{
+			name: "valid",
+			sync: config.Sync{
+				ToHost: config.SyncToHost{
+					CustomResources: map[string]config.SyncToHostCustomResource{
+						"certificates.cert-manager.io/v1": {
+							Enabled:       true,
+							StatusSync:    config.StatusSyncModeHostToVirtual,
+							ConflictRules: []config.ConflictRule{{Path: "spec.secretName", Owner: config.ConflictRuleOwnerHost}},
+						},
+					},
+				},
+				FromHost: config.SyncFromHost{
+					CustomResources: map[string]config.SyncFromHostCustomResource{
+						"virtualservices.networking.istio.io/v1beta1": {
+							Enabled: true,
+							Mappings: config.FromHostMappings{
+								ByName: map[string]string{"istio-system/*": "istio/*"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid key",
+			sync: config.Sync{
+				ToHost: config.SyncToHost{
+					CustomResources: map[string]config.SyncToHostCustomResource{
+						"certificates/v1": {Enabled: true},
+					},
+				},
+			},
+			wantErr: "invalid sync.toHost.customResources[certificates/v1]: expected format resource.group/version",
+		},
+		{
+			name: "invalid owner",
+			sync: config.Sync{
+				FromHost: config.SyncFromHost{
+					CustomResources: map[string]config.SyncFromHostCustomResource{
+						"certificates.cert-manager.io/v1": {
+							Enabled:       true,
+							ConflictRules: []config.ConflictRule{{Path: "spec", Owner: "Nobody"}},
+						},
+					},
+				},
+			},
+			wantErr: `invalid sync.fromHost.customResources[certificates.cert-manager.io/v1]: invalid conflictRules[0].owner "Nobody", must be one of: Virtual, Host`,
+		},
+		{
+			name: "both directions",
+			sync: config.Sync{
+				ToHost: config.SyncToHost{
+					CustomResources: map[string]config.SyncToHostCustomResource{
+						"certificates.cert-manager.io/v1": {Enabled: true},
+					},
+				},
+				FromHost: config.SyncFromHost{
+					CustomResources: map[string]config.SyncFromHostCustomResource{
+						"certificates.cert-manager.io/v1": {Enabled: true},
+					},
+				},
+			},
+			wantErr: "sync.toHost.customResources[certificates.cert-manager.io/v1] and sync.fromHost.customResources[certificates.cert-manager.io/v1] cannot be enabled at the same time",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCustomResources(tt.sync)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateNaming(t *testing.T) {
+	testCases := []struct {
+		name               string
+		naming             config.ExperimentalSyncSettingsNaming
+		multiNamespaceMode bool
+		wantErr            string
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:   "prefix",
+			naming: config.ExperimentalSyncSettingsNaming{Policy: config.NamingPolicyPrefix},
+		},
+		{
+			name:    "template without template",
+			naming:  config.ExperimentalSyncSettingsNaming{Policy: config.NamingPolicyTemplate},
+			wantErr: "experimental.syncSettings.naming.template is required if policy is template",
+		},
+		{
+			name:    "unknown policy",
+			naming:  config.ExperimentalSyncSettingsNaming{Policy: "short"},
+			wantErr: `unsupported experimental.syncSettings.naming.policy "short", must be one of hashed, prefix or template`,
+		},
+		{
+			name:    "template with labels",
+			naming:  config.ExperimentalSyncSettingsNaming{Policy: config.NamingPolicyTemplate, Template: "{{ .Labels.team }}-{{ .Name }}"},
+			wantErr: "invalid experimental.syncSettings.naming.template: naming template uses unsupported field .Labels, only .Name, .Namespace and .VClusterName are supported. Labels are not supported, as they can change after an object was synced",
+		},
+		{
+			name:               "multi namespace mode",
+			naming:             config.ExperimentalSyncSettingsNaming{Policy: config.NamingPolicyTemplate, Template: "{{ .Name }}"},
+			multiNamespaceMode: true,
+			wantErr:            "experimental.syncSettings.naming.policy template is not supported in multi namespace mode",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateNaming(tt.naming, tt.multiNamespaceMode)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateBackingStoreSnapshots(t *testing.T) {
+	testCases := []struct {
+		name      string
+		snapshots config.BackingStoreSnapshots
+		wantErr   string
+	}{
+		{
+			name:      "disabled",
+			snapshots: config.BackingStoreSnapshots{Schedule: "invalid"},
+		},
+		{
+			name: "valid",
+			snapshots: config.BackingStoreSnapshots{
+				Enabled:     true,
+				Schedule:    "@daily",
+				Destination: "s3://bucket/snapshots",
+				Retention:   config.BackingStoreSnapshotsRetention{Count: 3, MaxAge: "168h"},
+			},
+		},
+		{
+			name:      "invalid schedule",
+			snapshots: config.BackingStoreSnapshots{Enabled: true, Schedule: "* * *", Destination: "file:///data/snapshots"},
+			wantErr:   `invalid controlPlane.backingStore.snapshots.schedule "* * *": expected exactly 5 fields, found 3: [* * *]`,
+		},
+		{
+			name:      "invalid destination",
+			snapshots: config.BackingStoreSnapshots{Enabled: true, Schedule: "0 3 * * *", Destination: "/data/snapshots"},
+			wantErr:   `invalid controlPlane.backingStore.snapshots.destination "/data/snapshots", must be either file://path or s3://bucket/prefix`,
+		},
+		{
+			name: "negative count",
+			snapshots: config.BackingStoreSnapshots{
+				Enabled:     true,
+				Schedule:    "0 3 * * *",
+				Destination: "file:///data/snapshots",
+				Retention:   config.BackingStoreSnapshotsRetention{Count: -1},
+			},
+			wantErr: "controlPlane.backingStore.snapshots.retention.count cannot be negative",
+		},
+		{
+			name: "invalid max age",
+			snapshots: config.BackingStoreSnapshots{
+				Enabled:     true,
+				Schedule:    "0 3 * * *",
+				Destination: "file:///data/snapshots",
+				Retention:   config.BackingStoreSnapshotsRetention{MaxAge: "7d"},
+			},
+			wantErr: `controlPlane.backingStore.snapshots.retention.maxAge: time: unknown unit "d" in duration "7d"`,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBackingStoreSnapshots(tt.snapshots)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateCertificateRotation(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rotation config.ControlPlaneCertificateRotation
+		wantErr  string
+	}{
+		{
+			name:     "disabled",
+			rotation: config.ControlPlaneCertificateRotation{RenewBefore: "invalid"},
+		},
+		{
+			name:     "valid",
+			rotation: config.ControlPlaneCertificateRotation{Enabled: true, RenewBefore: "720h", CheckInterval: "1h"},
+		},
+		{
+			name:     "invalid renew before",
+			rotation: config.ControlPlaneCertificateRotation{Enabled: true, RenewBefore: "30d", CheckInterval: "1h"},
+			wantErr:  `controlPlane.advanced.certificateRotation.renewBefore: time: unknown unit "d" in duration "30d"`,
+		},
+		{
+			name:     "zero check interval",
+			rotation: config.ControlPlaneCertificateRotation{Enabled: true, RenewBefore: "720h", CheckInterval: "0s"},
+			wantErr:  "controlPlane.advanced.certificateRotation.checkInterval must be positive",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCertificateRotation(tt.rotation)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateAccounting(t *testing.T) {
+	report := config.ExperimentalAccountingReport{Enabled: true, Schedule: "@hourly", Format: "csv", Destination: "file:///data/accounting"}
+	testCases := []struct {
+		name       string
+		accounting config.ExperimentalAccounting
+		wantErr    string
+	}{
+		{
+			name:       "disabled",
+			accounting: config.ExperimentalAccounting{Interval: "invalid"},
+		},
+		{
+			name:       "valid",
+			accounting: config.ExperimentalAccounting{Enabled: true, Interval: "1m", Report: report},
+		},
+		{
+			name:       "zero interval",
+			accounting: config.ExperimentalAccounting{Enabled: true, Interval: "0s"},
+			wantErr:    "experimental.accounting.interval must be positive",
+		},
+		{
+			name: "invalid format",
+			accounting: config.ExperimentalAccounting{Enabled: true, Interval: "1m", Report: config.ExperimentalAccountingReport{
+				Enabled: true, Schedule: "@hourly", Format: "yaml",
+			}},
+			wantErr: `invalid experimental.accounting.report.format "yaml", must be either json or csv`,
+		},
+		{
+			name: "invalid destination",
+			accounting: config.ExperimentalAccounting{Enabled: true, Interval: "1m", Report: config.ExperimentalAccountingReport{
+				Enabled: true, Schedule: "@hourly", Format: "json", Destination: "s3://bucket",
+			}},
+			wantErr: `invalid experimental.accounting.report.destination "s3://bucket", must be either configmap://name or file://path`,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAccounting(tt.accounting)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateSleepMode(t *testing.T) {
+	testCases := []struct {
+		name      string
+		sleepMode config.SleepMode
+		wantErr   string
+	}{
+		{
+			name:      "disabled",
+			sleepMode: config.SleepMode{AutoSleep: config.SleepModeAutoSleep{AfterInactivity: "invalid"}},
+		},
+		{
+			name: "valid",
+			sleepMode: config.SleepMode{Enabled: true, TimeZone: "Europe/Berlin", AutoSleep: config.SleepModeAutoSleep{
+				AfterInactivity: "2h", Schedule: "0 20 * * 1-5",
+			}},
+		},
+		{
+			name:      "missing auto sleep",
+			sleepMode: config.SleepMode{Enabled: true},
+			wantErr:   "sleepMode.autoSleep.afterInactivity or sleepMode.autoSleep.schedule is required if sleep mode is enabled",
+		},
+		{
+			name:      "negative inactivity",
+			sleepMode: config.SleepMode{Enabled: true, AutoSleep: config.SleepModeAutoSleep{AfterInactivity: "-1h"}},
+			wantErr:   "sleepMode.autoSleep.afterInactivity must be positive",
+		},
+		{
+			name:      "invalid time zone",
+			sleepMode: config.SleepMode{Enabled: true, TimeZone: "Mars/Olympus", AutoSleep: config.SleepModeAutoSleep{AfterInactivity: "1h"}},
+			wantErr:   `invalid sleepMode.timeZone "Mars/Olympus": unknown time zone Mars/Olympus`,
+		},
+		{
+			name:      "invalid schedule",
+			sleepMode: config.SleepMode{Enabled: true, AutoSleep: config.SleepModeAutoSleep{Schedule: "every evening"}},
+			wantErr:   `invalid sleepMode.autoSleep.schedule "every evening": expected exactly 5 fields, found 2: [every evening]`,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSleepMode(tt.sleepMode)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateGatewayAPI(t *testing.T) {
+	testCases := []struct {
+		name       string
+		gatewayAPI config.SyncToHostGatewayAPI
+		wantErr    string
+	}{
+		{
+			name:       "valid",
+			gatewayAPI: config.SyncToHostGatewayAPI{Enabled: true, AllowedGateways: []string{"gateway-system/public"}},
+		},
+		{
+			name:       "missing namespace",
+			gatewayAPI: config.SyncToHostGatewayAPI{Enabled: true, AllowedGateways: []string{"gateway-system/public", "public"}},
+			wantErr:    `sync.toHost.gatewayAPI.allowedGateways[1] "public" must be in the form namespace/name`,
+		},
+		{
+			name:       "too many segments",
+			gatewayAPI: config.SyncToHostGatewayAPI{Enabled: true, AllowedGateways: []string{"gateway-system/public/test"}},
+			wantErr:    `sync.toHost.gatewayAPI.allowedGateways[0] "gateway-system/public/test" must be in the form namespace/name`,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateGatewayAPI(tt.gatewayAPI)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateSyncPatches(t *testing.T) {
+	testCases := []struct {
+		name    string
+		sync    config.Sync
+		wantErr string
+	}{
+		{
+			name: "valid",
+			sync: config.Sync{
+				ToHost: config.SyncToHost{
+					Ingresses: config.SyncToHostResource{
+						Enabled: true,
+						SyncPatches: config.SyncPatches{
+							Patches:        []*config.Patch{{Operation: config.PatchTypeReplace, Path: "spec.ingressClassName", Value: "nginx"}},
+							ReversePatches: []*config.Patch{{Operation: config.PatchTypeCopyFromObject, FromPath: "metadata.annotations", Path: "metadata.annotations"}},
+						},
+					},
+				},
+				FromHost: config.SyncFromHost{
+					Nodes: config.SyncNodes{SyncPatches: config.SyncPatches{
+						Patches: []*config.Patch{{Operation: config.PatchTypeRemove, Path: "metadata.labels.team"}},
+					}},
+				},
+			},
+		},
+		{
+			name: "missing path",
+			sync: config.Sync{
+				ToHost: config.SyncToHost{
+					Pods: config.SyncPods{SyncPatches: config.SyncPatches{
+						Patches: []*config.Patch{{Operation: config.PatchTypeAdd, Path: "metadata.labels.team", Value: "a"}, {Operation: config.PatchTypeRemove}},
+					}},
+				},
+			},
+			wantErr: "invalid sync.toHost.pods.patches[1]: path is required",
+		},
+		{
+			name: "invalid regex",
+			sync: config.Sync{
+				FromHost: config.SyncFromHost{
+					Secrets: config.SyncFromHostResource{SyncPatches: config.SyncPatches{
+						ReversePatches: []*config.Patch{{Operation: config.PatchTypeRewriteName, Path: "metadata.name", Regex: "(("}},
+					}},
+				},
+			},
+			wantErr: "invalid sync.fromHost.secrets.reversePatches[0]: invalid regex: error parsing regexp: missing closing ): `((`",
+		},
+		{
+			name: "unsupported operation",
+			sync: config.Sync{
+				FromHost: config.SyncFromHost{
+					CSIDrivers: config.EnableAutoSwitch{SyncPatches: config.SyncPatches{
+						Patches: []*config.Patch{{Operation: "move", Path: "spec"}},
+					}},
+				},
+			},
+			wantErr: "invalid sync.fromHost.csiDrivers.patches[0]: unsupported patch type move",
+		},
+		{
+			name: "valid expressions",
+			sync: config.Sync{
+				ToHost: config.SyncToHost{
+					Services: config.SyncToHostResource{SyncPatches: config.SyncPatches{
+						Patches: []*config.Patch{{
+							Operation:       config.PatchTypeAdd,
+							Path:            "metadata.annotations.owner",
+							ValueExpression: `vcluster.name + "/" + virtual.metadata.name`,
+							Conditions:      []*config.PatchCondition{{Expression: `has(virtual.metadata.labels) && "team" in virtual.metadata.labels`}},
+						}},
+					}},
+				},
+			},
+		},
+		{
+			name: "invalid condition expression",
+			sync: config.Sync{
+				ToHost: config.SyncToHost{
+					Services: config.SyncToHostResource{SyncPatches: config.SyncPatches{
+						Patches: []*config.Patch{{
+							Operation:  config.PatchTypeRemove,
+							Path:       "metadata.annotations.owner",
+							Conditions: []*config.PatchCondition{{Path: "metadata.name", Equal: "test"}, {Expression: `string(vcluster.name)`}},
+						}},
+					}},
+				},
+			},
+			wantErr: `invalid sync.toHost.services.patches[0]: invalid conditions[1].expression: expression "string(vcluster.name)" must return bool, but returns string`,
+		},
+		{
+			name: "value expression with value",
+			sync: config.Sync{
+				ToHost: config.SyncToHost{
+					Services: config.SyncToHostResource{SyncPatches: config.SyncPatches{
+						Patches: []*config.Patch{{Operation: config.PatchTypeAdd, Path: "metadata.labels.team", Value: "a", ValueExpression: `"b"`}},
+					}},
+				},
+			},
+			wantErr: "invalid sync.toHost.services.patches[0]: value and valueExpression cannot be used together",
+		},
+		{
+			name: "value expression with unsupported operation",
+			sync: config.Sync{
+				ToHost: config.SyncToHost{
+					Services: config.SyncToHostResource{SyncPatches: config.SyncPatches{
+						Patches: []*config.Patch{{Operation: config.PatchTypeRemove, Path: "metadata.labels.team", ValueExpression: `"b"`}},
+					}},
+				},
+			},
+			wantErr: "invalid sync.toHost.services.patches[0]: valueExpression is not supported for this operation",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSyncPatches(tt.sync)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateSyncSelectors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		toHost  config.SyncToHost
+		wantErr string
+	}{
+		{
+			name: "valid",
+			toHost: config.SyncToHost{
+				Pods: config.SyncPods{Selector: config.SyncToHostSelector{
+					LabelSelector:    map[string]string{"team": "a"},
+					MatchExpressions: []config.LabelSelectorRequirement{{Key: "vcluster.loft.sh/skip-sync", Operator: "DoesNotExist"}},
+					Namespaces:       config.SyncToHostSelectorNamespaces{Exclude: []string{"local-dev"}},
+				}},
+			},
+		},
+		{
+			name: "invalid operator",
+			toHost: config.SyncToHost{
+				Services: config.SyncToHostResource{Selector: config.SyncToHostSelector{
+					MatchExpressions: []config.LabelSelectorRequirement{{Key: "team", Operator: "Equals", Values: []string{"a"}}},
+				}},
+			},
+			wantErr: `invalid sync.toHost.services.selector: "Equals" is not a valid label selector operator`,
+		},
+		{
+			name: "invalid namespace",
+			toHost: config.SyncToHost{
+				Namespaces: config.SyncToHostNamespaces{Selector: config.SyncToHostSelector{
+					Namespaces: config.SyncToHostSelectorNamespaces{Include: []string{"Team_A"}},
+				}},
+			},
+			wantErr: "invalid sync.toHost.namespaces.selector: invalid namespace",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSyncSelectors(config.Sync{ToHost: tt.toHost})
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if tt.wantErr != "" && (err == nil || !strings.HasPrefix(err.Error(), tt.wantErr)) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateImageTranslation(t *testing.T) {
+	testCases := []struct {
+		name             string
+		imageTranslation config.SyncPodsImageTranslation
+		wantErr          string
+	}{
+		{
+			name: "valid",
+			imageTranslation: config.SyncPodsImageTranslation{
+				Rules:           []config.ImageRewriteRule{{From: "docker.io/*", To: "cache.example.com/docker/*"}},
+				RegistryMirrors: map[string]string{"ghcr.io": "harbor.example.com/ghcr"},
+				ResolveDigests:  config.ImageDigestResolution{Enabled: true, CacheTTL: "30m"},
+			},
+		},
+		{
+			name:             "too many wildcards",
+			imageTranslation: config.SyncPodsImageTranslation{Rules: []config.ImageRewriteRule{{From: "docker.io/*", To: "cache.example.com/*/*"}}},
+			wantErr:          "sync.toHost.pods.imageTranslation.rules[0]: to uses more wildcards than from",
+		},
+		{
+			name:             "mirror with path as registry",
+			imageTranslation: config.SyncPodsImageTranslation{RegistryMirrors: map[string]string{"docker.io/library": "mirror.example.com"}},
+			wantErr:          `sync.toHost.pods.imageTranslation.registryMirrors: invalid mirror "mirror.example.com" for registry "docker.io/library"`,
+		},
+		{
+			name:             "invalid cache ttl",
+			imageTranslation: config.SyncPodsImageTranslation{ResolveDigests: config.ImageDigestResolution{Enabled: true, CacheTTL: "1 hour"}},
+			wantErr:          `invalid sync.toHost.pods.imageTranslation.resolveDigests.cacheTTL: time: unknown unit " hour" in duration "1 hour"`,
+		},
+		{
+			name:             "invalid digest registry",
+			imageTranslation: config.SyncPodsImageTranslation{ResolveDigests: config.ImageDigestResolution{Enabled: true, Registries: []string{"ghcr.io/loft-sh"}}},
+			wantErr:          `sync.toHost.pods.imageTranslation.resolveDigests.registries[0]: invalid registry host "ghcr.io/loft-sh"`,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateImageTranslation(tt.imageTranslation)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateNamespaces(t *testing.T) {
+	testCases := []struct {
+		name       string
+		namespaces config.SyncToHostNamespaces
+		wantErr    string
+	}{
+		{
+			name: "valid",
+			namespaces: config.SyncToHostNamespaces{
+				Enabled:       true,
+				NameTemplate:  "{{ .VClusterName }}-{{ .Name }}",
+				ResourceQuota: config.NamespaceObjectTemplate{Enabled: true, Spec: map[string]interface{}{"hard": map[string]interface{}{"pods": "10"}}},
+				NetworkPolicy: config.NamespaceObjectTemplate{Enabled: true, Spec: map[string]interface{}{"podSelector": map[string]interface{}{}}},
+			},
+		},
+		{
+			name:       "template without name",
+			namespaces: config.SyncToHostNamespaces{Enabled: true, NameTemplate: "{{ .VClusterName }}"},
+			wantErr:    "invalid sync.toHost.namespaces.nameTemplate: namespace name template must use .Name exactly once",
+		},
+		{
+			name:       "template without vCluster name",
+			namespaces: config.SyncToHostNamespaces{Enabled: true, NameTemplate: "{{ .VClusterNamespace }}-{{ .Name }}"},
+			wantErr:    "invalid sync.toHost.namespaces.nameTemplate: namespace name template must use .VClusterName to distinguish the host namespaces of different vClusters",
+		},
+		{
+			name:       "template with too long vCluster namespace",
+			namespaces: config.SyncToHostNamespaces{Enabled: true, NameTemplate: "{{ .VClusterNamespace }}{{ .VClusterNamespace }}{{ .VClusterNamespace }}{{ .VClusterNamespace }}{{ .VClusterNamespace }}{{ .VClusterNamespace }}{{ .VClusterNamespace }}{{ .VClusterNamespace }}{{ .VClusterNamespace }}{{ .VClusterNamespace }}-{{ .VClusterName }}-{{ .Name }}"},
+			wantErr:    `invalid sync.toHost.namespaces.nameTemplate: namespace name template produces invalid namespace "team-ateam-ateam-ateam-ateam-ateam-ateam-ateam-ateam-ateam-a-my-vcluster--ca978112": must be no more than 63 characters`,
+		},
+		{
+			name:       "template without prefix or suffix",
+			namespaces: config.SyncToHostNamespaces{Enabled: true, NameTemplate: "{{ .Name }}"},
+			wantErr:    "invalid sync.toHost.namespaces.nameTemplate: namespace name template needs a prefix or suffix around .Name to distinguish the host namespaces of the vCluster",
+		},
+		{
+			name: "invalid limit range spec",
+			namespaces: config.SyncToHostNamespaces{
+				Enabled:    true,
+				LimitRange: config.NamespaceObjectTemplate{Enabled: true, Spec: map[string]interface{}{"limit": []interface{}{}}},
+			},
+			wantErr: `invalid sync.toHost.namespaces.limitRange.spec: strict decoding error: unknown field "limit"`,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateNamespaces(tt.namespaces, "my-vcluster", "team-a")
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateProxyOIDC(t *testing.T) {
+	testCases := []struct {
+		name    string
+		oidc    config.ControlPlaneProxyOIDC
+		wantErr string
+	}{
+		{
+			name: "disabled",
+			oidc: config.ControlPlaneProxyOIDC{IssuerURL: "http://dex"},
+		},
+		{
+			name: "valid",
+			oidc: config.ControlPlaneProxyOIDC{Enabled: true, IssuerURL: "https://dex.example.com/dex", ClientID: "vcluster"},
+		},
+		{
+			name:    "http issuer",
+			oidc:    config.ControlPlaneProxyOIDC{Enabled: true, IssuerURL: "http://dex.example.com", ClientID: "vcluster"},
+			wantErr: `invalid controlPlane.proxy.oidc.issuerURL "http://dex.example.com", must be a https url`,
+		},
+		{
+			name:    "missing client id",
+			oidc:    config.ControlPlaneProxyOIDC{Enabled: true, IssuerURL: "https://dex.example.com"},
+			wantErr: "controlPlane.proxy.oidc.clientID is required if oidc is enabled",
+		},
+		{
+			name:    "invalid ca",
+			oidc:    config.ControlPlaneProxyOIDC{Enabled: true, IssuerURL: "https://dex.example.com", ClientID: "vcluster", CA: "invalid"},
+			wantErr: "controlPlane.proxy.oidc.ca is not a valid PEM encoded certificate",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateProxyOIDC(tt.oidc)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -8,6 +8,13 @@ const (
 	PausedReplicasAnnotation = "loft.sh/paused-replicas"
 	PausedDateAnnotation     = "loft.sh/paused-date"
 
+	// LastActivityAnnotation is set on the vCluster pods and holds the time of the last request that counted as activity for sleep mode
+	LastActivityAnnotation = "vcluster.loft.sh/last-activity"
+	// SleepingSelectorAnnotation is set on the vCluster service while it points to the wake-up proxy and holds its original selector
+	SleepingSelectorAnnotation = "vcluster.loft.sh/sleeping-selector"
+	// SleepingSinceAnnotation is set on the vCluster service while it points to the wake-up proxy and holds the time it was redirected
+	SleepingSinceAnnotation = "vcluster.loft.sh/sleeping-since"
+
 	HostClusterPersistentVolumeAnnotation = "vcluster.loft.sh/host-pv"
 
 	HostClusterVSCAnnotation = "vcluster.loft.sh/host-volumesnapshotcontent"
@@ -0,0 +1,229 @@
+package generic
+
+import (
+	"fmt"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/config"
+	"github.com/loft-sh/vcluster/pkg/controllers/syncer"
+	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	"github.com/loft-sh/vcluster/pkg/mappings"
+	"github.com/loft-sh/vcluster/pkg/mappings/generic"
+	util "github.com/loft-sh/vcluster/pkg/util/context"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+)
+
+// CreateCustomResourceSyncers creates the syncers for sync.toHost.customResources and sync.fromHost.customResources
+func CreateCustomResourceSyncers(ctx *config.ControllerContext) error {
+	registerCtx := util.ToRegisterContext(ctx)
+	for key, customResource := range ctx.Config.Sync.ToHost.CustomResources {
+		if !customResource.Enabled {
+			continue
+		}
+
+		err := createToHostCustomResourceSyncer(registerCtx, key, customResource)
+		if err != nil {
+			return fmt.Errorf("sync.toHost.customResources[%s]: %w", key, err)
+		}
+	}
+
+	for key, customResource := range ctx.Config.Sync.FromHost.CustomResources {
+		if !customResource.Enabled {
+			continue
+		}
+
+		err := createFromHostCustomResourceSyncer(registerCtx, key, customResource)
+		if err != nil {
+			return fmt.Errorf("sync.fromHost.customResources[%s]: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+func createToHostCustomResourceSyncer(ctx *synccontext.RegisterContext, key string, customResource vclusterconfig.SyncToHostCustomResource) error {
+	gvk, isClusterScoped, hasStatusSubresource, err := ensureCustomResourceDefinition(ctx, key)
+	if err != nil {
+		if customResource.Optional {
+			klog.Infof("error ensuring custom resource %s from host cluster: %v. Skipping syncer as resource is optional", key, err)
+			return nil
+		}
+
+		return err
+	} else if isClusterScoped {
+		return fmt.Errorf("cluster scoped resources can only be synced from the host cluster")
+	}
+
+	// status and fields owned by the host are synced back to the virtual object
+	patches, reversePatches := customResourcePatches(customResource.Patches, customResource.ReversePatches, customResource.StatusSync, customResource.ConflictRules, vclusterconfig.ConflictRuleOwnerHost)
+	exportConfig := &vclusterconfig.Export{
+		SyncBase: vclusterconfig.SyncBase{
+			TypeInformation: vclusterconfig.TypeInformation{
+				APIVersion: gvk.GroupVersion().String(),
+				Kind:       gvk.Kind,
+			},
+			Optional:           customResource.Optional,
+			ReplaceWhenInvalid: customResource.ReplaceOnConflict,
+			Patches:            patches,
+			ReversePatches:     reversePatches,
+		},
+	}
+	if len(customResource.Selector.LabelSelector) > 0 {
+		exportConfig.Selector = &vclusterconfig.Selector{
+			LabelSelector: customResource.Selector.LabelSelector,
+		}
+	}
+
+	klog.Infof("creating exporter for %s", key)
+	s, err := createExporterFromConfig(ctx, exportConfig, hasStatusSubresource, true)
+	if err != nil {
+		return fmt.Errorf("create syncer: %w", err)
+	}
+
+	klog.Infof("registering export syncer for %s", key)
+	return syncer.RegisterSyncer(ctx, s)
+}
+
+func createFromHostCustomResourceSyncer(ctx *synccontext.RegisterContext, key string, customResource vclusterconfig.SyncFromHostCustomResource) error {
+	gvk, isClusterScoped, hasStatusSubresource, err := ensureCustomResourceDefinition(ctx, key)
+	if err != nil {
+		if customResource.Optional {
+			klog.Infof("error ensuring custom resource %s from host cluster: %v. Skipping syncer as resource is optional", key, err)
+			return nil
+		}
+
+		return err
+	}
+
+	// create the mapper
+	err = ensureNoMapper(gvk)
+	if err != nil {
+		return err
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	var mapper mappings.Mapper
+	if isClusterScoped {
+		mapper, err = generic.NewMirrorMapper(obj)
+	} else if len(customResource.Mappings.ByName) == 0 {
+		return fmt.Errorf("mappings.byName is required for namespaced resources")
+	} else {
+		mapper, err = generic.NewFromHostMapper(obj, customResource.Mappings.ByName)
+	}
+	if err != nil {
+		return fmt.Errorf("create mapper: %w", err)
+	}
+
+	// fields owned by the virtual cluster are synced back to the host object
+	patches, reversePatches := customResourcePatches(customResource.Patches, customResource.ReversePatches, customResource.StatusSync, customResource.ConflictRules, vclusterconfig.ConflictRuleOwnerVirtual)
+	importConfig := &vclusterconfig.Import{
+		SyncBase: vclusterconfig.SyncBase{
+			TypeInformation: vclusterconfig.TypeInformation{
+				APIVersion: gvk.GroupVersion().String(),
+				Kind:       gvk.Kind,
+			},
+			Optional:           customResource.Optional,
+			ReplaceWhenInvalid: customResource.ReplaceOnConflict,
+			Patches:            patches,
+			ReversePatches:     reversePatches,
+		},
+	}
+
+	klog.Infof("creating importer for %s", key)
+	s, err := createImporter(ctx, importConfig, isClusterScoped, hasStatusSubresource)
+	if err != nil {
+		return fmt.Errorf("create syncer: %w", err)
+	}
+
+	importer := s.(*importer)
+	importer.mapper = mapper
+	if len(customResource.Selector.LabelSelector) > 0 {
+		importer.selector, err = metav1.LabelSelectorAsSelector(metav1.SetAsLabelSelector(customResource.Selector.LabelSelector))
+		if err != nil {
+			return fmt.Errorf("invalid selector: %w", err)
+		}
+	}
+
+	// make the mapping available to the other syncers
+	err = mappings.Default.AddMapper(mapper)
+	if err != nil {
+		return fmt.Errorf("add mapper: %w", err)
+	}
+
+	klog.Infof("registering import syncer for %s", key)
+	return syncer.RegisterSyncer(ctx, importer)
+}
+
+// ensureNoMapper makes sure the given kind is not mapped by a built-in syncer or another generic syncer yet,
+// as adding a second mapper would replace the name translation the other syncer relies on
+func ensureNoMapper(gvk schema.GroupVersionKind) error {
+	if mappings.Default.Has(gvk) {
+		return fmt.Errorf("%s is already synced by another syncer", gvk.String())
+	}
+
+	return nil
+}
+
+// ensureCustomResourceDefinition resolves the kind of the given custom resource key and copies the
+// custom resource definition from the host cluster into the virtual cluster
+func ensureCustomResourceDefinition(ctx *synccontext.RegisterContext, key string) (schema.GroupVersionKind, bool, bool, error) {
+	gvr, err := config.ParseCustomResourceKey(key)
+	if err != nil {
+		return schema.GroupVersionKind{}, false, false, err
+	}
+
+	gvk, err := ctx.PhysicalManager.GetRESTMapper().KindFor(gvr)
+	if err != nil {
+		return schema.GroupVersionKind{}, false, false, fmt.Errorf("find kind for %s in host cluster: %w", gvr.String(), err)
+	}
+
+	isClusterScoped, hasStatusSubresource, err := translate.EnsureCRDFromPhysicalCluster(
+		ctx,
+		ctx.PhysicalManager.GetConfig(),
+		ctx.VirtualManager.GetConfig(),
+		gvk,
+	)
+	if err != nil {
+		return schema.GroupVersionKind{}, false, false, fmt.Errorf("ensure custom resource definition for %s: %w", gvk.String(), err)
+	}
+
+	return gvk, isClusterScoped, hasStatusSubresource, nil
+}
+
+// customResourcePatches converts the status sync mode and conflict rules into patches. Fields that are
+// owned by the reverseOwner are removed from the regular patches and copied back through the reverse patches.
+func customResourcePatches(patches, reversePatches []*vclusterconfig.Patch, statusSync vclusterconfig.StatusSyncMode, conflictRules []vclusterconfig.ConflictRule, reverseOwner vclusterconfig.ConflictRuleOwner) ([]*vclusterconfig.Patch, []*vclusterconfig.Patch) {
+	retPatches := append([]*vclusterconfig.Patch{}, patches...)
+	retReversePatches := []*vclusterconfig.Patch{}
+	if statusSync == vclusterconfig.StatusSyncModeDisabled {
+		retPatches = append(retPatches, &vclusterconfig.Patch{
+			Operation: vclusterconfig.PatchTypeRemove,
+			Path:      "status",
+		})
+	} else if reverseOwner == vclusterconfig.ConflictRuleOwnerHost {
+		retReversePatches = append(retReversePatches, &vclusterconfig.Patch{
+			Operation: vclusterconfig.PatchTypeCopyFromObject,
+			FromPath:  "status",
+			Path:      "status",
+		})
+	}
+
+	for _, rule := range conflictRules {
+		if rule.Owner != reverseOwner {
+			continue
+		}
+
+		retReversePatches = append(retReversePatches, &vclusterconfig.Patch{
+			Operation: vclusterconfig.PatchTypeCopyFromObject,
+			FromPath:  rule.Path,
+			Path:      rule.Path,
+		})
+	}
+
+	return retPatches, append(retReversePatches, reversePatches...)
+}
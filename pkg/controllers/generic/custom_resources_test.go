@@ -0,0 +1,34 @@
+package generic
+
+import (
+	"testing"
+
+	"github.com/loft-sh/vcluster/config"
+	"gotest.tools/assert"
+)
+
+func TestCustomResourcePatches(t *testing.T) {
+	userPatch := &config.Patch{Operation: config.PatchTypeAdd, Path: "metadata.labels.test", Value: "test"}
+	conflictRules := []config.ConflictRule{
+		{Path: "spec.secretName", Owner: config.ConflictRuleOwnerHost},
+		{Path: "metadata.labels", Owner: config.ConflictRuleOwnerVirtual},
+	}
+
+	// to host: status and host owned fields are copied back to the virtual object
+	patches, reversePatches := customResourcePatches([]*config.Patch{userPatch}, nil, "", conflictRules, config.ConflictRuleOwnerHost)
+	assert.DeepEqual(t, patches, []*config.Patch{userPatch})
+	assert.DeepEqual(t, reversePatches, []*config.Patch{
+		{Operation: config.PatchTypeCopyFromObject, FromPath: "status", Path: "status"},
+		{Operation: config.PatchTypeCopyFromObject, FromPath: "spec.secretName", Path: "spec.secretName"},
+	})
+
+	// from host: virtual owned fields are copied back to the host object
+	patches, reversePatches = customResourcePatches(nil, []*config.Patch{userPatch}, config.StatusSyncModeDisabled, conflictRules, config.ConflictRuleOwnerVirtual)
+	assert.DeepEqual(t, patches, []*config.Patch{
+		{Operation: config.PatchTypeRemove, Path: "status"},
+	})
+	assert.DeepEqual(t, reversePatches, []*config.Patch{
+		{Operation: config.PatchTypeCopyFromObject, FromPath: "metadata.labels", Path: "metadata.labels"},
+		userPatch,
+	})
+}
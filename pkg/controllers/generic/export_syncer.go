@@ -8,6 +8,7 @@ import (
 
 	"github.com/loft-sh/vcluster/pkg/config"
 	syncertypes "github.com/loft-sh/vcluster/pkg/controllers/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/mappings"
 	"github.com/loft-sh/vcluster/pkg/mappings/generic"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
@@ -63,7 +64,7 @@ func CreateExporters(ctx *config.ControllerContext) error {
 		reversePatches = append(reversePatches, exportConfig.ReversePatches...)
 		exportConfig.ReversePatches = reversePatches
 
-		s, err := createExporterFromConfig(registerCtx, exportConfig, hasStatusSubresource)
+		s, err := createExporterFromConfig(registerCtx, exportConfig, hasStatusSubresource, false)
 		klog.Infof("creating exporter for %s/%s", exportConfig.APIVersion, exportConfig.Kind)
 		if err != nil {
 			return fmt.Errorf("error creating %s(%s) syncer: %w", exportConfig.Kind, exportConfig.APIVersion, err)
@@ -79,7 +80,10 @@ func CreateExporters(ctx *config.ControllerContext) error {
 	return nil
 }
 
-func createExporterFromConfig(ctx *synccontext.RegisterContext, config *vclusterconfig.Export, hasStatusSubresource bool) (syncertypes.Syncer, error) {
+// createExporterFromConfig creates an exporter for the given config. If registerMapper is true, the mapper of the exporter
+// is made available to the other syncers, which fails if the kind is already mapped. The legacy genericSync exports
+// don't register their mapper, so existing configs that export an already mapped kind keep working.
+func createExporterFromConfig(ctx *synccontext.RegisterContext, config *vclusterconfig.Export, hasStatusSubresource, registerMapper bool) (syncertypes.Syncer, error) {
 	obj := &unstructured.Unstructured{}
 	obj.SetKind(config.Kind)
 	obj.SetAPIVersion(config.APIVersion)
@@ -99,11 +103,26 @@ func createExporterFromConfig(ctx *synccontext.RegisterContext, config *vcluster
 
 	gvk := schema.FromAPIVersionAndKind(config.APIVersion, config.Kind)
 	controllerID := fmt.Sprintf("%s/%s/GenericExport", strings.ToLower(gvk.Kind), strings.ToLower(gvk.Group))
+	if registerMapper {
+		err = ensureNoMapper(gvk)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	mapper, err := generic.NewMapper(ctx, obj, translate.Default.PhysicalName)
 	if err != nil {
 		return nil, err
 	}
 
+	// make the mapping available to the other syncers
+	if registerMapper {
+		err = mappings.Default.AddMapper(mapper)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &exporter{
 		GenericTranslator: translator.NewGenericTranslator(ctx, controllerID, obj, mapper),
 		ObjectPatcher: &exportPatcher{
@@ -111,7 +130,7 @@ func createExporterFromConfig(ctx *synccontext.RegisterContext, config *vcluster
 			gvk:    gvk,
 		},
 
-		patcher:  NewPatcher(ctx.VirtualManager.GetClient(), ctx.PhysicalManager.GetClient(), hasStatusSubresource, log.New(controllerID)),
+		patcher:  NewSyncContextPatcher(true, hasStatusSubresource, log.New(controllerID)),
 		gvk:      gvk,
 		selector: selector,
 		name:     controllerID,
@@ -141,7 +160,7 @@ func BuildCustomExporter(
 		ObjectPatcher:     objectPatcher,
 		GenericTranslator: genericTranslator,
 
-		patcher: NewPatcher(registerCtx.VirtualManager.GetClient(), registerCtx.PhysicalManager.GetClient(), hasStatusSubresource, log.New(controllerID)),
+		patcher: NewSyncContextPatcher(true, hasStatusSubresource, log.New(controllerID)),
 		gvk:     gvk,
 		name:    controllerID,
 
@@ -149,6 +168,43 @@ func BuildCustomExporter(
 	}, nil
 }
 
+// buildMappedExporter builds an exporter for the given kind that translates names with a mapper, which is made
+// available to the other syncers. The custom resource definition is copied into the virtual cluster first, as the
+// mapper registers a field index for the kind in the virtual cluster.
+func buildMappedExporter(ctx *synccontext.RegisterContext, controllerID string, objectPatcher ObjectPatcher, gvk schema.GroupVersionKind) (syncertypes.Syncer, error) {
+	err := ensureNoMapper(gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	_, hasStatusSubresource, err := translate.EnsureCRDFromPhysicalCluster(ctx, ctx.PhysicalManager.GetConfig(), ctx.VirtualManager.GetConfig(), gvk)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s(%s) syncer: %w", gvk.Kind, gvk.GroupVersion().String(), err)
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	mapper, err := generic.NewMapper(ctx, obj, translate.Default.PhysicalName)
+	if err != nil {
+		return nil, err
+	}
+
+	// make the mapping available to the other syncers
+	err = mappings.Default.AddMapper(mapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return &exporter{
+		ObjectPatcher:     objectPatcher,
+		GenericTranslator: translator.NewGenericTranslator(ctx, controllerID, obj, mapper),
+
+		patcher: NewSyncContextPatcher(true, hasStatusSubresource, log.New(controllerID)),
+		gvk:     gvk,
+		name:    controllerID,
+	}, nil
+}
+
 var _ syncertypes.Syncer = &exporter{}
 
 type exporter struct {
@@ -187,7 +243,7 @@ func (f *exporter) SyncToHost(ctx *synccontext.SyncContext, vObj client.Object)
 
 		f.EventRecorder().Eventf(vObj, "Warning", "SyncError", "Error syncing to physical cluster: %v", err)
 		return ctrl.Result{}, fmt.Errorf("error applying patches: %w", err)
-	} else if pObj == nil {
+	} else if pObj == nil || ctx.DryRun {
 		return ctrl.Result{}, nil
 	}
 
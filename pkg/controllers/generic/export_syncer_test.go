@@ -0,0 +1,72 @@
+package generic
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/vcluster/config"
+	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	"github.com/loft-sh/vcluster/pkg/controllers/syncer/dryrun"
+	generictesting "github.com/loft-sh/vcluster/pkg/controllers/syncer/testing"
+	"github.com/loft-sh/vcluster/pkg/mappings"
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	testingutil "github.com/loft-sh/vcluster/pkg/util/testing"
+	"gotest.tools/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestExporterBuiltInMapper(t *testing.T) {
+	registerCtx := generictesting.NewFakeRegisterContext(generictesting.NewFakeConfig(), testingutil.NewFakeClient(scheme.Scheme), testingutil.NewFakeClient(scheme.Scheme))
+
+	// kinds with a built-in mapper cannot be exported, as the built-in name translation would be replaced
+	_, err := createExporterFromConfig(registerCtx, &config.Export{
+		SyncBase: config.SyncBase{
+			TypeInformation: config.TypeInformation{APIVersion: "v1", Kind: "ConfigMap"},
+		},
+	}, false, true)
+	assert.ErrorContains(t, err, "v1, Kind=ConfigMap is already synced by another syncer")
+
+	// legacy genericSync exports keep working and don't replace the built-in mapper
+	builtInMapper := mappings.ConfigMaps()
+	_, err = createExporterFromConfig(registerCtx, &config.Export{
+		SyncBase: config.SyncBase{
+			TypeInformation: config.TypeInformation{APIVersion: "v1", Kind: "ConfigMap"},
+		},
+	}, false, false)
+	assert.NilError(t, err)
+	assert.Assert(t, mappings.ConfigMaps() == builtInMapper)
+}
+
+func TestExporterDryRun(t *testing.T) {
+	pClient := testingutil.NewFakeClient(scheme.Scheme)
+	vClient := testingutil.NewFakeClient(scheme.Scheme)
+	registerCtx := generictesting.NewFakeRegisterContext(generictesting.NewFakeConfig(), pClient, vClient)
+	s, err := createExporterFromConfig(registerCtx, &config.Export{
+		SyncBase: config.SyncBase{
+			TypeInformation: config.TypeInformation{APIVersion: "apps/v1", Kind: "Deployment"},
+		},
+	}, false, true)
+	assert.NilError(t, err)
+
+	vObj := &unstructured.Unstructured{}
+	vObj.SetAPIVersion("apps/v1")
+	vObj.SetKind("Deployment")
+	vObj.SetName("test")
+	vObj.SetNamespace("test")
+	assert.NilError(t, vClient.Create(registerCtx, vObj))
+
+	// the host object is only recorded and never created
+	out := &bytes.Buffer{}
+	syncCtx := synccontext.ConvertContext(registerCtx, s.Name())
+	syncCtx.DryRun = true
+	syncCtx.PhysicalClient = dryrun.NewClient(pClient, dryrun.NewFileRecorder(out), s.Name())
+	_, err = s.(*exporter).SyncToHost(syncCtx, vObj)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(out.String(), "Deployment"), out.String())
+
+	pObjs := &appsv1.DeploymentList{}
+	assert.NilError(t, pClient.List(registerCtx, pObjs))
+	assert.Equal(t, len(pObjs.Items), 0)
+}
@@ -0,0 +1,278 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/config"
+	"github.com/loft-sh/vcluster/pkg/controllers/syncer"
+	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	syncertypes "github.com/loft-sh/vcluster/pkg/controllers/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/mappings"
+	"github.com/loft-sh/vcluster/pkg/patches"
+	util "github.com/loft-sh/vcluster/pkg/util/context"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const gatewayAPIGroup = "gateway.networking.k8s.io"
+
+// GatewayAPIKinds are the Gateway API kinds that are synced by sync.toHost.gatewayAPI
+var GatewayAPIKinds = []schema.GroupVersionKind{
+	{Group: gatewayAPIGroup, Version: "v1", Kind: "HTTPRoute"},
+	{Group: gatewayAPIGroup, Version: "v1", Kind: "GRPCRoute"},
+	{Group: gatewayAPIGroup, Version: "v1alpha2", Kind: "TLSRoute"},
+	{Group: gatewayAPIGroup, Version: "v1beta1", Kind: "ReferenceGrant"},
+}
+
+// CreateGatewayAPISyncers creates the syncers for sync.toHost.gatewayAPI. Kinds that are not installed in the
+// host cluster are skipped.
+func CreateGatewayAPISyncers(ctx *config.ControllerContext) error {
+	gatewayAPI := ctx.Config.Sync.ToHost.GatewayAPI
+	if !gatewayAPI.Enabled {
+		return nil
+	}
+	registerCtx := util.ToRegisterContext(ctx)
+
+	for _, gvk := range GatewayAPIKinds {
+		_, err := registerCtx.PhysicalManager.GetRESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			klog.Infof("skipping gateway api syncer for %s, because it is not installed in the host cluster: %v", gvk.String(), err)
+			continue
+		}
+
+		s, err := createGatewayAPISyncer(registerCtx, gvk, gatewayAPI)
+		if err != nil {
+			return fmt.Errorf("error creating gateway api syncer for %s: %w", gvk.Kind, err)
+		}
+
+		klog.Infof("registering gateway api syncer for %s", gvk.String())
+		err = syncer.RegisterSyncer(registerCtx, s)
+		if err != nil {
+			return fmt.Errorf("error registering gateway api syncer for %s: %w", gvk.Kind, err)
+		}
+	}
+
+	return nil
+}
+
+func createGatewayAPISyncer(ctx *synccontext.RegisterContext, gvk schema.GroupVersionKind, gatewayAPI vclusterconfig.SyncToHostGatewayAPI) (syncertypes.Syncer, error) {
+	syncerPatches, err := patches.NewSyncerPatches(true, gatewayAPI.SyncPatches)
+	if err != nil {
+		return nil, err
+	}
+
+	controllerID := fmt.Sprintf("%s/%s%s", gvk.Kind, gvk.Group, vclusterconfig.GatewayAPISyncerSuffix)
+	return buildMappedExporter(ctx, controllerID, newGatewayAPIPatcher(gvk, gatewayAPI.AllowedGateways, syncerPatches), gvk)
+}
+
+func newGatewayAPIPatcher(gvk schema.GroupVersionKind, allowedGateways []string, syncerPatches *patches.SyncerPatches) *gatewayAPIPatcher {
+	allowed := map[string]bool{}
+	for _, gateway := range allowedGateways {
+		allowed[gateway] = true
+	}
+
+	return &gatewayAPIPatcher{
+		gvk:             gvk,
+		allowedGateways: allowed,
+		patches:         syncerPatches,
+	}
+}
+
+// gatewayAPIPatcher translates the references of Gateway API routes and reference grants to the host
+// cluster and syncs the route status back.
+type gatewayAPIPatcher struct {
+	gvk             schema.GroupVersionKind
+	allowedGateways map[string]bool
+	patches         *patches.SyncerPatches
+}
+
+var _ ObjectPatcher = &gatewayAPIPatcher{}
+
+func (p *gatewayAPIPatcher) ServerSideApply(ctx context.Context, vObj, pObj, _ client.Object) error {
+	pUnstructured, ok := pObj.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("unexpected object type %T", pObj)
+	}
+
+	// the status is owned by the host gateway controller
+	unstructured.RemoveNestedField(pUnstructured.Object, "status")
+	spec, ok := pUnstructured.Object["spec"].(map[string]interface{})
+	if ok {
+		if p.gvk.Kind == "ReferenceGrant" {
+			translateReferenceGrantSpec(ctx, vObj.GetNamespace(), spec)
+		} else {
+			p.translateRouteSpec(ctx, vObj.GetNamespace(), spec)
+		}
+	}
+
+	return p.patches.ApplyHost(pObj, vObj)
+}
+
+func (p *gatewayAPIPatcher) ReverseUpdate(_ context.Context, vObj, pObj client.Object) error {
+	vUnstructured, ok := vObj.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("unexpected object type %T", vObj)
+	}
+	pUnstructured, ok := pObj.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("unexpected object type %T", pObj)
+	}
+
+	before := vUnstructured.DeepCopy()
+	if p.gvk.Kind != "ReferenceGrant" {
+		pStatus, hasStatus, err := unstructured.NestedFieldCopy(pUnstructured.Object, "status")
+		if err != nil {
+			return err
+		} else if hasStatus {
+			vUnstructured.Object["status"] = pStatus
+		}
+	}
+
+	err := p.patches.ApplyVirtual(vObj, pObj)
+	if err != nil {
+		return err
+	} else if equality.Semantic.DeepEqual(before.Object, vUnstructured.Object) {
+		return ErrNoUpdateNeeded
+	}
+
+	return nil
+}
+
+// translateRouteSpec keeps the parent references to allowed host gateways and rewrites the backend references
+// to the host services.
+func (p *gatewayAPIPatcher) translateRouteSpec(ctx context.Context, vNamespace string, spec map[string]interface{}) {
+	parentRefs, _ := spec["parentRefs"].([]interface{})
+	allowedParentRefs := []interface{}{}
+	for _, parentRef := range parentRefs {
+		ref, ok := parentRef.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		group, _ := ref["group"].(string)
+		kind, _ := ref["kind"].(string)
+		name, _ := ref["name"].(string)
+		namespace, _ := ref["namespace"].(string)
+		if namespace == "" {
+			namespace = vNamespace
+		}
+		if (group != "" && group != gatewayAPIGroup) || (kind != "" && kind != "Gateway") || !p.allowedGateways[namespace+"/"+name] {
+			klog.FromContext(ctx).Info("removing parent reference to gateway that is not allowed", "namespace", namespace, "name", name, "kind", kind)
+			continue
+		}
+
+		ref["namespace"] = namespace
+		allowedParentRefs = append(allowedParentRefs, ref)
+	}
+	if len(parentRefs) > 0 {
+		spec["parentRefs"] = allowedParentRefs
+	}
+
+	rules, _ := spec["rules"].([]interface{})
+	for _, rule := range rules {
+		ruleMap, ok := rule.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		backendRefs, _ := ruleMap["backendRefs"].([]interface{})
+		for _, backendRef := range backendRefs {
+			ref, ok := backendRef.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			translateBackendRef(ctx, vNamespace, ref)
+			translateFilters(ctx, vNamespace, ref["filters"])
+		}
+		translateFilters(ctx, vNamespace, ruleMap["filters"])
+	}
+}
+
+// translateFilters rewrites the backend references of request mirror filters
+func translateFilters(ctx context.Context, vNamespace string, filters interface{}) {
+	filterList, _ := filters.([]interface{})
+	for _, filter := range filterList {
+		filterMap, ok := filter.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		requestMirror, ok := filterMap["requestMirror"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		backendRef, ok := requestMirror["backendRef"].(map[string]interface{})
+		if ok {
+			translateBackendRef(ctx, vNamespace, backendRef)
+		}
+	}
+}
+
+// translateBackendRef rewrites a reference to a virtual service to the host service
+func translateBackendRef(ctx context.Context, vNamespace string, ref map[string]interface{}) {
+	group, _ := ref["group"].(string)
+	kind, _ := ref["kind"].(string)
+	name, _ := ref["name"].(string)
+	if group != "" || (kind != "" && kind != "Service") || name == "" {
+		return
+	}
+
+	namespace, hasNamespace := ref["namespace"].(string)
+	if !hasNamespace || namespace == "" {
+		namespace = vNamespace
+	}
+
+	pName := mappings.VirtualToHost(ctx, name, namespace, mappings.Services())
+	ref["name"] = pName.Name
+	if hasNamespace {
+		ref["namespace"] = pName.Namespace
+	}
+}
+
+// translateReferenceGrantSpec rewrites the namespaces and names of a reference grant to the host objects
+func translateReferenceGrantSpec(ctx context.Context, vNamespace string, spec map[string]interface{}) {
+	from, _ := spec["from"].([]interface{})
+	for _, fromRef := range from {
+		ref, ok := fromRef.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		namespace, _ := ref["namespace"].(string)
+		if namespace != "" {
+			ref["namespace"] = translate.Default.PhysicalNamespace(namespace)
+		}
+	}
+
+	to, _ := spec["to"].([]interface{})
+	for _, toRef := range to {
+		ref, ok := toRef.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		group, _ := ref["group"].(string)
+		kind, _ := ref["kind"].(string)
+		name, _ := ref["name"].(string)
+		if name == "" {
+			continue
+		}
+
+		switch {
+		case group == "" && kind == "Service":
+			ref["name"] = mappings.VirtualToHostName(ctx, name, vNamespace, mappings.Services())
+		case group == "" && kind == "Secret":
+			ref["name"] = mappings.VirtualToHostName(ctx, name, vNamespace, mappings.Secrets())
+		default:
+			ref["name"] = translate.Default.PhysicalName(name, vNamespace)
+		}
+	}
+}
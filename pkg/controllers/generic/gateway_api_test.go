@@ -0,0 +1,119 @@
+package generic
+
+import (
+	"context"
+	"testing"
+
+	generictesting "github.com/loft-sh/vcluster/pkg/controllers/syncer/testing"
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	testingutil "github.com/loft-sh/vcluster/pkg/util/testing"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGatewayAPIRoute(t *testing.T) {
+	generictesting.NewFakeRegisterContext(generictesting.NewFakeConfig(), testingutil.NewFakeClient(scheme.Scheme), testingutil.NewFakeClient(scheme.Scheme))
+	patcher := newGatewayAPIPatcher(GatewayAPIKinds[0], []string{"gateway-system/public"}, nil)
+
+	vObj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "route", "namespace": "test"},
+	}}
+	pObj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"parentRefs": []interface{}{
+				map[string]interface{}{"name": "public", "namespace": "gateway-system"},
+				map[string]interface{}{"name": "internal", "namespace": "gateway-system"},
+				map[string]interface{}{"name": "public"},
+			},
+			"rules": []interface{}{
+				map[string]interface{}{
+					"backendRefs": []interface{}{
+						map[string]interface{}{"name": "backend", "port": int64(80)},
+						map[string]interface{}{"name": "other", "namespace": "other", "port": int64(80)},
+						map[string]interface{}{"group": "example.com", "kind": "Bucket", "name": "bucket"},
+					},
+					"filters": []interface{}{
+						map[string]interface{}{
+							"type":          "RequestMirror",
+							"requestMirror": map[string]interface{}{"backendRef": map[string]interface{}{"name": "mirror"}},
+						},
+					},
+				},
+			},
+		},
+		"status": map[string]interface{}{"parents": []interface{}{}},
+	}}
+
+	err := patcher.ServerSideApply(context.Background(), vObj, pObj, nil)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, pObj.Object, map[string]interface{}{
+		"spec": map[string]interface{}{
+			"parentRefs": []interface{}{
+				map[string]interface{}{"name": "public", "namespace": "gateway-system"},
+			},
+			"rules": []interface{}{
+				map[string]interface{}{
+					"backendRefs": []interface{}{
+						map[string]interface{}{"name": translate.Default.PhysicalName("backend", "test"), "port": int64(80)},
+						map[string]interface{}{"name": translate.Default.PhysicalName("other", "other"), "namespace": translate.Default.PhysicalNamespace("other"), "port": int64(80)},
+						map[string]interface{}{"group": "example.com", "kind": "Bucket", "name": "bucket"},
+					},
+					"filters": []interface{}{
+						map[string]interface{}{
+							"type":          "RequestMirror",
+							"requestMirror": map[string]interface{}{"backendRef": map[string]interface{}{"name": translate.Default.PhysicalName("mirror", "test")}},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	// status is synced back to the virtual route
+	pObj.Object["status"] = map[string]interface{}{
+		"parents": []interface{}{
+			map[string]interface{}{"conditions": []interface{}{map[string]interface{}{"type": "Accepted", "status": "True"}}},
+		},
+	}
+	err = patcher.ReverseUpdate(context.Background(), vObj, pObj)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, vObj.Object["status"], pObj.Object["status"])
+
+	err = patcher.ReverseUpdate(context.Background(), vObj, pObj)
+	assert.Equal(t, err, ErrNoUpdateNeeded)
+}
+
+func TestGatewayAPIReferenceGrant(t *testing.T) {
+	generictesting.NewFakeRegisterContext(generictesting.NewFakeConfig(), testingutil.NewFakeClient(scheme.Scheme), testingutil.NewFakeClient(scheme.Scheme))
+	patcher := newGatewayAPIPatcher(GatewayAPIKinds[3], nil, nil)
+
+	vObj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "grant", "namespace": "test"},
+	}}
+	pObj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"from": []interface{}{
+				map[string]interface{}{"group": "gateway.networking.k8s.io", "kind": "HTTPRoute", "namespace": "other"},
+			},
+			"to": []interface{}{
+				map[string]interface{}{"group": "", "kind": "Service", "name": "backend"},
+				map[string]interface{}{"group": "", "kind": "Service"},
+			},
+		},
+	}}
+
+	err := patcher.ServerSideApply(context.Background(), vObj, pObj, nil)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, pObj.Object, map[string]interface{}{
+		"spec": map[string]interface{}{
+			"from": []interface{}{
+				map[string]interface{}{"group": "gateway.networking.k8s.io", "kind": "HTTPRoute", "namespace": translate.Default.PhysicalNamespace("other")},
+			},
+			"to": []interface{}{
+				map[string]interface{}{"group": "", "kind": "Service", "name": translate.Default.PhysicalName("backend", "test")},
+				map[string]interface{}{"group": "", "kind": "Service"},
+			},
+		},
+	})
+}
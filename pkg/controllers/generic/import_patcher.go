@@ -21,11 +21,11 @@ type importPatcher struct {
 var _ ObjectPatcher = &importPatcher{}
 
 func (s *importPatcher) ServerSideApply(ctx context.Context, _, destObj, sourceObj client.Object) error {
-	return patches.ApplyPatches(destObj, sourceObj, s.config.Patches, s.config.ReversePatches, &hostToVirtualImportNameResolver{virtualClient: s.virtualClient, ctx: ctx})
+	return patches.ApplyPatches(destObj, sourceObj, s.config.Patches, s.config.ReversePatches, &hostToVirtualImportNameResolver{virtualClient: s.virtualClient, ctx: ctx}, false)
 }
 
 func (s *importPatcher) ReverseUpdate(_ context.Context, destObj, sourceObj client.Object) error {
-	return patches.ApplyPatches(destObj, sourceObj, s.config.ReversePatches, nil, &virtualToHostNameResolver{namespace: sourceObj.GetNamespace()})
+	return patches.ApplyPatches(destObj, sourceObj, s.config.ReversePatches, nil, patches.NewVirtualToHostNameResolver(sourceObj.GetNamespace()), true)
 }
 
 type hostToVirtualImportNameResolver struct {
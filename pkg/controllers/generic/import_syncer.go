@@ -9,6 +9,7 @@ import (
 	vclusterconfig "github.com/loft-sh/vcluster/config"
 	"github.com/loft-sh/vcluster/pkg/config"
 	syncertypes "github.com/loft-sh/vcluster/pkg/controllers/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/mappings"
 	"github.com/loft-sh/vcluster/pkg/scheme"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
@@ -25,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -43,7 +45,7 @@ func CreateImporters(ctx *config.ControllerContext) error {
 	}
 
 	registerCtx := util.ToRegisterContext(ctx)
-	if !registerCtx.Config.Experimental.MultiNamespaceMode.Enabled {
+	if !registerCtx.Config.Sync.ToHost.Namespaces.Enabled {
 		return fmt.Errorf("invalid configuration, 'import' type sync of the generic CRDs is allowed only in the multi-namespace mode")
 	}
 
@@ -91,7 +93,7 @@ func createImporter(ctx *synccontext.RegisterContext, config *vclusterconfig.Imp
 			virtualClient: ctx.VirtualManager.GetClient(),
 		},
 
-		patcher: NewPatcher(ctx.PhysicalManager.GetClient(), ctx.VirtualManager.GetClient(), hasStatusSubresource, log.New(controllerID)),
+		patcher: NewSyncContextPatcher(false, hasStatusSubresource, log.New(controllerID)),
 		gvk:     gvk,
 
 		replaceWhenInvalid: config.ReplaceWhenInvalid,
@@ -132,7 +134,7 @@ func BuildCustomImporter(
 		hostToVirtual: hostToVirtual,
 		virtualToHost: virtualToHost,
 
-		patcher: NewPatcher(registerCtx.PhysicalManager.GetClient(), registerCtx.VirtualManager.GetClient(), hasStatusSubresource, log.New(controllerID)),
+		patcher: NewSyncContextPatcher(false, hasStatusSubresource, log.New(controllerID)),
 		gvk:     gvk,
 		name:    controllerID,
 		syncerOptions: &syncertypes.Options{
@@ -153,6 +155,12 @@ type importer struct {
 	hostToVirtual HostToVirtual
 	virtualToHost VirtualToHost
 
+	// mapper is used instead of the default name translation if set. Host objects of
+	// importers with a mapper are never deleted by the importer, but reverse patches
+	// are still applied to them.
+	mapper   mappings.Mapper
+	selector labels.Selector
+
 	patcher            *Patcher
 	replaceWhenInvalid bool
 
@@ -183,9 +191,14 @@ func (s *importer) Options() *syncertypes.Options {
 var _ syncertypes.ToVirtualSyncer = &importer{}
 
 func (s *importer) SyncToVirtual(ctx *synccontext.SyncContext, pObj client.Object) (ctrl.Result, error) {
+	// check if selector matches
+	if !s.objectMatches(pObj) {
+		return ctrl.Result{}, nil
+	}
+
 	// check if annotation is already present
 	pAnnotations := pObj.GetAnnotations()
-	if pAnnotations != nil && pAnnotations[translate.ControllerLabel] == s.Name() && !s.syncerOptions.IsClusterScopedCRD { // only delete pObj if its not cluster scoped
+	if pAnnotations != nil && pAnnotations[translate.ControllerLabel] == s.Name() && !s.isHostSource() { // only delete pObj if its not the source
 		ctx.Log.Infof("Delete physical %s %s/%s, since virtual is missing, but physical object was already synced", s.gvk.Kind, pObj.GetNamespace(), pObj.GetName())
 		err := ctx.PhysicalClient.Delete(ctx, pObj)
 		if err != nil && !kerrors.IsNotFound(err) {
@@ -195,6 +208,17 @@ func (s *importer) SyncToVirtual(ctx *synccontext.SyncContext, pObj client.Objec
 		return ctrl.Result{}, nil
 	}
 
+	// make sure the target namespace exists
+	if s.mapper != nil {
+		vName := s.HostToVirtual(ctx, types.NamespacedName{Name: pObj.GetName(), Namespace: pObj.GetNamespace()}, pObj)
+		if vName.Namespace != "" {
+			err := clienthelper.EnsureNamespace(ctx, ctx.VirtualClient, vName.Namespace)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("ensure virtual namespace %s: %w", vName.Namespace, err)
+			}
+		}
+	}
+
 	// apply object to virtual cluster
 	ctx.Log.Infof("Create virtual %s, since it is missing, but physical object %s/%s exists", s.gvk.Kind, pObj.GetNamespace(), pObj.GetName())
 	vObj, err := s.patcher.ApplyPatches(ctx, pObj, nil, s)
@@ -284,9 +308,15 @@ func (s *importer) Sync(ctx *synccontext.SyncContext, pObj client.Object, vObj c
 		return ctrl.Result{}, nil
 	}
 
+	// check if physical object is not matching anymore
+	if !s.objectMatches(pObj) {
+		ctx.Log.Infof("delete virtual %s %s/%s, because physical object is not selected anymore", s.gvk.Kind, vObj.GetNamespace(), vObj.GetName())
+		return ctrl.Result{}, ctx.VirtualClient.Delete(ctx, vObj)
+	}
+
 	// check if either object is getting deleted
 	if vObj.GetDeletionTimestamp() != nil || pObj.GetDeletionTimestamp() != nil {
-		if pObj.GetDeletionTimestamp() == nil && !s.syncerOptions.IsClusterScopedCRD {
+		if pObj.GetDeletionTimestamp() == nil && !s.isHostSource() {
 			ctx.Log.Infof("delete physical object %s/%s, because the virtual object is being deleted", pObj.GetNamespace(), pObj.GetName())
 			if err := ctx.PhysicalClient.Delete(ctx, pObj); err != nil {
 				return ctrl.Result{}, err
@@ -385,7 +415,14 @@ func (s *importer) isVirtualManaged(vObj client.Object) bool {
 	return vObj.GetAnnotations() != nil && vObj.GetAnnotations()[translate.ControllerLabel] != "" && vObj.GetAnnotations()[translate.ControllerLabel] == s.Name()
 }
 
-func (s *importer) IsManaged(_ context.Context, pObj client.Object) (bool, error) {
+func (s *importer) IsManaged(ctx context.Context, pObj client.Object) (bool, error) {
+	if s.mapper != nil {
+		if s.excludeObject(pObj) {
+			return false, nil
+		}
+
+		return s.mapper.IsManaged(ctx, pObj)
+	}
 	if s.syncerOptions.IsClusterScopedCRD {
 		return true, nil
 	}
@@ -408,6 +445,9 @@ func (s *importer) IsManaged(_ context.Context, pObj client.Object) (bool, error
 }
 
 func (s *importer) VirtualToHost(ctx context.Context, req types.NamespacedName, vObj client.Object) types.NamespacedName {
+	if s.mapper != nil {
+		return s.mapper.VirtualToHost(ctx, req, vObj)
+	}
 	if s.virtualToHost != nil {
 		return s.virtualToHost(ctx, req, vObj)
 	}
@@ -416,6 +456,9 @@ func (s *importer) VirtualToHost(ctx context.Context, req types.NamespacedName,
 }
 
 func (s *importer) HostToVirtual(ctx context.Context, req types.NamespacedName, pObj client.Object) types.NamespacedName {
+	if s.mapper != nil {
+		return s.mapper.HostToVirtual(ctx, req, pObj)
+	}
 	if s.syncerOptions.IsClusterScopedCRD {
 		return types.NamespacedName{
 			Name: req.Name,
@@ -479,7 +522,7 @@ func (s *importer) updateVirtualAnnotations(a map[string]string) map[string]stri
 }
 
 func (s *importer) addAnnotationsToPhysicalObject(ctx *synccontext.SyncContext, pObj client.Object, vObj client.Object) error {
-	if s.syncerOptions.IsClusterScopedCRD {
+	if s.isHostSource() {
 		// do not add annotations to physical object
 		return nil
 	}
@@ -510,3 +553,13 @@ func (s *importer) addAnnotationsToPhysicalObject(ctx *synccontext.SyncContext,
 	ctx.Log.Infof("Patch controlled-by annotation on %s %s/%s", s.gvk.Kind, pObj.GetNamespace(), pObj.GetName())
 	return ctx.PhysicalClient.Patch(ctx, pObj, patch)
 }
+
+// isHostSource returns true if the host objects are the source of truth and
+// should never be deleted or annotated by the importer
+func (s *importer) isHostSource() bool {
+	return s.syncerOptions.IsClusterScopedCRD || s.mapper != nil
+}
+
+func (s *importer) objectMatches(obj client.Object) bool {
+	return s.selector == nil || s.selector.Matches(labels.Set(obj.GetLabels()))
+}
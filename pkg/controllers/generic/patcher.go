@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
 	"github.com/loft-sh/vcluster/pkg/controllers/syncer/types"
 	"github.com/loft-sh/vcluster/pkg/log"
 	"github.com/pkg/errors"
@@ -78,14 +79,39 @@ func NewPatcher(fromClient, toClient client.Client, statusIsSubresource bool, lo
 	}
 }
 
+// NewSyncContextPatcher creates a patcher that applies objects from the virtual to the host cluster if toHost is true
+// and from the host to the virtual cluster otherwise. The clients of the sync context are used for all writes,
+// so dry run and metrics apply to them as well.
+func NewSyncContextPatcher(toHost, statusIsSubresource bool, log log.Logger) *Patcher {
+	return &Patcher{
+		toHost:              toHost,
+		log:                 log,
+		statusIsSubresource: statusIsSubresource,
+	}
+}
+
 type Patcher struct {
+	// fromClient and toClient are nil if the clients of the sync context are used
 	fromClient          client.Client
 	toClient            client.Client
+	toHost              bool
 	log                 log.Logger
 	statusIsSubresource bool
 }
 
-func (s *Patcher) ApplyPatches(ctx context.Context, fromObj, toObj client.Object, modifier ObjectPatcherAndMetadataTranslator) (client.Object, error) {
+// clients returns the client of the cluster the objects are read from and the client of the cluster they are applied to
+func (s *Patcher) clients(ctx *synccontext.SyncContext) (client.Client, client.Client) {
+	if s.fromClient != nil && s.toClient != nil {
+		return s.fromClient, s.toClient
+	} else if s.toHost {
+		return ctx.VirtualClient, ctx.PhysicalClient
+	}
+
+	return ctx.PhysicalClient, ctx.VirtualClient
+}
+
+func (s *Patcher) ApplyPatches(ctx *synccontext.SyncContext, fromObj, toObj client.Object, modifier ObjectPatcherAndMetadataTranslator) (client.Object, error) {
+	_, toClient := s.clients(ctx)
 	translatedObject := modifier.TranslateMetadata(ctx, fromObj)
 	toObjBase, err := toUnstructured(translatedObject)
 	if err != nil {
@@ -110,7 +136,7 @@ func (s *Patcher) ApplyPatches(ctx context.Context, fromObj, toObj client.Object
 		if hasAfterStatus {
 			s.log.Infof("Server side apply status of %s", toObjCopied.GetName())
 			o := &client.SubResourcePatchOptions{PatchOptions: client.PatchOptions{FieldManager: fieldManager, Force: ptr.To(true)}}
-			err = s.toClient.Status().Patch(ctx, toObjCopied.DeepCopy(), client.Apply, o)
+			err = toClient.Status().Patch(ctx, toObjCopied.DeepCopy(), client.Apply, o)
 			if err != nil {
 				return nil, errors.Wrap(err, "apply status")
 			}
@@ -124,7 +150,7 @@ func (s *Patcher) ApplyPatches(ctx context.Context, fromObj, toObj client.Object
 	// always apply object
 	s.log.Infof("Server side apply %s", toObjCopied.GetName())
 	outObject := toObjCopied.DeepCopy()
-	err = s.toClient.Patch(ctx, outObject, client.Apply, client.ForceOwnership, client.FieldOwner(fieldManager))
+	err = toClient.Patch(ctx, outObject, client.Apply, client.ForceOwnership, client.FieldOwner(fieldManager))
 	if err != nil {
 		return nil, errors.Wrap(err, "apply object")
 	}
@@ -132,7 +158,8 @@ func (s *Patcher) ApplyPatches(ctx context.Context, fromObj, toObj client.Object
 	return outObject, nil
 }
 
-func (s *Patcher) ApplyReversePatches(ctx context.Context, fromObj, otherObj client.Object, modifier ObjectPatcherAndMetadataTranslator) (controllerutil.OperationResult, error) {
+func (s *Patcher) ApplyReversePatches(ctx *synccontext.SyncContext, fromObj, otherObj client.Object, modifier ObjectPatcherAndMetadataTranslator) (controllerutil.OperationResult, error) {
+	fromClient, _ := s.clients(ctx)
 	originalUnstructured, err := toUnstructured(fromObj)
 	if err != nil {
 		return controllerutil.OperationResultNone, err
@@ -163,7 +190,7 @@ func (s *Patcher) ApplyReversePatches(ctx context.Context, fromObj, otherObj cli
 		// update status
 		if (hasBeforeStatus || hasAfterStatus) && !equality.Semantic.DeepEqual(beforeStatus, afterStatus) {
 			s.log.Infof("Reverse update status of %s", fromCopied.GetName())
-			err = s.fromClient.Status().Update(ctx, fromCopied)
+			err = fromClient.Status().Update(ctx, fromCopied)
 			if err != nil {
 				return controllerutil.OperationResultNone, errors.Wrap(err, "update reverse status")
 			}
@@ -182,7 +209,7 @@ func (s *Patcher) ApplyReversePatches(ctx context.Context, fromObj, otherObj cli
 	// compare rest of the object
 	if !equality.Semantic.DeepEqual(originalUnstructured, fromCopied) {
 		s.log.Infof("Reverse update %s", fromCopied.GetName())
-		err = s.fromClient.Update(ctx, fromCopied)
+		err = fromClient.Update(ctx, fromCopied)
 		if err != nil {
 			return controllerutil.OperationResultNone, errors.Wrap(err, "update reverse")
 		}
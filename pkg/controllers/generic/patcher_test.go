@@ -0,0 +1,91 @@
+package generic
+
+import (
+	"context"
+	"testing"
+
+	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	"github.com/loft-sh/vcluster/pkg/log"
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	testingutil "github.com/loft-sh/vcluster/pkg/util/testing"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func TestPatcherSyncContextClients(t *testing.T) {
+	vObj := &unstructured.Unstructured{}
+	vObj.SetAPIVersion("apps/v1")
+	vObj.SetKind("ReplicaSet")
+	vObj.SetName("test")
+	vObj.SetNamespace("test")
+	pObj := vObj.DeepCopy()
+	pObj.SetName("host-test")
+
+	vClient := &writeRecorderClient{Client: testingutil.NewFakeClient(scheme.Scheme, vObj.DeepCopy())}
+	pClient := &writeRecorderClient{Client: testingutil.NewFakeClient(scheme.Scheme, pObj.DeepCopy())}
+	ctx := &synccontext.SyncContext{
+		Context:        context.Background(),
+		PhysicalClient: pClient,
+		VirtualClient:  vClient,
+	}
+
+	// writes of the patcher go through the clients of the sync context, which count metrics and record dry runs
+	patcher := NewSyncContextPatcher(true, false, log.New("test"))
+	_, err := patcher.ApplyPatches(ctx, vObj, pObj, &labelPatcher{})
+	assert.NilError(t, err)
+	result, err := patcher.ApplyReversePatches(ctx, vObj, pObj, &labelPatcher{})
+	assert.NilError(t, err)
+	assert.Equal(t, result, controllerutil.OperationResultUpdated)
+
+	assert.DeepEqual(t, pClient.writes, []string{"patch host-test"})
+	assert.DeepEqual(t, vClient.writes, []string{"update test"})
+
+	// patchers with explicit clients ignore the clients of the sync context
+	fromClient := &writeRecorderClient{Client: testingutil.NewFakeClient(scheme.Scheme, vObj.DeepCopy())}
+	toClient := &writeRecorderClient{Client: testingutil.NewFakeClient(scheme.Scheme, pObj.DeepCopy())}
+	_, err = NewPatcher(fromClient, toClient, false, log.New("test")).ApplyPatches(ctx, vObj, pObj, &labelPatcher{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, toClient.writes, []string{"patch host-test"})
+	assert.Equal(t, len(pClient.writes), 1)
+}
+
+// labelPatcher renames objects to host-<name> and adds a label during reverse updates
+type labelPatcher struct{}
+
+func (l *labelPatcher) TranslateMetadata(_ context.Context, obj client.Object) client.Object {
+	translated := obj.DeepCopyObject().(client.Object)
+	translated.SetName("host-" + obj.GetName())
+	return translated
+}
+
+func (l *labelPatcher) TranslateMetadataUpdate(_ context.Context, _, _ client.Object) (bool, map[string]string, map[string]string) {
+	return false, nil, nil
+}
+
+func (l *labelPatcher) ServerSideApply(_ context.Context, _, _, _ client.Object) error {
+	return nil
+}
+
+func (l *labelPatcher) ReverseUpdate(_ context.Context, destObj, _ client.Object) error {
+	destObj.SetLabels(map[string]string{"synced": "true"})
+	return nil
+}
+
+// writeRecorderClient records writes and skips apply patches, which are not supported by the fake client
+type writeRecorderClient struct {
+	client.Client
+
+	writes []string
+}
+
+func (w *writeRecorderClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	w.writes = append(w.writes, "update "+obj.GetName())
+	return w.Client.Update(ctx, obj, opts...)
+}
+
+func (w *writeRecorderClient) Patch(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+	w.writes = append(w.writes, "patch "+obj.GetName())
+	return nil
+}
@@ -0,0 +1,65 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/loft-sh/vcluster/pkg/config"
+	"github.com/loft-sh/vcluster/pkg/controllers/syncer"
+	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	syncertypes "github.com/loft-sh/vcluster/pkg/controllers/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/plugin"
+	plugintypes "github.com/loft-sh/vcluster/pkg/plugin/types"
+	util "github.com/loft-sh/vcluster/pkg/util/context"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CreatePluginSyncers creates the syncers that were registered by plugins. The syncers use the caches,
+// mappings and leader election of vCluster and call the plugin to translate the objects.
+func CreatePluginSyncers(ctx *config.ControllerContext) error {
+	pluginSyncers := plugin.DefaultManager.Syncers()
+	if len(pluginSyncers) == 0 {
+		return nil
+	}
+	registerCtx := util.ToRegisterContext(ctx)
+
+	for _, pluginSyncer := range pluginSyncers {
+		s, err := createPluginSyncer(registerCtx, pluginSyncer)
+		if err != nil {
+			return fmt.Errorf("error creating plugin syncer %s: %w", pluginSyncer.Name(), err)
+		}
+
+		klog.Infof("registering plugin syncer %s", pluginSyncer.Name())
+		err = syncer.RegisterSyncer(registerCtx, s)
+		if err != nil {
+			return fmt.Errorf("error registering plugin syncer %s: %w", pluginSyncer.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+func createPluginSyncer(ctx *synccontext.RegisterContext, pluginSyncer plugintypes.Syncer) (syncertypes.Syncer, error) {
+	return buildMappedExporter(ctx, pluginSyncer.Name()+"/PluginSync", &pluginPatcher{syncer: pluginSyncer}, pluginSyncer.GroupVersionKind())
+}
+
+// pluginPatcher delegates the translation of the objects to the plugin
+type pluginPatcher struct {
+	syncer plugintypes.Syncer
+}
+
+func (p *pluginPatcher) ServerSideApply(ctx context.Context, vObj, pObj, existingPObj client.Object) error {
+	return p.syncer.SyncToHost(ctx, vObj, pObj, existingPObj)
+}
+
+func (p *pluginPatcher) ReverseUpdate(ctx context.Context, vObj, pObj client.Object) error {
+	updated, err := p.syncer.SyncToVirtual(ctx, vObj, pObj)
+	if err != nil {
+		return err
+	} else if !updated {
+		return ErrNoUpdateNeeded
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package generic
+
+import (
+	"context"
+	"testing"
+
+	generictesting "github.com/loft-sh/vcluster/pkg/controllers/syncer/testing"
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	testingutil "github.com/loft-sh/vcluster/pkg/util/testing"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type fakePluginSyncer struct {
+	gvk schema.GroupVersionKind
+}
+
+func (f *fakePluginSyncer) Name() string {
+	return "fake"
+}
+
+func (f *fakePluginSyncer) GroupVersionKind() schema.GroupVersionKind {
+	return f.gvk
+}
+
+func (f *fakePluginSyncer) SyncToHost(_ context.Context, _, _, _ client.Object) error {
+	return nil
+}
+
+func (f *fakePluginSyncer) SyncToVirtual(_ context.Context, _, _ client.Object) (bool, error) {
+	return false, nil
+}
+
+func TestPluginSyncerBuiltInMapper(t *testing.T) {
+	registerCtx := generictesting.NewFakeRegisterContext(generictesting.NewFakeConfig(), testingutil.NewFakeClient(scheme.Scheme), testingutil.NewFakeClient(scheme.Scheme))
+
+	// plugins cannot replace the built-in syncers
+	_, err := createPluginSyncer(registerCtx, &fakePluginSyncer{gvk: corev1.SchemeGroupVersion.WithKind("Secret")})
+	assert.ErrorContains(t, err, "/v1, Kind=Secret is already synced by another syncer")
+}
@@ -112,12 +112,27 @@ func registerGenericSyncController(ctx *config.ControllerContext) error {
 		return err
 	}
 
+	err = generic.CreateCustomResourceSyncers(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = generic.CreateGatewayAPISyncers(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = generic.CreatePluginSyncers(ctx)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func registerServiceSyncControllers(ctx *config.ControllerContext) error {
 	hostNamespace := ctx.Config.WorkloadTargetNamespace
-	if ctx.Config.Experimental.MultiNamespaceMode.Enabled {
+	if ctx.Config.Sync.ToHost.Namespaces.Enabled {
 		hostNamespace = ctx.Config.WorkloadNamespace
 	}
 
@@ -161,6 +176,8 @@ func registerServiceSyncControllers(ctx *config.ControllerContext) error {
 			SyncServices:    mapping,
 			CreateNamespace: true,
 			CreateEndpoints: true,
+			EndpointSlices:  ctx.Config.Networking.ReplicateServices.EndpointSlices.Enabled,
+			MirrorEndpoints: ctx.Config.Networking.ReplicateServices.EndpointSlices.MirrorEndpoints,
 			From:            globalLocalManager,
 			To:              ctx.VirtualManager,
 			Log:             loghelper.New("map-host-service-syncer"),
@@ -180,12 +197,14 @@ func registerServiceSyncControllers(ctx *config.ControllerContext) error {
 		controller := &servicesync.ServiceSyncer{
 			SyncServices:          mapping,
 			IsVirtualToHostSyncer: true,
+			EndpointSlices:        ctx.Config.Networking.ReplicateServices.EndpointSlices.Enabled,
+			MirrorEndpoints:       ctx.Config.Networking.ReplicateServices.EndpointSlices.MirrorEndpoints,
 			From:                  ctx.VirtualManager,
 			To:                    ctx.LocalManager,
 			Log:                   loghelper.New("map-virtual-service-syncer"),
 		}
 
-		if ctx.Config.Experimental.MultiNamespaceMode.Enabled {
+		if ctx.Config.Sync.ToHost.Namespaces.Enabled {
 			controller.CreateEndpoints = true
 		}
 
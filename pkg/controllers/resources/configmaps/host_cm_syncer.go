@@ -0,0 +1,99 @@
+package configmaps
+
+import (
+	"fmt"
+
+	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	"github.com/loft-sh/vcluster/pkg/controllers/syncer/translator"
+	syncertypes "github.com/loft-sh/vcluster/pkg/controllers/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/mappings/generic"
+	"github.com/loft-sh/vcluster/pkg/patcher"
+	"github.com/loft-sh/vcluster/pkg/util/clienthelper"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewHostConfigMapSyncer(ctx *synccontext.RegisterContext) (syncertypes.Object, error) {
+	mapper, err := generic.NewFromHostMapper(&corev1.ConfigMap{}, ctx.Config.Sync.FromHost.ConfigMaps.Mappings.ByName)
+	if err != nil {
+		return nil, fmt.Errorf("create config map from host mapper: %w", err)
+	}
+
+	return &hostConfigMapSyncer{
+		Translator: translator.NewFromHostTranslator("host-configmap", &corev1.ConfigMap{}, mapper),
+	}, nil
+}
+
+type hostConfigMapSyncer struct {
+	syncertypes.Translator
+}
+
+var _ syncertypes.OptionsProvider = &hostConfigMapSyncer{}
+
+func (s *hostConfigMapSyncer) Options() *syncertypes.Options {
+	return &syncertypes.Options{
+		DisableUIDDeletion: true,
+	}
+}
+
+var _ syncertypes.ObjectExcluder = &hostConfigMapSyncer{}
+
+func (s *hostConfigMapSyncer) ExcludeVirtual(vObj client.Object) bool {
+	return vObj.GetAnnotations() == nil || vObj.GetAnnotations()[translate.HostNameAnnotation] == ""
+}
+
+func (s *hostConfigMapSyncer) ExcludePhysical(_ client.Object) bool {
+	return false
+}
+
+var _ syncertypes.ToVirtualSyncer = &hostConfigMapSyncer{}
+
+func (s *hostConfigMapSyncer) SyncToVirtual(ctx *synccontext.SyncContext, pObj client.Object) (ctrl.Result, error) {
+	vObj := s.TranslateMetadata(ctx, pObj).(*corev1.ConfigMap)
+	err := clienthelper.EnsureNamespace(ctx, ctx.VirtualClient, vObj.Namespace)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("ensure virtual namespace %s: %w", vObj.Namespace, err)
+	}
+
+	ctx.Log.Infof("create config map %s/%s, because it does not exist in virtual cluster", vObj.Namespace, vObj.Name)
+	return ctrl.Result{}, ctx.VirtualClient.Create(ctx, vObj)
+}
+
+var _ syncertypes.Syncer = &hostConfigMapSyncer{}
+
+func (s *hostConfigMapSyncer) Sync(ctx *synccontext.SyncContext, pObj client.Object, vObj client.Object) (_ ctrl.Result, retErr error) {
+	pConfigMap, vConfigMap, _, _ := synccontext.Cast[*corev1.ConfigMap](ctx, pObj, vObj)
+
+	// immutable config maps cannot be updated, so we recreate them instead
+	if vConfigMap.Immutable != nil && *vConfigMap.Immutable && (!equality.Semantic.DeepEqual(vConfigMap.Data, pConfigMap.Data) || !equality.Semantic.DeepEqual(vConfigMap.BinaryData, pConfigMap.BinaryData)) {
+		ctx.Log.Infof("delete virtual config map %s/%s, because it is immutable and host config map has changed", vObj.GetNamespace(), vObj.GetName())
+		return ctrl.Result{Requeue: true}, ctx.VirtualClient.Delete(ctx, vObj)
+	}
+
+	patch, err := patcher.NewSyncerPatcher(ctx, pObj, vObj)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("new syncer patcher: %w", err)
+	}
+
+	defer func() {
+		if err := patch.Patch(ctx, pObj, vObj); err != nil {
+			retErr = utilerrors.NewAggregate([]error{retErr, err})
+		}
+	}()
+
+	// the virtual config map always reflects the host config map, changes within the virtual cluster are reverted
+	_, vConfigMap.Annotations, vConfigMap.Labels = s.TranslateMetadataUpdate(ctx, vObj, pObj)
+	vConfigMap.Data = pConfigMap.Data
+	vConfigMap.BinaryData = pConfigMap.BinaryData
+	vConfigMap.Immutable = pConfigMap.Immutable
+	return ctrl.Result{}, nil
+}
+
+func (s *hostConfigMapSyncer) SyncToHost(ctx *synccontext.SyncContext, vObj client.Object) (ctrl.Result, error) {
+	ctx.Log.Infof("delete virtual config map %s/%s, because host object is missing", vObj.GetNamespace(), vObj.GetName())
+	return ctrl.Result{}, ctx.VirtualClient.Delete(ctx, vObj)
+}
@@ -0,0 +1,144 @@
+package configmaps
+
+import (
+	"testing"
+
+	"github.com/loft-sh/vcluster/pkg/config"
+	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	generictesting "github.com/loft-sh/vcluster/pkg/controllers/syncer/testing"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestHostConfigMapSync(t *testing.T) {
+	pObj := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ca-bundle",
+			Namespace: "platform",
+			Labels: map[string]string{
+				"app": "platform",
+			},
+		},
+		Data: map[string]string{
+			"ca.crt": "host",
+		},
+	}
+	vObj := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ca-bundle",
+			Namespace: "shared",
+			Labels: map[string]string{
+				"app": "platform",
+			},
+			Annotations: map[string]string{
+				translate.HostNameAnnotation:      "ca-bundle",
+				translate.HostNamespaceAnnotation: "platform",
+			},
+		},
+		Data: map[string]string{
+			"ca.crt": "host",
+		},
+	}
+	vObjChanged := vObj.DeepCopy()
+	vObjChanged.Labels = nil
+	vObjChanged.Data = map[string]string{
+		"ca.crt": "tenant",
+	}
+	vObjForged := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "other",
+			Namespace: "tenant",
+			Annotations: map[string]string{
+				translate.HostNameAnnotation:      "ca-bundle",
+				translate.HostNamespaceAnnotation: "platform",
+			},
+		},
+	}
+	pObjUnmanaged := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ca-bundle",
+			Namespace: "other",
+		},
+	}
+
+	adjustConfig := func(vConfig *config.VirtualClusterConfig) {
+		vConfig.Sync.FromHost.ConfigMaps.Enabled = true
+		vConfig.Sync.FromHost.ConfigMaps.Mappings.ByName = map[string]string{
+			"platform/*": "shared/*",
+		}
+	}
+
+	generictesting.RunTests(t, []*generictesting.SyncTest{
+		{
+			Name:                 "Sync to virtual",
+			AdjustConfig:         adjustConfig,
+			InitialPhysicalState: []runtime.Object{pObj},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				corev1.SchemeGroupVersion.WithKind("ConfigMap"): {vObj},
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				corev1.SchemeGroupVersion.WithKind("ConfigMap"): {pObj},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := generictesting.FakeStartSyncer(t, ctx, NewHostConfigMapSyncer)
+				_, err := syncer.(*hostConfigMapSyncer).SyncToVirtual(syncCtx, pObj)
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:                 "Revert virtual changes",
+			AdjustConfig:         adjustConfig,
+			InitialVirtualState:  []runtime.Object{vObjChanged},
+			InitialPhysicalState: []runtime.Object{pObj},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				corev1.SchemeGroupVersion.WithKind("ConfigMap"): {vObj},
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				corev1.SchemeGroupVersion.WithKind("ConfigMap"): {pObj},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := generictesting.FakeStartSyncer(t, ctx, NewHostConfigMapSyncer)
+				_, err := syncer.(*hostConfigMapSyncer).Sync(syncCtx, pObj.DeepCopy(), vObjChanged.DeepCopy())
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:                 "Delete virtual if host is gone",
+			AdjustConfig:         adjustConfig,
+			InitialVirtualState:  []runtime.Object{vObj},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := generictesting.FakeStartSyncer(t, ctx, NewHostConfigMapSyncer)
+				_, err := syncer.(*hostConfigMapSyncer).SyncToHost(syncCtx, vObj)
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:         "Mapping",
+			AdjustConfig: adjustConfig,
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := generictesting.FakeStartSyncer(t, ctx, NewHostConfigMapSyncer)
+				hostSyncer := syncer.(*hostConfigMapSyncer)
+
+				assert.Equal(t, hostSyncer.HostToVirtual(syncCtx, types.NamespacedName{Namespace: "platform", Name: "ca-bundle"}, pObj), types.NamespacedName{Namespace: "shared", Name: "ca-bundle"})
+				assert.Equal(t, hostSyncer.VirtualToHost(syncCtx, types.NamespacedName{Namespace: "shared", Name: "ca-bundle"}, vObj), types.NamespacedName{Namespace: "platform", Name: "ca-bundle"})
+				assert.Equal(t, hostSyncer.VirtualToHost(syncCtx, types.NamespacedName{Namespace: "tenant", Name: "other"}, vObjForged), types.NamespacedName{})
+				assert.Equal(t, hostSyncer.ExcludeVirtual(vObj), false)
+				assert.Equal(t, hostSyncer.ExcludeVirtual(pObjUnmanaged), true)
+
+				managed, err := hostSyncer.IsManaged(syncCtx, pObj)
+				assert.NilError(t, err)
+				assert.Equal(t, managed, true)
+
+				managed, err = hostSyncer.IsManaged(syncCtx, pObjUnmanaged)
+				assert.NilError(t, err)
+				assert.Equal(t, managed, false)
+			},
+		},
+	})
+}
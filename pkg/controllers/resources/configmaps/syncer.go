@@ -12,6 +12,7 @@ import (
 	syncertypes "github.com/loft-sh/vcluster/pkg/controllers/syncer/types"
 	"github.com/loft-sh/vcluster/pkg/mappings"
 	"github.com/loft-sh/vcluster/pkg/patcher"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -114,6 +115,9 @@ func (s *configMapSyncer) isConfigMapUsed(ctx *synccontext.SyncContext, vObj run
 	configMap, ok := vObj.(*corev1.ConfigMap)
 	if !ok || configMap == nil {
 		return false, fmt.Errorf("%#v is not a config map", vObj)
+	} else if configMap.Annotations != nil && configMap.Annotations[translate.HostNameAnnotation] != "" {
+		// config maps copied from the host by sync.fromHost.configMaps are never synced back
+		return false, nil
 	} else if configMap.Annotations != nil && configMap.Annotations[constants.SyncResourceAnnotation] == "true" {
 		return true, nil
 	} else if s.syncAllConfigMaps {
@@ -46,6 +46,16 @@ func TestSync(t *testing.T) {
 		ObjectMeta: syncedConfigMap.ObjectMeta,
 		Data:       updatedConfigMap.Data,
 	}
+	hostConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      baseConfigMap.Name,
+			Namespace: baseConfigMap.Namespace,
+			Annotations: map[string]string{
+				translate.HostNameAnnotation:      "host-configmap",
+				translate.HostNamespaceAnnotation: "host-namespace",
+			},
+		},
+	}
 	basePod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "test",
@@ -99,6 +109,21 @@ func TestSync(t *testing.T) {
 				assert.NilError(t, err)
 			},
 		},
+		{
+			Name: "Used config map copied from the host",
+			InitialVirtualState: []runtime.Object{
+				hostConfigMap,
+				basePod,
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				corev1.SchemeGroupVersion.WithKind("ConfigMap"): {},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := generictesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*configMapSyncer).SyncToHost(syncCtx, hostConfigMap)
+				assert.NilError(t, err)
+			},
+		},
 		{
 			Name: "Update used config map",
 			InitialVirtualState: []runtime.Object{
@@ -23,11 +23,17 @@ import (
 func New(ctx *synccontext.RegisterContext) (syncertypes.Object, error) {
 	return &endpointsSyncer{
 		GenericTranslator: translator.NewGenericTranslator(ctx, "endpoints", &corev1.Endpoints{}, mappings.Endpoints()),
+
+		skipMirror: ctx.Config.Sync.ToHost.EndpointSlices.Enabled,
 	}, nil
 }
 
 type endpointsSyncer struct {
 	syncertypes.GenericTranslator
+
+	// skipMirror excludes the endpoints from endpoint slice mirroring in the host cluster, as the endpoint
+	// slices are synced by vCluster
+	skipMirror bool
 }
 
 func (s *endpointsSyncer) SyncToHost(ctx *synccontext.SyncContext, vObj client.Object) (ctrl.Result, error) {
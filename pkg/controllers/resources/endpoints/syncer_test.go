@@ -3,6 +3,7 @@ package endpoints
 import (
 	"testing"
 
+	"github.com/loft-sh/vcluster/pkg/config"
 	"github.com/loft-sh/vcluster/pkg/controllers/syncer"
 	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
 	generictesting "github.com/loft-sh/vcluster/pkg/controllers/syncer/testing"
@@ -10,6 +11,7 @@ import (
 	"github.com/loft-sh/vcluster/pkg/util/translate"
 	"gotest.tools/assert"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -165,6 +167,8 @@ func TestSync(t *testing.T) {
 		ObjectMeta: syncedEndpoints.ObjectMeta,
 		Subsets:    updatedEndpoints.Subsets,
 	}
+	syncedSkipMirrorEndpoints := syncedEndpoints.DeepCopy()
+	syncedSkipMirrorEndpoints.Labels[discoveryv1.LabelSkipMirror] = "true"
 
 	request := ctrl.Request{
 		NamespacedName: types.NamespacedName{
@@ -190,6 +194,25 @@ func TestSync(t *testing.T) {
 				assert.NilError(t, err)
 			},
 		},
+		{
+			Name: "Forward create with endpoint slices",
+			AdjustConfig: func(vConfig *config.VirtualClusterConfig) {
+				vConfig.Sync.ToHost.EndpointSlices.Enabled = true
+			},
+			InitialVirtualState: []runtime.Object{
+				baseEndpoints,
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				corev1.SchemeGroupVersion.WithKind("Endpoints"): {
+					syncedSkipMirrorEndpoints,
+				},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := generictesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*endpointsSyncer).SyncToHost(syncCtx, baseEndpoints)
+				assert.NilError(t, err)
+			},
+		},
 		{
 			Name: "Forward update",
 			InitialVirtualState: []runtime.Object{
@@ -5,6 +5,7 @@ import (
 
 	"github.com/loft-sh/vcluster/pkg/mappings"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -18,10 +19,23 @@ func (s *endpointsSyncer) translate(ctx context.Context, vObj client.Object) *co
 	if endpoints.Annotations != nil {
 		delete(endpoints.Annotations, "control-plane.alpha.kubernetes.io/leader")
 	}
+	endpoints.Labels = s.translateLabels(endpoints.Labels)
 
 	return endpoints
 }
 
+func (s *endpointsSyncer) translateLabels(labels map[string]string) map[string]string {
+	if !s.skipMirror {
+		return labels
+	}
+
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[discoveryv1.LabelSkipMirror] = "true"
+	return labels
+}
+
 func (s *endpointsSyncer) translateSpec(ctx context.Context, endpoints *corev1.Endpoints) {
 	// translate the addresses
 	for i, subset := range endpoints.Subsets {
@@ -61,6 +75,7 @@ func (s *endpointsSyncer) translateUpdate(ctx context.Context, pObj, vObj *corev
 	// check annotations & labels
 	_, annotations, labels := s.TranslateMetadataUpdate(ctx, vObj, pObj)
 	delete(annotations, "control-plane.alpha.kubernetes.io/leader")
+	labels = s.translateLabels(labels)
 	if !equality.Semantic.DeepEqual(annotations, pObj.Annotations) || !equality.Semantic.DeepEqual(labels, pObj.Labels) {
 		pObj.Annotations = annotations
 		pObj.Labels = labels
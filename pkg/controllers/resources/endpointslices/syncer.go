@@ -0,0 +1,126 @@
+package endpointslices
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/loft-sh/vcluster/pkg/controllers/syncer"
+	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	"github.com/loft-sh/vcluster/pkg/controllers/syncer/translator"
+	syncertypes "github.com/loft-sh/vcluster/pkg/controllers/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/mappings"
+	"github.com/loft-sh/vcluster/pkg/patcher"
+	"github.com/loft-sh/vcluster/pkg/specialservices"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ManagedBy is the value of the endpointslice.kubernetes.io/managed-by label for endpoint slices written by vCluster.
+// This prevents the endpoint slice controllers of the cluster from taking over the endpoint slices.
+const ManagedBy = "vcluster.loft.sh"
+
+func New(ctx *synccontext.RegisterContext) (syncertypes.Object, error) {
+	return &endpointSlicesSyncer{
+		GenericTranslator: translator.NewGenericTranslator(ctx, "endpointslices", &discoveryv1.EndpointSlice{}, mappings.EndpointSlices()),
+	}, nil
+}
+
+type endpointSlicesSyncer struct {
+	syncertypes.GenericTranslator
+}
+
+func (s *endpointSlicesSyncer) SyncToHost(ctx *synccontext.SyncContext, vObj client.Object) (ctrl.Result, error) {
+	if ctx.IsDelete {
+		return syncer.DeleteVirtualObject(ctx, vObj, "host object was deleted")
+	}
+
+	return s.SyncToHostCreate(ctx, vObj, s.translate(ctx, vObj.(*discoveryv1.EndpointSlice)))
+}
+
+func (s *endpointSlicesSyncer) Sync(ctx *synccontext.SyncContext, pObj client.Object, vObj client.Object) (_ ctrl.Result, retErr error) {
+	patch, err := patcher.NewSyncerPatcher(ctx, pObj, vObj)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("new syncer patcher: %w", err)
+	}
+	defer func() {
+		if err := patch.Patch(ctx, pObj, vObj); err != nil {
+			retErr = errors.Join(retErr, err)
+		}
+
+		if retErr != nil {
+			s.EventRecorder().Eventf(vObj, "Warning", "SyncError", "Error syncing: %v", retErr)
+		}
+	}()
+
+	s.translateUpdate(ctx, pObj.(*discoveryv1.EndpointSlice), vObj.(*discoveryv1.EndpointSlice))
+	return ctrl.Result{}, nil
+}
+
+var _ syncertypes.Starter = &endpointSlicesSyncer{}
+
+// ReconcileStart skips endpoint slices of services with a selector, as the host cluster creates the endpoint
+// slices for these services itself.
+func (s *endpointSlicesSyncer) ReconcileStart(ctx *synccontext.SyncContext, req ctrl.Request) (bool, error) {
+	vEndpointSlice := &discoveryv1.EndpointSlice{}
+	err := ctx.VirtualClient.Get(ctx, req.NamespacedName, vEndpointSlice)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return true, err
+	}
+
+	serviceName := vEndpointSlice.Labels[discoveryv1.LabelServiceName]
+	if serviceName == "" {
+		return true, nil
+	}
+	serviceKey := types.NamespacedName{Namespace: req.Namespace, Name: serviceName}
+	if serviceKey == specialservices.DefaultKubernetesSvcKey {
+		return true, nil
+	}
+	if specialservices.Default != nil {
+		if _, ok := specialservices.Default.SpecialServicesToSync()[serviceKey]; ok {
+			return true, nil
+		}
+	}
+
+	svc := &corev1.Service{}
+	err = ctx.VirtualClient.Get(ctx, serviceKey, svc)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return true, nil
+		}
+
+		return true, err
+	} else if svc.Spec.Selector == nil {
+		return false, nil
+	}
+
+	// delete the endpoint slice if it was synced before the service had a selector
+	pEndpointSlice := &discoveryv1.EndpointSlice{}
+	err = ctx.PhysicalClient.Get(ctx, s.VirtualToHost(ctx, req.NamespacedName, vEndpointSlice), pEndpointSlice)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			klog.Infof("Error retrieving endpoint slice: %v", err)
+		}
+
+		return true, nil
+	} else if pEndpointSlice.Labels[discoveryv1.LabelManagedBy] == ManagedBy && pEndpointSlice.Annotations[translate.NameAnnotation] != "" {
+		klog.Infof("Delete endpoint slice %s/%s in physical cluster because the service has a selector now", pEndpointSlice.Namespace, pEndpointSlice.Name)
+		err = ctx.PhysicalClient.Delete(ctx, pEndpointSlice)
+		if err != nil && !kerrors.IsNotFound(err) {
+			return true, err
+		}
+	}
+
+	return true, nil
+}
+
+func (s *endpointSlicesSyncer) ReconcileEnd() {}
@@ -0,0 +1,178 @@
+package endpointslices
+
+import (
+	"testing"
+
+	"github.com/loft-sh/vcluster/pkg/config"
+	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	generictesting "github.com/loft-sh/vcluster/pkg/controllers/syncer/testing"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func enableEndpointSlices(vConfig *config.VirtualClusterConfig) {
+	vConfig.Sync.ToHost.EndpointSlices.Enabled = true
+}
+
+func TestSync(t *testing.T) {
+	vService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-service",
+			Namespace: "test",
+		},
+	}
+	vServiceWithSelector := &corev1.Service{
+		ObjectMeta: vService.ObjectMeta,
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "test"},
+		},
+	}
+	vEndpointSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-service-abcde",
+			Namespace: "test",
+			Labels: map[string]string{
+				discoveryv1.LabelServiceName: vService.Name,
+			},
+		},
+		AddressType: discoveryv1.AddressTypeIPv6,
+		Endpoints: []discoveryv1.Endpoint{
+			{
+				Addresses:  []string{"fd00::1"},
+				Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)},
+				TargetRef: &corev1.ObjectReference{
+					Kind:      "Pod",
+					Name:      "test-pod",
+					Namespace: "test",
+					UID:       "123",
+				},
+				Zone: ptr.To("zone-a"),
+				Hints: &discoveryv1.EndpointHints{
+					ForZones: []discoveryv1.ForZone{{Name: "zone-a"}},
+				},
+			},
+		},
+		Ports: []discoveryv1.EndpointPort{
+			{
+				Name: ptr.To("http"),
+				Port: ptr.To(int32(80)),
+			},
+		},
+	}
+	updatedEndpointSlice := vEndpointSlice.DeepCopy()
+	updatedEndpointSlice.Endpoints = append(updatedEndpointSlice.Endpoints, discoveryv1.Endpoint{
+		Addresses:  []string{"fd00::2"},
+		Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(false)},
+	})
+
+	pLabels := translate.Default.TranslateLabels(vEndpointSlice.Labels, vEndpointSlice.Namespace, nil)
+	pLabels[discoveryv1.LabelServiceName] = translate.Default.PhysicalName(vService.Name, vService.Namespace)
+	pLabels[discoveryv1.LabelManagedBy] = ManagedBy
+	pEndpointSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      translate.Default.PhysicalName(vEndpointSlice.Name, vEndpointSlice.Namespace),
+			Namespace: "test",
+			Annotations: map[string]string{
+				translate.NameAnnotation:      vEndpointSlice.Name,
+				translate.NamespaceAnnotation: vEndpointSlice.Namespace,
+				translate.UIDAnnotation:       "",
+				translate.KindAnnotation:      discoveryv1.SchemeGroupVersion.WithKind("EndpointSlice").String(),
+			},
+			Labels: pLabels,
+		},
+		AddressType: discoveryv1.AddressTypeIPv6,
+		Endpoints: []discoveryv1.Endpoint{
+			{
+				Addresses:  []string{"fd00::1"},
+				Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)},
+				TargetRef: &corev1.ObjectReference{
+					Kind:      "Pod",
+					Name:      translate.Default.PhysicalName("test-pod", "test"),
+					Namespace: "test",
+				},
+				Zone: ptr.To("zone-a"),
+				Hints: &discoveryv1.EndpointHints{
+					ForZones: []discoveryv1.ForZone{{Name: "zone-a"}},
+				},
+			},
+		},
+		Ports: vEndpointSlice.Ports,
+	}
+	pUpdatedEndpointSlice := pEndpointSlice.DeepCopy()
+	pUpdatedEndpointSlice.Endpoints = append(pUpdatedEndpointSlice.Endpoints, updatedEndpointSlice.Endpoints[1])
+
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: vEndpointSlice.Namespace, Name: vEndpointSlice.Name}}
+	generictesting.RunTests(t, []*generictesting.SyncTest{
+		{
+			Name:         "Forward create",
+			AdjustConfig: enableEndpointSlices,
+			InitialVirtualState: []runtime.Object{
+				vService,
+				vEndpointSlice,
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				discoveryv1.SchemeGroupVersion.WithKind("EndpointSlice"): {
+					pEndpointSlice,
+				},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := generictesting.FakeStartSyncer(t, ctx, New)
+				skip, err := syncer.(*endpointSlicesSyncer).ReconcileStart(syncCtx, request)
+				assert.NilError(t, err)
+				assert.Equal(t, skip, false)
+
+				_, err = syncer.(*endpointSlicesSyncer).SyncToHost(syncCtx, vEndpointSlice)
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:         "Forward update",
+			AdjustConfig: enableEndpointSlices,
+			InitialVirtualState: []runtime.Object{
+				vService,
+				updatedEndpointSlice,
+			},
+			InitialPhysicalState: []runtime.Object{
+				pEndpointSlice,
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				discoveryv1.SchemeGroupVersion.WithKind("EndpointSlice"): {
+					pUpdatedEndpointSlice,
+				},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := generictesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*endpointSlicesSyncer).Sync(syncCtx, pEndpointSlice.DeepCopy(), updatedEndpointSlice.DeepCopy())
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:         "Delete endpoint slice of service with selector",
+			AdjustConfig: enableEndpointSlices,
+			InitialVirtualState: []runtime.Object{
+				vServiceWithSelector,
+				vEndpointSlice,
+			},
+			InitialPhysicalState: []runtime.Object{
+				pEndpointSlice,
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				discoveryv1.SchemeGroupVersion.WithKind("EndpointSlice"): {},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := generictesting.FakeStartSyncer(t, ctx, New)
+				skip, err := syncer.(*endpointSlicesSyncer).ReconcileStart(syncCtx, request)
+				assert.NilError(t, err)
+				assert.Equal(t, skip, true)
+			},
+		},
+	})
+}
@@ -0,0 +1,61 @@
+package endpointslices
+
+import (
+	"context"
+
+	"github.com/loft-sh/vcluster/pkg/mappings"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+func (s *endpointSlicesSyncer) translate(ctx context.Context, vEndpointSlice *discoveryv1.EndpointSlice) *discoveryv1.EndpointSlice {
+	endpointSlice := s.TranslateMetadata(ctx, vEndpointSlice).(*discoveryv1.EndpointSlice)
+	endpointSlice.Labels = translateLabels(ctx, vEndpointSlice, endpointSlice.Labels)
+	translateEndpoints(ctx, endpointSlice)
+	return endpointSlice
+}
+
+// translateLabels points the endpoint slice to the host service and marks it as managed by vCluster
+func translateLabels(ctx context.Context, vEndpointSlice *discoveryv1.EndpointSlice, labels map[string]string) map[string]string {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	labels[discoveryv1.LabelServiceName] = mappings.VirtualToHostName(ctx, vEndpointSlice.Labels[discoveryv1.LabelServiceName], vEndpointSlice.Namespace, mappings.Services())
+	labels[discoveryv1.LabelManagedBy] = ManagedBy
+	return labels
+}
+
+// translateEndpoints rewrites the pod references of the endpoints. Addresses, conditions, zones and topology hints
+// are kept as is.
+func translateEndpoints(ctx context.Context, endpointSlice *discoveryv1.EndpointSlice) {
+	for i, endpoint := range endpointSlice.Endpoints {
+		if endpoint.TargetRef != nil && endpoint.TargetRef.Kind == "Pod" {
+			nameNamespace := mappings.VirtualToHost(ctx, endpoint.TargetRef.Name, endpoint.TargetRef.Namespace, mappings.Pods())
+			endpointSlice.Endpoints[i].TargetRef.Name = nameNamespace.Name
+			endpointSlice.Endpoints[i].TargetRef.Namespace = nameNamespace.Namespace
+			endpointSlice.Endpoints[i].TargetRef.UID = ""
+			endpointSlice.Endpoints[i].TargetRef.ResourceVersion = ""
+		}
+	}
+}
+
+func (s *endpointSlicesSyncer) translateUpdate(ctx context.Context, pObj, vObj *discoveryv1.EndpointSlice) {
+	// check endpoints & ports
+	translated := vObj.DeepCopy()
+	translateEndpoints(ctx, translated)
+	if !equality.Semantic.DeepEqual(translated.Endpoints, pObj.Endpoints) {
+		pObj.Endpoints = translated.Endpoints
+	}
+	if !equality.Semantic.DeepEqual(translated.Ports, pObj.Ports) {
+		pObj.Ports = translated.Ports
+	}
+
+	// check annotations & labels
+	_, annotations, labels := s.TranslateMetadataUpdate(ctx, vObj, pObj)
+	labels = translateLabels(ctx, vObj, labels)
+	if !equality.Semantic.DeepEqual(annotations, pObj.Annotations) || !equality.Semantic.DeepEqual(labels, pObj.Labels) {
+		pObj.Annotations = annotations
+		pObj.Labels = labels
+	}
+}
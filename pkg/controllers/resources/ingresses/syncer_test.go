@@ -3,7 +3,9 @@ package ingresses
 import (
 	"testing"
 
+	vclusterconfig "github.com/loft-sh/vcluster/config"
 	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	"github.com/loft-sh/vcluster/pkg/patches"
 	"gotest.tools/assert"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -210,6 +212,51 @@ func TestSync(t *testing.T) {
 				assert.NilError(t, err)
 			},
 		},
+		{
+			Name: "Update forward with patches",
+			InitialVirtualState: []runtime.Object{&networkingv1.Ingress{
+				ObjectMeta: vObjectMeta,
+				Spec:       *vBaseSpec.DeepCopy(),
+			}},
+			InitialPhysicalState: []runtime.Object{&networkingv1.Ingress{
+				ObjectMeta: pObjectMeta,
+				Spec:       networkingv1.IngressSpec{},
+			}},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				networkingv1.SchemeGroupVersion.WithKind("Ingress"): {&networkingv1.Ingress{
+					ObjectMeta: vObjectMeta,
+					Spec:       *vBaseSpec.DeepCopy(),
+				}},
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				networkingv1.SchemeGroupVersion.WithKind("Ingress"): {&networkingv1.Ingress{
+					ObjectMeta: pObjectMeta,
+					Spec: networkingv1.IngressSpec{
+						DefaultBackend:   pBaseSpec.DefaultBackend,
+						IngressClassName: stringPointer("host-ingress-class"),
+						Rules:            pBaseSpec.Rules,
+						TLS:              pBaseSpec.TLS,
+					},
+				}},
+			},
+			Sync: func(registerContext *synccontext.RegisterContext) {
+				syncCtx, syncer := generictesting.FakeStartSyncer(t, registerContext, NewSyncer)
+				syncCtx.Patches = &patches.SyncerPatches{
+					Host: []*vclusterconfig.Patch{{Operation: vclusterconfig.PatchTypeAdd, Path: "spec.ingressClassName", Value: "host-ingress-class"}},
+				}
+				pIngress := &networkingv1.Ingress{
+					ObjectMeta: pObjectMeta,
+					Spec:       networkingv1.IngressSpec{},
+				}
+				pIngress.ResourceVersion = "999"
+
+				_, err := syncer.(*ingressSyncer).Sync(syncCtx, pIngress, &networkingv1.Ingress{
+					ObjectMeta: vObjectMeta,
+					Spec:       *vBaseSpec.DeepCopy(),
+				})
+				assert.NilError(t, err)
+			},
+		},
 		{
 			Name:                 "Update forward not needed",
 			InitialVirtualState:  []runtime.Object{baseIngress.DeepCopy()},
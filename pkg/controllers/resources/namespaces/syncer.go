@@ -1,6 +1,7 @@
 package namespaces
 
 import (
+	"errors"
 	"fmt"
 
 	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
@@ -8,6 +9,7 @@ import (
 	syncertypes "github.com/loft-sh/vcluster/pkg/controllers/syncer/types"
 	"github.com/loft-sh/vcluster/pkg/mappings"
 	"github.com/loft-sh/vcluster/pkg/patcher"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -21,25 +23,37 @@ import (
 var excludedAnnotations = []string{
 	"scheduler.alpha.kubernetes.io/node-selector",
 	"scheduler.alpha.kubernetes.io/defaultTolerations",
+	NamespaceObjectsAnnotation,
 }
 
 const (
 	VClusterNameAnnotation      = "vcluster.loft.sh/vcluster-name"
 	VClusterNamespaceAnnotation = "vcluster.loft.sh/vcluster-namespace"
+
+	// NamespaceObjectsAnnotation records the kinds of the objects that were created from the templates in a host namespace
+	NamespaceObjectsAnnotation = "vcluster.loft.sh/namespace-objects"
 )
 
 func New(ctx *synccontext.RegisterContext) (syncertypes.Object, error) {
 	namespaceLabels := map[string]string{}
-	for k, v := range ctx.Config.Experimental.MultiNamespaceMode.NamespaceLabels {
+	for k, v := range ctx.Config.Sync.ToHost.Namespaces.ExtraLabels {
 		namespaceLabels[k] = v
 	}
 	namespaceLabels[VClusterNameAnnotation] = ctx.Config.Name
 	namespaceLabels[VClusterNamespaceAnnotation] = ctx.CurrentNamespace
 
+	namespaceObjects, err := newNamespaceObjects(ctx.Config.Sync.ToHost.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+
 	return &namespaceSyncer{
 		GenericTranslator:          translator.NewGenericTranslator(ctx, "namespace", &corev1.Namespace{}, mappings.Namespaces(), excludedAnnotations...),
 		workloadServiceAccountName: ctx.Config.ControlPlane.Advanced.WorkloadServiceAccount.Name,
 		namespaceLabels:            namespaceLabels,
+		namespaceObjects:           namespaceObjects,
+		namespaceObjectKinds:       namespaceObjectKinds(namespaceObjects),
+		markerLabel:                translate.SafeConcatName(ctx.CurrentNamespace, "x", ctx.Config.Name),
 	}, nil
 }
 
@@ -47,6 +61,9 @@ type namespaceSyncer struct {
 	syncertypes.GenericTranslator
 	workloadServiceAccountName string
 	namespaceLabels            map[string]string
+	namespaceObjects           []client.Object
+	namespaceObjectKinds       string
+	markerLabel                string
 }
 
 var _ syncertypes.Syncer = &namespaceSyncer{}
@@ -60,7 +77,7 @@ func (s *namespaceSyncer) SyncToHost(ctx *synccontext.SyncContext, vObj client.O
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, s.EnsureWorkloadServiceAccount(ctx, newNamespace.Name)
+	return ctrl.Result{}, s.ensureNamespaceContent(ctx, newNamespace.Name)
 }
 
 func (s *namespaceSyncer) Sync(ctx *synccontext.SyncContext, pObj client.Object, vObj client.Object) (_ ctrl.Result, retErr error) {
@@ -78,9 +95,32 @@ func (s *namespaceSyncer) Sync(ctx *synccontext.SyncContext, pObj client.Object,
 	// cast objects
 	pNamespace, vNamespace, _, _ := synccontext.Cast[*corev1.Namespace](ctx, pObj, vObj)
 
+	// check if the host namespace belongs to another virtual namespace, which can happen if a shortened
+	// name of the namespace name template collides
+	if pName := pNamespace.Annotations[translate.NameAnnotation]; pName != "" && pName != vNamespace.Name {
+		msg := fmt.Sprintf("conflict: cannot sync virtual namespace %s as host namespace %s already belongs to virtual namespace %s", vNamespace.Name, pNamespace.Name, pName)
+		s.EventRecorder().Eventf(vNamespace, "Warning", "SyncError", msg)
+		return ctrl.Result{}, errors.New(msg)
+	}
+
+	// delete the objects of namespace templates that were disabled since the last sync
+	err = s.DeleteDisabledNamespaceObjects(ctx, pNamespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	s.translateUpdate(ctx, pNamespace, vNamespace)
 
-	return ctrl.Result{}, s.EnsureWorkloadServiceAccount(ctx, pNamespace.Name)
+	return ctrl.Result{}, s.ensureNamespaceContent(ctx, pNamespace.Name)
+}
+
+func (s *namespaceSyncer) ensureNamespaceContent(ctx *synccontext.SyncContext, pNamespace string) error {
+	err := s.EnsureWorkloadServiceAccount(ctx, pNamespace)
+	if err != nil {
+		return err
+	}
+
+	return s.EnsureNamespaceObjects(ctx, pNamespace)
 }
 
 func (s *namespaceSyncer) EnsureWorkloadServiceAccount(ctx *synccontext.SyncContext, pNamespace string) error {
@@ -0,0 +1,164 @@
+package namespaces
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/loft-sh/vcluster/config"
+	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// NamespaceObjectName is the name of the objects that are created from the templates in each host namespace
+func NamespaceObjectName() string {
+	return translate.SafeConcatName("vcluster", translate.VClusterName)
+}
+
+// namespaceObjectTypes are the kinds of objects that can be created from the templates in each host namespace
+var namespaceObjectTypes = map[string]func() client.Object{
+	"LimitRange":    func() client.Object { return &corev1.LimitRange{} },
+	"NetworkPolicy": func() client.Object { return &networkingv1.NetworkPolicy{} },
+	"ResourceQuota": func() client.Object { return &corev1.ResourceQuota{} },
+}
+
+// namespaceObjectKind returns the kind of an object that is created from a template
+func namespaceObjectKind(obj client.Object) string {
+	switch obj.(type) {
+	case *corev1.LimitRange:
+		return "LimitRange"
+	case *networkingv1.NetworkPolicy:
+		return "NetworkPolicy"
+	case *corev1.ResourceQuota:
+		return "ResourceQuota"
+	}
+
+	return ""
+}
+
+// namespaceObjectKinds returns the sorted and comma separated kinds of the given objects, which is stored in the
+// NamespaceObjectsAnnotation of the host namespaces
+func namespaceObjectKinds(objects []client.Object) string {
+	kinds := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		kinds = append(kinds, namespaceObjectKind(obj))
+	}
+	slices.Sort(kinds)
+	return strings.Join(kinds, ",")
+}
+
+// newNamespaceObjects returns the resource quota, limit range and network policy that should get created in each host namespace
+func newNamespaceObjects(namespaces config.SyncToHostNamespaces) ([]client.Object, error) {
+	objects := []client.Object{}
+	if namespaces.ResourceQuota.Enabled {
+		resourceQuota := &corev1.ResourceQuota{}
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(namespaces.ResourceQuota.Spec, &resourceQuota.Spec)
+		if err != nil {
+			return nil, fmt.Errorf("convert resource quota spec: %w", err)
+		}
+
+		objects = append(objects, withTemplateMetadata(resourceQuota, namespaces.ResourceQuota))
+	}
+	if namespaces.LimitRange.Enabled {
+		limitRange := &corev1.LimitRange{}
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(namespaces.LimitRange.Spec, &limitRange.Spec)
+		if err != nil {
+			return nil, fmt.Errorf("convert limit range spec: %w", err)
+		}
+
+		objects = append(objects, withTemplateMetadata(limitRange, namespaces.LimitRange))
+	}
+	if namespaces.NetworkPolicy.Enabled {
+		networkPolicy := &networkingv1.NetworkPolicy{}
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(namespaces.NetworkPolicy.Spec, &networkPolicy.Spec)
+		if err != nil {
+			return nil, fmt.Errorf("convert network policy spec: %w", err)
+		}
+
+		objects = append(objects, withTemplateMetadata(networkPolicy, namespaces.NetworkPolicy))
+	}
+
+	return objects, nil
+}
+
+func withTemplateMetadata(obj client.Object, template config.NamespaceObjectTemplate) client.Object {
+	obj.SetName(NamespaceObjectName())
+	obj.SetLabels(maps.Clone(template.Labels))
+	obj.SetAnnotations(maps.Clone(template.Annotations))
+	return obj
+}
+
+// EnsureNamespaceObjects creates or updates the objects from the templates in the host namespace
+func (s *namespaceSyncer) EnsureNamespaceObjects(ctx *synccontext.SyncContext, pNamespace string) error {
+	for _, template := range s.namespaceObjects {
+		obj := template.DeepCopyObject().(client.Object)
+		obj.SetNamespace(pNamespace)
+		_, err := controllerutil.CreateOrPatch(ctx, ctx.PhysicalClient, obj, func() error {
+			labels := obj.GetLabels()
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			maps.Copy(labels, template.GetLabels())
+			labels[translate.MarkerLabel] = s.markerLabel
+			obj.SetLabels(labels)
+
+			annotations := obj.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			maps.Copy(annotations, template.GetAnnotations())
+			obj.SetAnnotations(annotations)
+
+			switch o := obj.(type) {
+			case *corev1.ResourceQuota:
+				o.Spec = template.(*corev1.ResourceQuota).Spec
+			case *corev1.LimitRange:
+				o.Spec = template.(*corev1.LimitRange).Spec
+			case *networkingv1.NetworkPolicy:
+				o.Spec = template.(*networkingv1.NetworkPolicy).Spec
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("ensure %T %s/%s: %w", obj, pNamespace, obj.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteDisabledNamespaceObjects deletes the objects in the host namespace that were created from templates that
+// are disabled by now. Only the kinds that are recorded in the NamespaceObjectsAnnotation of the host namespace
+// are deleted, so that objects of host namespaces synced without templates are never touched.
+func (s *namespaceSyncer) DeleteDisabledNamespaceObjects(ctx *synccontext.SyncContext, pNamespace *corev1.Namespace) error {
+	recordedKinds := pNamespace.Annotations[NamespaceObjectsAnnotation]
+	if recordedKinds == "" || recordedKinds == s.namespaceObjectKinds {
+		return nil
+	}
+
+	enabledKinds := strings.Split(s.namespaceObjectKinds, ",")
+	for _, kind := range strings.Split(recordedKinds, ",") {
+		newObject, ok := namespaceObjectTypes[kind]
+		if !ok || slices.Contains(enabledKinds, kind) {
+			continue
+		}
+
+		obj := newObject()
+		obj.SetNamespace(pNamespace.Name)
+		obj.SetName(NamespaceObjectName())
+		ctx.Log.Infof("delete %s %s/%s as its namespace template was disabled", kind, pNamespace.Name, obj.GetName())
+		err := ctx.PhysicalClient.Delete(ctx, obj)
+		if err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("delete %s %s/%s: %w", kind, pNamespace.Name, obj.GetName(), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package namespaces
+
+import (
+	"testing"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/config"
+	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	generictesting "github.com/loft-sh/vcluster/pkg/controllers/syncer/testing"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestDeleteDisabledNamespaceObjects(t *testing.T) {
+	objectMeta := func(namespace string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:            NamespaceObjectName(),
+			Namespace:       namespace,
+			ResourceVersion: generictesting.FakeClientResourceVersion,
+		}
+	}
+	resourceQuota := &corev1.ResourceQuota{ObjectMeta: objectMeta("host-namespace")}
+	limitRange := &corev1.LimitRange{ObjectMeta: objectMeta("host-namespace")}
+	otherLimitRange := &corev1.LimitRange{ObjectMeta: objectMeta("other-namespace")}
+	networkPolicy := &networkingv1.NetworkPolicy{ObjectMeta: objectMeta("host-namespace")}
+	enableResourceQuota := func(vConfig *config.VirtualClusterConfig) {
+		vConfig.Sync.ToHost.Namespaces.ResourceQuota.Enabled = true
+		vConfig.Sync.ToHost.Namespaces.ResourceQuota.Spec = map[string]interface{}{}
+	}
+	initialState := []runtime.Object{resourceQuota, limitRange, otherLimitRange, networkPolicy}
+
+	generictesting.RunTests(t, []*generictesting.SyncTest{
+		{
+			Name:                 "Delete recorded objects of disabled templates",
+			InitialPhysicalState: initialState,
+			AdjustConfig:         enableResourceQuota,
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				corev1.SchemeGroupVersion.WithKind("ResourceQuota"):       {resourceQuota},
+				corev1.SchemeGroupVersion.WithKind("LimitRange"):          {otherLimitRange},
+				networkingv1.SchemeGroupVersion.WithKind("NetworkPolicy"): {networkPolicy},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := generictesting.FakeStartSyncer(t, ctx, New)
+				err := syncer.(*namespaceSyncer).DeleteDisabledNamespaceObjects(syncCtx, &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "host-namespace",
+						Annotations: map[string]string{NamespaceObjectsAnnotation: "LimitRange,ResourceQuota"},
+					},
+				})
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:                 "Keep objects without recorded kinds",
+			InitialPhysicalState: initialState,
+			AdjustConfig:         enableResourceQuota,
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				corev1.SchemeGroupVersion.WithKind("ResourceQuota"):       {resourceQuota},
+				corev1.SchemeGroupVersion.WithKind("LimitRange"):          {limitRange, otherLimitRange},
+				networkingv1.SchemeGroupVersion.WithKind("NetworkPolicy"): {networkPolicy},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := generictesting.FakeStartSyncer(t, ctx, New)
+				err := syncer.(*namespaceSyncer).DeleteDisabledNamespaceObjects(syncCtx, &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{Name: "host-namespace"},
+				})
+				assert.NilError(t, err)
+			},
+		},
+	})
+}
+
+func TestNamespaceObjectKinds(t *testing.T) {
+	objects, err := newNamespaceObjects(vclusterconfig.SyncToHostNamespaces{
+		ResourceQuota: vclusterconfig.NamespaceObjectTemplate{Enabled: true},
+		NetworkPolicy: vclusterconfig.NamespaceObjectTemplate{Enabled: true},
+		LimitRange:    vclusterconfig.NamespaceObjectTemplate{Enabled: true},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, namespaceObjectKinds(objects), "LimitRange,NetworkPolicy,ResourceQuota")
+	assert.Equal(t, namespaceObjectKinds(nil), "")
+}
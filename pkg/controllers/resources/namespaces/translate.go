@@ -20,6 +20,14 @@ func (s *namespaceSyncer) translate(ctx context.Context, vObj client.Object) *co
 		newNamespace.Labels[k] = v
 	}
 
+	// record the kinds of the objects that are created from the templates
+	if s.namespaceObjectKinds != "" {
+		if newNamespace.Annotations == nil {
+			newNamespace.Annotations = map[string]string{}
+		}
+		newNamespace.Annotations[NamespaceObjectsAnnotation] = s.namespaceObjectKinds
+	}
+
 	return newNamespace
 }
 
@@ -34,6 +42,15 @@ func (s *namespaceSyncer) translateUpdate(ctx context.Context, pObj, vObj *corev
 	}
 	// set the kubernetes.io/metadata.name label
 	updatedLabels[corev1.LabelMetadataName] = pObj.Name
+	// record the kinds of the objects that are created from the templates
+	if s.namespaceObjectKinds != "" {
+		if updatedAnnotations == nil {
+			updatedAnnotations = map[string]string{}
+		}
+		updatedAnnotations[NamespaceObjectsAnnotation] = s.namespaceObjectKinds
+	} else {
+		delete(updatedAnnotations, NamespaceObjectsAnnotation)
+	}
 	// check if any labels or annotations changed
 	if !maps.Equal(updatedAnnotations, pObj.GetAnnotations()) || !maps.Equal(updatedLabels, pObj.GetLabels()) {
 		pObj.Annotations = updatedAnnotations
@@ -298,6 +298,7 @@ func (s *podSyncer) Sync(ctx *synccontext.SyncContext, pObj client.Object, vObj
 			}
 			vPod.Spec.EphemeralContainers[i].Env = envVar
 			vPod.Spec.EphemeralContainers[i].EnvFrom = envFrom
+			vPod.Spec.EphemeralContainers[i].Image = s.podTranslator.TranslateImage(ctx, vPod.Spec.EphemeralContainers[i].Image)
 		}
 
 		// add ephemeralContainers subresource to physical pod
@@ -361,10 +362,8 @@ func syncEphemeralContainers(vPod *corev1.Pod, pPod *corev1.Pod) bool {
 	if len(vPod.Spec.EphemeralContainers) != len(pPod.Spec.EphemeralContainers) {
 		return true
 	}
+	// images are not compared as ephemeral containers cannot be changed and the host image might be translated
 	for i := range vPod.Spec.EphemeralContainers {
-		if vPod.Spec.EphemeralContainers[i].Image != pPod.Spec.EphemeralContainers[i].Image {
-			return true
-		}
 		if vPod.Spec.EphemeralContainers[i].Name != pPod.Spec.EphemeralContainers[i].Name {
 			return true
 		}
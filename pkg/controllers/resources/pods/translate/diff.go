@@ -15,6 +15,8 @@ func (t *translator) Diff(ctx context.Context, vPod, pPod *corev1.Pod) error {
 	// has status changed?
 	oldVPodStatus := vPod.Status.DeepCopy()
 	vPod.Status = *pPod.Status.DeepCopy()
+	// claims generated from templates are tracked by the virtual controller manager and have different names in the host cluster
+	vPod.Status.ResourceClaimStatuses = oldVPodStatus.ResourceClaimStatuses
 	stripInjectedSidecarContainers(vPod, pPod)
 	updateConditions(pPod, vPod, oldVPodStatus)
 
@@ -26,7 +28,7 @@ func (t *translator) Diff(ctx context.Context, vPod, pPod *corev1.Pod) error {
 	}
 
 	// spec diff
-	t.calcSpecDiff(pPod, vPod)
+	t.calcSpecDiff(ctx, pPod, vPod)
 
 	// check annotations
 	_, updatedAnnotations, updatedLabels := translate.Default.ApplyMetadataUpdate(vPod, pPod, t.syncedLabels, getExcludedAnnotations(pPod)...)
@@ -63,7 +65,7 @@ func (t *translator) Diff(ctx context.Context, vPod, pPod *corev1.Pod) error {
 }
 
 func getExcludedAnnotations(pPod *corev1.Pod) []string {
-	annotations := []string{ClusterAutoScalerAnnotation, OwnerReferences, OwnerSetKind, NamespaceAnnotation, NameAnnotation, UIDAnnotation, ServiceAccountNameAnnotation, HostsRewrittenAnnotation, VClusterLabelsAnnotation}
+	annotations := []string{ClusterAutoScalerAnnotation, OwnerReferences, OwnerSetKind, NamespaceAnnotation, NameAnnotation, UIDAnnotation, ServiceAccountNameAnnotation, HostsRewrittenAnnotation, VClusterLabelsAnnotation, OriginalImagesAnnotation}
 	if pPod != nil {
 		for _, v := range pPod.Spec.Volumes {
 			if v.Projected != nil {
@@ -95,12 +97,13 @@ func getExcludedAnnotations(pPod *corev1.Pod) []string {
 // - spec.activeDeadlineSeconds
 //
 // TODO: check for ephemereal containers
-func (t *translator) calcSpecDiff(pObj, vObj *corev1.Pod) {
+func (t *translator) calcSpecDiff(ctx context.Context, pObj, vObj *corev1.Pod) {
 	// active deadlines different?
 	pObj.Spec.ActiveDeadlineSeconds = vObj.Spec.ActiveDeadlineSeconds
 
 	// is image different?
-	updatedContainer := calcContainerImageDiff(pObj.Spec.Containers, vObj.Spec.Containers, t.imageTranslator, nil)
+	originalImages := getOriginalImages(pObj)
+	updatedContainer := calcContainerImageDiff(ctx, pObj.Spec.Containers, vObj.Spec.Containers, t.imageTranslator, originalImages, nil)
 	if len(updatedContainer) != 0 {
 		pObj.Spec.Containers = updatedContainer
 	}
@@ -113,15 +116,16 @@ func (t *translator) calcSpecDiff(pObj, vObj *corev1.Pod) {
 		}
 	}
 
-	updatedContainer = calcContainerImageDiff(pObj.Spec.InitContainers, vObj.Spec.InitContainers, t.imageTranslator, skipContainers)
+	updatedContainer = calcContainerImageDiff(ctx, pObj.Spec.InitContainers, vObj.Spec.InitContainers, t.imageTranslator, originalImages, skipContainers)
 	if len(updatedContainer) != 0 {
 		pObj.Spec.InitContainers = updatedContainer
 	}
+	setOriginalImages(pObj, vObj)
 
 	pObj.Spec.SchedulingGates = vObj.Spec.SchedulingGates
 }
 
-func calcContainerImageDiff(pContainers, vContainers []corev1.Container, translateImages ImageTranslator, skipContainers map[string]bool) []corev1.Container {
+func calcContainerImageDiff(ctx context.Context, pContainers, vContainers []corev1.Container, translateImages ImageTranslator, originalImages map[string]string, skipContainers map[string]bool) []corev1.Container {
 	newContainers := []corev1.Container{}
 	changed := false
 	for _, p := range pContainers {
@@ -132,9 +136,15 @@ func calcContainerImageDiff(pContainers, vContainers []corev1.Container, transla
 
 		for _, v := range vContainers {
 			if p.Name == v.Name {
-				if p.Image != translateImages.Translate(v.Image) {
+				// keep the host image if the virtual image was not changed, as a digest could resolve differently now
+				if originalImages[p.Name] == v.Image {
+					newContainers = append(newContainers, p)
+					break
+				}
+
+				if translatedImage := translateImages.Translate(ctx, v.Image); p.Image != translatedImage {
 					newContainer := *p.DeepCopy()
-					newContainer.Image = translateImages.Translate(v.Image)
+					newContainer.Image = translatedImage
 					newContainers = append(newContainers, newContainer)
 					changed = true
 				} else {
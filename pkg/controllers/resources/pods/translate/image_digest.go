@@ -0,0 +1,245 @@
+package translate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/loft-sh/vcluster/pkg/util/loghelper"
+)
+
+const (
+	dockerHubRegistryHost = "registry-1.docker.io"
+	dockerHubAuthHost     = "auth.docker.io"
+)
+
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+const (
+	// failedDigestCacheTTL is how long an image that couldn't be resolved is not queried again
+	failedDigestCacheTTL = 5 * time.Minute
+
+	// digestResolutionTimeout bounds the time it takes to resolve a single image including the token request
+	digestResolutionTimeout = 10 * time.Second
+)
+
+// digestResolver resolves image tags to digests with the registry v2 api and caches the results
+type digestResolver struct {
+	client     *http.Client
+	cacheTTL   time.Duration
+	registries map[string]bool
+	insecure   map[string]bool
+
+	log loghelper.Logger
+
+	cacheMutex sync.Mutex
+	cache      map[string]cachedDigest
+}
+
+// cachedDigest holds a resolved digest or the error of a failed resolution
+type cachedDigest struct {
+	digest  string
+	err     error
+	expires time.Time
+}
+
+func newDigestResolver(cacheTTL time.Duration, registries, insecureRegistries []string) *digestResolver {
+	return &digestResolver{
+		client:     &http.Client{Timeout: digestResolutionTimeout},
+		cacheTTL:   cacheTTL,
+		registries: toSet(registries),
+		insecure:   toSet(insecureRegistries),
+		log:        loghelper.New("image-digest-resolver"),
+		cache:      map[string]cachedDigest{},
+	}
+}
+
+func toSet(values []string) map[string]bool {
+	set := map[string]bool{}
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
+
+// Resolves returns true if the tags of the registry of the given image are resolved. Only the configured
+// registries are queried, as the virtual cluster could otherwise make vCluster send requests to any host.
+func (d *digestResolver) Resolves(ref imageReference) bool {
+	return d.registries[ref.Normalized().Registry]
+}
+
+// Resolve returns the digest the tag of the given image points to. Failures are cached as well, so images that
+// cannot be resolved don't slow down every reconcile.
+func (d *digestResolver) Resolve(ctx context.Context, ref imageReference) (string, error) {
+	ref = ref.Normalized()
+	if ref.Tag == "" {
+		ref.Tag = "latest"
+	}
+
+	key := ref.String()
+	d.cacheMutex.Lock()
+	cached, ok := d.cache[key]
+	d.cacheMutex.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.digest, cached.err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, digestResolutionTimeout)
+	defer cancel()
+
+	digest, err := d.fetchDigest(ctx, ref)
+	if err != nil {
+		err = fmt.Errorf("resolve digest of %s: %w", key, err)
+		d.log.Infof("keep tag of image %s: %v", key, err)
+		cached = cachedDigest{err: err, expires: time.Now().Add(failedDigestCacheTTL)}
+	} else {
+		cached = cachedDigest{digest: digest, expires: time.Now().Add(d.cacheTTL)}
+	}
+
+	d.cacheMutex.Lock()
+	d.cache[key] = cached
+	d.cacheMutex.Unlock()
+	return cached.digest, cached.err
+}
+
+func (d *digestResolver) fetchDigest(ctx context.Context, ref imageReference) (string, error) {
+	host := ref.Registry
+	if host == defaultImageRegistry {
+		host = dockerHubRegistryHost
+	}
+	scheme := "https"
+	if d.insecure[ref.Registry] {
+		scheme = "http"
+	}
+	manifestURL := fmt.Sprintf("%s://%s/v2/%s/manifests/%s", scheme, host, ref.Repository, ref.Tag)
+
+	resp, err := d.headManifest(ctx, manifestURL, "")
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		token, err := d.fetchToken(ctx, host, resp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return "", err
+		}
+
+		resp, err = d.headManifest(ctx, manifestURL, token)
+		if err != nil {
+			return "", err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, manifestURL)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", fmt.Errorf("registry did not return a digest for %s", manifestURL)
+	}
+
+	return digest, nil
+}
+
+func (d *digestResolver) headManifest(ctx context.Context, manifestURL, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+	return resp, nil
+}
+
+// fetchToken retrieves an anonymous bearer token for the challenge returned by the registry. The token realm
+// has to be served by the registry host itself, except for docker hub, which uses auth.docker.io.
+func (d *digestResolver) fetchToken(ctx context.Context, host, challenge string) (string, error) {
+	params := parseBearerChallenge(challenge)
+	if params["realm"] == "" {
+		return "", fmt.Errorf("registry requires unsupported authentication %q", challenge)
+	}
+
+	tokenURL, err := url.Parse(params["realm"])
+	if err != nil {
+		return "", fmt.Errorf("parse token realm: %w", err)
+	} else if tokenURL.Host != host && (host != dockerHubRegistryHost || tokenURL.Host != dockerHubAuthHost) {
+		return "", fmt.Errorf("token realm %s doesn't belong to registry %s", params["realm"], host)
+	}
+	query := tokenURL.Query()
+	for _, key := range []string{"service", "scope"} {
+		if params[key] != "" {
+			query.Set(key, params[key])
+		}
+	}
+	tokenURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("get token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, params["realm"])
+	}
+
+	tokenResponse := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	err = json.NewDecoder(resp.Body).Decode(&tokenResponse)
+	if err != nil {
+		return "", fmt.Errorf("decode token: %w", err)
+	}
+	if tokenResponse.Token != "" {
+		return tokenResponse.Token, nil
+	}
+
+	return tokenResponse.AccessToken, nil
+}
+
+// parseBearerChallenge parses a header like Bearer realm="https://auth.docker.io/token",service="registry.docker.io"
+func parseBearerChallenge(challenge string) map[string]string {
+	params := map[string]string{}
+	scheme, rest, found := strings.Cut(challenge, " ")
+	if !found || !strings.EqualFold(scheme, "bearer") {
+		return params
+	}
+
+	for rest != "" {
+		var key, value string
+		key, rest, found = strings.Cut(strings.TrimLeft(rest, ", "), "=")
+		if !found {
+			break
+		}
+
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		params[strings.ToLower(strings.TrimSpace(key))] = value
+	}
+
+	return params
+}
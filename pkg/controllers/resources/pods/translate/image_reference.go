@@ -0,0 +1,65 @@
+package translate
+
+import "strings"
+
+const (
+	defaultImageRegistry = "docker.io"
+	officialImagePrefix  = "library/"
+)
+
+// imageReference is an image split into registry host, repository, tag and digest
+type imageReference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// parseImageReference splits the given image with the same rules the container runtimes use, where the first
+// path component is the registry host if it contains a dot or port or is localhost
+func parseImageReference(image string) imageReference {
+	ref := imageReference{}
+	rest := image
+	if idx := strings.Index(rest, "@"); idx >= 0 {
+		ref.Digest = rest[idx+1:]
+		rest = rest[:idx]
+	}
+	if idx := strings.LastIndex(rest, ":"); idx >= 0 && !strings.Contains(rest[idx+1:], "/") {
+		ref.Tag = rest[idx+1:]
+		rest = rest[:idx]
+	}
+	if idx := strings.Index(rest, "/"); idx >= 0 && (strings.ContainsAny(rest[:idx], ".:") || rest[:idx] == "localhost") {
+		ref.Registry = rest[:idx]
+		rest = rest[idx+1:]
+	}
+
+	ref.Repository = rest
+	return ref
+}
+
+// Normalized returns the fully qualified reference, e.g. docker.io/library/nginx:1.25 for nginx:1.25
+func (r imageReference) Normalized() imageReference {
+	if r.Registry == "" || r.Registry == "index.docker.io" {
+		r.Registry = defaultImageRegistry
+	}
+	if r.Registry == defaultImageRegistry && !strings.Contains(r.Repository, "/") {
+		r.Repository = officialImagePrefix + r.Repository
+	}
+
+	return r
+}
+
+func (r imageReference) String() string {
+	image := r.Repository
+	if r.Registry != "" {
+		image = r.Registry + "/" + image
+	}
+	if r.Tag != "" {
+		image += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		image += "@" + r.Digest
+	}
+
+	return image
+}
@@ -1,24 +1,199 @@
 package translate
 
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/vcluster/config"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// OriginalImagesAnnotation holds the virtual images of the host pod containers that were rewritten by container name
+const OriginalImagesAnnotation = "vcluster.loft.sh/original-images"
+
+const defaultDigestCacheTTL = time.Hour
+
 type ImageTranslator interface {
-	Translate(image string) string
+	Translate(ctx context.Context, image string) string
 }
 
 type imageTranslator struct {
 	translateImages map[string]string
+	rules           []imageRewriteRule
+	registryMirrors map[string]string
+	digestResolver  *digestResolver
 }
 
-func NewImageTranslator(translateImages map[string]string) (ImageTranslator, error) {
+type imageRewriteRule struct {
+	from *regexp.Regexp
+	to   string
+}
+
+func NewImageTranslator(translateImages map[string]string, imageTranslation config.SyncPodsImageTranslation) (ImageTranslator, error) {
+	rules := []imageRewriteRule{}
+	for idx, rule := range imageTranslation.Rules {
+		from, err := compileImagePattern(rule.From)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", idx, err)
+		}
+
+		rules = append(rules, imageRewriteRule{from: from, to: rule.To})
+	}
+
+	var resolver *digestResolver
+	if imageTranslation.ResolveDigests.Enabled {
+		cacheTTL := defaultDigestCacheTTL
+		if imageTranslation.ResolveDigests.CacheTTL != "" {
+			var err error
+			cacheTTL, err = time.ParseDuration(imageTranslation.ResolveDigests.CacheTTL)
+			if err != nil {
+				return nil, fmt.Errorf("parse digest cache ttl: %w", err)
+			}
+		}
+
+		// only the configured registries are resolved, which are the targets of the mirrors and rules and the explicitly listed ones
+		registries := append([]string{}, imageTranslation.ResolveDigests.Registries...)
+		for _, mirror := range imageTranslation.RegistryMirrors {
+			registries = append(registries, parseImageReference(strings.TrimSuffix(mirror, "/")+"/image").Normalized().Registry)
+		}
+		for _, rule := range imageTranslation.Rules {
+			registries = append(registries, parseImageReference(rule.To).Normalized().Registry)
+		}
+
+		resolver = newDigestResolver(cacheTTL, registries, imageTranslation.ResolveDigests.InsecureRegistries)
+	}
+
 	return &imageTranslator{
 		translateImages: translateImages,
+		rules:           rules,
+		registryMirrors: imageTranslation.RegistryMirrors,
+		digestResolver:  resolver,
 	}, nil
 }
 
-func (i *imageTranslator) Translate(image string) string {
+// compileImagePattern compiles an image pattern where each * matches any characters
+func compileImagePattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, fmt.Errorf("image pattern is empty")
+	}
+
+	parts := strings.Split(pattern, "*")
+	for i := range parts {
+		parts[i] = regexp.QuoteMeta(parts[i])
+	}
+
+	return regexp.Compile("^" + strings.Join(parts, "(.*)") + "$")
+}
+
+func (i *imageTranslator) Translate(ctx context.Context, image string) string {
 	out, ok := i.translateImages[image]
-	if ok {
-		return out
+	if !ok {
+		out = i.rewrite(image)
+	}
+
+	normalized := parseImageReference(out).Normalized()
+	if mirror := i.registryMirrors[normalized.Registry]; mirror != "" {
+		normalized.Registry = ""
+		normalized.Repository = strings.TrimSuffix(mirror, "/") + "/" + normalized.Repository
+		out = normalized.String()
+	}
+
+	if i.digestResolver != nil {
+		ref := parseImageReference(out)
+		if ref.Digest != "" || !i.digestResolver.Resolves(ref) {
+			return out
+		}
+
+		// failures are logged by the resolver
+		digest, err := i.digestResolver.Resolve(ctx, ref)
+		if err != nil {
+			return out
+		}
+
+		ref.Digest = digest
+		out = ref.String()
+	}
+
+	return out
+}
+
+// rewrite applies the first rule that matches the image as written or its fully qualified form
+func (i *imageTranslator) rewrite(image string) string {
+	candidates := []string{image}
+	if normalized := parseImageReference(image).Normalized().String(); normalized != image {
+		candidates = append(candidates, normalized)
+	}
+
+	for _, rule := range i.rules {
+		for _, candidate := range candidates {
+			matches := rule.from.FindStringSubmatch(candidate)
+			if matches == nil {
+				continue
+			}
+
+			out := rule.to
+			for _, match := range matches[1:] {
+				out = strings.Replace(out, "*", match, 1)
+			}
+			return out
+		}
 	}
 
 	return image
 }
+
+// getOriginalImages returns the virtual images recorded on the host pod by container name
+func getOriginalImages(pPod *corev1.Pod) map[string]string {
+	originalImages := map[string]string{}
+	if pPod.Annotations == nil || pPod.Annotations[OriginalImagesAnnotation] == "" {
+		return originalImages
+	}
+
+	_ = json.Unmarshal([]byte(pPod.Annotations[OriginalImagesAnnotation]), &originalImages)
+	return originalImages
+}
+
+// setOriginalImages records the virtual images of all host pod containers whose image was rewritten
+func setOriginalImages(pPod, vPod *corev1.Pod) {
+	vImages := map[string]string{}
+	for _, container := range vPod.Spec.InitContainers {
+		vImages[container.Name] = container.Image
+	}
+	for _, container := range vPod.Spec.Containers {
+		vImages[container.Name] = container.Image
+	}
+	for _, container := range vPod.Spec.EphemeralContainers {
+		vImages[container.Name] = container.Image
+	}
+
+	originalImages := map[string]string{}
+	record := func(name, image string) {
+		if vImage, ok := vImages[name]; ok && vImage != image {
+			originalImages[name] = vImage
+		}
+	}
+	for _, container := range pPod.Spec.InitContainers {
+		record(container.Name, container.Image)
+	}
+	for _, container := range pPod.Spec.Containers {
+		record(container.Name, container.Image)
+	}
+	for _, container := range pPod.Spec.EphemeralContainers {
+		record(container.Name, container.Image)
+	}
+
+	if len(originalImages) == 0 {
+		delete(pPod.Annotations, OriginalImagesAnnotation)
+		return
+	}
+
+	out, _ := json.Marshal(originalImages)
+	if pPod.Annotations == nil {
+		pPod.Annotations = map[string]string{}
+	}
+	pPod.Annotations[OriginalImagesAnnotation] = string(out)
+}
@@ -0,0 +1,194 @@
+package translate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/vcluster/config"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseImageReference(t *testing.T) {
+	testCases := map[string]imageReference{
+		"nginx":                               {Repository: "nginx"},
+		"nginx:1.25":                          {Repository: "nginx", Tag: "1.25"},
+		"bitnami/redis:7@sha256:abc":          {Repository: "bitnami/redis", Tag: "7", Digest: "sha256:abc"},
+		"localhost/app":                       {Registry: "localhost", Repository: "app"},
+		"registry.example.com:5000/team/app":  {Registry: "registry.example.com:5000", Repository: "team/app"},
+		"registry.example.com:5000/app:1.0.0": {Registry: "registry.example.com:5000", Repository: "app", Tag: "1.0.0"},
+	}
+	for image, expected := range testCases {
+		ref := parseImageReference(image)
+		assert.DeepEqual(t, ref, expected)
+		assert.Equal(t, ref.String(), image)
+	}
+
+	assert.Equal(t, parseImageReference("nginx:1.25").Normalized().String(), "docker.io/library/nginx:1.25")
+	assert.Equal(t, parseImageReference("index.docker.io/bitnami/redis").Normalized().String(), "docker.io/bitnami/redis")
+	assert.Equal(t, parseImageReference("ghcr.io/loft-sh/vcluster").Normalized().String(), "ghcr.io/loft-sh/vcluster")
+}
+
+func TestImageTranslator(t *testing.T) {
+	imageTranslator, err := NewImageTranslator(map[string]string{
+		"nginx:1.25": "mirror.example.com/nginx:1.25-patched",
+	}, config.SyncPodsImageTranslation{
+		Rules: []config.ImageRewriteRule{
+			{From: "ghcr.io/loft-sh/*", To: "cache.example.com/loft/*"},
+			{From: "docker.io/library/*:*-alpine", To: "cache.example.com/alpine/*:*"},
+			{From: "busybox", To: "registry.example.com/busybox:stable"},
+		},
+		RegistryMirrors: map[string]string{
+			"docker.io": "harbor.example.com/docker-hub/",
+		},
+	})
+	assert.NilError(t, err)
+
+	ctx := context.Background()
+	testCases := map[string]string{
+		// exact matches take precedence
+		"nginx:1.25": "mirror.example.com/nginx:1.25-patched",
+		// prefix rules
+		"ghcr.io/loft-sh/vcluster:0.20": "cache.example.com/loft/vcluster:0.20",
+		// wildcard rules match the fully qualified image
+		"redis:7-alpine": "cache.example.com/alpine/redis:7",
+		"busybox":        "registry.example.com/busybox:stable",
+		// registry mirrors
+		"nginx:1.26":                       "harbor.example.com/docker-hub/library/nginx:1.26",
+		"bitnami/redis@sha256:a":           "harbor.example.com/docker-hub/bitnami/redis@sha256:a",
+		"quay.io/prometheus/node-exporter": "quay.io/prometheus/node-exporter",
+	}
+	for image, expected := range testCases {
+		assert.Equal(t, imageTranslator.Translate(ctx, image), expected, image)
+	}
+
+	_, err = NewImageTranslator(nil, config.SyncPodsImageTranslation{Rules: []config.ImageRewriteRule{{To: "a"}}})
+	assert.ErrorContains(t, err, "image pattern is empty")
+}
+
+func TestImageDigestResolution(t *testing.T) {
+	const digest = "sha256:0123456789abcdef"
+	requests := map[string]int{}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		switch {
+		case r.URL.Path == "/token":
+			assert.Equal(t, r.URL.Query().Get("scope"), "repository:team/app:pull")
+			_, _ = w.Write([]byte(`{"token": "secret"}`))
+		case r.URL.Path == "/v2/team/app/manifests/1.0":
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="`+server.URL+`/token",service="registry",scope="repository:team/app:pull"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			assert.Assert(t, strings.Contains(r.Header.Get("Accept"), "application/vnd.oci.image.index.v1+json"))
+			w.Header().Set("Docker-Content-Digest", digest)
+		case r.URL.Path == "/v2/team/external-auth/manifests/1.0":
+			w.Header().Set("WWW-Authenticate", `Bearer realm="http://auth.example.com/token",service="registry"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	registry := strings.TrimPrefix(server.URL, "http://")
+
+	imageTranslator, err := NewImageTranslator(nil, config.SyncPodsImageTranslation{
+		Rules: []config.ImageRewriteRule{{From: "example.com/*", To: registry + "/*"}},
+		ResolveDigests: config.ImageDigestResolution{
+			Enabled:            true,
+			InsecureRegistries: []string{registry},
+		},
+	})
+	assert.NilError(t, err)
+
+	// tags are pinned after the rewrite and the digest is cached
+	ctx := context.Background()
+	assert.Equal(t, imageTranslator.Translate(ctx, "example.com/team/app:1.0"), registry+"/team/app:1.0@"+digest)
+	assert.Equal(t, imageTranslator.Translate(ctx, registry+"/team/app:1.0"), registry+"/team/app:1.0@"+digest)
+	assert.Equal(t, requests["/v2/team/app/manifests/1.0"], 2)
+
+	// images that cannot be resolved or already have a digest are kept and failures are cached
+	assert.Equal(t, imageTranslator.Translate(ctx, registry+"/team/other:1.0"), registry+"/team/other:1.0")
+	assert.Equal(t, imageTranslator.Translate(ctx, registry+"/team/other:1.0"), registry+"/team/other:1.0")
+	assert.Equal(t, requests["/v2/team/other/manifests/1.0"], 1)
+	assert.Equal(t, imageTranslator.Translate(ctx, registry+"/team/app:1.0@sha256:fff"), registry+"/team/app:1.0@sha256:fff")
+
+	// tokens are only requested from the registry itself
+	assert.Equal(t, imageTranslator.Translate(ctx, registry+"/team/external-auth:1.0"), registry+"/team/external-auth:1.0")
+	assert.Equal(t, requests["/token"], 1)
+
+	// registries that are not a mirror or rule target are never queried
+	otherTranslator, err := NewImageTranslator(nil, config.SyncPodsImageTranslation{
+		ResolveDigests: config.ImageDigestResolution{Enabled: true, InsecureRegistries: []string{registry}},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, otherTranslator.Translate(ctx, registry+"/team/app:2.0"), registry+"/team/app:2.0")
+	assert.Equal(t, requests["/v2/team/app/manifests/2.0"], 0)
+
+	// explicitly listed registries are resolved as well
+	listedTranslator, err := NewImageTranslator(nil, config.SyncPodsImageTranslation{
+		ResolveDigests: config.ImageDigestResolution{Enabled: true, Registries: []string{registry}, InsecureRegistries: []string{registry}},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, listedTranslator.Translate(ctx, registry+"/team/app:1.0"), registry+"/team/app:1.0@"+digest)
+}
+
+func TestDigestResolverRegistries(t *testing.T) {
+	translator, err := NewImageTranslator(nil, config.SyncPodsImageTranslation{
+		Rules:           []config.ImageRewriteRule{{From: "ghcr.io/loft-sh/*", To: "cache.example.com/loft/*"}},
+		RegistryMirrors: map[string]string{"docker.io": "harbor.example.com/docker-hub/", "quay.io": "mirror.example.com"},
+		ResolveDigests:  config.ImageDigestResolution{Enabled: true, Registries: []string{"ghcr.io"}},
+	})
+	assert.NilError(t, err)
+
+	resolver := translator.(*imageTranslator).digestResolver
+	for image, expected := range map[string]bool{
+		"cache.example.com/loft/vcluster":          true,
+		"harbor.example.com/docker-hub/nginx":      true,
+		"mirror.example.com/prometheus/prometheus": true,
+		"ghcr.io/loft-sh/vcluster":                 true,
+		"nginx":                                    false,
+		"169.254.169.254/latest/meta-data":         false,
+	} {
+		assert.Equal(t, resolver.Resolves(parseImageReference(image)), expected, image)
+	}
+}
+
+func TestOriginalImages(t *testing.T) {
+	imageTranslator, err := NewImageTranslator(nil, config.SyncPodsImageTranslation{
+		RegistryMirrors: map[string]string{"docker.io": "mirror.example.com"},
+	})
+	assert.NilError(t, err)
+	tr := &translator{imageTranslator: imageTranslator}
+
+	vPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "init", Image: "busybox"}},
+			Containers:     []corev1.Container{{Name: "app", Image: "nginx:1.25"}, {Name: "sidecar", Image: "ghcr.io/loft-sh/sidecar"}},
+		},
+	}
+	pPod := vPod.DeepCopy()
+	pPod.Spec.InitContainers[0].Image = "mirror.example.com/library/busybox"
+	pPod.Spec.Containers[0].Image = "mirror.example.com/library/nginx:1.25"
+	pPod.Spec.EphemeralContainers = []corev1.EphemeralContainer{{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debug", Image: "mirror.example.com/library/alpine"}}}
+	vPod.Spec.EphemeralContainers = []corev1.EphemeralContainer{{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debug", Image: "alpine"}}}
+
+	setOriginalImages(pPod, vPod)
+	assert.DeepEqual(t, getOriginalImages(pPod), map[string]string{"init": "busybox", "app": "nginx:1.25", "debug": "alpine"})
+
+	// unchanged virtual images keep the host image, even if it differs from the current translation
+	pPod.Spec.Containers[0].Image = "mirror.example.com/library/nginx:1.25@sha256:abc"
+	vPod.Spec.InitContainers[0].Image = "busybox:1.36"
+	tr.calcSpecDiff(context.Background(), pPod, vPod)
+	assert.Equal(t, pPod.Spec.Containers[0].Image, "mirror.example.com/library/nginx:1.25@sha256:abc")
+	assert.Equal(t, pPod.Spec.InitContainers[0].Image, "mirror.example.com/library/busybox:1.36")
+	assert.DeepEqual(t, getOriginalImages(pPod), map[string]string{"init": "busybox:1.36", "app": "nginx:1.25", "debug": "alpine"})
+}
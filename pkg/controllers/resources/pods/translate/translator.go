@@ -56,12 +56,13 @@ type Translator interface {
 	Diff(ctx context.Context, vPod, pPod *corev1.Pod) error
 
 	TranslateContainerEnv(ctx context.Context, envVar []corev1.EnvVar, envFrom []corev1.EnvFromSource, vPod *corev1.Pod, serviceEnvMap map[string]string) ([]corev1.EnvVar, []corev1.EnvFromSource, error)
+	TranslateImage(ctx context.Context, image string) string
 }
 
 func NewTranslator(ctx *synccontext.RegisterContext, eventRecorder record.EventRecorder) (Translator, error) {
-	imageTranslator, err := NewImageTranslator(ctx.Config.Sync.ToHost.Pods.TranslateImage)
+	imageTranslator, err := NewImageTranslator(ctx.Config.Sync.ToHost.Pods.TranslateImage, ctx.Config.Sync.ToHost.Pods.ImageTranslation)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("image translation: %w", err)
 	}
 
 	name := ctx.Config.Name
@@ -94,7 +95,7 @@ func NewTranslator(ctx *synccontext.RegisterContext, eventRecorder record.EventR
 
 		defaultImageRegistry: ctx.Config.ControlPlane.Advanced.DefaultImageRegistry,
 
-		multiNamespaceMode: ctx.Config.Experimental.MultiNamespaceMode.Enabled,
+		multiNamespaceMode: ctx.Config.Sync.ToHost.Namespaces.Enabled,
 
 		serviceAccountSecretsEnabled: ctx.Config.Sync.ToHost.Pods.UseSecretsForSATokens,
 		clusterDomain:                ctx.Config.Networking.Advanced.ClusterDomain,
@@ -109,6 +110,9 @@ func NewTranslator(ctx *synccontext.RegisterContext, eventRecorder record.EventR
 		enableScheduler:        ctx.Config.ControlPlane.Advanced.VirtualScheduler.Enabled,
 		syncedLabels:           ctx.Config.Experimental.SyncSettings.SyncLabels,
 
+		resourceClaimsEnabled:         ctx.Config.Sync.ToHost.ResourceClaims.Enabled,
+		resourceClaimTemplatesEnabled: ctx.Config.Sync.ToHost.ResourceClaimTemplates.Enabled,
+
 		mountPhysicalHostPaths: ctx.Config.ControlPlane.HostPathMapper.Enabled && !ctx.Config.ControlPlane.HostPathMapper.Central,
 
 		virtualLogsPath:       virtualLogsPath,
@@ -143,6 +147,9 @@ type translator struct {
 	enableScheduler              bool
 	syncedLabels                 []string
 
+	resourceClaimsEnabled         bool
+	resourceClaimTemplatesEnabled bool
+
 	virtualLogsPath       string
 	virtualPodLogsPath    string
 	virtualKubeletPodPath string
@@ -191,6 +198,9 @@ func (t *translator) Translate(ctx context.Context, vPod *corev1.Pod, services [
 		}
 	}
 
+	// translate dynamic resource allocation claims
+	t.translateResourceClaims(ctx, pPod, vPod)
+
 	// Add an annotation for namespace, name and uid
 	if pPod.Annotations == nil {
 		pPod.Annotations = map[string]string{}
@@ -301,7 +311,7 @@ func (t *translator) Translate(ctx context.Context, vPod *corev1.Pod, services [
 		}
 		pPod.Spec.Containers[i].Env = envVar
 		pPod.Spec.Containers[i].EnvFrom = envFrom
-		pPod.Spec.Containers[i].Image = t.imageTranslator.Translate(pPod.Spec.Containers[i].Image)
+		pPod.Spec.Containers[i].Image = t.imageTranslator.Translate(ctx, pPod.Spec.Containers[i].Image)
 	}
 
 	// translate init containers
@@ -312,7 +322,7 @@ func (t *translator) Translate(ctx context.Context, vPod *corev1.Pod, services [
 		}
 		pPod.Spec.InitContainers[i].Env = envVar
 		pPod.Spec.InitContainers[i].EnvFrom = envFrom
-		pPod.Spec.InitContainers[i].Image = t.imageTranslator.Translate(pPod.Spec.InitContainers[i].Image)
+		pPod.Spec.InitContainers[i].Image = t.imageTranslator.Translate(ctx, pPod.Spec.InitContainers[i].Image)
 	}
 
 	// translate ephemeral containers
@@ -323,9 +333,12 @@ func (t *translator) Translate(ctx context.Context, vPod *corev1.Pod, services [
 		}
 		pPod.Spec.EphemeralContainers[i].Env = envVar
 		pPod.Spec.EphemeralContainers[i].EnvFrom = envFrom
-		pPod.Spec.EphemeralContainers[i].Image = t.imageTranslator.Translate(pPod.Spec.EphemeralContainers[i].Image)
+		pPod.Spec.EphemeralContainers[i].Image = t.imageTranslator.Translate(ctx, pPod.Spec.EphemeralContainers[i].Image)
 	}
 
+	// record the images that were rewritten
+	setOriginalImages(pPod, vPod)
+
 	// translate image pull secrets
 	for i := range pPod.Spec.ImagePullSecrets {
 		pPod.Spec.ImagePullSecrets[i].Name = mappings.VirtualToHostName(ctx, pPod.Spec.ImagePullSecrets[i].Name, vPod.Namespace, mappings.Secrets())
@@ -608,6 +621,10 @@ func translateFieldRef(fieldSelector *corev1.ObjectFieldSelector) {
 	}
 }
 
+func (t *translator) TranslateImage(ctx context.Context, image string) string {
+	return t.imageTranslator.Translate(ctx, image)
+}
+
 func (t *translator) TranslateContainerEnv(ctx context.Context, envVar []corev1.EnvVar, envFrom []corev1.EnvFromSource, vPod *corev1.Pod, serviceEnvMap map[string]string) ([]corev1.EnvVar, []corev1.EnvFromSource, error) {
 	envNameMap := make(map[string]struct{})
 	for j, env := range envVar {
@@ -815,6 +832,18 @@ func (t *translator) translatePodAffinityTerm(vPod *corev1.Pod, term corev1.PodA
 	return newAffinityTerm
 }
 
+func (t *translator) translateResourceClaims(ctx context.Context, pPod *corev1.Pod, vPod *corev1.Pod) {
+	for i := range pPod.Spec.ResourceClaims {
+		source := &pPod.Spec.ResourceClaims[i].Source
+		if t.resourceClaimsEnabled && source.ResourceClaimName != nil {
+			source.ResourceClaimName = ptr.To(mappings.VirtualToHostName(ctx, *source.ResourceClaimName, vPod.Namespace, mappings.ResourceClaims()))
+		}
+		if t.resourceClaimTemplatesEnabled && source.ResourceClaimTemplateName != nil {
+			source.ResourceClaimTemplateName = ptr.To(mappings.VirtualToHostName(ctx, *source.ResourceClaimTemplateName, vPod.Namespace, mappings.ResourceClaimTemplates()))
+		}
+	}
+}
+
 func translateTopologySpreadConstraints(vPod *corev1.Pod, pPod *corev1.Pod) {
 	for i := range pPod.Spec.TopologySpreadConstraints {
 		pPod.Spec.TopologySpreadConstraints[i].LabelSelector = translate.Default.TranslateLabelSelector(pPod.Spec.TopologySpreadConstraints[i].LabelSelector)
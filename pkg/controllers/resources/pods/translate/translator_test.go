@@ -15,6 +15,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 )
 
 func TestPodAffinityTermsTranslation(t *testing.T) {
@@ -236,6 +237,56 @@ type translatePodVolumesTestCase struct {
 	expectedVolumes []corev1.Volume
 }
 
+func TestResourceClaimsTranslation(t *testing.T) {
+	pClient := testingutil.NewFakeClient(scheme.Scheme)
+	vClient := testingutil.NewFakeClient(scheme.Scheme)
+	vConfig := generictesting.NewFakeConfig()
+	vConfig.Sync.ToHost.ResourceClaims.Enabled = true
+	vConfig.Sync.ToHost.ResourceClaimTemplates.Enabled = true
+	resources.MustRegisterMappings(generictesting.NewFakeRegisterContext(vConfig, pClient, vClient))
+	tr := &translator{
+		resourceClaimsEnabled:         true,
+		resourceClaimTemplatesEnabled: true,
+	}
+
+	vPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod-name",
+			Namespace: "test-ns",
+		},
+		Spec: corev1.PodSpec{
+			ResourceClaims: []corev1.PodResourceClaim{
+				{
+					Name:   "gpu",
+					Source: corev1.ClaimSource{ResourceClaimName: ptr.To("shared-gpu")},
+				},
+				{
+					Name:   "gpu-template",
+					Source: corev1.ClaimSource{ResourceClaimTemplateName: ptr.To("gpu-template")},
+				},
+			},
+		},
+	}
+
+	pPod := vPod.DeepCopy()
+	tr.translateResourceClaims(context.Background(), pPod, vPod)
+	assert.DeepEqual(t, pPod.Spec.ResourceClaims, []corev1.PodResourceClaim{
+		{
+			Name:   "gpu",
+			Source: corev1.ClaimSource{ResourceClaimName: ptr.To(translate.Default.PhysicalName("shared-gpu", "test-ns"))},
+		},
+		{
+			Name:   "gpu-template",
+			Source: corev1.ClaimSource{ResourceClaimTemplateName: ptr.To(translate.Default.PhysicalName("gpu-template", "test-ns"))},
+		},
+	})
+
+	// claims are kept as is if the syncers are disabled
+	pPod = vPod.DeepCopy()
+	(&translator{}).translateResourceClaims(context.Background(), pPod, vPod)
+	assert.DeepEqual(t, pPod.Spec.ResourceClaims, vPod.Spec.ResourceClaims)
+}
+
 func appendNamespacesToMatchExpressions(source *metav1.LabelSelector, namespaces ...string) *metav1.LabelSelector {
 	ls := source.DeepCopy()
 	ls.MatchExpressions = append(ls.MatchExpressions, metav1.LabelSelectorRequirement{
@@ -0,0 +1,73 @@
+package resourceclaims
+
+import (
+	"fmt"
+
+	"github.com/loft-sh/vcluster/pkg/controllers/syncer"
+	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	"github.com/loft-sh/vcluster/pkg/controllers/syncer/translator"
+	syncertypes "github.com/loft-sh/vcluster/pkg/controllers/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/mappings"
+	"github.com/loft-sh/vcluster/pkg/patcher"
+	resourcev1alpha2 "k8s.io/api/resource/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func New(ctx *synccontext.RegisterContext) (syncertypes.Object, error) {
+	return &resourceClaimSyncer{
+		GenericTranslator: translator.NewGenericTranslator(ctx, "resourceclaim", &resourcev1alpha2.ResourceClaim{}, mappings.ResourceClaims()),
+	}, nil
+}
+
+type resourceClaimSyncer struct {
+	syncertypes.GenericTranslator
+}
+
+var _ syncertypes.Syncer = &resourceClaimSyncer{}
+
+func (s *resourceClaimSyncer) SyncToHost(ctx *synccontext.SyncContext, vObj client.Object) (ctrl.Result, error) {
+	if ctx.IsDelete {
+		return syncer.DeleteVirtualObject(ctx, vObj, "host object was deleted")
+	}
+
+	// claims that were generated from a template for a pod are generated by the host cluster as well,
+	// because the pod references the synced template in the host cluster
+	if isGeneratedForPod(vObj) {
+		return ctrl.Result{}, nil
+	}
+
+	return s.SyncToHostCreate(ctx, vObj, s.translate(ctx, vObj.(*resourcev1alpha2.ResourceClaim)))
+}
+
+func (s *resourceClaimSyncer) Sync(ctx *synccontext.SyncContext, pObj client.Object, vObj client.Object) (_ ctrl.Result, retErr error) {
+	pResourceClaim := pObj.(*resourcev1alpha2.ResourceClaim)
+	vResourceClaim := vObj.(*resourcev1alpha2.ResourceClaim)
+
+	patch, err := patcher.NewSyncerPatcher(ctx, pResourceClaim, vResourceClaim)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("new syncer patcher: %w", err)
+	}
+	defer func() {
+		if err := patch.Patch(ctx, pResourceClaim, vResourceClaim); err != nil {
+			retErr = utilerrors.NewAggregate([]error{retErr, err})
+		}
+		if retErr != nil {
+			s.EventRecorder().Eventf(vObj, "Warning", "SyncError", "Error syncing: %v", retErr)
+		}
+	}()
+
+	err = s.translateUpdate(ctx, pResourceClaim, vResourceClaim)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func isGeneratedForPod(vObj client.Object) bool {
+	controller := metav1.GetControllerOf(vObj)
+	return controller != nil && controller.APIVersion == "v1" && controller.Kind == "Pod"
+}
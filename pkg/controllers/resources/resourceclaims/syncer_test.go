@@ -0,0 +1,149 @@
+package resourceclaims
+
+import (
+	"testing"
+
+	"github.com/loft-sh/vcluster/pkg/config"
+	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	generictesting "github.com/loft-sh/vcluster/pkg/controllers/syncer/testing"
+	testingutil "github.com/loft-sh/vcluster/pkg/util/testing"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	resourcev1alpha2 "k8s.io/api/resource/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestSync(t *testing.T) {
+	translate.Default = translate.NewSingleNamespaceTranslator(generictesting.DefaultTestTargetNamespace)
+	gvk := resourcev1alpha2.SchemeGroupVersion.WithKind("ResourceClaim")
+
+	vObjectMeta := metav1.ObjectMeta{
+		Name:            "gpu",
+		Namespace:       "default",
+		ResourceVersion: generictesting.FakeClientResourceVersion,
+	}
+	pObjectMeta := metav1.ObjectMeta{
+		Name:      translate.Default.PhysicalName("gpu", vObjectMeta.Namespace),
+		Namespace: generictesting.DefaultTestTargetNamespace,
+		Annotations: map[string]string{
+			translate.NameAnnotation:      vObjectMeta.Name,
+			translate.NamespaceAnnotation: vObjectMeta.Namespace,
+			translate.UIDAnnotation:       "",
+			translate.KindAnnotation:      gvk.String(),
+		},
+		Labels: map[string]string{
+			translate.NamespaceLabel: vObjectMeta.Namespace,
+			translate.MarkerLabel:    translate.VClusterName,
+		},
+		ResourceVersion: generictesting.FakeClientResourceVersion,
+	}
+
+	vClaim := &resourcev1alpha2.ResourceClaim{
+		ObjectMeta: vObjectMeta,
+		Spec: resourcev1alpha2.ResourceClaimSpec{
+			ResourceClassName: "gpu.example.com",
+			ParametersRef: &resourcev1alpha2.ResourceClaimParametersReference{
+				APIGroup: "gpu.resource.example.com",
+				Kind:     "GpuClaimParameters",
+				Name:     "single-gpu",
+			},
+		},
+	}
+	pClaim := &resourcev1alpha2.ResourceClaim{
+		ObjectMeta: pObjectMeta,
+		Spec: resourcev1alpha2.ResourceClaimSpec{
+			ResourceClassName: "gpu.example.com",
+			ParametersRef: &resourcev1alpha2.ResourceClaimParametersReference{
+				APIGroup: "gpu.resource.example.com",
+				Kind:     "GpuClaimParameters",
+				Name:     translate.Default.PhysicalName("single-gpu", vObjectMeta.Namespace),
+			},
+		},
+	}
+
+	vGeneratedClaim := vClaim.DeepCopy()
+	vGeneratedClaim.OwnerReferences = []metav1.OwnerReference{
+		{APIVersion: "v1", Kind: "Pod", Name: "test", UID: "123", Controller: &[]bool{true}[0]},
+	}
+
+	vPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: vObjectMeta.Namespace,
+			UID:       "virtual-pod-uid",
+		},
+	}
+	allocation := &resourcev1alpha2.AllocationResult{
+		ResourceHandles: []resourcev1alpha2.ResourceHandle{{DriverName: "gpu.resource.example.com", Data: "gpu-0"}},
+	}
+	pAllocatedClaim := pClaim.DeepCopy()
+	pAllocatedClaim.Status = resourcev1alpha2.ResourceClaimStatus{
+		DriverName: "gpu.resource.example.com",
+		Allocation: allocation,
+		ReservedFor: []resourcev1alpha2.ResourceClaimConsumerReference{
+			{Resource: "pods", Name: translate.Default.PhysicalName("test", vObjectMeta.Namespace), UID: "host-pod-uid"},
+			{Resource: "pods", Name: "unknown", UID: "unknown-pod-uid"},
+		},
+	}
+	vAllocatedClaim := vClaim.DeepCopy()
+	vAllocatedClaim.Status = resourcev1alpha2.ResourceClaimStatus{
+		DriverName: "gpu.resource.example.com",
+		Allocation: allocation,
+		ReservedFor: []resourcev1alpha2.ResourceClaimConsumerReference{
+			{Resource: "pods", Name: "test", UID: "virtual-pod-uid"},
+		},
+	}
+
+	generictesting.RunTestsWithContext(t, func(vConfig *config.VirtualClusterConfig, pClient *testingutil.FakeIndexClient, vClient *testingutil.FakeIndexClient) *synccontext.RegisterContext {
+		vConfig.Sync.ToHost.ResourceClaims.Enabled = true
+		return generictesting.NewFakeRegisterContext(vConfig, pClient, vClient)
+	}, []*generictesting.SyncTest{
+		{
+			Name:                "Create host resource claim",
+			InitialVirtualState: []runtime.Object{vClaim.DeepCopy()},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				gvk: {vClaim.DeepCopy()},
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				gvk: {pClaim.DeepCopy()},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := generictesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*resourceClaimSyncer).SyncToHost(syncCtx, vClaim.DeepCopy())
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:                "Skip resource claim generated for pod",
+			InitialVirtualState: []runtime.Object{vGeneratedClaim.DeepCopy()},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				gvk: {vGeneratedClaim.DeepCopy()},
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := generictesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*resourceClaimSyncer).SyncToHost(syncCtx, vGeneratedClaim.DeepCopy())
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:                 "Sync allocation back to virtual resource claim",
+			InitialVirtualState:  []runtime.Object{vClaim.DeepCopy(), vPod.DeepCopy()},
+			InitialPhysicalState: []runtime.Object{pAllocatedClaim.DeepCopy()},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				gvk: {vAllocatedClaim.DeepCopy()},
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				gvk: {pAllocatedClaim.DeepCopy()},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := generictesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*resourceClaimSyncer).Sync(syncCtx, pAllocatedClaim.DeepCopy(), vClaim.DeepCopy())
+				assert.NilError(t, err)
+			},
+		},
+	})
+}
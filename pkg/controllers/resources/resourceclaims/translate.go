@@ -0,0 +1,75 @@
+package resourceclaims
+
+import (
+	"fmt"
+
+	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	"github.com/loft-sh/vcluster/pkg/mappings"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	corev1 "k8s.io/api/core/v1"
+	resourcev1alpha2 "k8s.io/api/resource/v1alpha2"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (s *resourceClaimSyncer) translate(ctx *synccontext.SyncContext, vObj *resourcev1alpha2.ResourceClaim) *resourcev1alpha2.ResourceClaim {
+	pObj := s.TranslateMetadata(ctx, vObj).(*resourcev1alpha2.ResourceClaim)
+	pObj.Status = resourcev1alpha2.ResourceClaimStatus{}
+	TranslateSpec(&pObj.Spec, vObj.Namespace)
+	return pObj
+}
+
+// TranslateSpec rewrites the claim parameters reference to the host object, which needs to be synced
+// by the same name translation, e.g. as a custom resource
+func TranslateSpec(spec *resourcev1alpha2.ResourceClaimSpec, vNamespace string) {
+	if spec.ParametersRef != nil {
+		spec.ParametersRef.Name = translate.Default.PhysicalName(spec.ParametersRef.Name, vNamespace)
+	}
+}
+
+func (s *resourceClaimSyncer) translateUpdate(ctx *synccontext.SyncContext, pObj, vObj *resourcev1alpha2.ResourceClaim) error {
+	// the spec is immutable, so only metadata needs to be updated
+	_, updatedAnnotations, updatedLabels := s.TranslateMetadataUpdate(ctx, vObj, pObj)
+	pObj.Annotations = updatedAnnotations
+	pObj.Labels = updatedLabels
+
+	// the allocation happens in the host cluster
+	status, err := translateStatusBackwards(ctx, pObj)
+	if err != nil {
+		return err
+	}
+	vObj.Status = *status
+	return nil
+}
+
+// translateStatusBackwards copies the host status and rewrites the pods the claim is reserved for to the virtual pods
+func translateStatusBackwards(ctx *synccontext.SyncContext, pObj *resourcev1alpha2.ResourceClaim) (*resourcev1alpha2.ResourceClaimStatus, error) {
+	status := pObj.Status.DeepCopy()
+	status.ReservedFor = nil
+	for _, consumer := range pObj.Status.ReservedFor {
+		if consumer.APIGroup != "" || consumer.Resource != "pods" {
+			continue
+		}
+
+		vName := mappings.Pods().HostToVirtual(ctx, types.NamespacedName{Name: consumer.Name, Namespace: pObj.Namespace}, nil)
+		if vName.Name == "" {
+			continue
+		}
+
+		vPod := &corev1.Pod{}
+		err := ctx.VirtualClient.Get(ctx, vName, vPod)
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+
+			return nil, fmt.Errorf("get virtual pod %s: %w", vName.String(), err)
+		}
+
+		consumer.Name = vPod.Name
+		consumer.UID = vPod.UID
+		status.ReservedFor = append(status.ReservedFor, consumer)
+	}
+
+	return status, nil
+}
@@ -0,0 +1,58 @@
+package resourceclaimtemplates
+
+import (
+	"fmt"
+
+	"github.com/loft-sh/vcluster/pkg/controllers/syncer"
+	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	"github.com/loft-sh/vcluster/pkg/controllers/syncer/translator"
+	syncertypes "github.com/loft-sh/vcluster/pkg/controllers/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/mappings"
+	"github.com/loft-sh/vcluster/pkg/patcher"
+	resourcev1alpha2 "k8s.io/api/resource/v1alpha2"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func New(ctx *synccontext.RegisterContext) (syncertypes.Object, error) {
+	return &resourceClaimTemplateSyncer{
+		GenericTranslator: translator.NewGenericTranslator(ctx, "resourceclaimtemplate", &resourcev1alpha2.ResourceClaimTemplate{}, mappings.ResourceClaimTemplates()),
+	}, nil
+}
+
+type resourceClaimTemplateSyncer struct {
+	syncertypes.GenericTranslator
+}
+
+var _ syncertypes.Syncer = &resourceClaimTemplateSyncer{}
+
+func (s *resourceClaimTemplateSyncer) SyncToHost(ctx *synccontext.SyncContext, vObj client.Object) (ctrl.Result, error) {
+	if ctx.IsDelete {
+		return syncer.DeleteVirtualObject(ctx, vObj, "host object was deleted")
+	}
+
+	return s.SyncToHostCreate(ctx, vObj, s.translate(ctx, vObj.(*resourcev1alpha2.ResourceClaimTemplate)))
+}
+
+func (s *resourceClaimTemplateSyncer) Sync(ctx *synccontext.SyncContext, pObj client.Object, vObj client.Object) (_ ctrl.Result, retErr error) {
+	pResourceClaimTemplate := pObj.(*resourcev1alpha2.ResourceClaimTemplate)
+	vResourceClaimTemplate := vObj.(*resourcev1alpha2.ResourceClaimTemplate)
+
+	patch, err := patcher.NewSyncerPatcher(ctx, pResourceClaimTemplate, vResourceClaimTemplate)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("new syncer patcher: %w", err)
+	}
+	defer func() {
+		if err := patch.Patch(ctx, pResourceClaimTemplate, vResourceClaimTemplate); err != nil {
+			retErr = utilerrors.NewAggregate([]error{retErr, err})
+		}
+		if retErr != nil {
+			s.EventRecorder().Eventf(vObj, "Warning", "SyncError", "Error syncing: %v", retErr)
+		}
+	}()
+
+	s.translateUpdate(ctx, pResourceClaimTemplate, vResourceClaimTemplate)
+
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,111 @@
+package resourceclaimtemplates
+
+import (
+	"testing"
+
+	"github.com/loft-sh/vcluster/pkg/config"
+	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	generictesting "github.com/loft-sh/vcluster/pkg/controllers/syncer/testing"
+	testingutil "github.com/loft-sh/vcluster/pkg/util/testing"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"gotest.tools/assert"
+	resourcev1alpha2 "k8s.io/api/resource/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestSync(t *testing.T) {
+	translate.Default = translate.NewSingleNamespaceTranslator(generictesting.DefaultTestTargetNamespace)
+	gvk := resourcev1alpha2.SchemeGroupVersion.WithKind("ResourceClaimTemplate")
+
+	vObjectMeta := metav1.ObjectMeta{
+		Name:            "gpu-template",
+		Namespace:       "default",
+		ResourceVersion: generictesting.FakeClientResourceVersion,
+	}
+	pObjectMeta := metav1.ObjectMeta{
+		Name:      translate.Default.PhysicalName("gpu-template", vObjectMeta.Namespace),
+		Namespace: generictesting.DefaultTestTargetNamespace,
+		Annotations: map[string]string{
+			translate.NameAnnotation:      vObjectMeta.Name,
+			translate.NamespaceAnnotation: vObjectMeta.Namespace,
+			translate.UIDAnnotation:       "",
+			translate.KindAnnotation:      gvk.String(),
+		},
+		Labels: map[string]string{
+			translate.NamespaceLabel: vObjectMeta.Namespace,
+			translate.MarkerLabel:    translate.VClusterName,
+		},
+		ResourceVersion: generictesting.FakeClientResourceVersion,
+	}
+
+	vTemplate := &resourcev1alpha2.ResourceClaimTemplate{
+		ObjectMeta: vObjectMeta,
+		Spec: resourcev1alpha2.ResourceClaimTemplateSpec{
+			Spec: resourcev1alpha2.ResourceClaimSpec{
+				ResourceClassName: "gpu.example.com",
+				ParametersRef: &resourcev1alpha2.ResourceClaimParametersReference{
+					APIGroup: "gpu.resource.example.com",
+					Kind:     "GpuClaimParameters",
+					Name:     "single-gpu",
+				},
+			},
+		},
+	}
+	pTemplate := &resourcev1alpha2.ResourceClaimTemplate{
+		ObjectMeta: pObjectMeta,
+		Spec: resourcev1alpha2.ResourceClaimTemplateSpec{
+			Spec: resourcev1alpha2.ResourceClaimSpec{
+				ResourceClassName: "gpu.example.com",
+				ParametersRef: &resourcev1alpha2.ResourceClaimParametersReference{
+					APIGroup: "gpu.resource.example.com",
+					Kind:     "GpuClaimParameters",
+					Name:     translate.Default.PhysicalName("single-gpu", vObjectMeta.Namespace),
+				},
+			},
+		},
+	}
+
+	vUpdatedTemplate := vTemplate.DeepCopy()
+	vUpdatedTemplate.Labels = map[string]string{"gpu": "shared"}
+	pUpdatedTemplate := pTemplate.DeepCopy()
+	pUpdatedTemplate.Labels[translate.Default.ConvertLabelKey("gpu")] = "shared"
+
+	generictesting.RunTestsWithContext(t, func(vConfig *config.VirtualClusterConfig, pClient *testingutil.FakeIndexClient, vClient *testingutil.FakeIndexClient) *synccontext.RegisterContext {
+		vConfig.Sync.ToHost.ResourceClaimTemplates.Enabled = true
+		return generictesting.NewFakeRegisterContext(vConfig, pClient, vClient)
+	}, []*generictesting.SyncTest{
+		{
+			Name:                "Create host resource claim template",
+			InitialVirtualState: []runtime.Object{vTemplate.DeepCopy()},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				gvk: {vTemplate.DeepCopy()},
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				gvk: {pTemplate.DeepCopy()},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := generictesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*resourceClaimTemplateSyncer).SyncToHost(syncCtx, vTemplate.DeepCopy())
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:                 "Update host resource claim template labels",
+			InitialVirtualState:  []runtime.Object{vUpdatedTemplate.DeepCopy()},
+			InitialPhysicalState: []runtime.Object{pTemplate.DeepCopy()},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				gvk: {vUpdatedTemplate.DeepCopy()},
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				gvk: {pUpdatedTemplate.DeepCopy()},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := generictesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*resourceClaimTemplateSyncer).Sync(syncCtx, pTemplate.DeepCopy(), vUpdatedTemplate.DeepCopy())
+				assert.NilError(t, err)
+			},
+		},
+	})
+}
@@ -0,0 +1,21 @@
+package resourceclaimtemplates
+
+import (
+	"context"
+
+	"github.com/loft-sh/vcluster/pkg/controllers/resources/resourceclaims"
+	resourcev1alpha2 "k8s.io/api/resource/v1alpha2"
+)
+
+func (s *resourceClaimTemplateSyncer) translate(ctx context.Context, vObj *resourcev1alpha2.ResourceClaimTemplate) *resourcev1alpha2.ResourceClaimTemplate {
+	pObj := s.TranslateMetadata(ctx, vObj).(*resourcev1alpha2.ResourceClaimTemplate)
+	resourceclaims.TranslateSpec(&pObj.Spec.Spec, vObj.Namespace)
+	return pObj
+}
+
+func (s *resourceClaimTemplateSyncer) translateUpdate(ctx context.Context, pObj, vObj *resourcev1alpha2.ResourceClaimTemplate) {
+	// the spec is immutable, so only metadata needs to be updated
+	_, updatedAnnotations, updatedLabels := s.TranslateMetadataUpdate(ctx, vObj, pObj)
+	pObj.Annotations = updatedAnnotations
+	pObj.Labels = updatedLabels
+}
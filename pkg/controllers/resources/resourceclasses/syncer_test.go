@@ -0,0 +1,101 @@
+package resourceclasses
+
+import (
+	"testing"
+
+	"github.com/loft-sh/vcluster/pkg/config"
+	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	generictesting "github.com/loft-sh/vcluster/pkg/controllers/syncer/testing"
+	testingutil "github.com/loft-sh/vcluster/pkg/util/testing"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	resourcev1alpha2 "k8s.io/api/resource/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const kind = "ResourceClass"
+
+func TestSync(t *testing.T) {
+	objectMeta := metav1.ObjectMeta{
+		Name: "gpu.example.com",
+	}
+
+	pObj := &resourcev1alpha2.ResourceClass{
+		ObjectMeta: objectMeta,
+		DriverName: "gpu.resource.example.com",
+	}
+	vObj := pObj.DeepCopy()
+
+	pObjUpdated := &resourcev1alpha2.ResourceClass{
+		ObjectMeta: objectMeta,
+		DriverName: "gpu.resource.example.com",
+		ParametersRef: &resourcev1alpha2.ResourceClassParametersReference{
+			APIGroup:  "gpu.resource.example.com",
+			Kind:      "DeviceClassParameters",
+			Name:      "default",
+			Namespace: "gpu-driver",
+		},
+		SuitableNodes: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "gpu", Operator: corev1.NodeSelectorOpExists}}},
+			},
+		},
+		StructuredParameters: boolRef(true),
+	}
+	vObjUpdated := pObjUpdated.DeepCopy()
+
+	generictesting.RunTestsWithContext(t, func(vConfig *config.VirtualClusterConfig, pClient *testingutil.FakeIndexClient, vClient *testingutil.FakeIndexClient) *synccontext.RegisterContext {
+		vConfig.Sync.FromHost.ResourceClasses.Enabled = true
+		return generictesting.NewFakeRegisterContext(vConfig, pClient, vClient)
+	}, []*generictesting.SyncTest{
+		{
+			Name:                 "Sync Up",
+			InitialVirtualState:  []runtime.Object{},
+			InitialPhysicalState: []runtime.Object{pObj},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				resourcev1alpha2.SchemeGroupVersion.WithKind(kind): {vObj},
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				resourcev1alpha2.SchemeGroupVersion.WithKind(kind): {pObj},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := generictesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*resourceClassSyncer).SyncToVirtual(syncCtx, pObj)
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:                  "Sync Down",
+			InitialVirtualState:   []runtime.Object{vObj},
+			ExpectedVirtualState:  map[schema.GroupVersionKind][]runtime.Object{},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := generictesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*resourceClassSyncer).SyncToHost(syncCtx, vObj)
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:                 "Sync",
+			InitialVirtualState:  []runtime.Object{vObj},
+			InitialPhysicalState: []runtime.Object{pObjUpdated},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				resourcev1alpha2.SchemeGroupVersion.WithKind(kind): {vObjUpdated},
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				resourcev1alpha2.SchemeGroupVersion.WithKind(kind): {pObjUpdated},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := generictesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*resourceClassSyncer).Sync(syncCtx, pObjUpdated, vObj)
+				assert.NilError(t, err)
+			},
+		},
+	})
+}
+
+func boolRef(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,24 @@
+package resourceclasses
+
+import (
+	"context"
+
+	resourcev1alpha2 "k8s.io/api/resource/v1alpha2"
+)
+
+func (s *resourceClassSyncer) translateBackwards(ctx context.Context, pResourceClass *resourcev1alpha2.ResourceClass) *resourcev1alpha2.ResourceClass {
+	return s.TranslateMetadata(ctx, pResourceClass).(*resourcev1alpha2.ResourceClass)
+}
+
+func (s *resourceClassSyncer) translateUpdateBackwards(ctx context.Context, pObj, vObj *resourcev1alpha2.ResourceClass) {
+	changed, updatedAnnotations, updatedLabels := s.TranslateMetadataUpdate(ctx, vObj, pObj)
+	if changed {
+		vObj.Labels = updatedLabels
+		vObj.Annotations = updatedAnnotations
+	}
+
+	vObj.DriverName = pObj.DriverName
+	vObj.ParametersRef = pObj.ParametersRef.DeepCopy()
+	vObj.SuitableNodes = pObj.SuitableNodes.DeepCopy()
+	vObj.StructuredParameters = pObj.StructuredParameters
+}
@@ -0,0 +1,55 @@
+package resourceslices
+
+import (
+	"fmt"
+
+	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	"github.com/loft-sh/vcluster/pkg/controllers/syncer/translator"
+	syncer "github.com/loft-sh/vcluster/pkg/controllers/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/mappings"
+	"github.com/loft-sh/vcluster/pkg/patcher"
+	resourcev1alpha2 "k8s.io/api/resource/v1alpha2"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func New(_ *synccontext.RegisterContext) (syncer.Object, error) {
+	return &resourceSliceSyncer{
+		Translator: translator.NewMirrorPhysicalTranslator("resourceslice", &resourcev1alpha2.ResourceSlice{}, mappings.ResourceSlices()),
+	}, nil
+}
+
+type resourceSliceSyncer struct {
+	syncer.Translator
+}
+
+var _ syncer.ToVirtualSyncer = &resourceSliceSyncer{}
+var _ syncer.Syncer = &resourceSliceSyncer{}
+
+func (s *resourceSliceSyncer) SyncToVirtual(ctx *synccontext.SyncContext, pObj client.Object) (ctrl.Result, error) {
+	vObj := s.translateBackwards(ctx, pObj.(*resourcev1alpha2.ResourceSlice))
+	ctx.Log.Infof("create ResourceSlice %s, because it does not exist in virtual cluster", vObj.Name)
+	return ctrl.Result{}, ctx.VirtualClient.Create(ctx, vObj)
+}
+
+func (s *resourceSliceSyncer) Sync(ctx *synccontext.SyncContext, pObj client.Object, vObj client.Object) (_ ctrl.Result, retErr error) {
+	patch, err := patcher.NewSyncerPatcher(ctx, pObj, vObj)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("new syncer patcher: %w", err)
+	}
+	defer func() {
+		if err := patch.Patch(ctx, pObj, vObj); err != nil {
+			retErr = utilerrors.NewAggregate([]error{retErr, err})
+		}
+	}()
+	// check if there is a change
+	s.translateUpdateBackwards(ctx, pObj.(*resourcev1alpha2.ResourceSlice), vObj.(*resourcev1alpha2.ResourceSlice))
+
+	return ctrl.Result{}, nil
+}
+
+func (s *resourceSliceSyncer) SyncToHost(ctx *synccontext.SyncContext, vObj client.Object) (ctrl.Result, error) {
+	ctx.Log.Infof("delete virtual ResourceSlice %s, because physical object is missing", vObj.GetName())
+	return ctrl.Result{}, ctx.VirtualClient.Delete(ctx, vObj)
+}
@@ -0,0 +1,88 @@
+package resourceslices
+
+import (
+	"testing"
+
+	"github.com/loft-sh/vcluster/pkg/config"
+	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	generictesting "github.com/loft-sh/vcluster/pkg/controllers/syncer/testing"
+	testingutil "github.com/loft-sh/vcluster/pkg/util/testing"
+	"gotest.tools/assert"
+	resourcev1alpha2 "k8s.io/api/resource/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const kind = "ResourceSlice"
+
+func TestSync(t *testing.T) {
+	objectMeta := metav1.ObjectMeta{
+		Name: "node-1-gpu.resource.example.com-abcde",
+	}
+
+	pObj := &resourcev1alpha2.ResourceSlice{
+		ObjectMeta: objectMeta,
+		NodeName:   "node-1",
+		DriverName: "gpu.resource.example.com",
+		ResourceModel: resourcev1alpha2.ResourceModel{
+			NamedResources: &resourcev1alpha2.NamedResourcesResources{
+				Instances: []resourcev1alpha2.NamedResourcesInstance{{Name: "gpu-0"}},
+			},
+		},
+	}
+	vObj := pObj.DeepCopy()
+
+	pObjUpdated := pObj.DeepCopy()
+	pObjUpdated.NamedResources.Instances = append(pObjUpdated.NamedResources.Instances, resourcev1alpha2.NamedResourcesInstance{Name: "gpu-1"})
+	vObjUpdated := pObjUpdated.DeepCopy()
+
+	generictesting.RunTestsWithContext(t, func(vConfig *config.VirtualClusterConfig, pClient *testingutil.FakeIndexClient, vClient *testingutil.FakeIndexClient) *synccontext.RegisterContext {
+		vConfig.Sync.FromHost.ResourceSlices.Enabled = true
+		return generictesting.NewFakeRegisterContext(vConfig, pClient, vClient)
+	}, []*generictesting.SyncTest{
+		{
+			Name:                 "Sync Up",
+			InitialVirtualState:  []runtime.Object{},
+			InitialPhysicalState: []runtime.Object{pObj},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				resourcev1alpha2.SchemeGroupVersion.WithKind(kind): {vObj},
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				resourcev1alpha2.SchemeGroupVersion.WithKind(kind): {pObj},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := generictesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*resourceSliceSyncer).SyncToVirtual(syncCtx, pObj)
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:                  "Sync Down",
+			InitialVirtualState:   []runtime.Object{vObj},
+			ExpectedVirtualState:  map[schema.GroupVersionKind][]runtime.Object{},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := generictesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*resourceSliceSyncer).SyncToHost(syncCtx, vObj)
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:                 "Sync",
+			InitialVirtualState:  []runtime.Object{vObj},
+			InitialPhysicalState: []runtime.Object{pObjUpdated},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				resourcev1alpha2.SchemeGroupVersion.WithKind(kind): {vObjUpdated},
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				resourcev1alpha2.SchemeGroupVersion.WithKind(kind): {pObjUpdated},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := generictesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*resourceSliceSyncer).Sync(syncCtx, pObjUpdated, vObj)
+				assert.NilError(t, err)
+			},
+		},
+	})
+}
@@ -0,0 +1,23 @@
+package resourceslices
+
+import (
+	"context"
+
+	resourcev1alpha2 "k8s.io/api/resource/v1alpha2"
+)
+
+func (s *resourceSliceSyncer) translateBackwards(ctx context.Context, pResourceSlice *resourcev1alpha2.ResourceSlice) *resourcev1alpha2.ResourceSlice {
+	return s.TranslateMetadata(ctx, pResourceSlice).(*resourcev1alpha2.ResourceSlice)
+}
+
+func (s *resourceSliceSyncer) translateUpdateBackwards(ctx context.Context, pObj, vObj *resourcev1alpha2.ResourceSlice) {
+	changed, updatedAnnotations, updatedLabels := s.TranslateMetadataUpdate(ctx, vObj, pObj)
+	if changed {
+		vObj.Labels = updatedLabels
+		vObj.Annotations = updatedAnnotations
+	}
+
+	vObj.NodeName = pObj.NodeName
+	vObj.DriverName = pObj.DriverName
+	pObj.ResourceModel.DeepCopyInto(&vObj.ResourceModel)
+}
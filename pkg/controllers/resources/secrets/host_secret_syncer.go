@@ -0,0 +1,98 @@
+package secrets
+
+import (
+	"fmt"
+
+	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	"github.com/loft-sh/vcluster/pkg/controllers/syncer/translator"
+	syncertypes "github.com/loft-sh/vcluster/pkg/controllers/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/mappings/generic"
+	"github.com/loft-sh/vcluster/pkg/patcher"
+	"github.com/loft-sh/vcluster/pkg/util/clienthelper"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewHostSecretSyncer(ctx *synccontext.RegisterContext) (syncertypes.Object, error) {
+	mapper, err := generic.NewFromHostMapper(&corev1.Secret{}, ctx.Config.Sync.FromHost.Secrets.Mappings.ByName)
+	if err != nil {
+		return nil, fmt.Errorf("create secret from host mapper: %w", err)
+	}
+
+	return &hostSecretSyncer{
+		Translator: translator.NewFromHostTranslator("host-secret", &corev1.Secret{}, mapper),
+	}, nil
+}
+
+type hostSecretSyncer struct {
+	syncertypes.Translator
+}
+
+var _ syncertypes.OptionsProvider = &hostSecretSyncer{}
+
+func (s *hostSecretSyncer) Options() *syncertypes.Options {
+	return &syncertypes.Options{
+		DisableUIDDeletion: true,
+	}
+}
+
+var _ syncertypes.ObjectExcluder = &hostSecretSyncer{}
+
+func (s *hostSecretSyncer) ExcludeVirtual(vObj client.Object) bool {
+	return vObj.GetAnnotations() == nil || vObj.GetAnnotations()[translate.HostNameAnnotation] == ""
+}
+
+func (s *hostSecretSyncer) ExcludePhysical(_ client.Object) bool {
+	return false
+}
+
+var _ syncertypes.ToVirtualSyncer = &hostSecretSyncer{}
+
+func (s *hostSecretSyncer) SyncToVirtual(ctx *synccontext.SyncContext, pObj client.Object) (ctrl.Result, error) {
+	vObj := s.TranslateMetadata(ctx, pObj).(*corev1.Secret)
+	err := clienthelper.EnsureNamespace(ctx, ctx.VirtualClient, vObj.Namespace)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("ensure virtual namespace %s: %w", vObj.Namespace, err)
+	}
+
+	ctx.Log.Infof("create secret %s/%s, because it does not exist in virtual cluster", vObj.Namespace, vObj.Name)
+	return ctrl.Result{}, ctx.VirtualClient.Create(ctx, vObj)
+}
+
+var _ syncertypes.Syncer = &hostSecretSyncer{}
+
+func (s *hostSecretSyncer) Sync(ctx *synccontext.SyncContext, pObj client.Object, vObj client.Object) (_ ctrl.Result, retErr error) {
+	pSecret, vSecret, _, _ := synccontext.Cast[*corev1.Secret](ctx, pObj, vObj)
+
+	// immutable secrets and secret types cannot be updated, so we recreate them instead
+	if vSecret.Type != pSecret.Type || (vSecret.Immutable != nil && *vSecret.Immutable && !equality.Semantic.DeepEqual(vSecret.Data, pSecret.Data)) {
+		ctx.Log.Infof("delete virtual secret %s/%s, because it cannot be updated to match the host secret", vObj.GetNamespace(), vObj.GetName())
+		return ctrl.Result{Requeue: true}, ctx.VirtualClient.Delete(ctx, vObj)
+	}
+
+	patch, err := patcher.NewSyncerPatcher(ctx, pObj, vObj)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("new syncer patcher: %w", err)
+	}
+
+	defer func() {
+		if err := patch.Patch(ctx, pObj, vObj); err != nil {
+			retErr = utilerrors.NewAggregate([]error{retErr, err})
+		}
+	}()
+
+	// the virtual secret always reflects the host secret, changes within the virtual cluster are reverted
+	_, vSecret.Annotations, vSecret.Labels = s.TranslateMetadataUpdate(ctx, vObj, pObj)
+	vSecret.Data = pSecret.Data
+	vSecret.Immutable = pSecret.Immutable
+	return ctrl.Result{}, nil
+}
+
+func (s *hostSecretSyncer) SyncToHost(ctx *synccontext.SyncContext, vObj client.Object) (ctrl.Result, error) {
+	ctx.Log.Infof("delete virtual secret %s/%s, because host object is missing", vObj.GetNamespace(), vObj.GetName())
+	return ctrl.Result{}, ctx.VirtualClient.Delete(ctx, vObj)
+}
@@ -10,6 +10,7 @@ import (
 	syncertypes "github.com/loft-sh/vcluster/pkg/controllers/syncer/types"
 	"github.com/loft-sh/vcluster/pkg/mappings"
 	"github.com/loft-sh/vcluster/pkg/patcher"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
 	"k8s.io/apimachinery/pkg/api/equality"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -157,6 +158,9 @@ func (s *secretSyncer) isSecretUsed(ctx *synccontext.SyncContext, vObj runtime.O
 	secret, ok := vObj.(*corev1.Secret)
 	if !ok || secret == nil {
 		return false, fmt.Errorf("%#v is not a secret", vObj)
+	} else if secret.Annotations != nil && secret.Annotations[translate.HostNameAnnotation] != "" {
+		// secrets copied from the host by sync.fromHost.secrets are never synced back
+		return false, nil
 	} else if secret.Annotations != nil && secret.Annotations[constants.SyncResourceAnnotation] == "true" {
 		return true, nil
 	}
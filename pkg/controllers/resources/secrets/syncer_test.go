@@ -62,6 +62,16 @@ func TestSync(t *testing.T) {
 		ObjectMeta: syncedSecret.ObjectMeta,
 		Data:       updatedSecret.Data,
 	}
+	hostSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      baseSecret.Name,
+			Namespace: baseSecret.Namespace,
+			Annotations: map[string]string{
+				translate.HostNameAnnotation:      "host-secret",
+				translate.HostNamespaceAnnotation: "host-namespace",
+			},
+		},
+	}
 	basePod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "test",
@@ -114,6 +124,21 @@ func TestSync(t *testing.T) {
 				assert.NilError(t, err)
 			},
 		},
+		{
+			Name: "Used secret copied from the host",
+			InitialVirtualState: []runtime.Object{
+				hostSecret,
+				basePod,
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				corev1.SchemeGroupVersion.WithKind("Secret"): {},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncContext, syncer := newFakeSyncer(t, ctx)
+				_, err := syncer.(*secretSyncer).SyncToHost(syncContext, hostSecret)
+				assert.NilError(t, err)
+			},
+		},
 		{
 			Name: "Update used secret",
 			InitialVirtualState: []runtime.Object{
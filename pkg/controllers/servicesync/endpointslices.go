@@ -0,0 +1,179 @@
+package servicesync
+
+import (
+	"context"
+	"strings"
+
+	"github.com/loft-sh/vcluster/pkg/controllers/resources/endpointslices"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilnet "k8s.io/utils/net"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func (e *ServiceSyncer) syncEndpointSlices(ctx context.Context, fromService, toService *corev1.Service) error {
+	var fromEndpointSlices []discoveryv1.EndpointSlice
+	if fromService.Spec.ClusterIP == corev1.ClusterIPNone {
+		// fetch the endpoint slices of the headless service
+		fromEndpointSliceList := &discoveryv1.EndpointSliceList{}
+		err := e.From.GetClient().List(ctx, fromEndpointSliceList, client.InNamespace(fromService.Namespace), client.MatchingLabels{discoveryv1.LabelServiceName: fromService.Name})
+		if err != nil {
+			return err
+		}
+
+		fromEndpointSlices = fromEndpointSliceList.Items
+	}
+
+	toEndpointSliceList := &discoveryv1.EndpointSliceList{}
+	err := e.To.GetClient().List(ctx, toEndpointSliceList, client.InNamespace(toService.Namespace), client.MatchingLabels{
+		discoveryv1.LabelServiceName: toService.Name,
+		discoveryv1.LabelManagedBy:   endpointslices.ManagedBy,
+	})
+	if err != nil {
+		return err
+	}
+	existingEndpointSlices := map[string]*discoveryv1.EndpointSlice{}
+	for i := range toEndpointSliceList.Items {
+		existingEndpointSlices[toEndpointSliceList.Items[i].Name] = &toEndpointSliceList.Items[i]
+	}
+
+	for _, expectedEndpointSlice := range expectedEndpointSlices(fromService, toService, fromEndpointSlices) {
+		existingEndpointSlice, ok := existingEndpointSlices[expectedEndpointSlice.Name]
+		delete(existingEndpointSlices, expectedEndpointSlice.Name)
+		if ok && existingEndpointSlice.AddressType != expectedEndpointSlice.AddressType {
+			// the address type is immutable
+			e.Log.Infof("Delete target endpoint slice %s/%s because the address type has changed", existingEndpointSlice.Namespace, existingEndpointSlice.Name)
+			err = e.To.GetClient().Delete(ctx, existingEndpointSlice)
+			if err != nil && !kerrors.IsNotFound(err) {
+				return err
+			}
+
+			ok = false
+		}
+
+		if !ok {
+			e.Log.Infof("Create target endpoint slice %s/%s because it is missing", expectedEndpointSlice.Namespace, expectedEndpointSlice.Name)
+			err = e.To.GetClient().Create(ctx, expectedEndpointSlice)
+			if err != nil {
+				return err
+			}
+		} else if !apiequality.Semantic.DeepEqual(existingEndpointSlice.Endpoints, expectedEndpointSlice.Endpoints) || !apiequality.Semantic.DeepEqual(existingEndpointSlice.Ports, expectedEndpointSlice.Ports) {
+			e.Log.Infof("Update target endpoint slice %s/%s because endpoints or ports are different", existingEndpointSlice.Namespace, existingEndpointSlice.Name)
+			existingEndpointSlice.Endpoints = expectedEndpointSlice.Endpoints
+			existingEndpointSlice.Ports = expectedEndpointSlice.Ports
+			err = e.To.GetClient().Update(ctx, existingEndpointSlice)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// delete endpoint slices that are not needed anymore
+	for _, existingEndpointSlice := range existingEndpointSlices {
+		e.Log.Infof("Delete target endpoint slice %s/%s because it is not needed anymore", existingEndpointSlice.Namespace, existingEndpointSlice.Name)
+		err = e.To.GetClient().Delete(ctx, existingEndpointSlice)
+		if err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// expectedEndpointSlices returns the endpoint slices of the target service. Headless services get a copy of each
+// endpoint slice of the source service including topology hints, other services get an endpoint slice for each
+// of their cluster ips.
+func expectedEndpointSlices(fromService, toService *corev1.Service, fromEndpointSlices []discoveryv1.EndpointSlice) []*discoveryv1.EndpointSlice {
+	endpointSlices := []*discoveryv1.EndpointSlice{}
+	if fromService.Spec.ClusterIP == corev1.ClusterIPNone {
+		for _, fromEndpointSlice := range fromEndpointSlices {
+			suffix := strings.TrimPrefix(fromEndpointSlice.Name, fromService.Name+"-")
+			endpointSlice := newEndpointSlice(toService, suffix, fromEndpointSlice.AddressType)
+			endpointSlice.Endpoints = fromEndpointSlice.DeepCopy().Endpoints
+			endpointSlice.Ports = fromEndpointSlice.DeepCopy().Ports
+			endpointSlices = append(endpointSlices, endpointSlice)
+		}
+
+		return endpointSlices
+	}
+
+	clusterIPs := fromService.Spec.ClusterIPs
+	if len(clusterIPs) == 0 && fromService.Spec.ClusterIP != "" {
+		clusterIPs = []string{fromService.Spec.ClusterIP}
+	}
+	for _, clusterIP := range clusterIPs {
+		addressType := discoveryv1.AddressTypeIPv4
+		if utilnet.IsIPv6String(clusterIP) {
+			addressType = discoveryv1.AddressTypeIPv6
+		}
+
+		endpointSlice := newEndpointSlice(toService, strings.ToLower(string(addressType)), addressType)
+		endpointSlice.Endpoints = []discoveryv1.Endpoint{
+			{
+				Addresses:  []string{clusterIP},
+				Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)},
+			},
+		}
+		endpointSlice.Ports = convertEndpointSlicePorts(toService.Spec.Ports)
+		endpointSlices = append(endpointSlices, endpointSlice)
+	}
+
+	return endpointSlices
+}
+
+func newEndpointSlice(toService *corev1.Service, suffix string, addressType discoveryv1.AddressType) *discoveryv1.EndpointSlice {
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      translate.SafeConcatName(toService.Name, suffix),
+			Namespace: toService.Namespace,
+			Labels: map[string]string{
+				discoveryv1.LabelServiceName: toService.Name,
+				discoveryv1.LabelManagedBy:   endpointslices.ManagedBy,
+				translate.ControllerLabel:    "vcluster",
+			},
+		},
+		AddressType: addressType,
+	}
+}
+
+func convertEndpointSlicePorts(servicePorts []corev1.ServicePort) []discoveryv1.EndpointPort {
+	endpointPorts := []discoveryv1.EndpointPort{}
+	for _, p := range servicePorts {
+		endpointPorts = append(endpointPorts, discoveryv1.EndpointPort{
+			Name:        ptr.To(p.Name),
+			Port:        ptr.To(p.Port),
+			Protocol:    ptr.To(p.Protocol),
+			AppProtocol: p.AppProtocol,
+		})
+	}
+	return endpointPorts
+}
+
+// deleteEndpoints removes the endpoints that were written for the target service before
+func (e *ServiceSyncer) deleteEndpoints(ctx context.Context, to types.NamespacedName) error {
+	toEndpoints := &corev1.Endpoints{}
+	err := e.To.GetClient().Get(ctx, to, toEndpoints)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+
+		return err
+	} else if toEndpoints.Labels[translate.ControllerLabel] != "vcluster" {
+		return nil
+	}
+
+	e.Log.Infof("Delete target endpoints %s/%s because endpoints are not mirrored", to.Namespace, to.Name)
+	err = e.To.GetClient().Delete(ctx, toEndpoints)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
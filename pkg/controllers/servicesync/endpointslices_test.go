@@ -0,0 +1,70 @@
+package servicesync
+
+import (
+	"testing"
+
+	"github.com/loft-sh/vcluster/pkg/controllers/resources/endpointslices"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestExpectedEndpointSlices(t *testing.T) {
+	toService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "to", Namespace: "to-namespace"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Name: "http", Port: 80, Protocol: corev1.ProtocolTCP}},
+		},
+	}
+	labels := map[string]string{
+		discoveryv1.LabelServiceName: "to",
+		discoveryv1.LabelManagedBy:   endpointslices.ManagedBy,
+		translate.ControllerLabel:    "vcluster",
+	}
+
+	// dual-stack services get an endpoint slice per cluster ip
+	fromService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "from", Namespace: "from-namespace"},
+		Spec: corev1.ServiceSpec{
+			ClusterIP:  "10.0.0.1",
+			ClusterIPs: []string{"10.0.0.1", "fd00::1"},
+		},
+	}
+	endpointSlices := expectedEndpointSlices(fromService, toService, nil)
+	assert.Equal(t, len(endpointSlices), 2)
+	assert.Equal(t, endpointSlices[0].Name, "to-ipv4")
+	assert.Equal(t, endpointSlices[0].Namespace, "to-namespace")
+	assert.DeepEqual(t, endpointSlices[0].Labels, labels)
+	assert.Equal(t, endpointSlices[0].AddressType, discoveryv1.AddressTypeIPv4)
+	assert.DeepEqual(t, endpointSlices[0].Endpoints, []discoveryv1.Endpoint{{Addresses: []string{"10.0.0.1"}, Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)}}})
+	assert.DeepEqual(t, endpointSlices[0].Ports, []discoveryv1.EndpointPort{{Name: ptr.To("http"), Port: ptr.To(int32(80)), Protocol: ptr.To(corev1.ProtocolTCP)}})
+	assert.Equal(t, endpointSlices[1].Name, "to-ipv6")
+	assert.Equal(t, endpointSlices[1].AddressType, discoveryv1.AddressTypeIPv6)
+	assert.DeepEqual(t, endpointSlices[1].Endpoints[0].Addresses, []string{"fd00::1"})
+
+	// headless services get a copy of the source endpoint slices
+	fromService.Spec.ClusterIP = corev1.ClusterIPNone
+	fromService.Spec.ClusterIPs = []string{corev1.ClusterIPNone}
+	fromEndpointSlice := discoveryv1.EndpointSlice{
+		ObjectMeta:  metav1.ObjectMeta{Name: "from-abcde", Namespace: "from-namespace"},
+		AddressType: discoveryv1.AddressTypeIPv6,
+		Endpoints: []discoveryv1.Endpoint{
+			{
+				Addresses: []string{"fd00::2"},
+				Zone:      ptr.To("zone-a"),
+				Hints:     &discoveryv1.EndpointHints{ForZones: []discoveryv1.ForZone{{Name: "zone-a"}}},
+			},
+		},
+		Ports: []discoveryv1.EndpointPort{{Name: ptr.To("http"), Port: ptr.To(int32(8080))}},
+	}
+	endpointSlices = expectedEndpointSlices(fromService, toService, []discoveryv1.EndpointSlice{fromEndpointSlice})
+	assert.Equal(t, len(endpointSlices), 1)
+	assert.Equal(t, endpointSlices[0].Name, "to-abcde")
+	assert.DeepEqual(t, endpointSlices[0].Labels, labels)
+	assert.Equal(t, endpointSlices[0].AddressType, discoveryv1.AddressTypeIPv6)
+	assert.DeepEqual(t, endpointSlices[0].Endpoints, fromEndpointSlice.Endpoints)
+	assert.DeepEqual(t, endpointSlices[0].Ports, fromEndpointSlice.Ports)
+}
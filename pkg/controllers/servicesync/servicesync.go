@@ -9,6 +9,7 @@ import (
 	"github.com/loft-sh/vcluster/pkg/util/loghelper"
 	"github.com/loft-sh/vcluster/pkg/util/translate"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -27,6 +28,11 @@ type ServiceSyncer struct {
 	CreateNamespace       bool
 	CreateEndpoints       bool
 
+	// EndpointSlices writes endpoint slices for the target service if CreateEndpoints is set
+	EndpointSlices bool
+	// MirrorEndpoints writes endpoints in addition to the endpoint slices
+	MirrorEndpoints bool
+
 	From ctrl.Manager
 	To   ctrl.Manager
 
@@ -43,7 +49,7 @@ func (e *ServiceSyncer) Register() error {
 		}
 	}
 
-	return ctrl.NewControllerManagedBy(e.From).
+	builder := ctrl.NewControllerManagedBy(e.From).
 		WithOptions(controller.Options{
 			CacheSyncTimeout: constants.DefaultCacheSyncTimeout,
 		}).
@@ -74,8 +80,26 @@ func (e *ServiceSyncer) Register() error {
 			return []reconcile.Request{{
 				NamespacedName: types.NamespacedName{Namespace: object.GetNamespace(), Name: object.GetName()},
 			}}
-		}))).
-		Complete(e)
+		})))
+	if e.CreateEndpoints && e.EndpointSlices {
+		builder = builder.WatchesRawSource(source.Kind(e.From.GetCache(), &discoveryv1.EndpointSlice{}, handler.TypedEnqueueRequestsFromMapFunc(func(_ context.Context, object *discoveryv1.EndpointSlice) []reconcile.Request {
+			if object == nil || object.Labels[discoveryv1.LabelServiceName] == "" {
+				return nil
+			}
+
+			serviceName := object.Labels[discoveryv1.LabelServiceName]
+			_, ok := e.SyncServices[object.GetNamespace()+"/"+serviceName]
+			if !ok {
+				return nil
+			}
+
+			return []reconcile.Request{{
+				NamespacedName: types.NamespacedName{Namespace: object.GetNamespace(), Name: serviceName},
+			}}
+		})))
+	}
+
+	return builder.Complete(e)
 }
 
 func (e *ServiceSyncer) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -243,6 +267,18 @@ func (e *ServiceSyncer) syncServiceAndEndpoints(ctx context.Context, fromService
 		return ctrl.Result{}, e.To.GetClient().Update(ctx, toService)
 	}
 
+	// check target endpoint slices
+	if e.EndpointSlices {
+		err = e.syncEndpointSlices(ctx, fromService, toService)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if !e.MirrorEndpoints {
+			return ctrl.Result{}, e.deleteEndpoints(ctx, to)
+		}
+	}
+
 	// check target endpoints
 	toEndpoints := &corev1.Endpoints{}
 	err = e.To.GetClient().Get(ctx, to, toEndpoints)
@@ -282,9 +318,7 @@ func (e *ServiceSyncer) syncServiceAndEndpoints(ctx context.Context, fromService
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      to.Name,
 				Namespace: to.Namespace,
-				Labels: map[string]string{
-					translate.ControllerLabel: "vcluster",
-				},
+				Labels:    e.endpointsLabels(nil),
 			},
 			Subsets: subsets,
 		}
@@ -320,15 +354,35 @@ func (e *ServiceSyncer) syncServiceAndEndpoints(ctx context.Context, fromService
 		}
 	}
 
-	if !apiequality.Semantic.DeepEqual(toEndpoints.Subsets, expectedSubsets) {
-		e.Log.Infof("Update target endpoints %s/%s because subsets are different", to.Namespace, to.Name)
+	expectedLabels := e.endpointsLabels(toEndpoints.Labels)
+	if !apiequality.Semantic.DeepEqual(toEndpoints.Subsets, expectedSubsets) || !apiequality.Semantic.DeepEqual(toEndpoints.Labels, expectedLabels) {
+		e.Log.Infof("Update target endpoints %s/%s because subsets or labels are different", to.Namespace, to.Name)
 		toEndpoints.Subsets = expectedSubsets
+		toEndpoints.Labels = expectedLabels
 		return ctrl.Result{}, e.To.GetClient().Update(ctx, toEndpoints)
 	}
 
 	return ctrl.Result{}, nil
 }
 
+// endpointsLabels returns the labels of the target endpoints. If endpoint slices are written, the endpoints are
+// excluded from endpoint slice mirroring to avoid duplicate endpoint slices.
+func (e *ServiceSyncer) endpointsLabels(existingLabels map[string]string) map[string]string {
+	labels := map[string]string{}
+	for k, v := range existingLabels {
+		labels[k] = v
+	}
+
+	labels[translate.ControllerLabel] = "vcluster"
+	if e.EndpointSlices {
+		labels[discoveryv1.LabelSkipMirror] = "true"
+	} else {
+		delete(labels, discoveryv1.LabelSkipMirror)
+	}
+
+	return labels
+}
+
 func convertPorts(servicePorts []corev1.ServicePort) []corev1.EndpointPort {
 	endpointPorts := []corev1.EndpointPort{}
 	for _, p := range servicePorts {
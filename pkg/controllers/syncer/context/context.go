@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/loft-sh/vcluster/pkg/config"
+	"github.com/loft-sh/vcluster/pkg/patches"
 	"github.com/loft-sh/vcluster/pkg/util/loghelper"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -29,6 +30,13 @@ type SyncContext struct {
 
 	EventSource EventSource
 	IsDelete    bool
+
+	// DryRun is true if writes to the host cluster are recorded instead of applied
+	DryRun bool
+
+	// Patches are the patches configured for the objects of the current syncer, which are applied after the
+	// built-in translation
+	Patches *patches.SyncerPatches
 }
 
 func SyncSourceTarget[T any](ctx *SyncContext, pObj, vObj T) (source T, target T) {
@@ -0,0 +1,163 @@
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// NewClient wraps the given client and sends all writes to the recorder instead of the api server.
+// Reads are still served by the wrapped client.
+func NewClient(c client.Client, recorder Recorder, syncerName string) client.Client {
+	return &dryRunClient{
+		Client:     c,
+		recorder:   recorder,
+		syncerName: syncerName,
+	}
+}
+
+type dryRunClient struct {
+	client.Client
+
+	recorder   Recorder
+	syncerName string
+}
+
+func (d *dryRunClient) Create(ctx context.Context, obj client.Object, _ ...client.CreateOption) error {
+	return d.record(ctx, obj, OperationCreate, "", "", func() ([]byte, error) {
+		return marshalObject(obj)
+	})
+}
+
+func (d *dryRunClient) Update(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	return d.record(ctx, obj, OperationUpdate, "", "", func() ([]byte, error) {
+		return d.updateDiff(ctx, obj)
+	})
+}
+
+func (d *dryRunClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, _ ...client.PatchOption) error {
+	return d.record(ctx, obj, OperationPatch, "", string(patch.Type()), func() ([]byte, error) {
+		return patch.Data(obj)
+	})
+}
+
+func (d *dryRunClient) Delete(ctx context.Context, obj client.Object, _ ...client.DeleteOption) error {
+	return d.record(ctx, obj, OperationDelete, "", "", func() ([]byte, error) {
+		return nil, nil
+	})
+}
+
+func (d *dryRunClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	deleteAllOfOptions := &client.DeleteAllOfOptions{}
+	deleteAllOfOptions.ApplyOptions(opts)
+	return d.record(ctx, obj, OperationDelete, "", "", func() ([]byte, error) {
+		return json.Marshal(map[string]string{
+			"namespace":     deleteAllOfOptions.Namespace,
+			"labelSelector": fmt.Sprint(deleteAllOfOptions.LabelSelector),
+		})
+	})
+}
+
+func (d *dryRunClient) Status() client.SubResourceWriter {
+	return d.SubResource("status")
+}
+
+func (d *dryRunClient) SubResource(subResource string) client.SubResourceClient {
+	return &dryRunSubResourceClient{
+		SubResourceClient: d.Client.SubResource(subResource),
+		client:            d,
+		subResource:       subResource,
+	}
+}
+
+type dryRunSubResourceClient struct {
+	client.SubResourceClient
+
+	client      *dryRunClient
+	subResource string
+}
+
+func (d *dryRunSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, _ ...client.SubResourceCreateOption) error {
+	return d.client.record(ctx, obj, OperationCreate, d.subResource, "", func() ([]byte, error) {
+		return marshalObject(subResource)
+	})
+}
+
+func (d *dryRunSubResourceClient) Update(ctx context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+	return d.client.record(ctx, obj, OperationUpdate, d.subResource, "", func() ([]byte, error) {
+		return d.client.updateDiff(ctx, obj)
+	})
+}
+
+func (d *dryRunSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, _ ...client.SubResourcePatchOption) error {
+	return d.client.record(ctx, obj, OperationPatch, d.subResource, string(patch.Type()), func() ([]byte, error) {
+		return patch.Data(obj)
+	})
+}
+
+func (d *dryRunClient) record(ctx context.Context, obj client.Object, operation Operation, subResource, patchType string, diff func() ([]byte, error)) error {
+	gvk, err := apiutil.GVKForObject(obj, d.Scheme())
+	if err != nil {
+		return err
+	}
+
+	out, err := diff()
+	if err != nil {
+		return fmt.Errorf("calculate dry run diff: %w", err)
+	} else if (operation == OperationUpdate || operation == OperationPatch) && (string(out) == "{}" || len(out) == 0) {
+		return nil
+	}
+
+	klog.FromContext(ctx).V(1).Info("Dry run", "syncer", d.syncerName, "operation", operation, "gvk", gvk.String(), "namespace", obj.GetNamespace(), "name", obj.GetName())
+	return d.recorder.Record(ctx, Change{
+		Time:             time.Now(),
+		Syncer:           d.syncerName,
+		GroupVersionKind: gvk.String(),
+		Namespace:        obj.GetNamespace(),
+		Name:             obj.GetName(),
+		Operation:        operation,
+		SubResource:      subResource,
+		PatchType:        patchType,
+		Diff:             out,
+	})
+}
+
+// updateDiff calculates a json merge patch between the current object in the cluster and the given object
+func (d *dryRunClient) updateDiff(ctx context.Context, obj client.Object) ([]byte, error) {
+	current := obj.DeepCopyObject().(client.Object)
+	err := d.Client.Get(ctx, client.ObjectKeyFromObject(obj), current)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return marshalObject(obj)
+		}
+
+		return nil, err
+	}
+
+	before, err := marshalObject(current)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := marshalObject(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonpatch.CreateMergePatch(before, after)
+}
+
+// marshalObject marshals the object without the fields that are set by the api server
+func marshalObject(obj client.Object) ([]byte, error) {
+	obj = obj.DeepCopyObject().(client.Object)
+	obj.SetManagedFields(nil)
+	obj.SetResourceVersion("")
+	return json.Marshal(obj)
+}
@@ -0,0 +1,147 @@
+package dryrun
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	testingutil "github.com/loft-sh/vcluster/pkg/util/testing"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestDryRunClient(t *testing.T) {
+	ctx := context.Background()
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "existing",
+			Namespace: "test",
+		},
+		Data: map[string]string{
+			"a": "b",
+		},
+	}
+	fakeClient := testingutil.NewFakeClient(scheme.Scheme, existing.DeepCopy())
+
+	out := &bytes.Buffer{}
+	dryRunClient := NewClient(fakeClient, NewFileRecorder(out), "configmap")
+
+	// create
+	created := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "created",
+			Namespace: "test",
+		},
+	}
+	err := dryRunClient.Create(ctx, created)
+	assert.NilError(t, err)
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(created), &corev1.ConfigMap{})
+	assert.Assert(t, kerrors.IsNotFound(err))
+
+	// update
+	updated := &corev1.ConfigMap{}
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(existing), updated)
+	assert.NilError(t, err)
+	updated.Data["a"] = "c"
+	err = dryRunClient.Update(ctx, updated)
+	assert.NilError(t, err)
+
+	// same update again is skipped
+	err = dryRunClient.Update(ctx, updated)
+	assert.NilError(t, err)
+
+	// delete
+	err = dryRunClient.Delete(ctx, existing)
+	assert.NilError(t, err)
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(existing), &corev1.ConfigMap{})
+	assert.NilError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(t, len(lines), 3)
+
+	changes := make([]Change, 0, len(lines))
+	for _, line := range lines {
+		change := Change{}
+		err = json.Unmarshal([]byte(line), &change)
+		assert.NilError(t, err)
+		changes = append(changes, change)
+	}
+
+	assert.Equal(t, changes[0].Operation, OperationCreate)
+	assert.Equal(t, changes[0].Name, "created")
+	assert.Equal(t, changes[0].GroupVersionKind, "/v1, Kind=ConfigMap")
+	assert.Equal(t, changes[1].Operation, OperationUpdate)
+	assert.Equal(t, string(changes[1].Diff), `{"data":{"a":"c"}}`)
+	assert.Equal(t, changes[2].Operation, OperationDelete)
+	assert.Equal(t, changes[2].Syncer, "configmap")
+}
+
+func TestConfigMapRecorder(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := testingutil.NewFakeClient(scheme.Scheme)
+	recorder := NewConfigMapRecorder(fakeClient, "test", "dry-run")
+
+	err := recorder.Record(ctx, Change{
+		GroupVersionKind: "apps/v1, Kind=Deployment",
+		Namespace:        "test",
+		Name:             "deployment",
+		Operation:        OperationDelete,
+	})
+	assert.NilError(t, err)
+	err = recorder.Flush(ctx)
+	assert.NilError(t, err)
+
+	configMap := &corev1.ConfigMap{}
+	err = fakeClient.Get(ctx, client.ObjectKey{Namespace: "test", Name: "dry-run"}, configMap)
+	assert.NilError(t, err)
+	assert.Equal(t, len(configMap.Data), 1)
+
+	change := Change{}
+	err = json.Unmarshal([]byte(configMap.Data["Deployment.v1.apps"]), &change)
+	assert.NilError(t, err)
+	assert.Equal(t, change.Name, "deployment")
+	assert.Equal(t, configMapKey("/v1, Kind=ConfigMap"), "ConfigMap.v1")
+}
+
+func TestConfigMapRecorderLimits(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := testingutil.NewFakeClient(scheme.Scheme)
+	recorder := NewConfigMapRecorder(fakeClient, "test", "dry-run")
+
+	bigDiff, err := json.Marshal(strings.Repeat("a", maxDiffSize))
+	assert.NilError(t, err)
+	for i := 0; i < maxChangesPerSyncer+10; i++ {
+		err = recorder.Record(ctx, Change{
+			Syncer:           "configmap",
+			GroupVersionKind: "/v1, Kind=ConfigMap",
+			Namespace:        "test",
+			Name:             fmt.Sprintf("configmap-%d", i),
+			Operation:        OperationCreate,
+			Diff:             bigDiff,
+		})
+		assert.NilError(t, err)
+	}
+	err = recorder.Flush(ctx)
+	assert.NilError(t, err)
+
+	configMap := &corev1.ConfigMap{}
+	err = fakeClient.Get(ctx, client.ObjectKey{Namespace: "test", Name: "dry-run"}, configMap)
+	assert.NilError(t, err)
+
+	lines := strings.Split(configMap.Data["ConfigMap.v1"], "\n")
+	assert.Equal(t, len(lines), maxChangesPerSyncer)
+	assert.Equal(t, configMap.Data[droppedKey], `{"configmap":10}`)
+	assert.Assert(t, len(configMap.Data["ConfigMap.v1"]) < maxConfigMapSize)
+
+	change := Change{}
+	err = json.Unmarshal([]byte(lines[0]), &change)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.HasPrefix(string(change.Diff), `"truncated diff of`))
+}
@@ -0,0 +1,357 @@
+package dryrun
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type Operation string
+
+// The config map recorder keeps the report below the size limit of a config map
+const (
+	// maxChangesPerSyncer is the maximum amount of objects that are recorded per syncer
+	maxChangesPerSyncer = 250
+
+	// maxDiffSize is the maximum size of a diff, bigger diffs are replaced by a summary
+	maxDiffSize = 8 * 1024
+
+	// maxConfigMapSize is the maximum size of the written config map data, which leaves some room
+	// below the 1 MiB limit of a config map for the rest of the object
+	maxConfigMapSize = 900 * 1024
+
+	// droppedKey is the config map key that contains the amount of changes per syncer that were not written
+	droppedKey = "dropped"
+)
+
+const (
+	OperationCreate Operation = "Create"
+	OperationUpdate Operation = "Update"
+	OperationPatch  Operation = "Patch"
+	OperationDelete Operation = "Delete"
+)
+
+// Change is a single write the syncer would have done in the host cluster
+type Change struct {
+	// Time is the time the change was recorded
+	Time time.Time `json:"time"`
+
+	// Syncer is the name of the syncer that wanted to apply the change
+	Syncer string `json:"syncer"`
+
+	// GroupVersionKind of the host object
+	GroupVersionKind string `json:"groupVersionKind"`
+
+	// Namespace and Name of the host object
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+
+	// Operation is the operation that would have been executed
+	Operation Operation `json:"operation"`
+
+	// SubResource is the sub resource that would have been written, e.g. status
+	SubResource string `json:"subResource,omitempty"`
+
+	// PatchType is the type of the patch for patch operations
+	PatchType string `json:"patchType,omitempty"`
+
+	// Diff is the full object for create operations and the patch for update and patch operations
+	Diff json.RawMessage `json:"diff,omitempty"`
+}
+
+// Recorder records the changes the syncer would have applied to the host cluster
+type Recorder interface {
+	Record(ctx context.Context, change Change) error
+}
+
+var (
+	defaultRecorderOnce sync.Once
+	defaultRecorder     Recorder
+	defaultRecorderErr  error
+)
+
+// RecorderFromContext returns the recorder configured in experimental.syncSettings.dryRun or nil if the
+// dry run mode is disabled. All syncers share the same recorder.
+func RecorderFromContext(ctx *synccontext.RegisterContext) (Recorder, error) {
+	if !ctx.Config.Experimental.SyncSettings.DryRun.Enabled {
+		return nil, nil
+	}
+
+	defaultRecorderOnce.Do(func() {
+		dryRunConfig := ctx.Config.Experimental.SyncSettings.DryRun
+		if dryRunConfig.ConfigMap != "" {
+			configMapRecorder := NewConfigMapRecorder(ctx.CurrentNamespaceClient, ctx.CurrentNamespace, dryRunConfig.ConfigMap)
+			go configMapRecorder.Start(ctx, 10*time.Second)
+			defaultRecorder = configMapRecorder
+			return
+		}
+
+		var out io.Writer = os.Stdout
+		if dryRunConfig.File != "" {
+			out, defaultRecorderErr = os.OpenFile(dryRunConfig.File, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+			if defaultRecorderErr != nil {
+				defaultRecorderErr = fmt.Errorf("open dry run file: %w", defaultRecorderErr)
+				return
+			}
+		}
+
+		defaultRecorder = NewFileRecorder(out)
+	})
+
+	return defaultRecorder, defaultRecorderErr
+}
+
+// NewFileRecorder creates a new recorder that writes every change as a json line to the given writer.
+// Changes that are identical to the last recorded change of the object are skipped.
+func NewFileRecorder(out io.Writer) Recorder {
+	return &fileRecorder{
+		out:  out,
+		last: map[string]string{},
+	}
+}
+
+type fileRecorder struct {
+	m    sync.Mutex
+	out  io.Writer
+	last map[string]string
+}
+
+func (f *fileRecorder) Record(_ context.Context, change Change) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	key := changeKey(change)
+	if f.last[key] == string(change.Diff) {
+		return nil
+	}
+
+	out, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.out.Write(append(out, '\n'))
+	if err != nil {
+		return err
+	}
+
+	f.last[key] = string(change.Diff)
+	return nil
+}
+
+// NewConfigMapRecorder creates a new recorder that keeps the latest change of each object and writes them into
+// the given config map. The config map contains a key per GroupVersionKind with a json line per object. To stay
+// below the size limit of a config map, the recorder keeps at most maxChangesPerSyncer objects per syncer, replaces
+// diffs bigger than maxDiffSize with a summary and counts the changes it couldn't write in the dropped key.
+func NewConfigMapRecorder(c client.Client, namespace, name string) *ConfigMapRecorder {
+	return &ConfigMapRecorder{
+		client:    c,
+		namespace: namespace,
+		name:      name,
+		changes:   map[string]map[string]Change{},
+		counts:    map[string]int{},
+		dropped:   map[string]int{},
+	}
+}
+
+type ConfigMapRecorder struct {
+	client    client.Client
+	namespace string
+	name      string
+
+	m       sync.Mutex
+	changes map[string]map[string]Change
+	counts  map[string]int
+	dropped map[string]int
+	dirty   bool
+}
+
+func (c *ConfigMapRecorder) Record(_ context.Context, change Change) error {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	dataKey := configMapKey(change.GroupVersionKind)
+	if c.changes[dataKey] == nil {
+		c.changes[dataKey] = map[string]Change{}
+	}
+
+	change.Diff = truncateDiff(change.Diff)
+	key := changeKey(change)
+	existing, ok := c.changes[dataKey][key]
+	if ok && string(existing.Diff) == string(change.Diff) {
+		return nil
+	} else if !ok {
+		if c.counts[change.Syncer] >= maxChangesPerSyncer {
+			if c.dropped[change.Syncer] == 0 {
+				klog.Warningf("Dry run report reached the limit of %d objects for syncer %s, further objects of this syncer are not recorded", maxChangesPerSyncer, change.Syncer)
+			}
+			c.dropped[change.Syncer]++
+			c.dirty = true
+			return nil
+		}
+
+		c.counts[change.Syncer]++
+	}
+
+	c.changes[dataKey][key] = change
+	c.dirty = true
+	return nil
+}
+
+// truncateDiff replaces diffs bigger than maxDiffSize with a json string that contains the size and hash of the diff
+func truncateDiff(diff json.RawMessage) json.RawMessage {
+	if len(diff) <= maxDiffSize {
+		return diff
+	}
+
+	summary, _ := json.Marshal(fmt.Sprintf("truncated diff of %d bytes (sha256 %x)", len(diff), sha256.Sum256(diff)))
+	return summary
+}
+
+// Start writes the recorded changes periodically into the config map until the context is done
+func (c *ConfigMapRecorder) Start(ctx context.Context, period time.Duration) {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		err := c.Flush(ctx)
+		if err != nil {
+			klog.Errorf("Error writing dry run report: %v", err)
+		}
+	}, period)
+}
+
+// Flush writes the recorded changes into the config map if there are new changes
+func (c *ConfigMapRecorder) Flush(ctx context.Context) error {
+	c.m.Lock()
+	if !c.dirty {
+		c.m.Unlock()
+		return nil
+	}
+
+	dataKeys := make([]string, 0, len(c.changes))
+	for dataKey := range c.changes {
+		dataKeys = append(dataKeys, dataKey)
+	}
+	sort.Strings(dataKeys)
+
+	dropped := map[string]int{}
+	for syncer, count := range c.dropped {
+		dropped[syncer] = count
+	}
+
+	size, overflow := 0, 0
+	data := map[string]string{}
+	for _, dataKey := range dataKeys {
+		changes := c.changes[dataKey]
+		keys := make([]string, 0, len(changes))
+		for key := range changes {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		lines := make([]string, 0, len(keys))
+		for _, key := range keys {
+			out, err := json.Marshal(changes[key])
+			if err != nil {
+				c.m.Unlock()
+				return err
+			}
+
+			// skip changes that don't fit into the config map anymore
+			if size+len(out)+1 > maxConfigMapSize {
+				dropped[changes[key].Syncer]++
+				overflow++
+				continue
+			}
+
+			size += len(out) + 1
+			lines = append(lines, string(out))
+		}
+		if len(lines) > 0 {
+			data[dataKey] = strings.Join(lines, "\n")
+		}
+	}
+	c.dirty = false
+	c.m.Unlock()
+
+	if overflow > 0 {
+		klog.Warningf("Dry run report exceeds the size of a config map, %d changes are not written", overflow)
+	}
+	if len(dropped) > 0 {
+		out, err := json.Marshal(dropped)
+		if err != nil {
+			return err
+		}
+
+		data[droppedKey] = string(out)
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err := c.client.Get(ctx, client.ObjectKey{Namespace: c.namespace, Name: c.name}, configMap)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			c.markDirty()
+			return fmt.Errorf("get config map: %w", err)
+		}
+
+		err = c.client.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: c.namespace,
+				Name:      c.name,
+			},
+			Data: data,
+		})
+		if err != nil {
+			c.markDirty()
+			return fmt.Errorf("create config map: %w", err)
+		}
+
+		return nil
+	}
+
+	configMap.Data = data
+	err = c.client.Update(ctx, configMap)
+	if err != nil {
+		c.markDirty()
+		return fmt.Errorf("update config map: %w", err)
+	}
+
+	return nil
+}
+
+func (c *ConfigMapRecorder) markDirty() {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.dirty = true
+}
+
+func changeKey(change Change) string {
+	return strings.Join([]string{change.GroupVersionKind, change.Namespace, change.Name, change.SubResource}, "/")
+}
+
+// configMapKey converts the group version kind into a valid config map key, e.g. Deployment.v1.apps
+func configMapKey(gvk string) string {
+	apiVersion, kind, _ := strings.Cut(gvk, ", Kind=")
+	group, version, found := strings.Cut(apiVersion, "/")
+	if !found {
+		return kind + "." + group
+	} else if group == "" {
+		return kind + "." + version
+	}
+
+	return kind + "." + version + "." + group
+}
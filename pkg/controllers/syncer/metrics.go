@@ -0,0 +1,163 @@
+package syncer
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var (
+	settingsMaxConcurrentReconciles = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vcluster_syncer_max_concurrent_reconciles",
+		Help: "Effective number of parallel reconciles of the syncer.",
+	}, []string{"controller"})
+	settingsBackoffBaseDelay = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vcluster_syncer_backoff_base_delay_seconds",
+		Help: "Effective delay after the first failed reconcile of an object.",
+	}, []string{"controller"})
+	settingsBackoffMaxDelay = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vcluster_syncer_backoff_max_delay_seconds",
+		Help: "Effective maximum delay between retries of a failed object.",
+	}, []string{"controller"})
+	settingsQPS = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vcluster_syncer_rate_limit_qps",
+		Help: "Effective number of reconciles per second the syncer is allowed to queue.",
+	}, []string{"controller"})
+	settingsBurst = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vcluster_syncer_rate_limit_burst",
+		Help: "Effective number of reconciles the syncer is allowed to queue at once.",
+	}, []string{"controller"})
+	settingsResyncPeriod = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vcluster_syncer_resync_period_seconds",
+		Help: "Effective period of the full resync of the syncer, 0 if disabled.",
+	}, []string{"controller"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		settingsMaxConcurrentReconciles,
+		settingsBackoffBaseDelay,
+		settingsBackoffMaxDelay,
+		settingsQPS,
+		settingsBurst,
+		settingsResyncPeriod,
+	)
+}
+
+// recordSettings exposes the effective settings of the controller as metrics
+func recordSettings(name string, settings *controllerSettings) {
+	settingsMaxConcurrentReconciles.WithLabelValues(name).Set(float64(settings.MaxConcurrentReconciles))
+	settingsBackoffBaseDelay.WithLabelValues(name).Set(settings.BackoffBaseDelay.Seconds())
+	settingsBackoffMaxDelay.WithLabelValues(name).Set(settings.BackoffMaxDelay.Seconds())
+	settingsQPS.WithLabelValues(name).Set(settings.QPS)
+	settingsBurst.WithLabelValues(name).Set(float64(settings.Burst))
+	settingsResyncPeriod.WithLabelValues(name).Set(settings.ResyncPeriod.Seconds())
+}
+
+const (
+	directionVirtualToHost = "VirtualToHost"
+	directionHostToVirtual = "HostToVirtual"
+)
+
+var (
+	syncDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "vcluster_syncer_sync_duration_seconds",
+		Help:    "Duration of a single sync of an object between the virtual and host cluster.",
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 15),
+	}, []string{"controller", "gvk", "direction"})
+	syncErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "vcluster_syncer_errors_total",
+		Help: "Number of reconciles of the syncer that returned an error.",
+	}, []string{"controller", "gvk"})
+	syncOperations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "vcluster_syncer_operations_total",
+		Help: "Number of objects the syncer created, updated or deleted.",
+	}, []string{"controller", "gvk", "cluster", "operation"})
+	syncConflicts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "vcluster_syncer_conflicts_total",
+		Help: "Number of conflicts the syncer ran into, either because of an unmanaged host object with the desired name, a host object that belongs to another virtual object or an outdated object.",
+	}, []string{"controller", "gvk", "reason"})
+	syncExclusions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "vcluster_syncer_exclusions_total",
+		Help: "Number of objects the syncer skipped because they are excluded from syncing.",
+	}, []string{"controller", "gvk", "cluster"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		syncDuration,
+		syncErrors,
+		syncOperations,
+		syncConflicts,
+		syncExclusions,
+	)
+}
+
+// gvkLabel formats the group version kind as label value, e.g. apps/v1/Deployment
+func gvkLabel(gvk schema.GroupVersionKind) string {
+	return gvk.GroupVersion().String() + "/" + gvk.Kind
+}
+
+// registerOldestUnsyncedGauge exposes the age of the oldest object of the controller that is queued, but not synced yet
+func registerOldestUnsyncedGauge(controller, gvk string, unsynced *unsyncedObjects) {
+	err := metrics.Registry.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "vcluster_syncer_oldest_unsynced_object_age_seconds",
+		Help: "Age of the oldest object that was queued, but not successfully synced yet.",
+		ConstLabels: prometheus.Labels{
+			"controller": controller,
+			"gvk":        gvk,
+		},
+	}, unsynced.OldestAge))
+	if err != nil && !errors.As(err, &prometheus.AlreadyRegisteredError{}) {
+		klog.Errorf("Error registering oldest unsynced object metric for %s: %v", controller, err)
+	}
+}
+
+// unsyncedObjects tracks when an object was queued for the first time since its last successful sync
+type unsyncedObjects struct {
+	m       sync.Mutex
+	objects map[string]time.Time
+}
+
+func newUnsyncedObjects() *unsyncedObjects {
+	return &unsyncedObjects{
+		objects: map[string]time.Time{},
+	}
+}
+
+func (u *unsyncedObjects) Add(req reconcile.Request) {
+	u.m.Lock()
+	defer u.m.Unlock()
+
+	key := req.String()
+	if _, ok := u.objects[key]; !ok {
+		u.objects[key] = time.Now()
+	}
+}
+
+func (u *unsyncedObjects) Remove(req reconcile.Request) {
+	u.m.Lock()
+	defer u.m.Unlock()
+
+	delete(u.objects, req.String())
+}
+
+func (u *unsyncedObjects) OldestAge() float64 {
+	u.m.Lock()
+	defer u.m.Unlock()
+
+	oldest := time.Duration(0)
+	for _, queued := range u.objects {
+		if age := time.Since(queued); age > oldest {
+			oldest = age
+		}
+	}
+
+	return oldest.Seconds()
+}
@@ -0,0 +1,86 @@
+package syncer
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// newMetricsClient wraps the given client and counts the successful writes of the controller
+func newMetricsClient(c client.Client, controller, cluster string) client.Client {
+	return &metricsClient{
+		Client:     c,
+		controller: controller,
+		cluster:    cluster,
+	}
+}
+
+type metricsClient struct {
+	client.Client
+
+	controller string
+	cluster    string
+}
+
+func (m *metricsClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	err := m.Client.Create(ctx, obj, opts...)
+	m.count(obj, "create", err)
+	return err
+}
+
+func (m *metricsClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	err := m.Client.Update(ctx, obj, opts...)
+	m.count(obj, "update", err)
+	return err
+}
+
+func (m *metricsClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	err := m.Client.Patch(ctx, obj, patch, opts...)
+	m.count(obj, "update", err)
+	return err
+}
+
+func (m *metricsClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	err := m.Client.Delete(ctx, obj, opts...)
+	m.count(obj, "delete", err)
+	return err
+}
+
+func (m *metricsClient) count(obj client.Object, operation string, err error) {
+	if err != nil {
+		return
+	}
+
+	gvk, err := apiutil.GVKForObject(obj, m.Scheme())
+	if err != nil {
+		return
+	}
+
+	syncOperations.WithLabelValues(m.controller, gvkLabel(gvk), m.cluster, operation).Inc()
+}
+
+func (m *metricsClient) Status() client.SubResourceWriter {
+	return &metricsStatusWriter{
+		SubResourceWriter: m.Client.Status(),
+		client:            m,
+	}
+}
+
+type metricsStatusWriter struct {
+	client.SubResourceWriter
+
+	client *metricsClient
+}
+
+func (m *metricsStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	err := m.SubResourceWriter.Update(ctx, obj, opts...)
+	m.client.count(obj, "update", err)
+	return err
+}
+
+func (m *metricsStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	err := m.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+	m.client.count(obj, "update", err)
+	return err
+}
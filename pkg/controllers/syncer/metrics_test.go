@@ -0,0 +1,57 @@
+package syncer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	testingutil "github.com/loft-sh/vcluster/pkg/util/testing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestUnsyncedObjects(t *testing.T) {
+	unsynced := newUnsyncedObjects()
+	assert.Equal(t, unsynced.OldestAge(), float64(0))
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "test"}}
+	unsynced.Add(req)
+	time.Sleep(10 * time.Millisecond)
+	assert.Assert(t, unsynced.OldestAge() > 0)
+
+	// adding the same object again should not reset the age
+	age := unsynced.OldestAge()
+	unsynced.Add(req)
+	assert.Assert(t, unsynced.OldestAge() >= age)
+
+	unsynced.Remove(req)
+	assert.Equal(t, unsynced.OldestAge(), float64(0))
+}
+
+func TestMetricsClient(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := testingutil.NewFakeClient(scheme.Scheme)
+	metricsClient := newMetricsClient(fakeClient, "metrics-test", "host")
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+	}
+	assert.NilError(t, metricsClient.Create(ctx, configMap))
+	assert.NilError(t, metricsClient.Update(ctx, configMap))
+	assert.NilError(t, metricsClient.Delete(ctx, configMap))
+
+	// failed writes are not counted
+	assert.Assert(t, metricsClient.Delete(ctx, configMap) != nil)
+
+	assert.Equal(t, testutil.ToFloat64(syncOperations.WithLabelValues("metrics-test", "v1/ConfigMap", "host", "create")), float64(1))
+	assert.Equal(t, testutil.ToFloat64(syncOperations.WithLabelValues("metrics-test", "v1/ConfigMap", "host", "update")), float64(1))
+	assert.Equal(t, testutil.ToFloat64(syncOperations.WithLabelValues("metrics-test", "v1/ConfigMap", "host", "delete")), float64(1))
+}
@@ -0,0 +1,53 @@
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/patches"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// newSyncerPatches returns the patches configured in sync.toHost or sync.fromHost for the built-in syncer with the given name
+func newSyncerPatches(sync config.Sync, name string) (*patches.SyncerPatches, error) {
+	// the gateway api syncers apply their patches themselves
+	builtInSyncer, ok := sync.BuiltInSyncer(name)
+	if !ok || strings.HasSuffix(name, config.GatewayAPISyncerSuffix) {
+		return nil, nil
+	}
+
+	return patches.NewSyncerPatches(builtInSyncer.ToHost, builtInSyncer.SyncPatches)
+}
+
+// newPatchesClient wraps the given client and applies the configured patches to the objects of the syncer before they are created.
+// Updates are patched by the syncer patcher instead.
+func newPatchesClient(c client.Client, gvk schema.GroupVersionKind, apply func(obj client.Object) error) client.Client {
+	return &patchesClient{
+		Client: c,
+		gvk:    gvk,
+		apply:  apply,
+	}
+}
+
+type patchesClient struct {
+	client.Client
+
+	gvk   schema.GroupVersionKind
+	apply func(obj client.Object) error
+}
+
+func (p *patchesClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	gvk, err := apiutil.GVKForObject(obj, p.Scheme())
+	if err == nil && gvk == p.gvk {
+		err = p.apply(obj)
+		if err != nil {
+			return fmt.Errorf("apply patches: %w", err)
+		}
+	}
+
+	return p.Client.Create(ctx, obj, opts...)
+}
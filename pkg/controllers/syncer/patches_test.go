@@ -0,0 +1,65 @@
+package syncer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/patches"
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	testingutil "github.com/loft-sh/vcluster/pkg/util/testing"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestNewSyncerPatches(t *testing.T) {
+	patch := &config.Patch{Operation: config.PatchTypeAdd, Path: "metadata.labels.team", Value: "a"}
+	sync := config.Sync{}
+	sync.ToHost.Ingresses.Patches = []*config.Patch{patch}
+	sync.FromHost.Secrets.Patches = []*config.Patch{patch}
+
+	ingressPatches, err := newSyncerPatches(sync, "ingress")
+	assert.NilError(t, err)
+	assert.Equal(t, ingressPatches.Host[0], patch)
+	assert.Equal(t, len(ingressPatches.Virtual), 0)
+
+	hostSecretPatches, err := newSyncerPatches(sync, "host-secret")
+	assert.NilError(t, err)
+	assert.Equal(t, hostSecretPatches.Virtual[0], patch)
+	assert.Equal(t, len(hostSecretPatches.Host), 0)
+
+	// syncers without patches
+	secretPatches, err := newSyncerPatches(sync, "secret")
+	assert.NilError(t, err)
+	assert.Assert(t, secretPatches == nil)
+	unknownPatches, err := newSyncerPatches(sync, "unknown")
+	assert.NilError(t, err)
+	assert.Assert(t, unknownPatches == nil)
+}
+
+func TestPatchesClient(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := testingutil.NewFakeClient(scheme.Scheme)
+	syncerPatches := &patches.SyncerPatches{
+		Host: []*config.Patch{{Operation: config.PatchTypeAdd, Path: "metadata.annotations", Value: map[string]interface{}{"host-only": "true"}}},
+	}
+	vConfigMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+	patchesClient := newPatchesClient(fakeClient, corev1.SchemeGroupVersion.WithKind("ConfigMap"), func(obj client.Object) error {
+		return syncerPatches.ApplyHost(obj, vConfigMap)
+	})
+
+	// objects of the syncer are patched
+	assert.NilError(t, patchesClient.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"}}))
+	configMap := &corev1.ConfigMap{}
+	assert.NilError(t, fakeClient.Get(ctx, types.NamespacedName{Name: "test", Namespace: "test"}, configMap))
+	assert.DeepEqual(t, configMap.Annotations, map[string]string{"host-only": "true"})
+
+	// other objects are created as is
+	assert.NilError(t, patchesClient.Create(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"}}))
+	secret := &corev1.Secret{}
+	assert.NilError(t, fakeClient.Get(ctx, types.NamespacedName{Name: "test", Namespace: "test"}, secret))
+	assert.Assert(t, secret.Annotations == nil)
+}
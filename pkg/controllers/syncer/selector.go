@@ -0,0 +1,80 @@
+package syncer
+
+import (
+	"fmt"
+
+	"github.com/loft-sh/vcluster/config"
+	syncertypes "github.com/loft-sh/vcluster/pkg/controllers/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/util/labelselector"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// newSyncerSelector returns the selector configured in sync.toHost for the built-in syncer with the given name
+// or nil if all objects should get synced
+func newSyncerSelector(sync config.Sync, name string) (syncertypes.ObjectExcluder, error) {
+	builtInSyncer, ok := sync.BuiltInSyncer(name)
+	if !ok || builtInSyncer.Selector == nil || builtInSyncer.Selector.IsEmpty() {
+		return nil, nil
+	}
+
+	selector := builtInSyncer.Selector
+	labelSelector, err := labelselector.FromConfig(*selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector: %w", err)
+	}
+
+	return &objectSelector{
+		labelSelector: labelSelector,
+		include:       toSet(selector.Namespaces.Include),
+		exclude:       toSet(selector.Namespaces.Exclude),
+		matchName:     name == "namespace",
+	}, nil
+}
+
+func toSet(values []string) map[string]bool {
+	if len(values) == 0 {
+		return nil
+	}
+
+	set := map[string]bool{}
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
+
+var _ syncertypes.ObjectExcluder = &objectSelector{}
+
+// objectSelector excludes virtual objects that do not match the configured labels or namespaces
+type objectSelector struct {
+	labelSelector labels.Selector
+
+	include map[string]bool
+	exclude map[string]bool
+
+	// matchName matches the namespace lists against the object name, which is used for namespaces
+	matchName bool
+}
+
+func (s *objectSelector) ExcludeVirtual(vObj client.Object) bool {
+	if !s.labelSelector.Matches(labels.Set(vObj.GetLabels())) {
+		return true
+	}
+
+	namespace := vObj.GetNamespace()
+	if s.matchName {
+		namespace = vObj.GetName()
+	} else if namespace == "" {
+		return false
+	}
+
+	if s.exclude[namespace] {
+		return true
+	}
+	return s.include != nil && !s.include[namespace]
+}
+
+func (s *objectSelector) ExcludePhysical(_ client.Object) bool {
+	return false
+}
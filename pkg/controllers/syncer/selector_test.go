@@ -0,0 +1,143 @@
+package syncer
+
+import (
+	"context"
+	"testing"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	syncertypes "github.com/loft-sh/vcluster/pkg/controllers/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/mappings/resources"
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	testingutil "github.com/loft-sh/vcluster/pkg/util/testing"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"github.com/moby/locker"
+
+	generictesting "github.com/loft-sh/vcluster/pkg/controllers/syncer/testing"
+	"github.com/loft-sh/vcluster/pkg/util/loghelper"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestNewSyncerSelector(t *testing.T) {
+	sync := vclusterconfig.Sync{}
+	sync.ToHost.Secrets.Selector = vclusterconfig.SyncToHostSelector{
+		LabelSelector:    map[string]string{"team": "a"},
+		MatchExpressions: []vclusterconfig.LabelSelectorRequirement{{Key: "vcluster.loft.sh/skip-sync", Operator: "DoesNotExist"}},
+		Namespaces:       vclusterconfig.SyncToHostSelectorNamespaces{Exclude: []string{"local-dev"}},
+	}
+	sync.ToHost.Namespaces.Selector.Namespaces.Include = []string{"team-a", "team-b"}
+	sync.ToHost.GatewayAPI.Selector.LabelSelector = map[string]string{"expose": "true"}
+
+	secretSelector, err := newSyncerSelector(sync, "secret")
+	assert.NilError(t, err)
+	secret := func(namespace string, labels map[string]string) client.Object {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: namespace, Labels: labels}}
+	}
+	assert.Assert(t, !secretSelector.ExcludeVirtual(secret("default", map[string]string{"team": "a"})))
+	assert.Assert(t, secretSelector.ExcludeVirtual(secret("default", map[string]string{"team": "b"})))
+	assert.Assert(t, secretSelector.ExcludeVirtual(secret("default", map[string]string{"team": "a", "vcluster.loft.sh/skip-sync": "true"})))
+	assert.Assert(t, secretSelector.ExcludeVirtual(secret("local-dev", map[string]string{"team": "a"})))
+	assert.Assert(t, !secretSelector.ExcludePhysical(secret("default", nil)))
+
+	// namespaces are matched by name
+	namespaceSelector, err := newSyncerSelector(sync, "namespace")
+	assert.NilError(t, err)
+	assert.Assert(t, !namespaceSelector.ExcludeVirtual(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}))
+	assert.Assert(t, namespaceSelector.ExcludeVirtual(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}))
+
+	// gateway api syncers share one selector
+	gatewaySelector, err := newSyncerSelector(sync, "HTTPRoute/gateway.networking.k8s.io/GatewayAPI")
+	assert.NilError(t, err)
+	assert.Assert(t, gatewaySelector.ExcludeVirtual(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}))
+
+	// cluster scoped objects are not filtered by namespace
+	sync.ToHost.PriorityClasses.Selector.Namespaces.Include = []string{"team-a"}
+	priorityClassSelector, err := newSyncerSelector(sync, "priorityclass")
+	assert.NilError(t, err)
+	assert.Assert(t, !priorityClassSelector.ExcludeVirtual(&corev1.Namespace{}))
+
+	// syncers without selectors
+	configMapSelector, err := newSyncerSelector(sync, "configmap")
+	assert.NilError(t, err)
+	assert.Assert(t, configMapSelector == nil)
+
+	sync.ToHost.ConfigMaps.Selector.MatchExpressions = []vclusterconfig.LabelSelectorRequirement{{Key: "team", Operator: "In"}}
+	_, err = newSyncerSelector(sync, "configmap")
+	assert.ErrorContains(t, err, "invalid label selector")
+}
+
+func TestReconcileOutOfScope(t *testing.T) {
+	ctx := context.Background()
+	vSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "a",
+			Namespace: namespaceInVclusterA,
+			Labels:    map[string]string{"vcluster.loft.sh/skip-sync": "true"},
+		},
+	}
+	pSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      translate.Default.PhysicalName("a", namespaceInVclusterA),
+			Namespace: vclusterNamespace,
+			Annotations: map[string]string{
+				translate.NameAnnotation:      "a",
+				translate.NamespaceAnnotation: namespaceInVclusterA,
+				translate.KindAnnotation:      corev1.SchemeGroupVersion.WithKind("Secret").String(),
+			},
+			Labels: map[string]string{
+				translate.MarkerLabel: translate.VClusterName,
+			},
+		},
+	}
+	pClient := testingutil.NewFakeClient(scheme.Scheme, pSecret.DeepCopy())
+	vClient := testingutil.NewFakeClient(scheme.Scheme, vSecret.DeepCopy())
+	fakeContext := generictesting.NewFakeRegisterContext(generictesting.NewFakeConfig(), pClient, vClient)
+	resources.MustRegisterMappings(fakeContext)
+
+	sync := vclusterconfig.Sync{}
+	sync.ToHost.Secrets.Selector.MatchExpressions = []vclusterconfig.LabelSelectorRequirement{{Key: "vcluster.loft.sh/skip-sync", Operator: "DoesNotExist"}}
+	selector, err := newSyncerSelector(sync, "secret")
+	assert.NilError(t, err)
+
+	syncerImpl, err := NewMockSyncer(fakeContext)
+	assert.NilError(t, err)
+	syncer := syncerImpl.(syncertypes.Syncer)
+	controller := &SyncController{
+		syncer:         syncer,
+		selector:       selector,
+		log:            loghelper.New(syncer.Name()),
+		vEventRecorder: &testingutil.FakeEventRecorder{},
+		physicalClient: pClient,
+
+		currentNamespace:       fakeContext.CurrentNamespace,
+		currentNamespaceClient: fakeContext.CurrentNamespaceClient,
+
+		virtualClient: vClient,
+		options:       &syncertypes.Options{},
+
+		locker: locker.New(),
+	}
+
+	// the host object of the out of scope virtual object is deleted
+	_, err = controller.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(vSecret)})
+	assert.NilError(t, err)
+	err = pClient.Get(ctx, client.ObjectKeyFromObject(pSecret), &corev1.Secret{})
+	assert.Assert(t, kerrors.IsNotFound(err))
+
+	// and not recreated
+	_, err = controller.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(vSecret)})
+	assert.NilError(t, err)
+	err = pClient.Get(ctx, client.ObjectKeyFromObject(pSecret), &corev1.Secret{})
+	assert.Assert(t, kerrors.IsNotFound(err))
+
+	// the object is synced again once it is back in scope
+	vSecret.Labels = nil
+	assert.NilError(t, vClient.Update(ctx, vSecret))
+	_, err = controller.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(vSecret)})
+	assert.NilError(t, err)
+	assert.NilError(t, pClient.Get(ctx, client.ObjectKeyFromObject(pSecret), &corev1.Secret{}))
+}
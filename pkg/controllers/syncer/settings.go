@@ -0,0 +1,88 @@
+package syncer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/loft-sh/vcluster/config"
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	defaultMaxConcurrentReconciles = 10
+	defaultBackoffBaseDelay        = 5 * time.Millisecond
+	defaultBackoffMaxDelay         = 1000 * time.Second
+	defaultQPS                     = 10
+	defaultBurst                   = 100
+)
+
+// controllerSettings are the effective settings of a single sync controller
+type controllerSettings struct {
+	MaxConcurrentReconciles int
+
+	BackoffBaseDelay time.Duration
+	BackoffMaxDelay  time.Duration
+
+	QPS   float64
+	Burst int
+
+	ResyncPeriod time.Duration
+}
+
+// newControllerSettings merges the configured settings for the syncer with the controller-runtime defaults
+func newControllerSettings(syncSettings config.ExperimentalSyncSettings, name string) (*controllerSettings, error) {
+	settings := &controllerSettings{
+		MaxConcurrentReconciles: defaultMaxConcurrentReconciles,
+		BackoffBaseDelay:        defaultBackoffBaseDelay,
+		BackoffMaxDelay:         defaultBackoffMaxDelay,
+		QPS:                     defaultQPS,
+		Burst:                   defaultBurst,
+	}
+
+	controllerConfig, ok := syncSettings.Controllers[name]
+	if !ok {
+		return settings, nil
+	}
+
+	if controllerConfig.MaxConcurrentReconciles > 0 {
+		settings.MaxConcurrentReconciles = controllerConfig.MaxConcurrentReconciles
+	}
+	if controllerConfig.QPS > 0 {
+		settings.QPS = controllerConfig.QPS
+	}
+	if controllerConfig.Burst > 0 {
+		settings.Burst = controllerConfig.Burst
+	}
+
+	var err error
+	if controllerConfig.Backoff.BaseDelay != "" {
+		settings.BackoffBaseDelay, err = time.ParseDuration(controllerConfig.Backoff.BaseDelay)
+		if err != nil {
+			return nil, fmt.Errorf("parse backoff.baseDelay: %w", err)
+		}
+	}
+	if controllerConfig.Backoff.MaxDelay != "" {
+		settings.BackoffMaxDelay, err = time.ParseDuration(controllerConfig.Backoff.MaxDelay)
+		if err != nil {
+			return nil, fmt.Errorf("parse backoff.maxDelay: %w", err)
+		}
+	}
+	if controllerConfig.ResyncPeriod != "" {
+		settings.ResyncPeriod, err = time.ParseDuration(controllerConfig.ResyncPeriod)
+		if err != nil {
+			return nil, fmt.Errorf("parse resyncPeriod: %w", err)
+		}
+	}
+
+	return settings, nil
+}
+
+// RateLimiter returns the same kind of rate limiter controller-runtime uses by default, which is a per object
+// exponential backoff combined with an overall token bucket.
+func (c *controllerSettings) RateLimiter() workqueue.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(c.BackoffBaseDelay, c.BackoffMaxDelay),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(c.QPS), c.Burst)},
+	)
+}
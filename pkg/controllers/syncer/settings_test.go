@@ -0,0 +1,64 @@
+package syncer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/loft-sh/vcluster/config"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gotest.tools/assert"
+)
+
+func TestControllerSettings(t *testing.T) {
+	syncSettings := config.ExperimentalSyncSettings{
+		Controllers: map[string]config.ExperimentalSyncSettingsController{
+			"secret": {
+				MaxConcurrentReconciles: 50,
+				QPS:                     0.5,
+				Backoff: config.ExperimentalSyncSettingsControllerBackoff{
+					MaxDelay: "1m",
+				},
+				ResyncPeriod: "10m",
+			},
+			"invalid": {
+				ResyncPeriod: "ten minutes",
+			},
+		},
+	}
+
+	// defaults
+	settings, err := newControllerSettings(syncSettings, "pod")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, settings, &controllerSettings{
+		MaxConcurrentReconciles: defaultMaxConcurrentReconciles,
+		BackoffBaseDelay:        defaultBackoffBaseDelay,
+		BackoffMaxDelay:         defaultBackoffMaxDelay,
+		QPS:                     defaultQPS,
+		Burst:                   defaultBurst,
+	})
+
+	// overrides
+	settings, err = newControllerSettings(syncSettings, "secret")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, settings, &controllerSettings{
+		MaxConcurrentReconciles: 50,
+		BackoffBaseDelay:        defaultBackoffBaseDelay,
+		BackoffMaxDelay:         time.Minute,
+		QPS:                     0.5,
+		Burst:                   defaultBurst,
+		ResyncPeriod:            10 * time.Minute,
+	})
+
+	// exponential backoff
+	rateLimiter := settings.RateLimiter()
+	assert.Equal(t, rateLimiter.When("test"), defaultBackoffBaseDelay)
+	assert.Equal(t, rateLimiter.When("test"), 2*defaultBackoffBaseDelay)
+
+	// metrics
+	recordSettings("secret", settings)
+	assert.Equal(t, testutil.ToFloat64(settingsMaxConcurrentReconciles.WithLabelValues("secret")), float64(50))
+	assert.Equal(t, testutil.ToFloat64(settingsResyncPeriod.WithLabelValues("secret")), float64(600))
+
+	_, err = newControllerSettings(syncSettings, "invalid")
+	assert.ErrorContains(t, err, "parse resyncPeriod")
+}
@@ -8,15 +8,22 @@ import (
 
 	"github.com/loft-sh/vcluster/pkg/constants"
 	syncertypes "github.com/loft-sh/vcluster/pkg/controllers/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/patches"
 	"github.com/loft-sh/vcluster/pkg/util/translate"
 	"github.com/moby/locker"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	controller2 "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	"github.com/loft-sh/vcluster/pkg/controllers/syncer/dryrun"
 	"github.com/loft-sh/vcluster/pkg/util/loghelper"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -38,8 +45,42 @@ func NewSyncController(ctx *synccontext.RegisterContext, syncer syncertypes.Sync
 		options = optionsProvider.Options()
 	}
 
+	// get the concurrency and rate limiting settings of the controller
+	settings, err := newControllerSettings(ctx.Config.Experimental.SyncSettings, syncer.Name())
+	if err != nil {
+		return nil, fmt.Errorf("experimental.syncSettings.controllers[%s]: %w", syncer.Name(), err)
+	}
+
+	// in dry run mode all host writes are recorded instead of applied
+	dryRunRecorder, err := dryrun.RecorderFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// resolve the group version kind for the metrics
+	gvk, err := apiutil.GVKForObject(syncer.Resource(), ctx.VirtualManager.GetScheme())
+	if err != nil {
+		return nil, fmt.Errorf("retrieve gvk for %s: %w", syncer.Name(), err)
+	}
+
+	// patches that are applied after the built-in translation
+	syncerPatches, err := newSyncerPatches(ctx.Config.Sync, syncer.Name())
+	if err != nil {
+		return nil, fmt.Errorf("patches for %s: %w", syncer.Name(), err)
+	}
+
+	// selector that limits which virtual objects are synced
+	selector, err := newSyncerSelector(ctx.Config.Sync, syncer.Name())
+	if err != nil {
+		return nil, fmt.Errorf("selector for %s: %w", syncer.Name(), err)
+	}
+
 	return &SyncController{
-		syncer: syncer,
+		syncer:      syncer,
+		gvk:         gvkLabel(gvk),
+		resourceGVK: gvk,
+		patches:     syncerPatches,
+		selector:    selector,
 
 		log:            loghelper.New(syncer.Name()),
 		vEventRecorder: ctx.VirtualManager.GetEventRecorderFor(syncer.Name() + "-syncer"),
@@ -51,6 +92,10 @@ func NewSyncController(ctx *synccontext.RegisterContext, syncer syncertypes.Sync
 		virtualClient: ctx.VirtualManager.GetClient(),
 		options:       options,
 
+		dryRunRecorder: dryRunRecorder,
+		settings:       settings,
+		unsynced:       newUnsyncedObjects(),
+
 		locker: locker.New(),
 	}, nil
 }
@@ -65,7 +110,11 @@ func RegisterSyncer(ctx *synccontext.RegisterContext, syncer syncertypes.Syncer)
 }
 
 type SyncController struct {
-	syncer syncertypes.Syncer
+	syncer      syncertypes.Syncer
+	gvk         string
+	resourceGVK schema.GroupVersionKind
+	patches     *patches.SyncerPatches
+	selector    syncertypes.ObjectExcluder
 
 	log            loghelper.Logger
 	vEventRecorder record.EventRecorder
@@ -78,10 +127,19 @@ type SyncController struct {
 	virtualClient client.Client
 	options       *syncertypes.Options
 
+	dryRunRecorder dryrun.Recorder
+	settings       *controllerSettings
+	unsynced       *unsyncedObjects
+
 	locker *locker.Locker
 }
 
-func (r *SyncController) Reconcile(ctx context.Context, origReq ctrl.Request) (_ ctrl.Result, err error) {
+func (r *SyncController) Reconcile(ctx context.Context, origReq ctrl.Request) (result ctrl.Result, err error) {
+	// track errors and objects that are not synced yet
+	defer func(req ctrl.Request) {
+		r.observeReconcile(req, result, err)
+	}(origReq)
+
 	// extract if this was a delete request
 	origReq, isDelete := fromDeleteRequest(origReq)
 
@@ -113,12 +171,18 @@ func (r *SyncController) Reconcile(ctx context.Context, origReq ctrl.Request) (_
 	syncContext := &synccontext.SyncContext{
 		Context:                ctx,
 		Log:                    log,
-		PhysicalClient:         r.physicalClient,
+		PhysicalClient:         newMetricsClient(r.physicalClient, r.syncer.Name(), "host"),
 		CurrentNamespace:       r.currentNamespace,
-		CurrentNamespaceClient: r.currentNamespaceClient,
-		VirtualClient:          r.virtualClient,
+		CurrentNamespaceClient: newMetricsClient(r.currentNamespaceClient, r.syncer.Name(), "host"),
+		VirtualClient:          newMetricsClient(r.virtualClient, r.syncer.Name(), "virtual"),
 		EventSource:            eventSource,
 		IsDelete:               isDelete,
+		Patches:                r.patches,
+	}
+	if r.dryRunRecorder != nil {
+		syncContext.DryRun = true
+		syncContext.PhysicalClient = dryrun.NewClient(syncContext.PhysicalClient, r.dryRunRecorder, r.syncer.Name())
+		syncContext.CurrentNamespaceClient = dryrun.NewClient(syncContext.CurrentNamespaceClient, r.dryRunRecorder, r.syncer.Name())
 	}
 
 	// check if we should skip reconcile
@@ -131,12 +195,37 @@ func (r *SyncController) Reconcile(ctx context.Context, origReq ctrl.Request) (_
 		}
 	}
 
+	// delete the host object if the virtual object is out of the selector scope
+	if r.selector != nil {
+		outOfScope, err := r.deleteOutOfScope(syncContext, vReq)
+		if err != nil || outOfScope {
+			return ctrl.Result{}, err
+		}
+	}
+
 	// retrieve the objects
 	vObj, pObj, err := r.getObjects(syncContext, vReq, pReq)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
+	// apply the configured patches to the objects the syncer creates
+	if r.patches != nil {
+		syncContext.PhysicalClient = newPatchesClient(syncContext.PhysicalClient, r.resourceGVK, func(obj client.Object) error {
+			return r.patches.ApplyHost(obj, vObj)
+		})
+		syncContext.VirtualClient = newPatchesClient(syncContext.VirtualClient, r.resourceGVK, func(obj client.Object) error {
+			return r.patches.ApplyVirtual(obj, pObj)
+		})
+	}
+
+	// measure how long the sync takes
+	if vObj != nil || pObj != nil {
+		defer func(direction string, start time.Time) {
+			syncDuration.WithLabelValues(r.syncer.Name(), r.gvk, direction).Observe(time.Since(start).Seconds())
+		}(r.syncDirection(eventSource, vObj, pObj), time.Now())
+	}
+
 	// check what function we should call
 	if vObj != nil && pObj != nil {
 		// make sure the object uid matches
@@ -174,6 +263,51 @@ func (r *SyncController) Reconcile(ctx context.Context, origReq ctrl.Request) (_
 	return ctrl.Result{}, nil
 }
 
+// deleteOutOfScope deletes the host object of a virtual object that does not match the selector anymore and returns
+// true if the virtual object is out of scope
+func (r *SyncController) deleteOutOfScope(ctx *synccontext.SyncContext, vReq ctrl.Request) (bool, error) {
+	vObj := r.syncer.Resource()
+	err := r.virtualClient.Get(ctx, vReq.NamespacedName, vObj)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("get virtual object: %w", err)
+	} else if !r.selector.ExcludeVirtual(vObj) {
+		return false, nil
+	}
+
+	syncExclusions.WithLabelValues(r.syncer.Name(), r.gvk, "virtual").Inc()
+	pName := r.syncer.VirtualToHost(ctx, vReq.NamespacedName, vObj)
+	if pName.Name == "" {
+		return true, nil
+	}
+
+	pObj := r.syncer.Resource()
+	err = r.physicalClient.Get(ctx, pName, pObj)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return true, nil
+		}
+
+		return false, fmt.Errorf("get physical object: %w", err)
+	} else if pObj.GetDeletionTimestamp() != nil {
+		return true, nil
+	}
+
+	// only delete host objects that were synced from this virtual object
+	isManaged, err := r.syncer.IsManaged(ctx, pObj)
+	if err != nil {
+		return false, fmt.Errorf("failed to check if physical object is managed: %w", err)
+	} else if !isManaged || isNameCollision(vObj, pObj) {
+		return true, nil
+	}
+
+	_, err = DeleteHostObject(ctx, pObj, "virtual object is out of the selector scope")
+	return true, err
+}
+
 func (r *SyncController) getObjects(ctx *synccontext.SyncContext, vReq, pReq ctrl.Request) (vObj client.Object, pObj client.Object, err error) {
 	// if we got a host request, we retrieve host object first
 	if pReq.Name != "" {
@@ -244,6 +378,7 @@ func (r *SyncController) getVirtualObject(ctx context.Context, req types.Namespa
 	// check if we should skip resource
 	// this is to distinguish generic and plugin syncers with the core syncers
 	if vObj != nil && r.excludeVirtual(vObj) {
+		syncExclusions.WithLabelValues(r.syncer.Name(), r.gvk, "virtual").Inc()
 		return true, nil, nil
 	}
 
@@ -274,6 +409,7 @@ func (r *SyncController) getPhysicalObject(ctx context.Context, req types.Namesp
 		if err != nil {
 			return false, nil, err
 		} else if excluded {
+			syncExclusions.WithLabelValues(r.syncer.Name(), r.gvk, "host").Inc()
 			return true, nil, nil
 		}
 	}
@@ -290,10 +426,16 @@ func (r *SyncController) excludePhysical(ctx context.Context, pObj, vObj client.
 		if !excluderOk && vObj != nil {
 			msg := fmt.Sprintf("conflict: cannot sync virtual object %s/%s as unmanaged physical object %s/%s exists with desired name", vObj.GetNamespace(), vObj.GetName(), pObj.GetNamespace(), pObj.GetName())
 			r.vEventRecorder.Eventf(vObj, "Warning", "SyncError", msg)
+			syncConflicts.WithLabelValues(r.syncer.Name(), r.gvk, "UnmanagedObject").Inc()
 			return false, fmt.Errorf(msg)
 		}
 
 		return true, nil
+	} else if vObj != nil && isNameCollision(vObj, pObj) {
+		msg := fmt.Sprintf("conflict: cannot sync virtual object %s/%s as physical object %s/%s already belongs to virtual object %s/%s", vObj.GetNamespace(), vObj.GetName(), pObj.GetNamespace(), pObj.GetName(), pObj.GetAnnotations()[translate.NamespaceAnnotation], pObj.GetAnnotations()[translate.NameAnnotation])
+		r.vEventRecorder.Eventf(vObj, "Warning", "SyncError", msg)
+		syncConflicts.WithLabelValues(r.syncer.Name(), r.gvk, "NameCollision").Inc()
+		return false, fmt.Errorf(msg)
 	}
 
 	if excluderOk {
@@ -310,7 +452,26 @@ func (r *SyncController) excludePhysical(ctx context.Context, pObj, vObj client.
 	return false, nil
 }
 
+// isNameCollision checks if the physical object was synced from a different virtual object, which can
+// happen with naming policies that don't include the virtual namespace in the physical name
+func isNameCollision(vObj, pObj client.Object) bool {
+	if vObj.GetNamespace() == "" || !translate.Default.SingleNamespaceTarget() {
+		return false
+	}
+
+	pAnnotations := pObj.GetAnnotations()
+	if pAnnotations[translate.NameAnnotation] == "" || pAnnotations[translate.NamespaceAnnotation] == "" {
+		return false
+	}
+
+	return pAnnotations[translate.NameAnnotation] != vObj.GetName() || pAnnotations[translate.NamespaceAnnotation] != vObj.GetNamespace()
+}
+
 func (r *SyncController) excludeVirtual(vObj client.Object) bool {
+	if r.selector != nil && r.selector.ExcludeVirtual(vObj) {
+		return true
+	}
+
 	excluder, ok := r.syncer.(syncertypes.ObjectExcluder)
 	if ok {
 		return excluder.ExcludeVirtual(vObj)
@@ -357,13 +518,13 @@ func (r *SyncController) enqueueVirtual(ctx context.Context, obj client.Object,
 		// add a new request for the host object
 		name := r.syncer.VirtualToHost(ctx, types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}, obj)
 		if name.Name != "" {
-			q.Add(toDeleteRequest(toHostRequest(reconcile.Request{
+			r.add(q, toDeleteRequest(toHostRequest(reconcile.Request{
 				NamespacedName: name,
 			})))
 		}
 
 		// add a new request for the virtual object
-		q.Add(toDeleteRequest(reconcile.Request{
+		r.add(q, toDeleteRequest(reconcile.Request{
 			NamespacedName: types.NamespacedName{
 				Namespace: obj.GetNamespace(),
 				Name:      obj.GetName(),
@@ -373,7 +534,7 @@ func (r *SyncController) enqueueVirtual(ctx context.Context, obj client.Object,
 	}
 
 	// add a new request for the virtual object
-	q.Add(reconcile.Request{
+	r.add(q, reconcile.Request{
 		NamespacedName: types.NamespacedName{
 			Namespace: obj.GetNamespace(),
 			Name:      obj.GetName(),
@@ -400,13 +561,13 @@ func (r *SyncController) enqueuePhysical(ctx context.Context, obj client.Object,
 		// add a new request for the virtual object
 		name := r.syncer.HostToVirtual(ctx, types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}, obj)
 		if name.Name != "" {
-			q.Add(toDeleteRequest(reconcile.Request{
+			r.add(q, toDeleteRequest(reconcile.Request{
 				NamespacedName: name,
 			}))
 		}
 
 		// add a new request for the host object
-		q.Add(toDeleteRequest(toHostRequest(reconcile.Request{
+		r.add(q, toDeleteRequest(toHostRequest(reconcile.Request{
 			NamespacedName: types.NamespacedName{
 				Namespace: obj.GetNamespace(),
 				Name:      obj.GetName(),
@@ -416,7 +577,7 @@ func (r *SyncController) enqueuePhysical(ctx context.Context, obj client.Object,
 	}
 
 	// add a new request for the host object
-	q.Add(toHostRequest(reconcile.Request{
+	r.add(q, toHostRequest(reconcile.Request{
 		NamespacedName: types.NamespacedName{
 			Namespace: obj.GetNamespace(),
 			Name:      obj.GetName(),
@@ -428,13 +589,26 @@ func (r *SyncController) Register(ctx *synccontext.RegisterContext) error {
 	// build the basic controller
 	controller := ctrl.NewControllerManagedBy(ctx.VirtualManager).
 		WithOptions(controller2.Options{
-			MaxConcurrentReconciles: 10,
+			MaxConcurrentReconciles: r.settings.MaxConcurrentReconciles,
+			RateLimiter:             r.settings.RateLimiter(),
 			CacheSyncTimeout:        constants.DefaultCacheSyncTimeout,
 		}).
 		Named(r.syncer.Name()).
 		Watches(r.syncer.Resource(), newEventHandler(r.enqueueVirtual)).
 		WatchesRawSource(source.Kind(ctx.PhysicalManager.GetCache(), r.syncer.Resource(), newEventHandler(r.enqueuePhysical)))
 
+	// periodically reconcile all objects again
+	if r.settings.ResyncPeriod > 0 {
+		virtualEvents := make(chan event.GenericEvent)
+		physicalEvents := make(chan event.GenericEvent)
+		controller = controller.
+			WatchesRawSource(source.Channel(virtualEvents, newEventHandler(r.enqueueVirtual))).
+			WatchesRawSource(source.Channel(physicalEvents, newEventHandler(r.enqueuePhysical)))
+		go r.resync(ctx, virtualEvents, physicalEvents)
+	}
+	recordSettings(r.syncer.Name(), r.settings)
+	registerOldestUnsyncedGauge(r.syncer.Name(), r.gvk, r.unsynced)
+
 	// should add extra stuff?
 	modifier, isControllerModifier := r.syncer.(syncertypes.ControllerModifier)
 	if isControllerModifier {
@@ -448,6 +622,130 @@ func (r *SyncController) Register(ctx *synccontext.RegisterContext) error {
 	return controller.Complete(r)
 }
 
+// add queues the request and remembers when the object was queued
+func (r *SyncController) add(q workqueue.RateLimitingInterface, req reconcile.Request) {
+	if r.unsynced != nil {
+		r.unsynced.Add(req)
+	}
+
+	q.Add(req)
+}
+
+// observeReconcile records errors and conflicts of the reconcile and marks the object as synced if successful
+func (r *SyncController) observeReconcile(req ctrl.Request, result ctrl.Result, err error) {
+	if err != nil {
+		syncErrors.WithLabelValues(r.syncer.Name(), r.gvk).Inc()
+		if kerrors.IsConflict(err) {
+			syncConflicts.WithLabelValues(r.syncer.Name(), r.gvk, "Outdated").Inc()
+		}
+		return
+	} else if result.Requeue || result.RequeueAfter > 0 {
+		return
+	}
+
+	if r.unsynced != nil {
+		r.unsynced.Remove(req)
+	}
+}
+
+// syncDirection returns in which direction the syncer syncs the given objects
+func (r *SyncController) syncDirection(eventSource synccontext.EventSource, vObj, pObj client.Object) string {
+	if vObj != nil && pObj != nil {
+		if eventSource == synccontext.EventSourceHost {
+			return directionHostToVirtual
+		}
+
+		return directionVirtualToHost
+	} else if pObj != nil {
+		if _, ok := r.syncer.(syncertypes.ToVirtualSyncer); ok {
+			return directionHostToVirtual
+		}
+	}
+
+	return directionVirtualToHost
+}
+
+// resync enqueues all virtual and host objects of the syncer every resync period
+func (r *SyncController) resync(ctx *synccontext.RegisterContext, virtualEvents, physicalEvents chan<- event.GenericEvent) {
+	ticker := time.NewTicker(r.settings.ResyncPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		r.log.Debugf("resync all objects")
+		err := r.sendResyncEvents(ctx, ctx.VirtualManager.GetClient(), virtualEvents)
+		if err != nil {
+			r.log.Errorf("error resyncing virtual objects: %v", err)
+		}
+
+		err = r.sendResyncEvents(ctx, ctx.PhysicalManager.GetClient(), physicalEvents)
+		if err != nil {
+			r.log.Errorf("error resyncing host objects: %v", err)
+		}
+	}
+}
+
+func (r *SyncController) sendResyncEvents(ctx context.Context, c client.Client, events chan<- event.GenericEvent) error {
+	list, err := r.newResourceList(c)
+	if err != nil {
+		return err
+	}
+
+	err = c.List(ctx, list)
+	if err != nil {
+		return err
+	}
+
+	return meta.EachListItem(list, func(obj runtime.Object) error {
+		clientObj, ok := obj.(client.Object)
+		if !ok {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case events <- event.GenericEvent{Object: clientObj}:
+		}
+
+		return nil
+	})
+}
+
+// newResourceList creates a list of the same type as the syncer resource, so that the list is
+// served from the existing informer
+func (r *SyncController) newResourceList(c client.Client) (client.ObjectList, error) {
+	resource := r.syncer.Resource()
+	gvk, err := apiutil.GVKForObject(resource, c.Scheme())
+	if err != nil {
+		return nil, err
+	}
+
+	listGVK := gvk.GroupVersion().WithKind(gvk.Kind + "List")
+	if _, ok := resource.(*unstructured.Unstructured); ok {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(listGVK)
+		return list, nil
+	}
+
+	obj, err := c.Scheme().New(listGVK)
+	if err != nil {
+		return nil, err
+	}
+
+	list, ok := obj.(client.ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a list", listGVK.String())
+	}
+
+	return list, nil
+}
+
 func DeleteHostObject(ctx *synccontext.SyncContext, obj client.Object, reason string) (ctrl.Result, error) {
 	return deleteObject(ctx, obj, reason, false)
 }
@@ -6,6 +6,7 @@ import (
 	"sort"
 	"testing"
 
+	vclusterconfig "github.com/loft-sh/vcluster/config"
 	syncertypes "github.com/loft-sh/vcluster/pkg/controllers/syncer/types"
 	"github.com/loft-sh/vcluster/pkg/mappings"
 	"github.com/loft-sh/vcluster/pkg/mappings/resources"
@@ -324,3 +325,65 @@ func TestReconcile(t *testing.T) {
 		}
 	}
 }
+
+func TestReconcileNameCollision(t *testing.T) {
+	ctx := context.Background()
+	vSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "a",
+			Namespace: namespaceInVclusterA,
+			UID:       "123",
+		},
+	}
+	pSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "suffix-a",
+			Namespace: vclusterNamespace,
+			Annotations: map[string]string{
+				translate.NameAnnotation:      "a",
+				translate.NamespaceAnnotation: "other",
+				translate.KindAnnotation:      corev1.SchemeGroupVersion.WithKind("Secret").String(),
+			},
+			Labels: map[string]string{
+				translate.MarkerLabel: translate.VClusterName,
+			},
+		},
+	}
+	pClient := testingutil.NewFakeClient(scheme.Scheme, pSecret.DeepCopy())
+	vClient := testingutil.NewFakeClient(scheme.Scheme, vSecret.DeepCopy())
+	fakeContext := generictesting.NewFakeRegisterContext(generictesting.NewFakeConfig(), pClient, vClient)
+
+	// objects with the same name in different namespaces collide with the prefix naming policy
+	namingPolicy, err := translate.NewNamingPolicy(vclusterconfig.ExperimentalSyncSettingsNaming{Policy: vclusterconfig.NamingPolicyPrefix})
+	assert.NilError(t, err)
+	translate.Default = translate.NewSingleNamespaceTranslatorWithNamingPolicy(vclusterNamespace, namingPolicy)
+	defer func() {
+		translate.Default = translate.NewSingleNamespaceTranslator(vclusterNamespace)
+	}()
+	resources.MustRegisterMappings(fakeContext)
+
+	syncerImpl, err := NewMockSyncer(fakeContext)
+	assert.NilError(t, err)
+	syncer := syncerImpl.(syncertypes.Syncer)
+	controller := &SyncController{
+		syncer:         syncer,
+		log:            loghelper.New(syncer.Name()),
+		vEventRecorder: &testingutil.FakeEventRecorder{},
+		physicalClient: pClient,
+
+		currentNamespace:       fakeContext.CurrentNamespace,
+		currentNamespaceClient: fakeContext.CurrentNamespaceClient,
+
+		virtualClient: vClient,
+		options:       &syncertypes.Options{},
+
+		locker: locker.New(),
+	}
+
+	_, err = controller.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(vSecret)})
+	assert.ErrorContains(t, err, "conflict: cannot sync virtual object default/a as physical object test/suffix-a already belongs to virtual object other/a")
+
+	// the host object of the other virtual object is untouched
+	err = generictesting.CompareObjs(ctx, t, "physical state", pClient, corev1.SchemeGroupVersion.WithKind("Secret"), scheme.Scheme, []runtime.Object{pSecret}, nil)
+	assert.NilError(t, err)
+}
@@ -0,0 +1,69 @@
+package translator
+
+import (
+	"context"
+
+	syncertypes "github.com/loft-sh/vcluster/pkg/controllers/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/mappings"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewFromHostTranslator creates a new translator that copies namespaced host objects into the virtual cluster
+// and remembers the origin of the copy within the virtual object annotations
+func NewFromHostTranslator(name string, obj client.Object, mapper mappings.Mapper) syncertypes.Translator {
+	return &fromHostTranslator{
+		Mapper: mapper,
+
+		name: name,
+		obj:  obj,
+	}
+}
+
+type fromHostTranslator struct {
+	mappings.Mapper
+
+	name string
+	obj  client.Object
+}
+
+func (n *fromHostTranslator) Name() string {
+	return n.name
+}
+
+func (n *fromHostTranslator) Resource() client.Object {
+	return n.obj.DeepCopyObject().(client.Object)
+}
+
+func (n *fromHostTranslator) TranslateMetadata(ctx context.Context, pObj client.Object) client.Object {
+	vName := n.HostToVirtual(ctx, types.NamespacedName{Namespace: pObj.GetNamespace(), Name: pObj.GetName()}, pObj)
+
+	vObj := pObj.DeepCopyObject().(client.Object)
+	vObj.SetName(vName.Name)
+	vObj.SetNamespace(vName.Namespace)
+	vObj.SetResourceVersion("")
+	vObj.SetUID("")
+	vObj.SetManagedFields(nil)
+	vObj.SetOwnerReferences(nil)
+	vObj.SetAnnotations(n.translateAnnotations(pObj))
+	return vObj
+}
+
+func (n *fromHostTranslator) TranslateMetadataUpdate(_ context.Context, vObj client.Object, pObj client.Object) (changed bool, annotations map[string]string, labels map[string]string) {
+	updatedAnnotations := n.translateAnnotations(pObj)
+	updatedLabels := pObj.GetLabels()
+	return !equality.Semantic.DeepEqual(updatedAnnotations, vObj.GetAnnotations()) || !equality.Semantic.DeepEqual(updatedLabels, vObj.GetLabels()), updatedAnnotations, updatedLabels
+}
+
+func (n *fromHostTranslator) translateAnnotations(pObj client.Object) map[string]string {
+	annotations := map[string]string{}
+	for k, v := range pObj.GetAnnotations() {
+		annotations[k] = v
+	}
+
+	annotations[translate.HostNameAnnotation] = pObj.GetName()
+	annotations[translate.HostNamespaceAnnotation] = pObj.GetNamespace()
+	return annotations
+}
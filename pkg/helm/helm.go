@@ -50,6 +50,7 @@ type Client interface {
 	Upgrade(ctx context.Context, name, namespace string, options UpgradeOptions) error
 	Pull(ctx context.Context, name string, options UpgradeOptions) error
 	Delete(name, namespace string) error
+	DeleteWithoutHooks(name, namespace string) error
 	Exists(name, namespace string) (bool, error)
 	Rollback(ctx context.Context, name, namespace string) error
 	Status(ctx context.Context, name, namespace string) ([]byte, error)
@@ -261,6 +262,16 @@ func (c *client) execute(ctx context.Context, args []string, operation string, w
 }
 
 func (c *client) Delete(name, namespace string) error {
+	return c.delete(name, namespace)
+}
+
+// DeleteWithoutHooks deletes the release without running its hooks, e.g. the pre-delete hook that deletes the
+// host namespaces of a multi-namespace mode vCluster
+func (c *client) DeleteWithoutHooks(name, namespace string) error {
+	return c.delete(name, namespace, "--no-hooks")
+}
+
+func (c *client) delete(name, namespace string, extraArgs ...string) error {
 	kubeConfig, err := WriteKubeConfig(c.config)
 	if err != nil {
 		return err
@@ -268,6 +279,7 @@ func (c *client) Delete(name, namespace string) error {
 	defer os.Remove(kubeConfig)
 
 	args := []string{"delete", name, "--namespace", namespace, "--kubeconfig", kubeConfig, "--repository-config=''"}
+	args = append(args, extraArgs...)
 
 	c.log.Debug("Delete helm chart with helm " + strings.Join(args, " "))
 	output, err := exec.Command(c.helmPath, args...).CombinedOutput()
@@ -0,0 +1,321 @@
+package metricsserver
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/apiservice"
+	"github.com/loft-sh/vcluster/pkg/config"
+	"github.com/loft-sh/vcluster/pkg/mappings"
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	"github.com/loft-sh/vcluster/pkg/server/filters"
+	"github.com/loft-sh/vcluster/pkg/server/handler"
+	requestpkg "github.com/loft-sh/vcluster/pkg/util/request"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/rest"
+	custommetricsv1beta2 "k8s.io/metrics/pkg/apis/custom_metrics/v1beta2"
+	externalmetricsv1beta1 "k8s.io/metrics/pkg/apis/external_metrics/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	customMetricsHostPort   = 9002
+	externalMetricsHostPort = 9003
+
+	// NamespaceMetricsResource is the resource of custom metrics that describe a namespace itself
+	NamespaceMetricsResource = "metrics"
+	// AllObjectsName selects all objects matching the label selector in custom metrics requests
+	AllObjectsName = "*"
+)
+
+var CustomMetricsGroupVersion = custommetricsv1beta2.SchemeGroupVersion
+
+var ExternalMetricsGroupVersion = externalmetricsv1beta1.SchemeGroupVersion
+
+// metricsAdapter is a metrics api of a host metrics adapter that gets proxied into the virtual cluster
+type metricsAdapter struct {
+	config       func(metricsServer *vclusterconfig.MetricsServer) vclusterconfig.MetricsAdapter
+	serviceName  string
+	hostPort     int
+	groupVersion schema.GroupVersion
+}
+
+var metricsAdapters = []metricsAdapter{
+	{
+		config: func(metricsServer *vclusterconfig.MetricsServer) vclusterconfig.MetricsAdapter {
+			return metricsServer.CustomMetrics
+		},
+		serviceName:  "custom-metrics-apiserver",
+		hostPort:     customMetricsHostPort,
+		groupVersion: CustomMetricsGroupVersion,
+	},
+	{
+		config: func(metricsServer *vclusterconfig.MetricsServer) vclusterconfig.MetricsAdapter {
+			return metricsServer.ExternalMetrics
+		},
+		serviceName:  "external-metrics-apiserver",
+		hostPort:     externalMetricsHostPort,
+		groupVersion: ExternalMetricsGroupVersion,
+	},
+}
+
+// registerMetricsAdapters proxies the custom and external metrics apis of the host metrics adapter. Requests either
+// reach the vCluster proxy directly or come through the api aggregation of the virtual api server, so they are
+// served in both places.
+func registerMetricsAdapters(ctx *config.ControllerContext) error {
+	metricsServer := &ctx.Config.Integrations.MetricsServer
+	if !metricsServer.CustomMetrics.Enabled && !metricsServer.ExternalMetrics.Enabled {
+		return nil
+	}
+
+	for _, adapter := range metricsAdapters {
+		adapterConfig := adapter.config(metricsServer)
+		if !adapterConfig.Enabled {
+			continue
+		}
+
+		targetService := cmp.Or(adapterConfig.APIService.Service.Name, "prometheus-adapter")
+		targetServiceNamespace := cmp.Or(adapterConfig.APIService.Service.Namespace, "monitoring")
+		targetServicePort := cmp.Or(adapterConfig.APIService.Service.Port, 443)
+		err := apiservice.StartAPIServiceProxy(ctx, targetService, targetServiceNamespace, targetServicePort, adapter.hostPort, newMetricsAdapterResourceHandler(ctx))
+		if err != nil {
+			return fmt.Errorf("start api service proxy for %s: %w", adapter.groupVersion.String(), err)
+		}
+	}
+
+	ctx.PostServerHooks = append(ctx.PostServerHooks, func(h http.Handler, ctx *config.ControllerContext) http.Handler {
+		return WithMetricsAdapterProxy(
+			h,
+			ctx.VirtualManager.GetClient(),
+			ctx.LocalManager.GetConfig(),
+			ctx.Config.Integrations.MetricsServer,
+		)
+	})
+	return nil
+}
+
+func registerOrDeregisterMetricsAdapterAPIServices(ctx *config.ControllerContext) error {
+	for _, adapter := range metricsAdapters {
+		var err error
+		if adapter.config(&ctx.Config.Integrations.MetricsServer).Enabled {
+			err = apiservice.RegisterAPIService(ctx, adapter.serviceName, adapter.hostPort, adapter.groupVersion)
+		} else {
+			err = apiservice.DeregisterAPIService(ctx, adapter.groupVersion)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newMetricsAdapterResourceHandler serves the resource requests that reach the api service proxy through the api
+// aggregation of the virtual api server, e.g. from the horizontal pod autoscaler.
+func newMetricsAdapterResourceHandler(ctx *config.ControllerContext) http.Handler {
+	requestInfoFactory := &request.RequestInfoFactory{
+		APIPrefixes:          sets.NewString("api", "apis"),
+		GrouplessAPIPrefixes: sets.NewString("api"),
+	}
+	proxy := WithMetricsAdapterProxy(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			requestpkg.FailWithStatus(w, req, http.StatusForbidden, fmt.Errorf("path %s is not allowed", req.URL.Path))
+		}),
+		ctx.VirtualManager.GetClient(),
+		ctx.LocalManager.GetConfig(),
+		ctx.Config.Integrations.MetricsServer,
+	)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		info, err := requestInfoFactory.NewRequestInfo(req)
+		if err != nil {
+			requestpkg.FailWithStatus(w, req, http.StatusBadRequest, err)
+			return
+		}
+
+		proxy.ServeHTTP(w, req.WithContext(request.WithRequestInfo(req.Context(), info)))
+	})
+}
+
+func WithMetricsAdapterProxy(
+	h http.Handler,
+	cachedVirtualClient client.Client,
+	hostConfig *rest.Config,
+	metricsServer vclusterconfig.MetricsServer,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// first get request info
+		info, ok := request.RequestInfoFrom(req.Context())
+		if !ok {
+			requestpkg.FailWithStatus(w, req, http.StatusInternalServerError, fmt.Errorf("request info is missing"))
+			return
+		}
+
+		if (metricsServer.CustomMetrics.Enabled && isCustomMetricsRequest(info)) ||
+			(metricsServer.ExternalMetrics.Enabled && isExternalMetricsRequest(info)) {
+			handleMetricsAdapterRequest(w, req, info, cachedVirtualClient, hostConfig)
+			return
+		}
+
+		h.ServeHTTP(w, req)
+	})
+}
+
+func isCustomMetricsRequest(r *request.RequestInfo) bool {
+	return r.IsResourceRequest && r.APIGroup == CustomMetricsGroupVersion.Group && r.APIVersion == CustomMetricsGroupVersion.Version
+}
+
+func isExternalMetricsRequest(r *request.RequestInfo) bool {
+	return r.IsResourceRequest && r.APIGroup == ExternalMetricsGroupVersion.Group && r.APIVersion == ExternalMetricsGroupVersion.Version
+}
+
+// metricsAdapterRequest is a custom or external metrics request that was translated to the host cluster
+type metricsAdapterRequest struct {
+	requestInfo *request.RequestInfo
+
+	// allObjects is set if the metrics of all pods matching the label selector are requested
+	allObjects bool
+}
+
+func handleMetricsAdapterRequest(
+	w http.ResponseWriter,
+	req *http.Request,
+	info *request.RequestInfo,
+	cachedVirtualClient client.Client,
+	hostConfig *rest.Config,
+) {
+	adapterRequest, err := translateMetricsAdapterRequest(req, info)
+	if err != nil {
+		requestpkg.FailWithStatus(w, req, http.StatusForbidden, err)
+		return
+	}
+
+	// fetch the virtual pods to filter the metrics of other pods in the host namespace
+	var vPods []corev1.Pod
+	if adapterRequest.allObjects {
+		vPods, err = getVirtualPodObjectsInNamespace(req.Context(), cachedVirtualClient, info.Namespace)
+		if err != nil {
+			requestpkg.FailWithStatus(w, req, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	proxyHandler, err := handler.Handler("", hostConfig, nil)
+	if err != nil {
+		requestpkg.FailWithStatus(w, req, http.StatusInternalServerError, err)
+		return
+	}
+
+	// execute request in host cluster
+	req.Header.Del("Authorization")
+	code, header, data, err := filters.ExecuteRequest(req, proxyHandler)
+	if err != nil {
+		responsewriters.ErrorNegotiated(err, serializer.NewCodecFactory(scheme.Scheme), corev1.SchemeGroupVersion, w, req)
+		return
+	} else if code != http.StatusOK || info.APIGroup == ExternalMetricsGroupVersion.Group {
+		// external metrics do not describe any objects, so there is nothing to translate back
+		filters.WriteWithHeader(w, code, header, data)
+		return
+	}
+
+	metricValueList := &custommetricsv1beta2.MetricValueList{}
+	_, _, err = serializer.NewCodecFactory(scheme.Scheme).UniversalDeserializer().Decode(data, nil, metricValueList)
+	if err != nil {
+		requestpkg.FailWithStatus(w, req, http.StatusInternalServerError, err)
+		return
+	}
+
+	adapterRequest.rewriteMetricValueList(req.Context(), metricValueList, vPods)
+	filters.WriteObjectNegotiated(w, req, metricValueList, scheme.Scheme)
+}
+
+// translateMetricsAdapterRequest rewrites the path and label selector of a custom or external metrics request
+// to the host objects. Only namespaced requests are allowed, as cluster scoped metrics would expose the host cluster.
+func translateMetricsAdapterRequest(req *http.Request, info *request.RequestInfo) (*metricsAdapterRequest, error) {
+	// /apis/{group}/{version}/namespaces/{namespace}/{resource}/{name}/{metric}
+	splitted := strings.Split(req.URL.Path, "/")
+	if info.Namespace == "" || len(splitted) < 7 || splitted[4] != "namespaces" {
+		return nil, fmt.Errorf("only namespaced metrics are allowed")
+	}
+	splitted[5] = translate.Default.PhysicalNamespace(info.Namespace)
+
+	adapterRequest := &metricsAdapterRequest{
+		requestInfo: info,
+	}
+	if info.APIGroup == CustomMetricsGroupVersion.Group && info.Resource != NamespaceMetricsResource {
+		if len(splitted) < 9 {
+			return nil, fmt.Errorf("invalid custom metrics path %s", req.URL.Path)
+		}
+
+		if info.Name == AllObjectsName {
+			// other objects than pods can't be filtered by the labels of their virtual objects
+			if info.Resource != PodResource {
+				return nil, fmt.Errorf("selecting all %s is not allowed, only pods can be selected by label selector", info.Resource)
+			}
+
+			err := translateLabelSelectors(req)
+			if err != nil {
+				return nil, err
+			}
+
+			adapterRequest.allObjects = true
+		} else if info.Resource == PodResource {
+			splitted[7] = mappings.VirtualToHostName(req.Context(), info.Name, info.Namespace, mappings.Pods())
+		} else {
+			splitted[7] = translate.Default.PhysicalName(info.Name, info.Namespace)
+		}
+	}
+
+	req.URL.Path = strings.Join(splitted, "/")
+	return adapterRequest, nil
+}
+
+// rewriteMetricValueList translates the objects described by the host metrics back to the virtual objects
+func (r *metricsAdapterRequest) rewriteMetricValueList(ctx context.Context, metricValueList *custommetricsv1beta2.MetricValueList, vPods []corev1.Pod) {
+	if r.requestInfo.Resource == NamespaceMetricsResource {
+		for i := range metricValueList.Items {
+			metricValueList.Items[i].DescribedObject.Name = r.requestInfo.Namespace
+		}
+		return
+	} else if !r.allObjects {
+		for i := range metricValueList.Items {
+			metricValueList.Items[i].DescribedObject.Name = r.requestInfo.Name
+			metricValueList.Items[i].DescribedObject.Namespace = r.requestInfo.Namespace
+		}
+		return
+	}
+
+	hostPodMap := make(map[types.NamespacedName]corev1.Pod)
+	for _, vPod := range vPods {
+		hostPodMap[mappings.VirtualToHost(ctx, vPod.Name, vPod.Namespace, mappings.Pods())] = vPod
+	}
+
+	filteredItems := []custommetricsv1beta2.MetricValue{}
+	for _, metricValue := range metricValueList.Items {
+		vPod, found := hostPodMap[types.NamespacedName{
+			Name:      metricValue.DescribedObject.Name,
+			Namespace: metricValue.DescribedObject.Namespace,
+		}]
+		if !found {
+			continue
+		}
+
+		// translate back metric value
+		metricValue.DescribedObject.Name = vPod.Name
+		metricValue.DescribedObject.Namespace = vPod.Namespace
+		metricValue.DescribedObject.UID = vPod.UID
+		filteredItems = append(filteredItems, metricValue)
+	}
+	metricValueList.Items = filteredItems
+}
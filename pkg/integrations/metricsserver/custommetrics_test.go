@@ -0,0 +1,131 @@
+package metricsserver
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	generictesting "github.com/loft-sh/vcluster/pkg/controllers/syncer/testing"
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	testingutil "github.com/loft-sh/vcluster/pkg/util/testing"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	custommetricsv1beta2 "k8s.io/metrics/pkg/apis/custom_metrics/v1beta2"
+)
+
+func TestTranslateMetricsAdapterRequest(t *testing.T) {
+	generictesting.NewFakeRegisterContext(generictesting.NewFakeConfig(), testingutil.NewFakeClient(scheme.Scheme), testingutil.NewFakeClient(scheme.Scheme))
+	requestInfoFactory := &request.RequestInfoFactory{
+		APIPrefixes:          sets.NewString("api", "apis"),
+		GrouplessAPIPrefixes: sets.NewString("api"),
+	}
+
+	testCases := []struct {
+		name          string
+		path          string
+		expectedPath  string
+		expectedQuery string
+		allObjects    bool
+		wantErr       string
+	}{
+		{
+			name:         "single pod",
+			path:         "/apis/custom.metrics.k8s.io/v1beta2/namespaces/test/pods/pod/requests",
+			expectedPath: "/apis/custom.metrics.k8s.io/v1beta2/namespaces/" + translate.Default.PhysicalNamespace("test") + "/pods/" + translate.Default.PhysicalName("pod", "test") + "/requests",
+		},
+		{
+			name:         "single service",
+			path:         "/apis/custom.metrics.k8s.io/v1beta2/namespaces/test/services/svc/requests",
+			expectedPath: "/apis/custom.metrics.k8s.io/v1beta2/namespaces/" + translate.Default.PhysicalNamespace("test") + "/services/" + translate.Default.PhysicalName("svc", "test") + "/requests",
+		},
+		{
+			name:          "all pods",
+			path:          "/apis/custom.metrics.k8s.io/v1beta2/namespaces/test/pods/*/requests?labelSelector=app%3Dweb",
+			expectedPath:  "/apis/custom.metrics.k8s.io/v1beta2/namespaces/" + translate.Default.PhysicalNamespace("test") + "/pods/*/requests",
+			expectedQuery: url.Values{"labelSelector": []string{translate.Default.ConvertLabelKey("app") + "=web"}}.Encode(),
+			allObjects:    true,
+		},
+		{
+			name:         "namespace metrics",
+			path:         "/apis/custom.metrics.k8s.io/v1beta2/namespaces/test/metrics/requests",
+			expectedPath: "/apis/custom.metrics.k8s.io/v1beta2/namespaces/" + translate.Default.PhysicalNamespace("test") + "/metrics/requests",
+		},
+		{
+			name:         "external metrics",
+			path:         "/apis/external.metrics.k8s.io/v1beta1/namespaces/test/queue_length?labelSelector=queue%3Dworker",
+			expectedPath: "/apis/external.metrics.k8s.io/v1beta1/namespaces/" + translate.Default.PhysicalNamespace("test") + "/queue_length",
+			// the label selector of external metrics selects metrics and not objects
+			expectedQuery: "labelSelector=queue%3Dworker",
+		},
+		{
+			name:    "all services",
+			path:    "/apis/custom.metrics.k8s.io/v1beta2/namespaces/test/services/*/requests",
+			wantErr: "selecting all services is not allowed, only pods can be selected by label selector",
+		},
+		{
+			name:    "cluster scoped",
+			path:    "/apis/custom.metrics.k8s.io/v1beta2/nodes/node/requests",
+			wantErr: "only namespaced metrics are allowed",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", testCase.path, nil)
+			info, err := requestInfoFactory.NewRequestInfo(req)
+			assert.NilError(t, err)
+
+			adapterRequest, err := translateMetricsAdapterRequest(req, info)
+			if testCase.wantErr != "" {
+				assert.Error(t, err, testCase.wantErr)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, req.URL.Path, testCase.expectedPath)
+			assert.Equal(t, req.URL.RawQuery, testCase.expectedQuery)
+			assert.Equal(t, adapterRequest.allObjects, testCase.allObjects)
+		})
+	}
+}
+
+func TestRewriteMetricValueList(t *testing.T) {
+	generictesting.NewFakeRegisterContext(generictesting.NewFakeConfig(), testingutil.NewFakeClient(scheme.Scheme), testingutil.NewFakeClient(scheme.Scheme))
+
+	vPods := []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "test", UID: "web-uid"}},
+	}
+	metricValueList := &custommetricsv1beta2.MetricValueList{
+		Items: []custommetricsv1beta2.MetricValue{
+			{DescribedObject: corev1.ObjectReference{Kind: "Pod", Name: translate.Default.PhysicalName("web", "test"), Namespace: translate.Default.PhysicalNamespace("test")}},
+			{DescribedObject: corev1.ObjectReference{Kind: "Pod", Name: "other", Namespace: translate.Default.PhysicalNamespace("test")}},
+		},
+	}
+
+	adapterRequest := &metricsAdapterRequest{
+		requestInfo: &request.RequestInfo{Namespace: "test", Resource: PodResource, Name: AllObjectsName},
+		allObjects:  true,
+	}
+	adapterRequest.rewriteMetricValueList(context.Background(), metricValueList, vPods)
+	assert.DeepEqual(t, metricValueList.Items, []custommetricsv1beta2.MetricValue{
+		{DescribedObject: corev1.ObjectReference{Kind: "Pod", Name: "web", Namespace: "test", UID: "web-uid"}},
+	})
+
+	metricValueList = &custommetricsv1beta2.MetricValueList{
+		Items: []custommetricsv1beta2.MetricValue{
+			{DescribedObject: corev1.ObjectReference{Kind: "Namespace", Name: translate.Default.PhysicalNamespace("test")}},
+		},
+	}
+	adapterRequest = &metricsAdapterRequest{
+		requestInfo: &request.RequestInfo{Namespace: "test", Resource: NamespaceMetricsResource, Name: "requests"},
+	}
+	adapterRequest.rewriteMetricValueList(context.Background(), metricValueList, nil)
+	assert.DeepEqual(t, metricValueList.Items, []custommetricsv1beta2.MetricValue{
+		{DescribedObject: corev1.ObjectReference{Kind: "Namespace", Name: "test"}},
+	})
+}
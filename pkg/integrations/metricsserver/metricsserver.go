@@ -53,7 +53,7 @@ func Register(ctx *config.ControllerContext) error {
 		targetService := cmp.Or(ctx.Config.Integrations.MetricsServer.APIService.Service.Name, "metrics-server")
 		targetServiceNamespace := cmp.Or(ctx.Config.Integrations.MetricsServer.APIService.Service.Namespace, "kube-system")
 		targetServicePort := cmp.Or(ctx.Config.Integrations.MetricsServer.APIService.Service.Port, 443)
-		err := apiservice.StartAPIServiceProxy(ctx, targetService, targetServiceNamespace, targetServicePort, hostPort)
+		err := apiservice.StartAPIServiceProxy(ctx, targetService, targetServiceNamespace, targetServicePort, hostPort, nil)
 		if err != nil {
 			return fmt.Errorf("start api service proxy: %w", err)
 		}
@@ -64,15 +64,20 @@ func Register(ctx *config.ControllerContext) error {
 				ctx.Config.WorkloadTargetNamespace,
 				ctx.VirtualManager.GetClient(),
 				ctx.LocalManager.GetConfig(),
-				ctx.Config.Experimental.MultiNamespaceMode.Enabled,
+				ctx.Config.Sync.ToHost.Namespaces.Enabled,
 			)
 		})
 	}
 
-	return nil
+	return registerMetricsAdapters(ctx)
 }
 
 func RegisterOrDeregisterAPIService(ctx *config.ControllerContext) error {
+	err := registerOrDeregisterMetricsAdapterAPIServices(ctx)
+	if err != nil {
+		return err
+	}
+
 	if ctx.Config.Integrations.MetricsServer.Enabled {
 		return apiservice.RegisterAPIService(ctx, "metrics-server", hostPort, GroupVersion)
 	}
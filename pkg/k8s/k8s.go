@@ -20,6 +20,28 @@ import (
 	"k8s.io/klog/v2"
 )
 
+// StartKine starts kine in the background with the given data source and listen address
+func StartKine(ctx context.Context, dataSource, listenAddress string, certificates *etcd.Certificates) {
+	go func() {
+		args := []string{}
+		args = append(args, "/usr/local/bin/kine")
+		args = append(args, "--endpoint="+dataSource)
+		if certificates != nil {
+			args = append(args, "--ca-file="+certificates.CaCert)
+			args = append(args, "--key-file="+certificates.ServerKey)
+			args = append(args, "--cert-file="+certificates.ServerCert)
+		}
+		args = append(args, "--metrics-bind-address=0")
+		args = append(args, "--listen-address="+listenAddress)
+
+		// now start kine
+		err := RunCommand(ctx, args, "kine")
+		if err != nil {
+			klog.Fatal("could not run kine", err)
+		}
+	}()
+}
+
 func StartK8S(
 	ctx context.Context,
 	serviceCIDR string,
@@ -42,22 +64,11 @@ func StartK8S(
 		}
 
 		// start embedded mode
-		go func() {
-			args := []string{}
-			args = append(args, "/usr/local/bin/kine")
-			args = append(args, "--endpoint="+dataSource)
-			args = append(args, "--ca-file="+vConfig.ControlPlane.BackingStore.Database.External.CaFile)
-			args = append(args, "--key-file="+vConfig.ControlPlane.BackingStore.Database.External.KeyFile)
-			args = append(args, "--cert-file="+vConfig.ControlPlane.BackingStore.Database.External.CertFile)
-			args = append(args, "--metrics-bind-address=0")
-			args = append(args, "--listen-address="+constants.K8sKineEndpoint)
-
-			// now start kine
-			err := RunCommand(ctx, args, "kine")
-			if err != nil {
-				klog.Fatal("could not run kine", err)
-			}
-		}()
+		StartKine(ctx, dataSource, constants.K8sKineEndpoint, &etcd.Certificates{
+			CaCert:     vConfig.ControlPlane.BackingStore.Database.External.CaFile,
+			ServerCert: vConfig.ControlPlane.BackingStore.Database.External.CertFile,
+			ServerKey:  vConfig.ControlPlane.BackingStore.Database.External.KeyFile,
+		})
 
 		etcdEndpoints = constants.K8sKineEndpoint
 	} else if vConfig.ControlPlane.BackingStore.Database.External.Enabled {
@@ -11,6 +11,8 @@ import (
 	"github.com/loft-sh/vcluster/pkg/util/translate"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	clientv1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -41,7 +43,7 @@ func StartLeaderElection(ctx *config.ControllerContext, scheme *runtime.Scheme,
 	}
 
 	// Identity used to distinguish between multiple controller manager instances
-	id, err := os.Hostname()
+	id, err := identity()
 	if err != nil {
 		return err
 	}
@@ -50,11 +52,11 @@ func StartLeaderElection(ctx *config.ControllerContext, scheme *runtime.Scheme,
 	rl, err := resourcelock.New(
 		resourcelock.LeasesResourceLock,
 		ctx.Config.WorkloadNamespace,
-		translate.SafeConcatName("vcluster", translate.VClusterName, "controller"),
+		leaseName(translate.VClusterName),
 		leaderElectionClient.CoreV1(),
 		leaderElectionClient.CoordinationV1(),
 		resourcelock.ResourceLockConfig{
-			Identity:      id + "-external-vcluster-controller",
+			Identity:      id,
 			EventRecorder: recorder,
 		},
 	)
@@ -92,3 +94,34 @@ func StartLeaderElection(ctx *config.ControllerContext, scheme *runtime.Scheme,
 
 	return nil
 }
+
+// IsLeader checks if this vCluster replica currently holds the leader election lease. This can be used
+// before the leader election was started, e.g. during the initialization of the control plane.
+func IsLeader(ctx context.Context, client kubernetes.Interface, namespace, vClusterName string) (bool, error) {
+	id, err := identity()
+	if err != nil {
+		return false, err
+	}
+
+	lease, err := client.CoordinationV1().Leases(namespace).Get(ctx, leaseName(vClusterName), metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("get leader election lease: %w", err)
+	}
+
+	return lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity == id, nil
+}
+
+func leaseName(vClusterName string) string {
+	return translate.SafeConcatName("vcluster", vClusterName, "controller")
+}
+
+func identity() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", err
+	}
+
+	return hostname + "-external-vcluster-controller", nil
+}
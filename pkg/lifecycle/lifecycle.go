@@ -10,6 +10,7 @@ import (
 	"github.com/loft-sh/vcluster/pkg/constants"
 	"github.com/loft-sh/vcluster/pkg/util/translate"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -19,7 +20,7 @@ import (
 )
 
 // PauseVCluster pauses a running vcluster
-func PauseVCluster(ctx context.Context, kubeClient *kubernetes.Clientset, name, namespace string, log log.BaseLogger) error {
+func PauseVCluster(ctx context.Context, kubeClient kubernetes.Interface, name, namespace string, log log.BaseLogger) error {
 	// scale down vcluster itself
 	labelSelector := "app=vcluster,release=" + name
 	found, err := scaleDownStatefulSet(ctx, kubeClient, labelSelector, namespace, log)
@@ -56,7 +57,7 @@ func PauseVCluster(ctx context.Context, kubeClient *kubernetes.Clientset, name,
 }
 
 // DeletePods deletes all pods associated with a running vcluster
-func DeletePods(ctx context.Context, kubeClient *kubernetes.Clientset, labelSelector, namespace string, log log.BaseLogger) error {
+func DeletePods(ctx context.Context, kubeClient kubernetes.Interface, labelSelector, namespace string, log log.BaseLogger) error {
 	list, err := kubeClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
 	if err != nil {
 		return err
@@ -78,18 +79,55 @@ func DeletePods(ctx context.Context, kubeClient *kubernetes.Clientset, labelSele
 	return nil
 }
 
-func DeleteMultiNamespaceVClusterWorkloads(ctx context.Context, client *kubernetes.Clientset, vclusterName, vclusterNamespace string, _ log.BaseLogger) error {
-	// get all host namespaces managed by this multinamespace mode enabled vcluster
+// listMultiNamespaceVClusterNamespaces returns all host namespaces managed by the multi-namespace mode enabled vcluster
+func listMultiNamespaceVClusterNamespaces(ctx context.Context, client kubernetes.Interface, vclusterName, vclusterNamespace string) (*corev1.NamespaceList, error) {
 	namespaces, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{
 		LabelSelector: labels.FormatLabels(map[string]string{
 			translate.MarkerLabel: translate.SafeConcatName(vclusterNamespace, "x", vclusterName),
 		}),
 	})
 	if err != nil && !kerrors.IsForbidden(err) {
-		return fmt.Errorf("list namespaces: %w", err)
+		return nil, fmt.Errorf("list namespaces: %w", err)
 	}
 	if namespaces == nil {
-		return errors.New("list namespaces: nil result")
+		return nil, errors.New("list namespaces: nil result")
+	}
+
+	return namespaces, nil
+}
+
+// DeleteMultiNamespaceVClusterNamespaces deletes all host namespaces that were created by the multi-namespace mode
+// enabled vcluster, so they are not orphaned after the vcluster was deleted
+func DeleteMultiNamespaceVClusterNamespaces(ctx context.Context, client kubernetes.Interface, vclusterName, vclusterNamespace string, log log.BaseLogger) error {
+	namespaces, err := listMultiNamespaceVClusterNamespaces(ctx, client, vclusterName, vclusterNamespace)
+	if err != nil {
+		return err
+	}
+
+	for _, ns := range namespaces.Items {
+		if ns.DeletionTimestamp != nil {
+			continue
+		}
+
+		err = client.CoreV1().Namespaces().Delete(ctx, ns.Name, metav1.DeleteOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("delete namespace %s: %w", ns.Name, err)
+		}
+
+		log.Donef("Successfully deleted virtual cluster namespace %s", ns.Name)
+	}
+
+	return nil
+}
+
+func DeleteMultiNamespaceVClusterWorkloads(ctx context.Context, client kubernetes.Interface, vclusterName, vclusterNamespace string, _ log.BaseLogger) error {
+	// get all host namespaces managed by this multinamespace mode enabled vcluster
+	namespaces, err := listMultiNamespaceVClusterNamespaces(ctx, client, vclusterName, vclusterNamespace)
+	if err != nil {
+		return err
 	}
 
 	// delete all pods inside the above returned namespaces
@@ -235,7 +273,7 @@ func scaleDownStatefulSet(ctx context.Context, kubeClient kubernetes.Interface,
 }
 
 // ResumeVCluster resumes a paused vcluster
-func ResumeVCluster(ctx context.Context, kubeClient *kubernetes.Clientset, name, namespace string, log log.BaseLogger) error {
+func ResumeVCluster(ctx context.Context, kubeClient kubernetes.Interface, name, namespace string, log log.BaseLogger) error {
 	// scale up vcluster itself
 	labelSelector := "app=vcluster,release=" + name
 	found, err := scaleUpStatefulSet(ctx, kubeClient, labelSelector, namespace, log)
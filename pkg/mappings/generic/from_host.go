@@ -0,0 +1,104 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/loft-sh/vcluster/pkg/config"
+	"github.com/loft-sh/vcluster/pkg/mappings"
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// NewFromHostMapper creates a new mapper that maps host objects to virtual objects as defined in the
+// given sync.fromHost mappings
+func NewFromHostMapper(obj client.Object, byName map[string]string) (mappings.Mapper, error) {
+	gvk, err := apiutil.GVKForObject(obj, scheme.Scheme)
+	if err != nil {
+		return nil, fmt.Errorf("retrieve GVK for object failed: %w", err)
+	}
+
+	hostToVirtual, err := config.ParseFromHostMappings(byName)
+	if err != nil {
+		return nil, err
+	}
+
+	virtualToHost := make(map[types.NamespacedName]types.NamespacedName, len(hostToVirtual))
+	for hostName, virtualName := range hostToVirtual {
+		virtualToHost[virtualName] = hostName
+	}
+
+	return &fromHostMapper{
+		gvk: gvk,
+
+		hostToVirtual: hostToVirtual,
+		virtualToHost: virtualToHost,
+	}, nil
+}
+
+type fromHostMapper struct {
+	gvk schema.GroupVersionKind
+
+	hostToVirtual map[types.NamespacedName]types.NamespacedName
+	virtualToHost map[types.NamespacedName]types.NamespacedName
+}
+
+func (f *fromHostMapper) GroupVersionKind() schema.GroupVersionKind {
+	return f.gvk
+}
+
+func (f *fromHostMapper) VirtualToHost(_ context.Context, req types.NamespacedName, vObj client.Object) types.NamespacedName {
+	if vObj != nil {
+		vAnnotations := vObj.GetAnnotations()
+		if vAnnotations != nil && vAnnotations[translate.HostNameAnnotation] != "" {
+			hostName := types.NamespacedName{
+				Namespace: vAnnotations[translate.HostNamespaceAnnotation],
+				Name:      vAnnotations[translate.HostNameAnnotation],
+			}
+
+			// make sure the annotations were not modified to point to another host object
+			if lookupFromHostMapping(f.hostToVirtual, hostName) != req {
+				return types.NamespacedName{}
+			}
+
+			return hostName
+		}
+	}
+
+	return lookupFromHostMapping(f.virtualToHost, req)
+}
+
+func (f *fromHostMapper) HostToVirtual(_ context.Context, req types.NamespacedName, _ client.Object) types.NamespacedName {
+	return lookupFromHostMapping(f.hostToVirtual, req)
+}
+
+func (f *fromHostMapper) IsManaged(_ context.Context, pObj client.Object) (bool, error) {
+	// objects that were created by vCluster itself are never synced back
+	if pObj.GetLabels() != nil && pObj.GetLabels()[translate.MarkerLabel] != "" {
+		return false, nil
+	}
+
+	return lookupFromHostMapping(f.hostToVirtual, types.NamespacedName{Namespace: pObj.GetNamespace(), Name: pObj.GetName()}).Name != "", nil
+}
+
+func lookupFromHostMapping(mappings map[types.NamespacedName]types.NamespacedName, req types.NamespacedName) types.NamespacedName {
+	if req.Name == "" {
+		return types.NamespacedName{}
+	}
+
+	// exact match
+	if target, ok := mappings[req]; ok {
+		return target
+	}
+
+	// whole namespace match
+	if target, ok := mappings[types.NamespacedName{Namespace: req.Namespace, Name: "*"}]; ok {
+		return types.NamespacedName{Namespace: target.Namespace, Name: req.Name}
+	}
+
+	return types.NamespacedName{}
+}
@@ -0,0 +1,13 @@
+package resources
+
+import (
+	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	"github.com/loft-sh/vcluster/pkg/mappings"
+	"github.com/loft-sh/vcluster/pkg/mappings/generic"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	discoveryv1 "k8s.io/api/discovery/v1"
+)
+
+func CreateEndpointSlicesMapper(ctx *synccontext.RegisterContext) (mappings.Mapper, error) {
+	return generic.NewMapper(ctx, &discoveryv1.EndpointSlice{}, translate.Default.PhysicalName)
+}
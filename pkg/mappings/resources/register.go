@@ -21,6 +21,7 @@ func getMappers(ctx *synccontext.RegisterContext) []BuildMapper {
 		isEnabled(ctx.Config.Sync.FromHost.CSIDrivers.Enabled == "true", CreateCSIDriversMapper),
 		isEnabled(ctx.Config.Sync.FromHost.CSIStorageCapacities.Enabled == "true", CreateCSIStorageCapacitiesMapper),
 		CreateEndpointsMapper,
+		isEnabled(ctx.Config.Sync.ToHost.EndpointSlices.Enabled, CreateEndpointSlicesMapper),
 		CreateEventsMapper,
 		CreateIngressClassesMapper,
 		CreateIngressesMapper,
@@ -32,6 +33,10 @@ func getMappers(ctx *synccontext.RegisterContext) []BuildMapper {
 		CreateServiceMapper,
 		CreatePriorityClassesMapper,
 		CreatePodDisruptionBudgetsMapper,
+		isEnabled(ctx.Config.Sync.ToHost.ResourceClaims.Enabled, CreateResourceClaimsMapper),
+		isEnabled(ctx.Config.Sync.ToHost.ResourceClaimTemplates.Enabled, CreateResourceClaimTemplatesMapper),
+		isEnabled(ctx.Config.Sync.FromHost.ResourceClasses.Enabled, CreateResourceClassesMapper),
+		isEnabled(ctx.Config.Sync.FromHost.ResourceSlices.Enabled, CreateResourceSlicesMapper),
 		CreatePersistentVolumesMapper,
 		CreatePodsMapper,
 		CreateStorageClassesMapper,
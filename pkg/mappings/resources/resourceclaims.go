@@ -0,0 +1,13 @@
+package resources
+
+import (
+	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	"github.com/loft-sh/vcluster/pkg/mappings"
+	"github.com/loft-sh/vcluster/pkg/mappings/generic"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	resourcev1alpha2 "k8s.io/api/resource/v1alpha2"
+)
+
+func CreateResourceClaimsMapper(ctx *synccontext.RegisterContext) (mappings.Mapper, error) {
+	return generic.NewMapper(ctx, &resourcev1alpha2.ResourceClaim{}, translate.Default.PhysicalName)
+}
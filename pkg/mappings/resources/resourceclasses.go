@@ -0,0 +1,12 @@
+package resources
+
+import (
+	synccontext "github.com/loft-sh/vcluster/pkg/controllers/syncer/context"
+	"github.com/loft-sh/vcluster/pkg/mappings"
+	"github.com/loft-sh/vcluster/pkg/mappings/generic"
+	resourcev1alpha2 "k8s.io/api/resource/v1alpha2"
+)
+
+func CreateResourceClassesMapper(_ *synccontext.RegisterContext) (mappings.Mapper, error) {
+	return generic.NewMirrorMapper(&resourcev1alpha2.ResourceClass{})
+}
@@ -5,8 +5,10 @@ import (
 
 	volumesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
+	resourcev1alpha2 "k8s.io/api/resource/v1alpha2"
 	schedulingv1 "k8s.io/api/scheduling/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -94,6 +96,10 @@ func Endpoints() Mapper {
 	return Default.ByGVK(corev1.SchemeGroupVersion.WithKind("Endpoints"))
 }
 
+func EndpointSlices() Mapper {
+	return Default.ByGVK(discoveryv1.SchemeGroupVersion.WithKind("EndpointSlice"))
+}
+
 func Services() Mapper {
 	return Default.ByGVK(corev1.SchemeGroupVersion.WithKind("Service"))
 }
@@ -134,6 +140,22 @@ func PriorityClasses() Mapper {
 	return Default.ByGVK(schedulingv1.SchemeGroupVersion.WithKind("PriorityClass"))
 }
 
+func ResourceClaims() Mapper {
+	return Default.ByGVK(resourcev1alpha2.SchemeGroupVersion.WithKind("ResourceClaim"))
+}
+
+func ResourceClaimTemplates() Mapper {
+	return Default.ByGVK(resourcev1alpha2.SchemeGroupVersion.WithKind("ResourceClaimTemplate"))
+}
+
+func ResourceClasses() Mapper {
+	return Default.ByGVK(resourcev1alpha2.SchemeGroupVersion.WithKind("ResourceClass"))
+}
+
+func ResourceSlices() Mapper {
+	return Default.ByGVK(resourcev1alpha2.SchemeGroupVersion.WithKind("ResourceSlice"))
+}
+
 func VirtualToHostName(ctx context.Context, vName, vNamespace string, mapper Mapper) string {
 	return mapper.VirtualToHost(ctx, types.NamespacedName{Name: vName, Namespace: vNamespace}, nil).Name
 }
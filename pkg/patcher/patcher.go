@@ -65,7 +65,17 @@ type SyncerPatcher struct {
 
 // Patch will attempt to patch the given object, including its status.
 func (h *SyncerPatcher) Patch(ctx *synccontext.SyncContext, pObj, vObj client.Object) error {
-	err := h.vPatcher.Patch(ctx, vObj)
+	// apply the configured patches after the built-in translation
+	err := ctx.Patches.ApplyVirtual(vObj, pObj)
+	if err != nil {
+		return fmt.Errorf("apply virtual patches: %w", err)
+	}
+	err = ctx.Patches.ApplyHost(pObj, vObj)
+	if err != nil {
+		return fmt.Errorf("apply host patches: %w", err)
+	}
+
+	err = h.vPatcher.Patch(ctx, vObj)
 	if err != nil {
 		return fmt.Errorf("patch virtual object: %w", err)
 	}
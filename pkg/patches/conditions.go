@@ -21,7 +21,8 @@ func ValidateAllConditions(obj *yaml.Node, match *yaml.Node, conditions []*confi
 }
 
 func ValidateCondition(obj *yaml.Node, match *yaml.Node, condition *config.PatchCondition) (bool, error) {
-	if condition == nil {
+	// expressions are evaluated once for the whole patch before it is applied
+	if condition == nil || (condition.Path == "" && condition.SubPath == "" && condition.Expression != "") {
 		return true, nil
 	}
 
@@ -0,0 +1,163 @@
+package expression
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var (
+	envOnce sync.Once
+	env     *cel.Env
+	envErr  error
+
+	// programs caches the compiled programs by expression
+	programs sync.Map
+
+	vClusterMutex sync.RWMutex
+	vCluster      = map[string]interface{}{}
+)
+
+// SetVCluster sets the vCluster fields that are available to expressions as vcluster.name, vcluster.namespace
+// and vcluster.labels
+func SetVCluster(name, namespace string, labels map[string]string) {
+	vClusterLabels := map[string]interface{}{}
+	for key, value := range labels {
+		vClusterLabels[key] = value
+	}
+
+	vClusterMutex.Lock()
+	defer vClusterMutex.Unlock()
+	vCluster = map[string]interface{}{
+		"name":      name,
+		"namespace": namespace,
+		"labels":    vClusterLabels,
+	}
+}
+
+// Variables returns the variables of an expression that is evaluated for the given patched object and the object
+// it was synced from. The other object can be nil if it does not exist.
+func Variables(object, otherObject map[string]interface{}, objectIsHost bool) map[string]interface{} {
+	var otherValue interface{}
+	if otherObject != nil {
+		otherValue = otherObject
+	}
+
+	vClusterMutex.RLock()
+	defer vClusterMutex.RUnlock()
+	variables := map[string]interface{}{
+		"object":      object,
+		"otherObject": otherValue,
+		"virtual":     otherValue,
+		"host":        object,
+		"vcluster":    vCluster,
+	}
+	if !objectIsHost {
+		variables["virtual"] = object
+		variables["host"] = otherValue
+	}
+
+	return variables
+}
+
+// CompileCondition type-checks and compiles the given expression, which needs to return a bool. Compiled
+// expressions are cached, so this is cheap to call for expressions that were compiled before.
+func CompileCondition(expression string) (cel.Program, error) {
+	return compile(expression, cel.BoolType)
+}
+
+// CompileValue type-checks and compiles the given expression, which can return any value.
+func CompileValue(expression string) (cel.Program, error) {
+	return compile(expression, nil)
+}
+
+// EvaluateCondition evaluates the given condition expression with the given variables
+func EvaluateCondition(expression string, variables map[string]interface{}) (bool, error) {
+	program, err := CompileCondition(expression)
+	if err != nil {
+		return false, err
+	}
+
+	out, _, err := program.Eval(variables)
+	if err != nil {
+		return false, fmt.Errorf("evaluate expression %q: %w", expression, err)
+	}
+
+	result, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression %q returned %s instead of bool", expression, out.Type().TypeName())
+	}
+
+	return result, nil
+}
+
+// EvaluateValue evaluates the given value expression with the given variables and returns the result as
+// json compatible value
+func EvaluateValue(expression string, variables map[string]interface{}) (interface{}, error) {
+	program, err := CompileValue(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	out, _, err := program.Eval(variables)
+	if err != nil {
+		return nil, fmt.Errorf("evaluate expression %q: %w", expression, err)
+	}
+
+	value, err := out.ConvertToNative(reflect.TypeOf(&structpb.Value{}))
+	if err != nil {
+		return nil, fmt.Errorf("convert result of expression %q: %w", expression, err)
+	}
+
+	return value.(*structpb.Value).AsInterface(), nil
+}
+
+func compile(expression string, outputType *cel.Type) (cel.Program, error) {
+	cacheKey := "value:" + expression
+	if outputType != nil {
+		cacheKey = outputType.String() + ":" + expression
+	}
+	if program, ok := programs.Load(cacheKey); ok {
+		return program.(cel.Program), nil
+	}
+
+	celEnv, err := getEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	ast, issues := celEnv.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("compile expression %q: %w", expression, issues.Err())
+	}
+	if outputType != nil && !ast.OutputType().IsExactType(outputType) && !ast.OutputType().IsExactType(cel.DynType) {
+		return nil, fmt.Errorf("expression %q must return %s, but returns %s", expression, outputType, ast.OutputType())
+	}
+
+	program, err := celEnv.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("create program for expression %q: %w", expression, err)
+	}
+
+	programs.Store(cacheKey, program)
+	return program, nil
+}
+
+func getEnv() (*cel.Env, error) {
+	envOnce.Do(func() {
+		env, envErr = cel.NewEnv(
+			cel.Variable("object", cel.DynType),
+			cel.Variable("otherObject", cel.DynType),
+			cel.Variable("virtual", cel.DynType),
+			cel.Variable("host", cel.DynType),
+			cel.Variable("vcluster", cel.MapType(cel.StringType, cel.DynType)),
+			ext.Strings(),
+		)
+	})
+
+	return env, envErr
+}
@@ -0,0 +1,51 @@
+package expression
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestCompile(t *testing.T) {
+	_, err := CompileCondition(`object.metadata.name == "test"`)
+	assert.NilError(t, err)
+	_, err = CompileCondition(`vcluster.name + "-suffix"`)
+	assert.ErrorContains(t, err, "must return bool")
+	_, err = CompileCondition(`object.metadata.name ==`)
+	assert.ErrorContains(t, err, "compile expression")
+	_, err = CompileCondition(`unknown.name == "test"`)
+	assert.ErrorContains(t, err, "undeclared reference to 'unknown'")
+
+	_, err = CompileValue(`vcluster.name + "-suffix"`)
+	assert.NilError(t, err)
+}
+
+func TestEvaluate(t *testing.T) {
+	SetVCluster("my-vcluster", "vcluster-ns", map[string]string{"team": "a"})
+	defer SetVCluster("", "", nil)
+
+	virtual := map[string]interface{}{"metadata": map[string]interface{}{"name": "test", "namespace": "default"}}
+	host := map[string]interface{}{"metadata": map[string]interface{}{"name": "test-x-default-x-my-vcluster", "labels": map[string]interface{}{"team": "a"}}}
+
+	// host object is patched
+	variables := Variables(host, virtual, true)
+	matched, err := EvaluateCondition(`host.metadata.labels["team"] == vcluster.labels["team"] && virtual.metadata.namespace == "default"`, variables)
+	assert.NilError(t, err)
+	assert.Assert(t, matched)
+	value, err := EvaluateValue(`object.metadata.name.replace("-x-", ".")`, variables)
+	assert.NilError(t, err)
+	assert.Equal(t, value, "test.default.my-vcluster")
+
+	// virtual object is patched and the host object does not exist
+	variables = Variables(virtual, nil, false)
+	matched, err = EvaluateCondition(`host == null && object.metadata.name == virtual.metadata.name`, variables)
+	assert.NilError(t, err)
+	assert.Assert(t, matched)
+	value, err = EvaluateValue(`{"vcluster": vcluster.name, "namespace": vcluster.namespace, "ports": [80, 443]}`, variables)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, value, map[string]interface{}{"vcluster": "my-vcluster", "namespace": "vcluster-ns", "ports": []interface{}{float64(80), float64(443)}})
+
+	// missing fields fail at runtime
+	_, err = EvaluateCondition(`object.spec.replicas > 1`, variables)
+	assert.ErrorContains(t, err, "evaluate expression")
+}
@@ -3,10 +3,13 @@ package patches
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"regexp"
 
 	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/patches/expression"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	jsonyaml "github.com/ghodss/yaml"
 	"github.com/pkg/errors"
@@ -23,7 +26,9 @@ type NameResolver interface {
 	TranslateNamespaceRef(namespace string) (string, error)
 }
 
-func ApplyPatches(destObj, sourceObj client.Object, patchesConf []*vclusterconfig.Patch, reversePatchesConf []*vclusterconfig.Patch, nameResolver NameResolver) error {
+// ApplyPatches applies the patches to destObj. destIsHost defines if destObj is a host object, which decides if it is
+// bound to the host or virtual variable of expressions.
+func ApplyPatches(destObj, sourceObj client.Object, patchesConf []*vclusterconfig.Patch, reversePatchesConf []*vclusterconfig.Patch, nameResolver NameResolver, destIsHost bool) error {
 	node1, err := NewJSONNode(destObj)
 	if err != nil {
 		return errors.Wrap(err, "new json yaml node")
@@ -37,7 +42,24 @@ func ApplyPatches(destObj, sourceObj client.Object, patchesConf []*vclusterconfi
 		}
 	}
 
+	var variables map[string]interface{}
 	for _, p := range patchesConf {
+		if hasExpressions(p) {
+			if variables == nil {
+				variables, err = expressionVariables(destObj, sourceObj, destIsHost)
+				if err != nil {
+					return errors.Wrap(err, "expression variables")
+				}
+			}
+
+			p, err = evaluateExpressions(p, variables)
+			if err != nil {
+				return err
+			} else if p == nil {
+				continue
+			}
+		}
+
 		err := applyPatch(node1, node2, p, nameResolver)
 		if err != nil {
 			return errors.Wrap(err, "apply patch")
@@ -64,6 +86,8 @@ func ApplyPatches(destObj, sourceObj client.Object, patchesConf []*vclusterconfi
 		return errors.Wrap(err, "marshal yaml")
 	}
 
+	// reset the object first, otherwise fields that were removed by a patch would be kept
+	resetObject(destObj)
 	err = jsonyaml.Unmarshal(objYaml, destObj)
 	if err != nil {
 		return errors.Wrap(err, "convert object")
@@ -72,6 +96,73 @@ func ApplyPatches(destObj, sourceObj client.Object, patchesConf []*vclusterconfi
 	return nil
 }
 
+func hasExpressions(patch *vclusterconfig.Patch) bool {
+	if patch.ValueExpression != "" {
+		return true
+	}
+	for _, condition := range patch.Conditions {
+		if condition != nil && condition.Expression != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// evaluateExpressions evaluates the expression conditions and the value expression of the given patch. Returns nil
+// if the patch should not be applied.
+func evaluateExpressions(patch *vclusterconfig.Patch, variables map[string]interface{}) (*vclusterconfig.Patch, error) {
+	for _, condition := range patch.Conditions {
+		if condition == nil || condition.Expression == "" {
+			continue
+		}
+
+		matched, err := expression.EvaluateCondition(condition.Expression, variables)
+		if err != nil {
+			return nil, errors.Wrap(err, "validate conditions")
+		} else if !matched {
+			return nil, nil
+		}
+	}
+
+	if patch.ValueExpression == "" {
+		return patch, nil
+	}
+
+	value, err := expression.EvaluateValue(patch.ValueExpression, variables)
+	if err != nil {
+		return nil, errors.Wrap(err, "value expression")
+	}
+
+	evaluatedPatch := *patch
+	evaluatedPatch.Value = value
+	return &evaluatedPatch, nil
+}
+
+func expressionVariables(destObj, sourceObj client.Object, destIsHost bool) (map[string]interface{}, error) {
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(destObj)
+	if err != nil {
+		return nil, err
+	}
+
+	var otherObject map[string]interface{}
+	if sourceObj != nil {
+		otherObject, err = runtime.DefaultUnstructuredConverter.ToUnstructured(sourceObj)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return expression.Variables(object, otherObject, destIsHost), nil
+}
+
+func resetObject(obj client.Object) {
+	value := reflect.ValueOf(obj)
+	if value.Kind() == reflect.Pointer && !value.IsNil() {
+		value.Elem().Set(reflect.Zero(value.Elem().Type()))
+	}
+}
+
 func applyPatch(obj1, obj2 *yaml.Node, patch *vclusterconfig.Patch, resolver NameResolver) error {
 	switch patch.Operation {
 	case vclusterconfig.PatchTypeRewriteName:
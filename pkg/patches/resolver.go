@@ -0,0 +1,95 @@
+package patches
+
+import (
+	"fmt"
+	"regexp"
+
+	patchesregex "github.com/loft-sh/vcluster/pkg/patches/regex"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// NewVirtualToHostNameResolver returns a name resolver that translates names of the given virtual namespace to the host cluster
+func NewVirtualToHostNameResolver(namespace string) NameResolver {
+	return &virtualToHostNameResolver{namespace: namespace}
+}
+
+type virtualToHostNameResolver struct {
+	namespace string
+}
+
+func (r *virtualToHostNameResolver) TranslateName(name string, regex *regexp.Regexp, _ string) (string, error) {
+	return r.TranslateNameWithNamespace(name, r.namespace, regex, "")
+}
+
+func (r *virtualToHostNameResolver) TranslateNameWithNamespace(name string, namespace string, regex *regexp.Regexp, _ string) (string, error) {
+	if regex != nil {
+		return patchesregex.ProcessRegex(regex, name, func(name, ns string) types.NamespacedName {
+			// if the regex match doesn't contain namespace - use the namespace set in this resolver
+			if ns == "" {
+				ns = namespace
+			}
+
+			return types.NamespacedName{
+				Namespace: translate.Default.PhysicalNamespace(namespace),
+				Name:      translate.Default.PhysicalName(name, ns),
+			}
+		}), nil
+	}
+
+	return translate.Default.PhysicalName(name, namespace), nil
+}
+
+func (r *virtualToHostNameResolver) TranslateLabelExpressionsSelector(selector *metav1.LabelSelector) (*metav1.LabelSelector, error) {
+	return translate.Default.TranslateLabelSelectorCluster(selector), nil
+}
+
+func (r *virtualToHostNameResolver) TranslateLabelKey(key string) (string, error) {
+	return translate.Default.ConvertLabelKey(key), nil
+}
+
+func (r *virtualToHostNameResolver) TranslateLabelSelector(selector map[string]string) (map[string]string, error) {
+	labelSelector := &metav1.LabelSelector{
+		MatchLabels: selector,
+	}
+
+	return metav1.LabelSelectorAsMap(
+		translate.Default.TranslateLabelSelector(labelSelector))
+}
+
+func (r *virtualToHostNameResolver) TranslateNamespaceRef(namespace string) (string, error) {
+	return translate.Default.PhysicalNamespace(namespace), nil
+}
+
+// NewHostToVirtualNameResolver returns a name resolver for patches applied to virtual objects, which does not support
+// any name translation
+func NewHostToVirtualNameResolver() NameResolver {
+	return &hostToVirtualNameResolver{}
+}
+
+type hostToVirtualNameResolver struct{}
+
+func (r *hostToVirtualNameResolver) TranslateName(string, *regexp.Regexp, string) (string, error) {
+	return "", fmt.Errorf("translation not supported from host to virtual object")
+}
+
+func (r *hostToVirtualNameResolver) TranslateNameWithNamespace(string, string, *regexp.Regexp, string) (string, error) {
+	return "", fmt.Errorf("translation not supported from host to virtual object")
+}
+
+func (r *hostToVirtualNameResolver) TranslateLabelKey(string) (string, error) {
+	return "", fmt.Errorf("translation not supported from host to virtual object")
+}
+
+func (r *hostToVirtualNameResolver) TranslateLabelExpressionsSelector(*metav1.LabelSelector) (*metav1.LabelSelector, error) {
+	return nil, fmt.Errorf("translation not supported from host to virtual object")
+}
+
+func (r *hostToVirtualNameResolver) TranslateLabelSelector(map[string]string) (map[string]string, error) {
+	return nil, fmt.Errorf("translation not supported from host to virtual object")
+}
+
+func (r *hostToVirtualNameResolver) TranslateNamespaceRef(string) (string, error) {
+	return "", fmt.Errorf("translation not supported from host to virtual object")
+}
@@ -0,0 +1,65 @@
+package patches
+
+import (
+	"fmt"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	patchesregex "github.com/loft-sh/vcluster/pkg/patches/regex"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SyncerPatches are the patches of a built-in syncer that are applied after the built-in translation
+type SyncerPatches struct {
+	// Host are the patches applied to the host object, the virtual object is used as source object
+	Host []*vclusterconfig.Patch
+
+	// Virtual are the patches applied to the virtual object, the host object is used as source object
+	Virtual []*vclusterconfig.Patch
+}
+
+// NewSyncerPatches creates the syncer patches for the given sync.toHost or sync.fromHost configuration. Returns nil
+// if no patches are configured.
+func NewSyncerPatches(toHost bool, config vclusterconfig.SyncPatches) (*SyncerPatches, error) {
+	if len(config.Patches) == 0 && len(config.ReversePatches) == 0 {
+		return nil, nil
+	}
+
+	for _, p := range append(config.Patches, config.ReversePatches...) {
+		if p.Regex != "" && p.ParsedRegex == nil {
+			parsed, err := patchesregex.PrepareRegex(p.Regex)
+			if err != nil {
+				return nil, fmt.Errorf("invalid regex %q: %w", p.Regex, err)
+			}
+			p.ParsedRegex = parsed
+		}
+	}
+
+	if toHost {
+		return &SyncerPatches{Host: config.Patches, Virtual: config.ReversePatches}, nil
+	}
+
+	return &SyncerPatches{Host: config.ReversePatches, Virtual: config.Patches}, nil
+}
+
+// ApplyHost applies the host patches to the given host object. The virtual object can be nil if it does not exist.
+func (s *SyncerPatches) ApplyHost(pObj, vObj client.Object) error {
+	if s == nil || len(s.Host) == 0 || pObj == nil {
+		return nil
+	}
+
+	namespace := pObj.GetNamespace()
+	if vObj != nil {
+		namespace = vObj.GetNamespace()
+	}
+
+	return ApplyPatches(pObj, vObj, s.Host, nil, NewVirtualToHostNameResolver(namespace), true)
+}
+
+// ApplyVirtual applies the virtual patches to the given virtual object. The host object can be nil if it does not exist.
+func (s *SyncerPatches) ApplyVirtual(vObj, pObj client.Object) error {
+	if s == nil || len(s.Virtual) == 0 || vObj == nil {
+		return nil
+	}
+
+	return ApplyPatches(vObj, pObj, s.Virtual, nil, NewHostToVirtualNameResolver(), false)
+}
@@ -0,0 +1,146 @@
+package patches
+
+import (
+	"testing"
+
+	"github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/patches/expression"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewSyncerPatches(t *testing.T) {
+	syncerPatches, err := NewSyncerPatches(true, config.SyncPatches{})
+	assert.NilError(t, err)
+	assert.Assert(t, syncerPatches == nil)
+
+	patch := &config.Patch{Operation: config.PatchTypeRewriteName, Path: "spec.secretName", Regex: "^(.*)$"}
+	reversePatch := &config.Patch{Operation: config.PatchTypeRemove, Path: "status"}
+	syncerPatches, err = NewSyncerPatches(true, config.SyncPatches{Patches: []*config.Patch{patch}, ReversePatches: []*config.Patch{reversePatch}})
+	assert.NilError(t, err)
+	assert.Equal(t, syncerPatches.Host[0], patch)
+	assert.Equal(t, syncerPatches.Virtual[0], reversePatch)
+	assert.Assert(t, patch.ParsedRegex != nil)
+
+	syncerPatches, err = NewSyncerPatches(false, config.SyncPatches{Patches: []*config.Patch{patch}, ReversePatches: []*config.Patch{reversePatch}})
+	assert.NilError(t, err)
+	assert.Equal(t, syncerPatches.Host[0], reversePatch)
+	assert.Equal(t, syncerPatches.Virtual[0], patch)
+
+	_, err = NewSyncerPatches(true, config.SyncPatches{Patches: []*config.Patch{{Operation: config.PatchTypeRewriteName, Path: "spec", Regex: "(("}}})
+	assert.ErrorContains(t, err, "invalid regex")
+}
+
+func TestSyncerPatchesApply(t *testing.T) {
+	syncerPatches := &SyncerPatches{
+		Host: []*config.Patch{
+			{Operation: config.PatchTypeAdd, Path: "metadata.annotations", Value: map[string]interface{}{"host-only": "true"}},
+			{Operation: config.PatchTypeRemove, Path: "metadata.annotations.virtual-only"},
+			{Operation: config.PatchTypeRewriteName, Path: "spec.serviceAccountName"},
+		},
+		Virtual: []*config.Patch{
+			{Operation: config.PatchTypeCopyFromObject, FromPath: "spec.nodeName", Path: "metadata.labels.host-node"},
+		},
+	}
+
+	vPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec:       corev1.PodSpec{ServiceAccountName: "default"},
+	}
+	pPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        translate.Default.PhysicalName("test", "default"),
+			Namespace:   "test",
+			Annotations: map[string]string{"virtual-only": "true", "other": "true"},
+		},
+		Spec: corev1.PodSpec{ServiceAccountName: "default", NodeName: "node-1"},
+	}
+
+	err := syncerPatches.ApplyHost(pPod, vPod)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, pPod.Annotations, map[string]string{"host-only": "true", "other": "true"})
+	assert.Equal(t, pPod.Spec.ServiceAccountName, translate.Default.PhysicalName("default", "default"))
+
+	err = syncerPatches.ApplyVirtual(vPod, pPod)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, vPod.Labels, map[string]string{"host-node": "node-1"})
+
+	// nothing to do without patches or objects
+	var empty *SyncerPatches
+	assert.NilError(t, empty.ApplyHost(pPod, vPod))
+	assert.NilError(t, syncerPatches.ApplyVirtual(nil, pPod))
+}
+
+func TestSyncerPatchesExpressions(t *testing.T) {
+	expression.SetVCluster("my-vcluster", "vcluster", map[string]string{"team": "a"})
+	defer expression.SetVCluster("", "", nil)
+
+	syncerPatches := &SyncerPatches{
+		Host: []*config.Patch{
+			{
+				Operation:       config.PatchTypeAdd,
+				Path:            "metadata.annotations.owner",
+				ValueExpression: `vcluster.name + "/" + virtual.metadata.namespace + "/" + virtual.metadata.name`,
+			},
+			{
+				Operation:  config.PatchTypeAdd,
+				Path:       "metadata.labels.team",
+				Value:      "b",
+				Conditions: []*config.PatchCondition{{Expression: `vcluster.labels["team"] == "b"`}},
+			},
+			{
+				Operation:  config.PatchTypeAdd,
+				Path:       "spec.priority",
+				Value:      10,
+				Conditions: []*config.PatchCondition{{Path: "spec.nodeName", Equal: "node-1"}, {Expression: `has(host.spec.nodeName) && object.spec.nodeName == "node-1"`}},
+			},
+		},
+		Virtual: []*config.Patch{
+			{
+				Operation:       config.PatchTypeReplace,
+				Path:            "spec.nodeName",
+				ValueExpression: `host.spec.nodeName + "-virtual"`,
+			},
+		},
+	}
+
+	vPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec:       corev1.PodSpec{NodeName: "node"},
+	}
+	pPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: translate.Default.PhysicalName("test", "default"), Namespace: "test"},
+		Spec:       corev1.PodSpec{NodeName: "node-1"},
+	}
+
+	err := syncerPatches.ApplyHost(pPod, vPod)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, pPod.Annotations, map[string]string{"owner": "my-vcluster/default/test"})
+	assert.Assert(t, pPod.Labels == nil)
+	assert.Equal(t, *pPod.Spec.Priority, int32(10))
+
+	err = syncerPatches.ApplyVirtual(vPod, pPod)
+	assert.NilError(t, err)
+	assert.Equal(t, vPod.Spec.NodeName, "node-1-virtual")
+}
+
+func TestApplyPatchesDirection(t *testing.T) {
+	patch := &config.Patch{
+		Operation:       config.PatchTypeAdd,
+		Path:            "metadata.annotations.virtual",
+		ValueExpression: `virtual.metadata.name`,
+	}
+	vPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "virtual-pod", Namespace: "default"}}
+	pPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "host-pod", Namespace: "test"}}
+
+	// the direction is independent of the name resolver, e.g. import syncers patch host objects with their own resolver
+	err := ApplyPatches(pPod, vPod, []*config.Patch{patch}, nil, NewHostToVirtualNameResolver(), true)
+	assert.NilError(t, err)
+	assert.Equal(t, pPod.Annotations["virtual"], "virtual-pod")
+
+	err = ApplyPatches(vPod, pPod, []*config.Patch{patch}, nil, NewVirtualToHostNameResolver("default"), false)
+	assert.NilError(t, err)
+	assert.Equal(t, vPod.Annotations["virtual"], "virtual-pod")
+}
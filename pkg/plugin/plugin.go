@@ -87,6 +87,10 @@ func (m *manager) HasPlugins() bool {
 	return m.legacyManager.HasPlugins() || m.pluginManager.HasPlugins()
 }
 
+func (m *manager) Syncers() []plugintypes.Syncer {
+	return m.pluginManager.Syncers
+}
+
 func (m *manager) SetProFeatures(proFeatures map[string]bool) {
 	m.pluginManager.ProFeatures = proFeatures
 }
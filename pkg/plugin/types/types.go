@@ -6,6 +6,7 @@ import (
 
 	"github.com/loft-sh/vcluster/pkg/config"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/rest"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -35,6 +36,9 @@ type Manager interface {
 	// HasPlugins returns if there are any plugins to start
 	HasPlugins() bool
 
+	// Syncers returns the syncers that were registered by plugins
+	Syncers() []Syncer
+
 	// SetProFeatures is used by vCluster.Pro to signal what pro features are enabled
 	SetProFeatures(proFeatures map[string]bool)
 	// WithInterceptors is a middleware that allows us to delegate some requests to out of
@@ -42,6 +46,23 @@ type Manager interface {
 	WithInterceptors(http.Handler) http.Handler
 }
 
+// Syncer is a syncer for a single type that is run by vCluster and translates the objects
+// by calling the plugin. Deletion is handled by vCluster itself.
+type Syncer interface {
+	// Name returns the name of the syncer
+	Name() string
+
+	// GroupVersionKind returns the type that is synced
+	GroupVersionKind() schema.GroupVersionKind
+
+	// SyncToHost changes the translated host object pObj before it is applied. existingPObj is nil
+	// if the host object doesn't exist yet.
+	SyncToHost(ctx context.Context, vObj, pObj, existingPObj client.Object) error
+
+	// SyncToVirtual syncs changes of the host object back into vObj and returns if vObj was changed
+	SyncToVirtual(ctx context.Context, vObj, pObj client.Object) (bool, error)
+}
+
 type VersionKindType struct {
 	APIVersion string
 	Kind       string
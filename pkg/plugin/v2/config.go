@@ -32,6 +32,7 @@ type InitConfigPro struct {
 type PluginConfig struct {
 	ClientHooks  []*ClientHook                `json:"clientHooks,omitempty"`
 	Interceptors map[string][]InterceptorRule `json:"interceptors,omitempty"`
+	Syncers      []*Syncer                    `json:"syncers,omitempty"`
 }
 
 type ClientHook struct {
@@ -40,6 +41,12 @@ type ClientHook struct {
 	Types      []string `json:"types,omitempty"`
 }
 
+// Syncer is a syncer for the given type that is run by vCluster, which calls the plugin to translate the objects
+type Syncer struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+}
+
 type InterceptorRule struct {
 	APIGroups       []string `json:"apiGroups,omitempty"`
 	Resources       []string `json:"resources,omitempty"`
@@ -57,6 +57,9 @@ type Manager struct {
 	// ClientHooks that were loaded
 	ClientHooks map[plugintypes.VersionKindType][]*vClusterPlugin
 
+	// Syncers that were loaded
+	Syncers []plugintypes.Syncer
+
 	// map to track the port that needs to be targeted for the interceptors
 	// structure is group>resource>verb>resourceName
 	ResourceInterceptorsPorts map[string]map[string]map[string]map[string]portHandlerName
@@ -142,6 +145,12 @@ func (m *Manager) Start(
 			return fmt.Errorf("error adding interceptor for plugin %s: %w", vClusterPlugin.Path, err)
 		}
 
+		// register syncers
+		err = m.registerSyncers(vClusterPlugin, pluginConfig.Syncers)
+		if err != nil {
+			return fmt.Errorf("error adding syncer for plugin %s: %w", vClusterPlugin.Path, err)
+		}
+
 		klog.FromContext(ctx).Info("Successfully loaded plugin", "plugin", vClusterPlugin.Path)
 
 		port++
@@ -137,6 +137,82 @@ func (*Mutate) Descriptor() ([]byte, []int) {
 	return file_pluginv2_proto_rawDescGZIP(), []int{2}
 }
 
+type SyncToHost struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SyncToHost) Reset() {
+	*x = SyncToHost{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pluginv2_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncToHost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncToHost) ProtoMessage() {}
+
+func (x *SyncToHost) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginv2_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncToHost.ProtoReflect.Descriptor instead.
+func (*SyncToHost) Descriptor() ([]byte, []int) {
+	return file_pluginv2_proto_rawDescGZIP(), []int{3}
+}
+
+type SyncToVirtual struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SyncToVirtual) Reset() {
+	*x = SyncToVirtual{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pluginv2_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncToVirtual) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncToVirtual) ProtoMessage() {}
+
+func (x *SyncToVirtual) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginv2_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncToVirtual.ProtoReflect.Descriptor instead.
+func (*SyncToVirtual) Descriptor() ([]byte, []int) {
+	return file_pluginv2_proto_rawDescGZIP(), []int{4}
+}
+
 type SetLeader struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -146,7 +222,7 @@ type SetLeader struct {
 func (x *SetLeader) Reset() {
 	*x = SetLeader{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pluginv2_proto_msgTypes[3]
+		mi := &file_pluginv2_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -159,7 +235,7 @@ func (x *SetLeader) String() string {
 func (*SetLeader) ProtoMessage() {}
 
 func (x *SetLeader) ProtoReflect() protoreflect.Message {
-	mi := &file_pluginv2_proto_msgTypes[3]
+	mi := &file_pluginv2_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -172,7 +248,7 @@ func (x *SetLeader) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetLeader.ProtoReflect.Descriptor instead.
 func (*SetLeader) Descriptor() ([]byte, []int) {
-	return file_pluginv2_proto_rawDescGZIP(), []int{3}
+	return file_pluginv2_proto_rawDescGZIP(), []int{5}
 }
 
 type Initialize_Request struct {
@@ -186,7 +262,7 @@ type Initialize_Request struct {
 func (x *Initialize_Request) Reset() {
 	*x = Initialize_Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pluginv2_proto_msgTypes[4]
+		mi := &file_pluginv2_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -199,7 +275,7 @@ func (x *Initialize_Request) String() string {
 func (*Initialize_Request) ProtoMessage() {}
 
 func (x *Initialize_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_pluginv2_proto_msgTypes[4]
+	mi := &file_pluginv2_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -231,7 +307,7 @@ type Initialize_Response struct {
 func (x *Initialize_Response) Reset() {
 	*x = Initialize_Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pluginv2_proto_msgTypes[5]
+		mi := &file_pluginv2_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -244,7 +320,7 @@ func (x *Initialize_Response) String() string {
 func (*Initialize_Response) ProtoMessage() {}
 
 func (x *Initialize_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_pluginv2_proto_msgTypes[5]
+	mi := &file_pluginv2_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -269,7 +345,7 @@ type GetPluginConfig_Request struct {
 func (x *GetPluginConfig_Request) Reset() {
 	*x = GetPluginConfig_Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pluginv2_proto_msgTypes[6]
+		mi := &file_pluginv2_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -282,7 +358,7 @@ func (x *GetPluginConfig_Request) String() string {
 func (*GetPluginConfig_Request) ProtoMessage() {}
 
 func (x *GetPluginConfig_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_pluginv2_proto_msgTypes[6]
+	mi := &file_pluginv2_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -309,7 +385,7 @@ type GetPluginConfig_Response struct {
 func (x *GetPluginConfig_Response) Reset() {
 	*x = GetPluginConfig_Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pluginv2_proto_msgTypes[7]
+		mi := &file_pluginv2_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -322,7 +398,7 @@ func (x *GetPluginConfig_Response) String() string {
 func (*GetPluginConfig_Response) ProtoMessage() {}
 
 func (x *GetPluginConfig_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_pluginv2_proto_msgTypes[7]
+	mi := &file_pluginv2_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -359,7 +435,7 @@ type Mutate_Request struct {
 func (x *Mutate_Request) Reset() {
 	*x = Mutate_Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pluginv2_proto_msgTypes[8]
+		mi := &file_pluginv2_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -372,7 +448,7 @@ func (x *Mutate_Request) String() string {
 func (*Mutate_Request) ProtoMessage() {}
 
 func (x *Mutate_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_pluginv2_proto_msgTypes[8]
+	mi := &file_pluginv2_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -428,7 +504,7 @@ type Mutate_Response struct {
 func (x *Mutate_Response) Reset() {
 	*x = Mutate_Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pluginv2_proto_msgTypes[9]
+		mi := &file_pluginv2_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -441,7 +517,7 @@ func (x *Mutate_Response) String() string {
 func (*Mutate_Response) ProtoMessage() {}
 
 func (x *Mutate_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_pluginv2_proto_msgTypes[9]
+	mi := &file_pluginv2_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -471,6 +547,265 @@ func (x *Mutate_Response) GetMutated() bool {
 	return false
 }
 
+type SyncToHost_Request struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ApiVersion string `protobuf:"bytes,1,opt,name=apiVersion,proto3" json:"apiVersion,omitempty"`
+	Kind       string `protobuf:"bytes,2,opt,name=kind,proto3"       json:"kind,omitempty"`
+	// virtualObject is the virtual object that should be synced
+	VirtualObject string `protobuf:"bytes,3,opt,name=virtualObject,proto3" json:"virtualObject,omitempty"`
+	// hostObject is the virtual object translated by vCluster, the plugin can change it before it is applied
+	HostObject string `protobuf:"bytes,4,opt,name=hostObject,proto3" json:"hostObject,omitempty"`
+	// existingHostObject is the current host object, empty during creation
+	ExistingHostObject string `protobuf:"bytes,5,opt,name=existingHostObject,proto3" json:"existingHostObject,omitempty"`
+}
+
+func (x *SyncToHost_Request) Reset() {
+	*x = SyncToHost_Request{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pluginv2_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncToHost_Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncToHost_Request) ProtoMessage() {}
+
+func (x *SyncToHost_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginv2_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncToHost_Request.ProtoReflect.Descriptor instead.
+func (*SyncToHost_Request) Descriptor() ([]byte, []int) {
+	return file_pluginv2_proto_rawDescGZIP(), []int{3, 0}
+}
+
+func (x *SyncToHost_Request) GetApiVersion() string {
+	if x != nil {
+		return x.ApiVersion
+	}
+	return ""
+}
+
+func (x *SyncToHost_Request) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *SyncToHost_Request) GetVirtualObject() string {
+	if x != nil {
+		return x.VirtualObject
+	}
+	return ""
+}
+
+func (x *SyncToHost_Request) GetHostObject() string {
+	if x != nil {
+		return x.HostObject
+	}
+	return ""
+}
+
+func (x *SyncToHost_Request) GetExistingHostObject() string {
+	if x != nil {
+		return x.ExistingHostObject
+	}
+	return ""
+}
+
+type SyncToHost_Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// hostObject is the host object that should be applied, empty if the translated object should be applied as is
+	HostObject string `protobuf:"bytes,1,opt,name=hostObject,proto3" json:"hostObject,omitempty"`
+}
+
+func (x *SyncToHost_Response) Reset() {
+	*x = SyncToHost_Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pluginv2_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncToHost_Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncToHost_Response) ProtoMessage() {}
+
+func (x *SyncToHost_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginv2_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncToHost_Response.ProtoReflect.Descriptor instead.
+func (*SyncToHost_Response) Descriptor() ([]byte, []int) {
+	return file_pluginv2_proto_rawDescGZIP(), []int{3, 1}
+}
+
+func (x *SyncToHost_Response) GetHostObject() string {
+	if x != nil {
+		return x.HostObject
+	}
+	return ""
+}
+
+type SyncToVirtual_Request struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ApiVersion string `protobuf:"bytes,1,opt,name=apiVersion,proto3" json:"apiVersion,omitempty"`
+	Kind       string `protobuf:"bytes,2,opt,name=kind,proto3"       json:"kind,omitempty"`
+	// virtualObject is the current virtual object
+	VirtualObject string `protobuf:"bytes,3,opt,name=virtualObject,proto3" json:"virtualObject,omitempty"`
+	// hostObject is the current host object
+	HostObject string `protobuf:"bytes,4,opt,name=hostObject,proto3" json:"hostObject,omitempty"`
+}
+
+func (x *SyncToVirtual_Request) Reset() {
+	*x = SyncToVirtual_Request{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pluginv2_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncToVirtual_Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncToVirtual_Request) ProtoMessage() {}
+
+func (x *SyncToVirtual_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginv2_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncToVirtual_Request.ProtoReflect.Descriptor instead.
+func (*SyncToVirtual_Request) Descriptor() ([]byte, []int) {
+	return file_pluginv2_proto_rawDescGZIP(), []int{4, 0}
+}
+
+func (x *SyncToVirtual_Request) GetApiVersion() string {
+	if x != nil {
+		return x.ApiVersion
+	}
+	return ""
+}
+
+func (x *SyncToVirtual_Request) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *SyncToVirtual_Request) GetVirtualObject() string {
+	if x != nil {
+		return x.VirtualObject
+	}
+	return ""
+}
+
+func (x *SyncToVirtual_Request) GetHostObject() string {
+	if x != nil {
+		return x.HostObject
+	}
+	return ""
+}
+
+type SyncToVirtual_Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// virtualObject is the updated virtual object
+	VirtualObject string `protobuf:"bytes,1,opt,name=virtualObject,proto3" json:"virtualObject,omitempty"`
+	Updated       bool   `protobuf:"varint,2,opt,name=updated,proto3"      json:"updated,omitempty"`
+}
+
+func (x *SyncToVirtual_Response) Reset() {
+	*x = SyncToVirtual_Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pluginv2_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncToVirtual_Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncToVirtual_Response) ProtoMessage() {}
+
+func (x *SyncToVirtual_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginv2_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncToVirtual_Response.ProtoReflect.Descriptor instead.
+func (*SyncToVirtual_Response) Descriptor() ([]byte, []int) {
+	return file_pluginv2_proto_rawDescGZIP(), []int{4, 1}
+}
+
+func (x *SyncToVirtual_Response) GetVirtualObject() string {
+	if x != nil {
+		return x.VirtualObject
+	}
+	return ""
+}
+
+func (x *SyncToVirtual_Response) GetUpdated() bool {
+	if x != nil {
+		return x.Updated
+	}
+	return false
+}
+
 type SetLeader_Request struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -480,7 +815,7 @@ type SetLeader_Request struct {
 func (x *SetLeader_Request) Reset() {
 	*x = SetLeader_Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pluginv2_proto_msgTypes[10]
+		mi := &file_pluginv2_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -493,7 +828,7 @@ func (x *SetLeader_Request) String() string {
 func (*SetLeader_Request) ProtoMessage() {}
 
 func (x *SetLeader_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_pluginv2_proto_msgTypes[10]
+	mi := &file_pluginv2_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -506,7 +841,7 @@ func (x *SetLeader_Request) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetLeader_Request.ProtoReflect.Descriptor instead.
 func (*SetLeader_Request) Descriptor() ([]byte, []int) {
-	return file_pluginv2_proto_rawDescGZIP(), []int{3, 0}
+	return file_pluginv2_proto_rawDescGZIP(), []int{5, 0}
 }
 
 type SetLeader_Response struct {
@@ -518,7 +853,7 @@ type SetLeader_Response struct {
 func (x *SetLeader_Response) Reset() {
 	*x = SetLeader_Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pluginv2_proto_msgTypes[11]
+		mi := &file_pluginv2_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -531,7 +866,7 @@ func (x *SetLeader_Response) String() string {
 func (*SetLeader_Response) ProtoMessage() {}
 
 func (x *SetLeader_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_pluginv2_proto_msgTypes[11]
+	mi := &file_pluginv2_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -544,7 +879,7 @@ func (x *SetLeader_Response) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetLeader_Response.ProtoReflect.Descriptor instead.
 func (*SetLeader_Response) Descriptor() ([]byte, []int) {
-	return file_pluginv2_proto_rawDescGZIP(), []int{3, 1}
+	return file_pluginv2_proto_rawDescGZIP(), []int{5, 1}
 }
 
 var File_pluginv2_proto protoreflect.FileDescriptor
@@ -570,33 +905,72 @@ var file_pluginv2_proto_rawDesc = []byte{
 	0x3c, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f,
 	0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x62, 0x6a,
 	0x65, 0x63, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x75, 0x74, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6d, 0x75, 0x74, 0x61, 0x74, 0x65, 0x64, 0x22, 0x22, 0x0a,
-	0x09, 0x53, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x1a, 0x09, 0x0a, 0x07, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x32, 0xb4, 0x02, 0x0a, 0x06, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x12, 0x49, 0x0a, 0x0a,
-	0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x12, 0x1c, 0x2e, 0x70, 0x6c, 0x75,
-	0x67, 0x69, 0x6e, 0x76, 0x32, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65,
-	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
-	0x6e, 0x76, 0x32, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x2e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x09, 0x53, 0x65, 0x74, 0x4c, 0x65,
-	0x61, 0x64, 0x65, 0x72, 0x12, 0x1b, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x76, 0x32, 0x2e,
-	0x53, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x76, 0x32, 0x2e, 0x53, 0x65, 0x74,
-	0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x58, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x12, 0x21, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x76, 0x32, 0x2e, 0x47, 0x65,
-	0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x76, 0x32,
-	0x2e, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x06, 0x4d, 0x75, 0x74,
-	0x61, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x76, 0x32, 0x2e, 0x4d,
-	0x75, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
-	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x76, 0x32, 0x2e, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x65, 0x2e,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x34, 0x5a, 0x32, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x6f, 0x66, 0x74, 0x2d, 0x73, 0x68, 0x2f, 0x76,
-	0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x6c, 0x75, 0x67,
-	0x69, 0x6e, 0x2f, 0x76, 0x32, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x76, 0x32, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6d, 0x75, 0x74, 0x61, 0x74, 0x65, 0x64, 0x22, 0xee, 0x01,
+	0x0a, 0x0a, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x48, 0x6f, 0x73, 0x74, 0x1a, 0xb3, 0x01, 0x0a,
+	0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x70, 0x69, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70,
+	0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x24, 0x0a, 0x0d,
+	0x76, 0x69, 0x72, 0x74, 0x75, 0x61, 0x6c, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x76, 0x69, 0x72, 0x74, 0x75, 0x61, 0x6c, 0x4f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x68, 0x6f, 0x73, 0x74, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x68, 0x6f, 0x73, 0x74, 0x4f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x12, 0x2e, 0x0a, 0x12, 0x65, 0x78, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x48, 0x6f,
+	0x73, 0x74, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12,
+	0x65, 0x78, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x48, 0x6f, 0x73, 0x74, 0x4f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x1a, 0x2a, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e,
+	0x0a, 0x0a, 0x68, 0x6f, 0x73, 0x74, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x68, 0x6f, 0x73, 0x74, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x22, 0xe1,
+	0x01, 0x0a, 0x0d, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x56, 0x69, 0x72, 0x74, 0x75, 0x61, 0x6c,
+	0x1a, 0x83, 0x01, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a,
+	0x61, 0x70, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x61, 0x70, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64,
+	0x12, 0x24, 0x0a, 0x0d, 0x76, 0x69, 0x72, 0x74, 0x75, 0x61, 0x6c, 0x4f, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x76, 0x69, 0x72, 0x74, 0x75, 0x61, 0x6c,
+	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x68, 0x6f, 0x73, 0x74, 0x4f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x68, 0x6f, 0x73, 0x74,
+	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x1a, 0x4a, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x76, 0x69, 0x72, 0x74, 0x75, 0x61, 0x6c, 0x4f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x76, 0x69, 0x72, 0x74, 0x75,
+	0x61, 0x6c, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x64, 0x22, 0x22, 0x0a, 0x09, 0x53, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x1a,
+	0x09, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x0a, 0x08, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xd3, 0x03, 0x0a, 0x06, 0x50, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x12, 0x49, 0x0a, 0x0a, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x12,
+	0x1c, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x76, 0x32, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x69,
+	0x61, 0x6c, 0x69, 0x7a, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x76, 0x32, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c,
+	0x69, 0x7a, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x09,
+	0x53, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x1b, 0x2e, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x76, 0x32, 0x2e, 0x53, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x76,
+	0x32, 0x2e, 0x53, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x21, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x76, 0x32, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x70, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x76, 0x32, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d,
+	0x0a, 0x06, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x76, 0x32, 0x2e, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x76, 0x32, 0x2e, 0x4d, 0x75,
+	0x74, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a,
+	0x0a, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x1c, 0x2e, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x76, 0x32, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x48, 0x6f, 0x73,
+	0x74, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x76, 0x32, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x48, 0x6f, 0x73, 0x74, 0x2e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x0d, 0x53, 0x79, 0x6e, 0x63,
+	0x54, 0x6f, 0x56, 0x69, 0x72, 0x74, 0x75, 0x61, 0x6c, 0x12, 0x1f, 0x2e, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x76, 0x32, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x56, 0x69, 0x72, 0x74, 0x75,
+	0x61, 0x6c, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x70, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x76, 0x32, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x56, 0x69, 0x72, 0x74,
+	0x75, 0x61, 0x6c, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x34, 0x5a, 0x32,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x6f, 0x66, 0x74, 0x2d,
+	0x73, 0x68, 0x2f, 0x76, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x76, 0x32, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x76, 0x32, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -611,32 +985,42 @@ func file_pluginv2_proto_rawDescGZIP() []byte {
 	return file_pluginv2_proto_rawDescData
 }
 
-var file_pluginv2_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_pluginv2_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_pluginv2_proto_goTypes = []interface{}{
 	(*Initialize)(nil),               // 0: pluginv2.Initialize
 	(*GetPluginConfig)(nil),          // 1: pluginv2.GetPluginConfig
 	(*Mutate)(nil),                   // 2: pluginv2.Mutate
-	(*SetLeader)(nil),                // 3: pluginv2.SetLeader
-	(*Initialize_Request)(nil),       // 4: pluginv2.Initialize.Request
-	(*Initialize_Response)(nil),      // 5: pluginv2.Initialize.Response
-	(*GetPluginConfig_Request)(nil),  // 6: pluginv2.GetPluginConfig.Request
-	(*GetPluginConfig_Response)(nil), // 7: pluginv2.GetPluginConfig.Response
-	(*Mutate_Request)(nil),           // 8: pluginv2.Mutate.Request
-	(*Mutate_Response)(nil),          // 9: pluginv2.Mutate.Response
-	(*SetLeader_Request)(nil),        // 10: pluginv2.SetLeader.Request
-	(*SetLeader_Response)(nil),       // 11: pluginv2.SetLeader.Response
+	(*SyncToHost)(nil),               // 3: pluginv2.SyncToHost
+	(*SyncToVirtual)(nil),            // 4: pluginv2.SyncToVirtual
+	(*SetLeader)(nil),                // 5: pluginv2.SetLeader
+	(*Initialize_Request)(nil),       // 6: pluginv2.Initialize.Request
+	(*Initialize_Response)(nil),      // 7: pluginv2.Initialize.Response
+	(*GetPluginConfig_Request)(nil),  // 8: pluginv2.GetPluginConfig.Request
+	(*GetPluginConfig_Response)(nil), // 9: pluginv2.GetPluginConfig.Response
+	(*Mutate_Request)(nil),           // 10: pluginv2.Mutate.Request
+	(*Mutate_Response)(nil),          // 11: pluginv2.Mutate.Response
+	(*SyncToHost_Request)(nil),       // 12: pluginv2.SyncToHost.Request
+	(*SyncToHost_Response)(nil),      // 13: pluginv2.SyncToHost.Response
+	(*SyncToVirtual_Request)(nil),    // 14: pluginv2.SyncToVirtual.Request
+	(*SyncToVirtual_Response)(nil),   // 15: pluginv2.SyncToVirtual.Response
+	(*SetLeader_Request)(nil),        // 16: pluginv2.SetLeader.Request
+	(*SetLeader_Response)(nil),       // 17: pluginv2.SetLeader.Response
 }
 var file_pluginv2_proto_depIdxs = []int32{
-	4,  // 0: pluginv2.Plugin.Initialize:input_type -> pluginv2.Initialize.Request
-	10, // 1: pluginv2.Plugin.SetLeader:input_type -> pluginv2.SetLeader.Request
-	6,  // 2: pluginv2.Plugin.GetPluginConfig:input_type -> pluginv2.GetPluginConfig.Request
-	8,  // 3: pluginv2.Plugin.Mutate:input_type -> pluginv2.Mutate.Request
-	5,  // 4: pluginv2.Plugin.Initialize:output_type -> pluginv2.Initialize.Response
-	11, // 5: pluginv2.Plugin.SetLeader:output_type -> pluginv2.SetLeader.Response
-	7,  // 6: pluginv2.Plugin.GetPluginConfig:output_type -> pluginv2.GetPluginConfig.Response
-	9,  // 7: pluginv2.Plugin.Mutate:output_type -> pluginv2.Mutate.Response
-	4,  // [4:8] is the sub-list for method output_type
-	0,  // [0:4] is the sub-list for method input_type
+	6,  // 0: pluginv2.Plugin.Initialize:input_type -> pluginv2.Initialize.Request
+	16, // 1: pluginv2.Plugin.SetLeader:input_type -> pluginv2.SetLeader.Request
+	8,  // 2: pluginv2.Plugin.GetPluginConfig:input_type -> pluginv2.GetPluginConfig.Request
+	10, // 3: pluginv2.Plugin.Mutate:input_type -> pluginv2.Mutate.Request
+	12, // 4: pluginv2.Plugin.SyncToHost:input_type -> pluginv2.SyncToHost.Request
+	14, // 5: pluginv2.Plugin.SyncToVirtual:input_type -> pluginv2.SyncToVirtual.Request
+	7,  // 6: pluginv2.Plugin.Initialize:output_type -> pluginv2.Initialize.Response
+	17, // 7: pluginv2.Plugin.SetLeader:output_type -> pluginv2.SetLeader.Response
+	9,  // 8: pluginv2.Plugin.GetPluginConfig:output_type -> pluginv2.GetPluginConfig.Response
+	11, // 9: pluginv2.Plugin.Mutate:output_type -> pluginv2.Mutate.Response
+	13, // 10: pluginv2.Plugin.SyncToHost:output_type -> pluginv2.SyncToHost.Response
+	15, // 11: pluginv2.Plugin.SyncToVirtual:output_type -> pluginv2.SyncToVirtual.Response
+	6,  // [6:12] is the sub-list for method output_type
+	0,  // [0:6] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
@@ -685,7 +1069,7 @@ func file_pluginv2_proto_init() {
 			}
 		}
 		file_pluginv2_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetLeader); i {
+			switch v := v.(*SyncToHost); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -697,7 +1081,7 @@ func file_pluginv2_proto_init() {
 			}
 		}
 		file_pluginv2_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Initialize_Request); i {
+			switch v := v.(*SyncToVirtual); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -709,7 +1093,7 @@ func file_pluginv2_proto_init() {
 			}
 		}
 		file_pluginv2_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Initialize_Response); i {
+			switch v := v.(*SetLeader); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -721,7 +1105,7 @@ func file_pluginv2_proto_init() {
 			}
 		}
 		file_pluginv2_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetPluginConfig_Request); i {
+			switch v := v.(*Initialize_Request); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -733,7 +1117,7 @@ func file_pluginv2_proto_init() {
 			}
 		}
 		file_pluginv2_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetPluginConfig_Response); i {
+			switch v := v.(*Initialize_Response); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -745,7 +1129,7 @@ func file_pluginv2_proto_init() {
 			}
 		}
 		file_pluginv2_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Mutate_Request); i {
+			switch v := v.(*GetPluginConfig_Request); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -757,7 +1141,7 @@ func file_pluginv2_proto_init() {
 			}
 		}
 		file_pluginv2_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Mutate_Response); i {
+			switch v := v.(*GetPluginConfig_Response); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -769,7 +1153,7 @@ func file_pluginv2_proto_init() {
 			}
 		}
 		file_pluginv2_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetLeader_Request); i {
+			switch v := v.(*Mutate_Request); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -781,6 +1165,78 @@ func file_pluginv2_proto_init() {
 			}
 		}
 		file_pluginv2_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Mutate_Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pluginv2_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncToHost_Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pluginv2_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncToHost_Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pluginv2_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncToVirtual_Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pluginv2_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncToVirtual_Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pluginv2_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetLeader_Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pluginv2_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SetLeader_Response); i {
 			case 0:
 				return &v.state
@@ -799,7 +1255,7 @@ func file_pluginv2_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pluginv2_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
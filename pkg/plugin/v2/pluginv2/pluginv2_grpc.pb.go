@@ -27,6 +27,8 @@ type PluginClient interface {
 	SetLeader(ctx context.Context, in *SetLeader_Request, opts ...grpc.CallOption) (*SetLeader_Response, error)
 	GetPluginConfig(ctx context.Context, in *GetPluginConfig_Request, opts ...grpc.CallOption) (*GetPluginConfig_Response, error)
 	Mutate(ctx context.Context, in *Mutate_Request, opts ...grpc.CallOption) (*Mutate_Response, error)
+	SyncToHost(ctx context.Context, in *SyncToHost_Request, opts ...grpc.CallOption) (*SyncToHost_Response, error)
+	SyncToVirtual(ctx context.Context, in *SyncToVirtual_Request, opts ...grpc.CallOption) (*SyncToVirtual_Response, error)
 }
 
 type pluginClient struct {
@@ -73,6 +75,24 @@ func (c *pluginClient) Mutate(ctx context.Context, in *Mutate_Request, opts ...g
 	return out, nil
 }
 
+func (c *pluginClient) SyncToHost(ctx context.Context, in *SyncToHost_Request, opts ...grpc.CallOption) (*SyncToHost_Response, error) {
+	out := new(SyncToHost_Response)
+	err := c.cc.Invoke(ctx, "/pluginv2.Plugin/SyncToHost", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginClient) SyncToVirtual(ctx context.Context, in *SyncToVirtual_Request, opts ...grpc.CallOption) (*SyncToVirtual_Response, error) {
+	out := new(SyncToVirtual_Response)
+	err := c.cc.Invoke(ctx, "/pluginv2.Plugin/SyncToVirtual", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PluginServer is the server API for Plugin service.
 // All implementations must embed UnimplementedPluginServer
 // for forward compatibility
@@ -81,6 +101,8 @@ type PluginServer interface {
 	SetLeader(context.Context, *SetLeader_Request) (*SetLeader_Response, error)
 	GetPluginConfig(context.Context, *GetPluginConfig_Request) (*GetPluginConfig_Response, error)
 	Mutate(context.Context, *Mutate_Request) (*Mutate_Response, error)
+	SyncToHost(context.Context, *SyncToHost_Request) (*SyncToHost_Response, error)
+	SyncToVirtual(context.Context, *SyncToVirtual_Request) (*SyncToVirtual_Response, error)
 	mustEmbedUnimplementedPluginServer()
 }
 
@@ -100,6 +122,12 @@ func (UnimplementedPluginServer) GetPluginConfig(context.Context, *GetPluginConf
 func (UnimplementedPluginServer) Mutate(context.Context, *Mutate_Request) (*Mutate_Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Mutate not implemented")
 }
+func (UnimplementedPluginServer) SyncToHost(context.Context, *SyncToHost_Request) (*SyncToHost_Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncToHost not implemented")
+}
+func (UnimplementedPluginServer) SyncToVirtual(context.Context, *SyncToVirtual_Request) (*SyncToVirtual_Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncToVirtual not implemented")
+}
 func (UnimplementedPluginServer) mustEmbedUnimplementedPluginServer() {}
 
 // UnsafePluginServer may be embedded to opt out of forward compatibility for this service.
@@ -185,6 +213,42 @@ func _Plugin_Mutate_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Plugin_SyncToHost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncToHost_Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginServer).SyncToHost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pluginv2.Plugin/SyncToHost",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginServer).SyncToHost(ctx, req.(*SyncToHost_Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Plugin_SyncToVirtual_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncToVirtual_Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginServer).SyncToVirtual(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pluginv2.Plugin/SyncToVirtual",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginServer).SyncToVirtual(ctx, req.(*SyncToVirtual_Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Plugin_ServiceDesc is the grpc.ServiceDesc for Plugin service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -208,6 +272,14 @@ var Plugin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Mutate",
 			Handler:    _Plugin_Mutate_Handler,
 		},
+		{
+			MethodName: "SyncToHost",
+			Handler:    _Plugin_SyncToHost_Handler,
+		},
+		{
+			MethodName: "SyncToVirtual",
+			Handler:    _Plugin_SyncToVirtual_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pluginv2.proto",
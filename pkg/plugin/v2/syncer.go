@@ -0,0 +1,160 @@
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	plugintypes "github.com/loft-sh/vcluster/pkg/plugin/types"
+	"github.com/loft-sh/vcluster/pkg/plugin/v2/pluginv2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func (m *Manager) registerSyncers(vClusterPlugin *vClusterPlugin, syncers []*Syncer) error {
+	for _, syncerInfo := range syncers {
+		if syncerInfo.APIVersion == "" {
+			return fmt.Errorf("api version is empty in plugin %s syncer", vClusterPlugin.Path)
+		} else if syncerInfo.Kind == "" {
+			return fmt.Errorf("kind is empty in plugin %s syncer", vClusterPlugin.Path)
+		}
+
+		gvk := schema.FromAPIVersionAndKind(syncerInfo.APIVersion, syncerInfo.Kind)
+		for _, existing := range m.Syncers {
+			if existing.GroupVersionKind() == gvk {
+				return fmt.Errorf("syncer for %s %s is already registered by %s", syncerInfo.APIVersion, syncerInfo.Kind, existing.Name())
+			}
+		}
+
+		m.Syncers = append(m.Syncers, &pluginSyncer{
+			plugin: vClusterPlugin,
+			gvk:    gvk,
+		})
+		klog.Infof("Register syncer for %s %s in plugin %s", syncerInfo.APIVersion, syncerInfo.Kind, vClusterPlugin.Path)
+	}
+
+	return nil
+}
+
+var _ plugintypes.Syncer = &pluginSyncer{}
+
+// pluginSyncer calls the plugin over grpc to translate the objects of a single type
+type pluginSyncer struct {
+	plugin *vClusterPlugin
+	gvk    schema.GroupVersionKind
+}
+
+func (p *pluginSyncer) Name() string {
+	return fmt.Sprintf("%s/%s/%s", strings.ToLower(p.gvk.Kind), strings.ToLower(p.gvk.Group), filepath.Base(filepath.Dir(p.plugin.Path)))
+}
+
+func (p *pluginSyncer) GroupVersionKind() schema.GroupVersionKind {
+	return p.gvk
+}
+
+func (p *pluginSyncer) SyncToHost(ctx context.Context, vObj, pObj, existingPObj client.Object) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	encodedVObj, err := json.Marshal(vObj)
+	if err != nil {
+		return fmt.Errorf("encode virtual object: %w", err)
+	}
+	encodedPObj, err := json.Marshal(pObj)
+	if err != nil {
+		return fmt.Errorf("encode host object: %w", err)
+	}
+	encodedExistingPObj := []byte{}
+	if existingPObj != nil {
+		encodedExistingPObj, err = json.Marshal(existingPObj)
+		if err != nil {
+			return fmt.Errorf("encode existing host object: %w", err)
+		}
+	}
+
+	apiVersion, kind := p.gvk.ToAPIVersionAndKind()
+	klog.FromContext(ctx).V(1).Info("calling plugin to sync object to host", "plugin", p.plugin.Path, "apiVersion", apiVersion, "kind", kind, "name", vObj.GetName())
+	result, err := p.plugin.GRPCClient.SyncToHost(ctx, &pluginv2.SyncToHost_Request{
+		ApiVersion:         apiVersion,
+		Kind:               kind,
+		VirtualObject:      string(encodedVObj),
+		HostObject:         string(encodedPObj),
+		ExistingHostObject: string(encodedExistingPObj),
+	})
+	if err != nil {
+		return fmt.Errorf("call plugin sync to host %s: %w", p.plugin.Path, err)
+	} else if result.HostObject == "" {
+		return nil
+	}
+
+	err = decodeInto(result.HostObject, pObj)
+	if err != nil {
+		return fmt.Errorf("decode host object: %w", err)
+	}
+
+	return nil
+}
+
+func (p *pluginSyncer) SyncToVirtual(ctx context.Context, vObj, pObj client.Object) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	encodedVObj, err := json.Marshal(vObj)
+	if err != nil {
+		return false, fmt.Errorf("encode virtual object: %w", err)
+	}
+	encodedPObj, err := json.Marshal(pObj)
+	if err != nil {
+		return false, fmt.Errorf("encode host object: %w", err)
+	}
+
+	apiVersion, kind := p.gvk.ToAPIVersionAndKind()
+	klog.FromContext(ctx).V(1).Info("calling plugin to sync object to virtual", "plugin", p.plugin.Path, "apiVersion", apiVersion, "kind", kind, "name", vObj.GetName())
+	result, err := p.plugin.GRPCClient.SyncToVirtual(ctx, &pluginv2.SyncToVirtual_Request{
+		ApiVersion:    apiVersion,
+		Kind:          kind,
+		VirtualObject: string(encodedVObj),
+		HostObject:    string(encodedPObj),
+	})
+	if err != nil {
+		return false, fmt.Errorf("call plugin sync to virtual %s: %w", p.plugin.Path, err)
+	} else if !result.Updated {
+		return false, nil
+	}
+
+	err = decodeInto(result.VirtualObject, vObj)
+	if err != nil {
+		return false, fmt.Errorf("decode virtual object: %w", err)
+	}
+
+	return true, nil
+}
+
+// decodeInto replaces the contents of obj with the given encoded object. Plugins cannot change the identity
+// of the object, so responses with a different name or namespace are rejected and the uid and resource version
+// of obj are kept.
+func decodeInto(encoded string, obj client.Object) error {
+	decoded := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	err := json.Unmarshal([]byte(encoded), &decoded.Object)
+	if err != nil {
+		return err
+	} else if decoded.GetName() != obj.GetName() || decoded.GetNamespace() != obj.GetNamespace() {
+		return fmt.Errorf("plugin changed the name of %s to %s, which is not allowed", client.ObjectKeyFromObject(obj).String(), client.ObjectKeyFromObject(decoded).String())
+	}
+	decoded.SetUID(obj.GetUID())
+	decoded.SetResourceVersion(obj.GetResourceVersion())
+
+	unstructuredObj, ok := obj.(*unstructured.Unstructured)
+	if ok {
+		unstructuredObj.Object = decoded.Object
+		return nil
+	}
+
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(decoded.Object, obj)
+}
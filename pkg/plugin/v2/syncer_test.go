@@ -0,0 +1,139 @@
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/loft-sh/vcluster/pkg/plugin/v2/pluginv2"
+	"google.golang.org/grpc"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type fakeSyncerClient struct {
+	pluginv2.PluginClient
+
+	syncToHost    func(req *pluginv2.SyncToHost_Request) *pluginv2.SyncToHost_Response
+	syncToVirtual func(req *pluginv2.SyncToVirtual_Request) *pluginv2.SyncToVirtual_Response
+}
+
+func (f *fakeSyncerClient) SyncToHost(_ context.Context, req *pluginv2.SyncToHost_Request, _ ...grpc.CallOption) (*pluginv2.SyncToHost_Response, error) {
+	return f.syncToHost(req), nil
+}
+
+func (f *fakeSyncerClient) SyncToVirtual(_ context.Context, req *pluginv2.SyncToVirtual_Request, _ ...grpc.CallOption) (*pluginv2.SyncToVirtual_Response, error) {
+	return f.syncToVirtual(req), nil
+}
+
+func TestRegisterSyncers(t *testing.T) {
+	m := NewManager()
+	first := &vClusterPlugin{Path: "/plugins/first/plugin"}
+	second := &vClusterPlugin{Path: "/plugins/second/plugin"}
+
+	err := m.registerSyncers(first, []*Syncer{{APIVersion: "example.com/v1", Kind: "Database"}})
+	assert.NilError(t, err)
+	assert.Equal(t, len(m.Syncers), 1)
+	assert.Equal(t, m.Syncers[0].Name(), "database/example.com/first")
+	assert.Equal(t, m.Syncers[0].GroupVersionKind(), schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Database"})
+
+	err = m.registerSyncers(second, []*Syncer{{APIVersion: "example.com/v1"}})
+	assert.ErrorContains(t, err, "kind is empty in plugin /plugins/second/plugin syncer")
+
+	err = m.registerSyncers(second, []*Syncer{{APIVersion: "example.com/v1", Kind: "Database"}})
+	assert.ErrorContains(t, err, "syncer for example.com/v1 Database is already registered by database/example.com/first")
+}
+
+func TestPluginSyncer(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeSyncerClient{
+		syncToHost: func(req *pluginv2.SyncToHost_Request) *pluginv2.SyncToHost_Response {
+			if req.ExistingHostObject != "" {
+				return &pluginv2.SyncToHost_Response{}
+			}
+
+			pObj := map[string]interface{}{}
+			_ = json.Unmarshal([]byte(req.HostObject), &pObj)
+			pObj["spec"] = map[string]interface{}{"translated": true}
+			encoded, _ := json.Marshal(pObj)
+			return &pluginv2.SyncToHost_Response{HostObject: string(encoded)}
+		},
+		syncToVirtual: func(req *pluginv2.SyncToVirtual_Request) *pluginv2.SyncToVirtual_Response {
+			vObj := map[string]interface{}{}
+			_ = json.Unmarshal([]byte(req.VirtualObject), &vObj)
+			if _, ok := vObj["status"]; ok {
+				return &pluginv2.SyncToVirtual_Response{}
+			}
+
+			vObj["status"] = map[string]interface{}{"ready": true}
+			encoded, _ := json.Marshal(vObj)
+			return &pluginv2.SyncToVirtual_Response{VirtualObject: string(encoded), Updated: true}
+		},
+	}
+	syncer := &pluginSyncer{
+		plugin: &vClusterPlugin{Path: "/plugins/test/plugin", GRPCClient: client},
+		gvk:    schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Database"},
+	}
+
+	newObject := func(name string, fields map[string]interface{}) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: fields}
+		obj.SetGroupVersionKind(syncer.gvk)
+		obj.SetName(name)
+		return obj
+	}
+
+	// the plugin changes the host object during creation
+	vObj := newObject("test", map[string]interface{}{"spec": map[string]interface{}{"virtual": true}})
+	pObj := newObject("test-x-default", map[string]interface{}{"spec": map[string]interface{}{"virtual": true}})
+	err := syncer.SyncToHost(ctx, vObj, pObj, nil)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, pObj.Object["spec"], map[string]interface{}{"translated": true})
+	assert.Equal(t, pObj.GetName(), "test-x-default")
+
+	// an empty response keeps the translated host object
+	err = syncer.SyncToHost(ctx, vObj, pObj, pObj.DeepCopy())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, pObj.Object["spec"], map[string]interface{}{"translated": true})
+
+	// the plugin syncs the status back
+	updated, err := syncer.SyncToVirtual(ctx, vObj, pObj)
+	assert.NilError(t, err)
+	assert.Assert(t, updated)
+	assert.DeepEqual(t, vObj.Object["status"], map[string]interface{}{"ready": true})
+
+	updated, err = syncer.SyncToVirtual(ctx, vObj, pObj)
+	assert.NilError(t, err)
+	assert.Assert(t, !updated)
+}
+
+func TestDecodeInto(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("example.com/v1")
+	obj.SetKind("Database")
+	obj.SetName("test")
+	obj.SetNamespace("default")
+	obj.SetUID("123")
+	obj.SetResourceVersion("1")
+
+	// uid and resource version are kept if the plugin drops them
+	err := decodeInto(`{"apiVersion":"example.com/v1","kind":"Database","metadata":{"name":"test","namespace":"default"},"spec":{"size":1}}`, obj)
+	assert.NilError(t, err)
+	assert.Equal(t, string(obj.GetUID()), "123")
+	assert.Equal(t, obj.GetResourceVersion(), "1")
+	assert.DeepEqual(t, obj.Object["spec"], map[string]interface{}{"size": float64(1)})
+
+	// the plugin cannot rename the object
+	err = decodeInto(`{"apiVersion":"example.com/v1","kind":"Database","metadata":{"name":"other","namespace":"default"}}`, obj)
+	assert.ErrorContains(t, err, "plugin changed the name of default/test to default/other")
+	assert.Equal(t, obj.GetName(), "test")
+
+	// typed objects are decoded as well
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "456"}}
+	err = decodeInto(`{"metadata":{"name":"test","namespace":"default","uid":"789"},"data":{"a":"b"}}`, configMap)
+	assert.NilError(t, err)
+	assert.Equal(t, string(configMap.UID), "456")
+	assert.DeepEqual(t, configMap.Data, map[string]string{"a": "b"})
+}
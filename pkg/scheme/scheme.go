@@ -13,6 +13,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+	custommetricsv1beta2 "k8s.io/metrics/pkg/apis/custom_metrics/v1beta2"
+	externalmetricsv1beta1 "k8s.io/metrics/pkg/apis/external_metrics/v1beta1"
 	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 )
 
@@ -28,6 +30,8 @@ func init() {
 	_ = apidiscoveryv2beta1.AddToScheme(Scheme)
 	_ = apidiscoveryv2.AddToScheme(Scheme)
 	_ = metricsv1beta1.AddToScheme(Scheme)
+	_ = custommetricsv1beta2.AddToScheme(Scheme)
+	_ = externalmetricsv1beta1.AddToScheme(Scheme)
 
 	// Register the fake conversions
 	_ = apis.RegisterConversions(Scheme)
@@ -0,0 +1,160 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/config"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"gopkg.in/natefinch/lumberjack.v2"
+	auditinternal "k8s.io/apiserver/pkg/apis/audit"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	"k8s.io/apiserver/pkg/audit"
+	"k8s.io/apiserver/pkg/audit/policy"
+	"k8s.io/apiserver/pkg/util/webhook"
+	"k8s.io/apiserver/plugin/pkg/audit/buffered"
+	auditlog "k8s.io/apiserver/plugin/pkg/audit/log"
+	auditwebhook "k8s.io/apiserver/plugin/pkg/audit/webhook"
+	"k8s.io/klog/v2"
+)
+
+const (
+	DefaultLogPath    = "/data/audit/audit.log"
+	DefaultLogMaxSize = 100
+
+	webhookConfigPath = "/tmp/vcluster-audit-webhook.yaml"
+)
+
+// NewBackend creates the audit backend and policy evaluator for controlPlane.advanced.audit. If audit
+// logging is disabled, both are nil.
+func NewBackend(ctx *config.ControllerContext) (audit.Backend, audit.PolicyRuleEvaluator, error) {
+	auditConfig := ctx.Config.ControlPlane.Advanced.Audit
+	if !auditConfig.Enabled {
+		return nil, nil, nil
+	}
+
+	auditPolicy, err := LoadPolicy(auditConfig.Policy)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load audit policy: %w", err)
+	}
+
+	backends := []audit.Backend{}
+	if auditConfig.Log.Enabled {
+		backends = append(backends, newLogBackend(auditConfig.Log))
+	}
+	if auditConfig.Webhook.Enabled {
+		webhookBackend, err := newWebhookBackend(auditConfig.Webhook)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		backends = append(backends, webhookBackend)
+	}
+	if auditConfig.HostBuffer.Enabled {
+		hostBuffer, err := NewHostBufferBackend(ctx.WorkloadNamespaceClient, ctx.Config.WorkloadNamespace, ctx.Config.Name, auditConfig.HostBuffer)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		backends = append(backends, hostBuffer)
+	}
+	if len(backends) == 0 {
+		return nil, nil, fmt.Errorf("audit logging is enabled, but no backend is configured")
+	}
+
+	klog.Infof("Audit logging enabled with %d backend(s)", len(backends))
+	return audit.Union(backends...), policy.NewPolicyRuleEvaluator(auditPolicy), nil
+}
+
+// LoadPolicy converts the audit policy of the vCluster config into an audit policy. If no rules are
+// defined, the metadata of all requests is recorded.
+func LoadPolicy(auditPolicy vclusterconfig.AuditPolicy) (*auditinternal.Policy, error) {
+	if len(auditPolicy.Rules) == 0 {
+		auditPolicy.Rules = []vclusterconfig.AuditPolicyRule{
+			{
+				Level: string(auditinternal.LevelMetadata),
+			},
+		}
+	}
+
+	raw, err := json.Marshal(auditPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	// add the type information
+	policyMap := map[string]interface{}{}
+	err = json.Unmarshal(raw, &policyMap)
+	if err != nil {
+		return nil, err
+	}
+	policyMap["apiVersion"] = auditv1.SchemeGroupVersion.String()
+	policyMap["kind"] = "Policy"
+
+	raw, err = json.Marshal(policyMap)
+	if err != nil {
+		return nil, err
+	}
+
+	return policy.LoadPolicyFromBytes(raw)
+}
+
+func newLogBackend(logConfig vclusterconfig.AuditLog) audit.Backend {
+	path := logConfig.Path
+	if path == "" {
+		path = DefaultLogPath
+	}
+	if path == "-" {
+		return auditlog.NewBackend(os.Stdout, auditlog.FormatJson, auditv1.SchemeGroupVersion)
+	}
+
+	maxSize := logConfig.MaxSize
+	if maxSize == 0 {
+		maxSize = DefaultLogMaxSize
+	}
+
+	return auditlog.NewBackend(&lumberjack.Logger{
+		Filename:   filepath.Clean(path),
+		MaxAge:     logConfig.MaxAge,
+		MaxBackups: logConfig.MaxBackups,
+		MaxSize:    maxSize,
+		Compress:   logConfig.Compress,
+	}, auditlog.FormatJson, auditv1.SchemeGroupVersion)
+}
+
+func newWebhookBackend(webhookConfig vclusterconfig.AuditWebhook) (audit.Backend, error) {
+	if webhookConfig.Config == "" {
+		return nil, fmt.Errorf("controlPlane.advanced.audit.webhook.config is required")
+	}
+
+	// the webhook backend expects a kube config file
+	err := os.WriteFile(webhookConfigPath, []byte(webhookConfig.Config), 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("write audit webhook config: %w", err)
+	}
+
+	webhookBackend, err := auditwebhook.NewBackend(webhookConfigPath, auditv1.SchemeGroupVersion, webhook.DefaultRetryBackoffWithInitialDelay(auditwebhook.DefaultInitialBackoffDelay), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create audit webhook backend: %w", err)
+	}
+
+	// batch events to not block requests
+	return buffered.NewBackend(webhookBackend, buffered.BatchConfig{
+		BufferSize:     10000,
+		MaxBatchSize:   400,
+		MaxBatchWait:   30 * time.Second,
+		ThrottleEnable: true,
+		ThrottleQPS:    10,
+		ThrottleBurst:  15,
+		AsyncDelegate:  true,
+	}), nil
+}
+
+// hostObjectName returns the default name of the host buffer object
+func hostObjectName(vClusterName string) string {
+	return translate.SafeConcatName("vc", "audit", vClusterName)
+}
@@ -0,0 +1,95 @@
+package audit
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	testingutil "github.com/loft-sh/vcluster/pkg/util/testing"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	auditinternal "k8s.io/apiserver/pkg/apis/audit"
+	"k8s.io/apiserver/pkg/audit/policy"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestLoadPolicy(t *testing.T) {
+	// default policy
+	auditPolicy, err := LoadPolicy(vclusterconfig.AuditPolicy{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(auditPolicy.Rules), 1)
+	assert.Equal(t, auditPolicy.Rules[0].Level, auditinternal.LevelMetadata)
+
+	// custom policy
+	auditPolicy, err = LoadPolicy(vclusterconfig.AuditPolicy{
+		OmitStages: []string{string(auditinternal.StageRequestReceived)},
+		Rules: []vclusterconfig.AuditPolicyRule{
+			{
+				Level: string(auditinternal.LevelNone),
+				Resources: []vclusterconfig.AuditGroupResources{
+					{Resources: []string{"events"}},
+				},
+			},
+			{
+				Level: string(auditinternal.LevelRequest),
+				Verbs: []string{"create", "update", "patch", "delete"},
+			},
+		},
+	})
+	assert.NilError(t, err)
+
+	evaluator := policy.NewPolicyRuleEvaluator(auditPolicy)
+	attributes := &authorizer.AttributesRecord{
+		User:            &user.DefaultInfo{Name: "test"},
+		Verb:            "delete",
+		Resource:        "events",
+		ResourceRequest: true,
+	}
+	assert.Equal(t, evaluator.EvaluatePolicyRule(attributes).Level, auditinternal.LevelNone)
+	attributes.Resource = "pods"
+	assert.Equal(t, evaluator.EvaluatePolicyRule(attributes).Level, auditinternal.LevelRequest)
+
+	// invalid level
+	_, err = LoadPolicy(vclusterconfig.AuditPolicy{
+		Rules: []vclusterconfig.AuditPolicyRule{{Level: "Everything"}},
+	})
+	assert.ErrorContains(t, err, "Everything")
+}
+
+func TestHostBufferBackend(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := testingutil.NewFakeClient(scheme.Scheme)
+	backend, err := NewHostBufferBackend(fakeClient, "test", "my-vcluster", vclusterconfig.AuditHostBuffer{
+		Enabled:   true,
+		MaxEvents: 2,
+	})
+	assert.NilError(t, err)
+
+	for _, auditID := range []string{"1", "2", "3"} {
+		assert.Assert(t, backend.ProcessEvents(&auditinternal.Event{
+			AuditID: types.UID("audit-" + auditID),
+			Stage:   auditinternal.StageResponseComplete,
+			Level:   auditinternal.LevelMetadata,
+			Verb:    "get",
+		}))
+	}
+	assert.NilError(t, backend.Flush(ctx))
+
+	configMap := &corev1.ConfigMap{}
+	err = fakeClient.Get(ctx, client.ObjectKey{Namespace: "test", Name: "vc-audit-my-vcluster"}, configMap)
+	assert.NilError(t, err)
+
+	// only the latest events are kept
+	lines := strings.Split(configMap.Data[HostBufferKey], "\n")
+	assert.Equal(t, len(lines), 2)
+	assert.Assert(t, strings.Contains(lines[0], "audit-2"))
+	assert.Assert(t, strings.Contains(lines[1], "audit-3"))
+
+	_, err = NewHostBufferBackend(fakeClient, "test", "my-vcluster", vclusterconfig.AuditHostBuffer{Kind: "Pod"})
+	assert.ErrorContains(t, err, "unsupported audit host buffer kind")
+}
@@ -0,0 +1,175 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	auditinternal "k8s.io/apiserver/pkg/apis/audit"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	"k8s.io/apiserver/pkg/audit"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	DefaultHostBufferMaxEvents = 100
+
+	// HostBufferKey is the key within the config map or secret that holds the audit events
+	HostBufferKey = "audit.log"
+
+	hostBufferFlushInterval = 5 * time.Second
+)
+
+// NewHostBufferBackend creates an audit backend that keeps the latest audit events in memory and writes
+// them periodically into a config map or secret in the given host namespace.
+func NewHostBufferBackend(hostClient client.Client, namespace, vClusterName string, bufferConfig vclusterconfig.AuditHostBuffer) (*HostBufferBackend, error) {
+	kind := bufferConfig.Kind
+	if kind == "" {
+		kind = "ConfigMap"
+	} else if kind != "ConfigMap" && kind != "Secret" {
+		return nil, fmt.Errorf("unsupported audit host buffer kind %s, must be either ConfigMap or Secret", kind)
+	}
+
+	name := bufferConfig.Name
+	if name == "" {
+		name = hostObjectName(vClusterName)
+	}
+
+	maxEvents := bufferConfig.MaxEvents
+	if maxEvents <= 0 {
+		maxEvents = DefaultHostBufferMaxEvents
+	}
+
+	return &HostBufferBackend{
+		client:    hostClient,
+		namespace: namespace,
+		name:      name,
+		kind:      kind,
+		events:    make([]string, 0, maxEvents),
+		maxEvents: maxEvents,
+		encoder:   audit.Codecs.LegacyCodec(auditv1.SchemeGroupVersion),
+	}, nil
+}
+
+type HostBufferBackend struct {
+	client    client.Client
+	namespace string
+	name      string
+	kind      string
+	encoder   runtime.Encoder
+
+	m         sync.Mutex
+	events    []string
+	maxEvents int
+	dirty     bool
+}
+
+var _ audit.Backend = &HostBufferBackend{}
+
+func (h *HostBufferBackend) ProcessEvents(events ...*auditinternal.Event) bool {
+	encoded := make([]string, 0, len(events))
+	for _, event := range events {
+		out, err := runtime.Encode(h.encoder, event)
+		if err != nil {
+			audit.HandlePluginError(h.String(), err, event)
+			return false
+		}
+
+		encoded = append(encoded, strings.TrimSpace(string(out)))
+	}
+
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	// drop the oldest events if the buffer is full
+	h.events = append(h.events, encoded...)
+	if len(h.events) > h.maxEvents {
+		h.events = append(h.events[:0], h.events[len(h.events)-h.maxEvents:]...)
+	}
+	h.dirty = true
+	return true
+}
+
+func (h *HostBufferBackend) Run(stopCh <-chan struct{}) error {
+	go wait.Until(func() {
+		err := h.Flush(context.Background())
+		if err != nil {
+			klog.Errorf("Error writing audit events to %s %s/%s: %v", h.kind, h.namespace, h.name, err)
+		}
+	}, hostBufferFlushInterval, stopCh)
+	return nil
+}
+
+func (h *HostBufferBackend) Shutdown() {
+	err := h.Flush(context.Background())
+	if err != nil {
+		klog.Errorf("Error writing audit events to %s %s/%s: %v", h.kind, h.namespace, h.name, err)
+	}
+}
+
+func (h *HostBufferBackend) String() string {
+	return "host-buffer"
+}
+
+// Flush writes the buffered audit events into the host object if there are new events
+func (h *HostBufferBackend) Flush(ctx context.Context) error {
+	h.m.Lock()
+	if !h.dirty {
+		h.m.Unlock()
+		return nil
+	}
+	data := strings.Join(h.events, "\n")
+	h.dirty = false
+	h.m.Unlock()
+
+	err := h.write(ctx, data)
+	if err != nil {
+		h.m.Lock()
+		h.dirty = true
+		h.m.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+func (h *HostBufferBackend) write(ctx context.Context, data string) error {
+	var obj client.Object
+	if h.kind == "Secret" {
+		obj = &corev1.Secret{}
+	} else {
+		obj = &corev1.ConfigMap{}
+	}
+
+	err := h.client.Get(ctx, client.ObjectKey{Namespace: h.namespace, Name: h.name}, obj)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return err
+		}
+
+		obj.SetNamespace(h.namespace)
+		obj.SetName(h.name)
+		setData(obj, data)
+		return h.client.Create(ctx, obj)
+	}
+
+	setData(obj, data)
+	return h.client.Update(ctx, obj)
+}
+
+func setData(obj client.Object, data string) {
+	switch t := obj.(type) {
+	case *corev1.Secret:
+		t.Data = map[string][]byte{HostBufferKey: []byte(data)}
+	case *corev1.ConfigMap:
+		t.Data = map[string]string{HostBufferKey: data}
+	}
+}
@@ -0,0 +1,21 @@
+package filters
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/loft-sh/vcluster/pkg/sleepmode"
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+// WithActivity records the requests of authenticated users as activity for sleep mode
+func WithActivity(h http.Handler, tracker *sleepmode.ActivityTracker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		info, ok := request.UserFrom(req.Context())
+		if ok {
+			tracker.Record(info, req.URL.Path, time.Now())
+		}
+
+		h.ServeHTTP(w, req)
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/audit"
 	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
 	"k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/client-go/rest"
@@ -22,6 +23,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// HostObjectAuditAnnotation is the audit annotation that holds the host object a redirected request touched
+const HostObjectAuditAnnotation = "vcluster.loft.sh/host-object"
+
 func WithRedirect(h http.Handler, localConfig *rest.Config, localScheme *runtime.Scheme, uncachedVirtualClient client.Client, admit admission.Interface, resources []delegatingauthorizer.GroupVersionResourceVerb) http.Handler {
 	s := serializer.NewCodecFactory(localScheme)
 	parameterCodec := runtime.NewParameterCodec(uncachedVirtualClient.Scheme())
@@ -59,11 +63,16 @@ func WithRedirect(h http.Handler, localConfig *rest.Config, localScheme *runtime
 				splitted[6] = pName.Name
 				req.URL.Path = strings.Join(splitted, "/")
 
+				// record the host object in the audit event
+				audit.AddAuditAnnotation(req.Context(), HostObjectAuditAnnotation, pName.Namespace+"/"+pName.Name)
+
 				// we have to add a trailing slash here, because otherwise the
 				// host api server would redirect us to a wrong path
 				if len(splitted) == 8 {
 					req.URL.Path += "/"
 				}
+			} else {
+				audit.AddAuditAnnotation(req.Context(), HostObjectAuditAnnotation, info.Name)
 			}
 
 			h, err := handler.Handler("", localConfig, nil)
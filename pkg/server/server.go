@@ -10,16 +10,19 @@ import (
 	"time"
 
 	"github.com/loft-sh/vcluster/pkg/authentication/delegatingauthenticator"
+	"github.com/loft-sh/vcluster/pkg/authentication/oidcauthenticator"
 	"github.com/loft-sh/vcluster/pkg/authorization/allowall"
 	"github.com/loft-sh/vcluster/pkg/authorization/delegatingauthorizer"
 	"github.com/loft-sh/vcluster/pkg/authorization/impersonationauthorizer"
 	"github.com/loft-sh/vcluster/pkg/authorization/kubeletauthorizer"
 	"github.com/loft-sh/vcluster/pkg/config"
 	"github.com/loft-sh/vcluster/pkg/plugin"
+	vclusteraudit "github.com/loft-sh/vcluster/pkg/server/audit"
 	"github.com/loft-sh/vcluster/pkg/server/cert"
 	"github.com/loft-sh/vcluster/pkg/server/filters"
 	"github.com/loft-sh/vcluster/pkg/server/handler"
 	servertypes "github.com/loft-sh/vcluster/pkg/server/types"
+	"github.com/loft-sh/vcluster/pkg/sleepmode"
 	"github.com/loft-sh/vcluster/pkg/util/pluginhookclient"
 	"github.com/loft-sh/vcluster/pkg/util/serverhelper"
 	"github.com/pkg/errors"
@@ -31,6 +34,8 @@ import (
 	webhookinit "k8s.io/apiserver/pkg/admission/plugin/webhook/initializer"
 	"k8s.io/apiserver/pkg/admission/plugin/webhook/mutating"
 	"k8s.io/apiserver/pkg/admission/plugin/webhook/validating"
+	"k8s.io/apiserver/pkg/audit"
+	"k8s.io/apiserver/pkg/authentication/authenticator"
 	unionauthentication "k8s.io/apiserver/pkg/authentication/request/union"
 	"k8s.io/apiserver/pkg/authorization/union"
 	"k8s.io/apiserver/pkg/endpoints/filterlatency"
@@ -63,6 +68,11 @@ type Server struct {
 	clientCaFile           string
 	redirectResources      []delegatingauthorizer.GroupVersionResourceVerb
 	fakeKubeletIPs         bool
+
+	auditBackend             audit.Backend
+	auditPolicyRuleEvaluator audit.PolicyRuleEvaluator
+
+	oidcAuthenticator authenticator.Request
 }
 
 // NewServer creates and installs a new Server.
@@ -136,6 +146,20 @@ func NewServer(ctx *config.ControllerContext, requestHeaderCaFile, clientCaFile
 		},
 	}
 
+	// init audit logging
+	s.auditBackend, s.auditPolicyRuleEvaluator, err = vclusteraudit.NewBackend(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "init audit")
+	}
+
+	// init oidc authentication
+	if ctx.Config.ControlPlane.Proxy.OIDC.Enabled {
+		s.oidcAuthenticator, err = oidcauthenticator.New(ctx.Context, ctx.Config.ControlPlane.Proxy.OIDC)
+		if err != nil {
+			return nil, errors.Wrap(err, "init oidc")
+		}
+	}
+
 	// init plugins
 	admissionHandler, err := initAdmission(ctx, virtualConfig)
 	if err != nil {
@@ -164,6 +188,11 @@ func NewServer(ctx *config.ControllerContext, requestHeaderCaFile, clientCaFile
 		h = filters.WithPprof(h)
 	}
 
+	// record activity for sleep mode
+	if ctx.Config.SleepMode.Enabled && ctx.Config.SleepMode.AutoSleep.AfterInactivity != "" {
+		h = filters.WithActivity(h, sleepmode.StartActivityTracker(ctx))
+	}
+
 	// post hooks
 	for _, f := range ctx.PostServerHooks {
 		h = f(h, ctx)
@@ -223,6 +252,22 @@ func (s *Server) ServeOnListenerTLS(address string, port int, stopChan <-chan st
 
 	// make sure the tokens are correctly authenticated
 	serverConfig.Authentication.Authenticator = unionauthentication.NewFailOnError(delegatingauthenticator.New(s.uncachedVirtualClient), serverConfig.Authentication.Authenticator)
+	if s.oidcAuthenticator != nil {
+		// oidc tokens are validated by the proxy itself, all other requests are authenticated as before
+		serverConfig.Authentication.Authenticator = unionauthentication.New(s.oidcAuthenticator, serverConfig.Authentication.Authenticator)
+	}
+
+	// start audit logging
+	if s.auditBackend != nil {
+		err = s.auditBackend.Run(stopChan)
+		if err != nil {
+			return errors.Wrap(err, "start audit backend")
+		}
+		defer s.auditBackend.Shutdown()
+
+		serverConfig.AuditBackend = s.auditBackend
+		serverConfig.AuditPolicyRuleEvaluator = s.auditPolicyRuleEvaluator
+	}
 
 	// create server
 	klog.Info("Starting tls proxy server at " + address + ":" + strconv.Itoa(port))
@@ -2,12 +2,15 @@ package setup
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 
 	vclusterconfig "github.com/loft-sh/vcluster/config"
 	"github.com/loft-sh/vcluster/pkg/config"
 	"github.com/loft-sh/vcluster/pkg/k3s"
+	"github.com/loft-sh/vcluster/pkg/patches/expression"
 	"github.com/loft-sh/vcluster/pkg/util/translate"
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -17,8 +20,11 @@ import (
 )
 
 const (
-	AnnotationDistro = "vcluster.loft.sh/distro"
-	AnnotationStore  = "vcluster.loft.sh/store"
+	AnnotationDistro       = "vcluster.loft.sh/distro"
+	AnnotationStore        = "vcluster.loft.sh/store"
+	AnnotationNamingPolicy = "vcluster.loft.sh/naming-policy"
+
+	AnnotationNamespaceNameTemplate = "vcluster.loft.sh/namespace-name-template"
 )
 
 func InitAndValidateConfig(ctx context.Context, vConfig *config.VirtualClusterConfig) error {
@@ -46,8 +52,16 @@ func InitAndValidateConfig(ctx context.Context, vConfig *config.VirtualClusterCo
 	}
 
 	// get workload target namespace
-	if vConfig.Experimental.MultiNamespaceMode.Enabled {
-		translate.Default = translate.NewMultiNamespaceTranslator(vConfig.WorkloadNamespace)
+	if vConfig.Sync.ToHost.Namespaces.Enabled {
+		var namespaceTemplate *translate.NamespaceTemplate
+		if vConfig.Sync.ToHost.Namespaces.NameTemplate != "" {
+			namespaceTemplate, err = translate.NewNamespaceTemplate(vConfig.Sync.ToHost.Namespaces.NameTemplate, vConfig.Name, vConfig.WorkloadNamespace)
+			if err != nil {
+				return fmt.Errorf("create namespace name template: %w", err)
+			}
+		}
+
+		translate.Default = translate.NewMultiNamespaceTranslatorWithNamespaceTemplate(vConfig.WorkloadNamespace, namespaceTemplate)
 	} else {
 		// ensure target namespace
 		vConfig.WorkloadTargetNamespace = vConfig.Experimental.SyncSettings.TargetNamespace
@@ -55,7 +69,37 @@ func InitAndValidateConfig(ctx context.Context, vConfig *config.VirtualClusterCo
 			vConfig.WorkloadTargetNamespace = vConfig.WorkloadNamespace
 		}
 
-		translate.Default = translate.NewSingleNamespaceTranslator(vConfig.WorkloadTargetNamespace)
+		namingPolicy, err := translate.NewNamingPolicy(vConfig.Experimental.SyncSettings.Naming)
+		if err != nil {
+			return fmt.Errorf("create naming policy: %w", err)
+		}
+
+		translate.Default = translate.NewSingleNamespaceTranslatorWithNamingPolicy(vConfig.WorkloadTargetNamespace, namingPolicy)
+	}
+
+	// make the vCluster available to patch expressions
+	expression.SetVCluster(vConfig.Name, vConfig.ControlPlaneNamespace, vConfig.ControlPlane.StatefulSet.Labels)
+
+	// this needs to happen before the backing store changes are checked, as these
+	// annotations are used to detect if the vCluster existed before
+	if err := EnsureNamingPolicyUnchanged(
+		ctx,
+		vConfig.ControlPlaneClient,
+		vConfig.Name,
+		vConfig.ControlPlaneNamespace,
+		vConfig.Experimental.SyncSettings.Naming,
+	); err != nil {
+		return err
+	}
+
+	if err := EnsureNamespaceNameTemplateUnchanged(
+		ctx,
+		vConfig.ControlPlaneClient,
+		vConfig.Name,
+		vConfig.ControlPlaneNamespace,
+		vConfig.Sync.ToHost.Namespaces,
+	); err != nil {
+		return err
 	}
 
 	if err := EnsureBackingStoreChanges(
@@ -189,6 +233,99 @@ func updateSecretAnnotations(ctx context.Context, client kubernetes.Interface, n
 	})
 }
 
+// EnsureNamingPolicyUnchanged makes sure the naming policy of an existing vCluster is not changed, as this would
+// change the names of all synced host objects. The used naming policy is stored as annotation on the vCluster's config secret.
+func EnsureNamingPolicyUnchanged(ctx context.Context, client kubernetes.Interface, name, namespace string, naming vclusterconfig.ExperimentalSyncSettingsNaming) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		secret, err := client.CoreV1().Secrets(namespace).Get(ctx, "vc-config-"+name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("get secret: %w", err)
+		}
+
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+
+		namingPolicy := NamingPolicyAnnotationValue(naming)
+		annotatedNamingPolicy, ok := secret.Annotations[AnnotationNamingPolicy]
+		if !ok && secret.Annotations[AnnotationDistro] != "" {
+			// vCluster existed before naming policies were introduced
+			annotatedNamingPolicy = string(vclusterconfig.NamingPolicyHashed)
+		}
+		if annotatedNamingPolicy != "" && annotatedNamingPolicy != namingPolicy {
+			return fmt.Errorf("seems like you were using naming policy %s before and now have switched to %s, changing the naming policy of an existing vCluster is not supported", annotatedNamingPolicy, namingPolicy)
+		} else if ok {
+			return nil
+		}
+
+		secret.Annotations[AnnotationNamingPolicy] = namingPolicy
+		if _, err := client.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("update secret: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// NamingPolicyAnnotationValue returns the value that identifies the naming policy. For template
+// policies a hash of the template is included, so that template changes are detected as well.
+func NamingPolicyAnnotationValue(naming vclusterconfig.ExperimentalSyncSettingsNaming) string {
+	switch naming.Policy {
+	case "", vclusterconfig.NamingPolicyHashed:
+		return string(vclusterconfig.NamingPolicyHashed)
+	case vclusterconfig.NamingPolicyTemplate:
+		digest := sha256.Sum256([]byte(naming.Template))
+		return string(naming.Policy) + "-" + hex.EncodeToString(digest[:])[0:10]
+	}
+
+	return string(naming.Policy)
+}
+
+// EnsureNamespaceNameTemplateUnchanged makes sure the host namespace name template of an existing vCluster is not changed,
+// as the host namespaces created with the previous template would not be recognized anymore. A hash of the used template
+// is stored as annotation on the vCluster's config secret.
+func EnsureNamespaceNameTemplateUnchanged(ctx context.Context, client kubernetes.Interface, name, namespace string, namespaces vclusterconfig.SyncToHostNamespaces) error {
+	if !namespaces.Enabled {
+		return nil
+	}
+
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		secret, err := client.CoreV1().Secrets(namespace).Get(ctx, "vc-config-"+name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("get secret: %w", err)
+		}
+
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+
+		nameTemplate := NamespaceNameTemplateAnnotationValue(namespaces.NameTemplate)
+		annotatedNameTemplate, ok := secret.Annotations[AnnotationNamespaceNameTemplate]
+		if ok && annotatedNameTemplate != nameTemplate {
+			return fmt.Errorf("seems like you have changed sync.toHost.namespaces.nameTemplate, changing the host namespace name template of an existing vCluster is not supported")
+		} else if ok {
+			return nil
+		}
+
+		secret.Annotations[AnnotationNamespaceNameTemplate] = nameTemplate
+		if _, err := client.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("update secret: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// NamespaceNameTemplateAnnotationValue returns the value that identifies the host namespace name template
+func NamespaceNameTemplateAnnotationValue(nameTemplate string) string {
+	if nameTemplate == "" {
+		return "default"
+	}
+
+	digest := sha256.Sum256([]byte(nameTemplate))
+	return "template-" + hex.EncodeToString(digest[:])[0:10]
+}
+
 // SetGlobalOwner fetches the owning service and populates in translate.Owner if: the vcluster is configured to setOwner is,
 // and if the currentNamespace == targetNamespace (because cross namespace owner refs don't work).
 func SetGlobalOwner(ctx context.Context, vConfig *config.VirtualClusterConfig) error {
@@ -200,7 +337,7 @@ func SetGlobalOwner(ctx context.Context, vConfig *config.VirtualClusterConfig) e
 		return nil
 	}
 
-	if vConfig.Experimental.MultiNamespaceMode.Enabled {
+	if vConfig.Sync.ToHost.Namespaces.Enabled {
 		klog.Warningf("Skip setting owner, because multi namespace mode is enabled")
 		return nil
 	}
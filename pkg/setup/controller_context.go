@@ -13,8 +13,11 @@ import (
 	"github.com/loft-sh/vcluster/pkg/scheme"
 	"github.com/loft-sh/vcluster/pkg/telemetry"
 	"github.com/loft-sh/vcluster/pkg/util/blockingcacheclient"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/kubernetes"
@@ -75,6 +78,11 @@ func NewControllerContext(ctx context.Context, options *config.VirtualClusterCon
 		return nil, err
 	}
 
+	// host namespaces of a namespace name template are recognized by their marker label
+	if setter, ok := translate.Default.(translate.HostNamespaceReaderSetter); ok {
+		setter.SetHostNamespaceReader(localManager.GetCache())
+	}
+
 	// create virtual manager
 	virtualClusterManager, err := NewVirtualManager(virtualConfig, ctrl.Options{
 		Scheme:         scheme.Scheme,
@@ -104,7 +112,7 @@ func NewControllerContext(ctx context.Context, options *config.VirtualClusterCon
 func getLocalCacheOptions(options *config.VirtualClusterConfig) cache.Options {
 	// is multi namespace mode?
 	defaultNamespaces := make(map[string]cache.Config)
-	if !options.Experimental.MultiNamespaceMode.Enabled {
+	if !options.Sync.ToHost.Namespaces.Enabled {
 		defaultNamespaces[options.WorkloadTargetNamespace] = cache.Config{}
 	}
 	// do we need access to another namespace to export the kubeconfig ?
@@ -117,7 +125,74 @@ func getLocalCacheOptions(options *config.VirtualClusterConfig) cache.Options {
 	if len(defaultNamespaces) == 0 {
 		return cache.Options{DefaultNamespaces: nil}
 	}
-	return cache.Options{DefaultNamespaces: defaultNamespaces}
+
+	// do we need access to config maps, secrets or custom resources in other namespaces to sync them from the host?
+	byObject := map[client.Object]cache.ByObject{}
+	if options.Sync.FromHost.ConfigMaps.Enabled {
+		addFromHostNamespaces(byObject, &corev1.ConfigMap{}, options.Sync.FromHost.ConfigMaps.Mappings.ByName, defaultNamespaces)
+	}
+	if options.Sync.FromHost.Secrets.Enabled {
+		addFromHostNamespaces(byObject, &corev1.Secret{}, options.Sync.FromHost.Secrets.Mappings.ByName, defaultNamespaces)
+	}
+	addFromHostCustomResourceNamespaces(byObject, options, defaultNamespaces)
+	if len(byObject) == 0 {
+		return cache.Options{DefaultNamespaces: defaultNamespaces}
+	}
+	return cache.Options{DefaultNamespaces: defaultNamespaces, ByObject: byObject}
+}
+
+func addFromHostCustomResourceNamespaces(byObject map[client.Object]cache.ByObject, options *config.VirtualClusterConfig, defaultNamespaces map[string]cache.Config) {
+	var discoveryClient discovery.DiscoveryInterface
+	for key, customResource := range options.Sync.FromHost.CustomResources {
+		if !customResource.Enabled || len(customResource.Mappings.ByName) == 0 {
+			continue
+		}
+
+		gvr, err := config.ParseCustomResourceKey(key)
+		if err != nil {
+			continue
+		}
+
+		// we need to find out the kind of the resource
+		if discoveryClient == nil {
+			discoveryClient, err = discovery.NewDiscoveryClientForConfig(options.WorkloadConfig)
+			if err != nil {
+				klog.Errorf("Error creating discovery client: %v", err)
+				return
+			}
+		}
+		resources, err := discoveryClient.ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+		if err != nil {
+			klog.Infof("Error discovering %s, will not cache objects outside of the vCluster namespace: %v", key, err)
+			continue
+		}
+
+		for _, resource := range resources.APIResources {
+			if resource.Name != gvr.Resource || !resource.Namespaced {
+				continue
+			}
+
+			obj := &unstructured.Unstructured{}
+			obj.SetGroupVersionKind(gvr.GroupVersion().WithKind(resource.Kind))
+			addFromHostNamespaces(byObject, obj, customResource.Mappings.ByName, defaultNamespaces)
+		}
+	}
+}
+
+func addFromHostNamespaces(byObject map[client.Object]cache.ByObject, obj client.Object, byName map[string]string, defaultNamespaces map[string]cache.Config) {
+	mappings, err := config.ParseFromHostMappings(byName)
+	if err != nil || len(mappings) == 0 {
+		return
+	}
+
+	namespaces := make(map[string]cache.Config, len(defaultNamespaces)+len(mappings))
+	for namespace := range defaultNamespaces {
+		namespaces[namespace] = cache.Config{}
+	}
+	for hostName := range mappings {
+		namespaces[hostName.Namespace] = cache.Config{}
+	}
+	byObject[obj] = cache.ByObject{Namespaces: namespaces}
 }
 
 func startPlugins(ctx context.Context, virtualConfig *rest.Config, virtualRawConfig *clientcmdapi.Config, options *config.VirtualClusterConfig) error {
@@ -347,7 +422,7 @@ func newCurrentNamespaceClient(ctx context.Context, localManager ctrl.Manager, o
 	// as the regular cache is scoped to the options.TargetNamespace and cannot return
 	// objects from the current namespace.
 	currentNamespaceCache := localManager.GetCache()
-	if !options.Experimental.MultiNamespaceMode.Enabled && options.WorkloadNamespace != options.WorkloadTargetNamespace {
+	if !options.Sync.ToHost.Namespaces.Enabled && options.WorkloadNamespace != options.WorkloadTargetNamespace {
 		currentNamespaceCache, err = cache.New(localManager.GetConfig(), cache.Options{
 			Scheme:            localManager.GetScheme(),
 			Mapper:            localManager.GetRESTMapper(),
@@ -6,6 +6,7 @@ import (
 	"math"
 	"time"
 
+	"github.com/loft-sh/vcluster/pkg/accounting"
 	"github.com/loft-sh/vcluster/pkg/config"
 	"github.com/loft-sh/vcluster/pkg/controllers"
 	"github.com/loft-sh/vcluster/pkg/controllers/resources/services"
@@ -14,6 +15,8 @@ import (
 	"github.com/loft-sh/vcluster/pkg/coredns"
 	"github.com/loft-sh/vcluster/pkg/plugin"
 	"github.com/loft-sh/vcluster/pkg/pro"
+	"github.com/loft-sh/vcluster/pkg/sleepmode"
+	"github.com/loft-sh/vcluster/pkg/snapshot"
 	"github.com/loft-sh/vcluster/pkg/specialservices"
 	"github.com/loft-sh/vcluster/pkg/util/kubeconfig"
 	"github.com/loft-sh/vcluster/pkg/util/loghelper"
@@ -120,6 +123,30 @@ func StartControllers(controllerContext *config.ControllerContext, syncers []syn
 		return fmt.Errorf("register pro controllers: %w", err)
 	}
 
+	// start scheduled snapshots
+	if controllerContext.Config.ControlPlane.BackingStore.Snapshots.Enabled {
+		err = snapshot.StartScheduler(controllerContext)
+		if err != nil {
+			return fmt.Errorf("start scheduled snapshots: %w", err)
+		}
+	}
+
+	// start resource accounting
+	if controllerContext.Config.Experimental.Accounting.Enabled {
+		err = accounting.Start(controllerContext)
+		if err != nil {
+			return fmt.Errorf("start accounting: %w", err)
+		}
+	}
+
+	// start sleep mode
+	if controllerContext.Config.SleepMode.Enabled {
+		err = sleepmode.Start(controllerContext)
+		if err != nil {
+			return fmt.Errorf("start sleep mode: %w", err)
+		}
+	}
+
 	// run leader hooks
 	for _, hook := range controllerContext.AcquiredLeaderHooks {
 		err = hook(controllerContext)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io/fs"
+	"math/rand"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -17,6 +18,7 @@ import (
 	"github.com/loft-sh/vcluster/pkg/k0s"
 	"github.com/loft-sh/vcluster/pkg/k3s"
 	"github.com/loft-sh/vcluster/pkg/k8s"
+	"github.com/loft-sh/vcluster/pkg/leaderelection"
 	"github.com/loft-sh/vcluster/pkg/pro"
 	"github.com/loft-sh/vcluster/pkg/specialservices"
 	"github.com/loft-sh/vcluster/pkg/telemetry"
@@ -29,6 +31,9 @@ import (
 	"k8s.io/klog/v2"
 )
 
+// certificateRestartInterval is the interval in which the replicas of a vCluster restart after the certificates changed
+const certificateRestartInterval = time.Minute
+
 // Initialize creates the required secrets and configmaps for the control plane to start
 func Initialize(ctx context.Context, options *config.VirtualClusterConfig) error {
 	// Ensure that service CIDR range is written into the expected location
@@ -173,6 +178,14 @@ func initialize(ctx context.Context, parentCtx context.Context, options *config.
 			if err != nil {
 				return err
 			}
+
+			// renew the certificates before they expire
+			if options.ControlPlane.Advanced.CertificateRotation.Enabled {
+				err = certs.StartRotationController(parentCtx, options.ControlPlaneClient, options.ControlPlaneNamespace, options.Name, options.ControlPlane.Advanced.CertificateRotation, restartToLoadCertificates(parentCtx, options))
+				if err != nil {
+					return fmt.Errorf("start certificate rotation: %w", err)
+				}
+			}
 		}
 
 		// should start embedded etcd?
@@ -357,6 +370,44 @@ func readK0sFiles(certDir string) (map[string][]byte, error) {
 	return files, nil
 }
 
+// restartToLoadCertificates returns a func that exits the vCluster container, which is restarted by the kubelet and
+// loads the changed certificates during startup. The control plane components only read their certificates on startup,
+// so the api server is briefly unavailable whenever the certificates are renewed or a ca rotation advances. To keep the
+// api server available with multiple replicas, only the leader restarts immediately and the other replicas restart
+// one after another in the order of their ordinal, with some jitter in case the ordinal cannot be determined.
+func restartToLoadCertificates(ctx context.Context, options *config.VirtualClusterConfig) func() {
+	return func() {
+		if options.ControlPlane.StatefulSet.HighAvailability.Replicas > 1 {
+			isLeader, err := leaderelection.IsLeader(ctx, options.WorkloadClient, options.WorkloadNamespace, options.Name)
+			if err != nil {
+				klog.Errorf("Error checking leadership, restarting delayed: %v", err)
+			}
+
+			if !isLeader {
+				delay := followerRestartDelay()
+				klog.Infof("Certificates have changed, restarting the vCluster container in %s to load them", delay.String())
+				time.Sleep(delay)
+			}
+		}
+
+		klog.Info("Certificates have changed, restarting the vCluster container to load them")
+		klog.Flush()
+		os.Exit(1)
+	}
+}
+
+// followerRestartDelay returns the delay before a replica that is not the leader restarts to load changed certificates
+func followerRestartDelay() time.Duration {
+	jitter := time.Duration(rand.Int63n(int64(certificateRestartInterval / 2)))
+	hostname, _ := os.Hostname()
+	ordinal, err := strconv.Atoi(hostname[strings.LastIndex(hostname, "-")+1:])
+	if err != nil {
+		return certificateRestartInterval + jitter
+	}
+
+	return time.Duration(ordinal+1)*certificateRestartInterval + jitter
+}
+
 func secretContainsK0sCerts(secret *corev1.Secret) bool {
 	if secret.Data == nil {
 		return false
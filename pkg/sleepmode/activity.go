@@ -0,0 +1,138 @@
+package sleepmode
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/config"
+	"github.com/loft-sh/vcluster/pkg/constants"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// persistInterval is the interval in which the last activity is written to the vCluster pod
+const persistInterval = 30 * time.Second
+
+// ignoredPaths are requests that never count as activity, such as the probes of the host kubelet
+var ignoredPaths = []string{"/healthz", "/livez", "/readyz", "/version", "/metrics"}
+
+// controllerUsers are the users of the controllers running within the vCluster
+var controllerUsers = []string{
+	"system:kube-controller-manager",
+	"system:kube-scheduler",
+	"system:kube-proxy",
+	"system:apiserver",
+	"system:serviceaccount:kube-system:*",
+}
+
+// ActivityTracker records the time of the last request that counts as activity and writes it to the vCluster
+// pod, so that the leader can decide when to put the vCluster to sleep.
+type ActivityTracker struct {
+	Exclude vclusterconfig.SleepModeAutoSleepExclude
+
+	lastActivity atomic.Int64
+}
+
+// StartActivityTracker creates a new activity tracker and starts writing the last activity to the current pod
+func StartActivityTracker(ctx *config.ControllerContext) *ActivityTracker {
+	tracker := &ActivityTracker{Exclude: ctx.Config.SleepMode.AutoSleep.Exclude}
+	podName := os.Getenv("POD_NAME")
+	if podName == "" {
+		klog.FromContext(ctx).Info("POD_NAME is not set, activity of this vCluster pod will not be recorded")
+		return tracker
+	}
+
+	go tracker.Persist(ctx, ctx.Config.ControlPlaneClient, ctx.Config.ControlPlaneNamespace, podName)
+	return tracker
+}
+
+// Record records a request of the given user if it counts as activity
+func (a *ActivityTracker) Record(info user.Info, path string, now time.Time) {
+	if !a.IsActivity(info, path) {
+		return
+	}
+
+	a.lastActivity.Store(now.UnixNano())
+}
+
+// LastActivity returns the time of the last recorded activity or the zero time if there was none
+func (a *ActivityTracker) LastActivity() time.Time {
+	lastActivity := a.lastActivity.Load()
+	if lastActivity == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, lastActivity)
+}
+
+// IsActivity returns true if a request of the given user to the given path counts as activity
+func (a *ActivityTracker) IsActivity(info user.Info, path string) bool {
+	if info == nil || info.GetName() == user.Anonymous || slices.Contains(info.GetGroups(), user.AllUnauthenticated) {
+		return false
+	}
+	for _, ignoredPath := range ignoredPaths {
+		if path == ignoredPath || strings.HasPrefix(path, ignoredPath+"/") {
+			return false
+		}
+	}
+	if a.Exclude.Kubelet && (slices.Contains(info.GetGroups(), user.NodesGroup) || strings.HasPrefix(info.GetName(), "system:node:")) {
+		return false
+	}
+	if a.Exclude.Controllers && matchesUser(controllerUsers, info.GetName()) {
+		return false
+	}
+
+	return !matchesUser(a.Exclude.Users, info.GetName())
+}
+
+// Persist writes the last activity to the annotations of the given pod until the context is done
+func (a *ActivityTracker) Persist(ctx context.Context, kubeClient kubernetes.Interface, namespace, podName string) {
+	logger := klog.FromContext(ctx).WithName("sleep-mode")
+	persisted := time.Time{}
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		lastActivity := a.LastActivity()
+		if !lastActivity.After(persisted) {
+			return
+		}
+
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": map[string]string{
+					constants.LastActivityAnnotation: lastActivity.UTC().Format(time.RFC3339),
+				},
+			},
+		})
+		if err != nil {
+			logger.Error(err, "create last activity patch")
+			return
+		}
+
+		_, err = kubeClient.CoreV1().Pods(namespace).Patch(ctx, podName, types.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			logger.Error(err, "record last activity", "pod", podName)
+			return
+		}
+
+		persisted = lastActivity
+	}, persistInterval)
+}
+
+func matchesUser(users []string, name string) bool {
+	for _, u := range users {
+		if u == name || (strings.HasSuffix(u, "*") && strings.HasPrefix(name, strings.TrimSuffix(u, "*"))) {
+			return true
+		}
+	}
+
+	return false
+}
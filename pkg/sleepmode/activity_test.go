@@ -0,0 +1,88 @@
+package sleepmode
+
+import (
+	"testing"
+	"time"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"gotest.tools/v3/assert"
+	"k8s.io/apiserver/pkg/authentication/user"
+)
+
+func TestIsActivity(t *testing.T) {
+	tracker := &ActivityTracker{Exclude: vclusterconfig.SleepModeAutoSleepExclude{
+		Kubelet:     true,
+		Controllers: true,
+		Users:       []string{"ci-bot", "system:serviceaccount:monitoring:*"},
+	}}
+
+	testCases := []struct {
+		name     string
+		user     user.Info
+		path     string
+		activity bool
+	}{
+		{
+			name:     "user",
+			user:     &user.DefaultInfo{Name: "admin", Groups: []string{user.AllAuthenticated}},
+			path:     "/api/v1/namespaces/default/pods",
+			activity: true,
+		},
+		{
+			name: "probe",
+			user: &user.DefaultInfo{Name: "admin"},
+			path: "/readyz",
+		},
+		{
+			name: "anonymous",
+			user: &user.DefaultInfo{Name: user.Anonymous, Groups: []string{user.AllUnauthenticated}},
+			path: "/api",
+		},
+		{
+			name: "kubelet",
+			user: &user.DefaultInfo{Name: "system:node:node-1", Groups: []string{user.NodesGroup}},
+			path: "/api/v1/nodes/node-1",
+		},
+		{
+			name: "controller",
+			user: &user.DefaultInfo{Name: "system:serviceaccount:kube-system:coredns"},
+			path: "/api/v1/services",
+		},
+		{
+			name: "excluded user",
+			user: &user.DefaultInfo{Name: "ci-bot"},
+			path: "/api/v1/pods",
+		},
+		{
+			name: "excluded user prefix",
+			user: &user.DefaultInfo{Name: "system:serviceaccount:monitoring:prometheus"},
+			path: "/api/v1/pods",
+		},
+		{
+			name:     "other service account",
+			user:     &user.DefaultInfo{Name: "system:serviceaccount:default:app"},
+			path:     "/api/v1/pods",
+			activity: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tracker.IsActivity(tt.user, tt.path), tt.activity)
+		})
+	}
+
+	// kubelets count as activity if they are not excluded
+	tracker.Exclude.Kubelet = false
+	assert.Assert(t, tracker.IsActivity(&user.DefaultInfo{Name: "system:node:node-1", Groups: []string{user.NodesGroup}}, "/api/v1/nodes/node-1"))
+}
+
+func TestRecord(t *testing.T) {
+	tracker := &ActivityTracker{}
+	assert.Assert(t, tracker.LastActivity().IsZero())
+
+	now := time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)
+	tracker.Record(&user.DefaultInfo{Name: "admin"}, "/api/v1/pods", now)
+	tracker.Record(&user.DefaultInfo{Name: "admin"}, "/healthz", now.Add(time.Minute))
+	assert.Assert(t, tracker.LastActivity().Equal(now))
+}
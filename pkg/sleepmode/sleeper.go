@@ -0,0 +1,209 @@
+package sleepmode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/vcluster/pkg/config"
+	"github.com/loft-sh/vcluster/pkg/constants"
+	"github.com/loft-sh/vcluster/pkg/lifecycle"
+	"github.com/robfig/cron/v3"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// checkInterval is the interval in which the leader checks if the vCluster was inactive long enough
+const checkInterval = time.Minute
+
+// WakeupProxyLabels returns the labels of the wake-up proxy pods of the given vCluster
+func WakeupProxyLabels(vClusterName string) map[string]string {
+	return map[string]string{
+		"app":     "vcluster-wakeup-proxy",
+		"release": vClusterName,
+	}
+}
+
+// Sleeper puts the vCluster to sleep by pointing its service to the wake-up proxy and scaling it down
+type Sleeper struct {
+	KubeClient kubernetes.Interface
+	Name       string
+	Namespace  string
+	Service    string
+
+	Now func() time.Time
+}
+
+// Start puts the vCluster to sleep after the configured inactivity and on the configured schedule. This
+// should only be called by the leader.
+func Start(ctx *config.ControllerContext) error {
+	autoSleep := ctx.Config.SleepMode.AutoSleep
+	location, err := time.LoadLocation(ctx.Config.SleepMode.TimeZone)
+	if err != nil {
+		return fmt.Errorf("load sleep mode time zone: %w", err)
+	}
+
+	sleeper := &Sleeper{
+		KubeClient: ctx.Config.ControlPlaneClient,
+		Name:       ctx.Config.Name,
+		Namespace:  ctx.Config.ControlPlaneNamespace,
+		Service:    ctx.Config.ControlPlaneService,
+		Now:        time.Now,
+	}
+	logger := klog.FromContext(ctx).WithName("sleep-mode")
+	if autoSleep.AfterInactivity != "" {
+		afterInactivity, err := time.ParseDuration(autoSleep.AfterInactivity)
+		if err != nil {
+			return fmt.Errorf("parse sleep mode inactivity: %w", err)
+		}
+
+		logger.Info("Start auto sleep", "afterInactivity", afterInactivity)
+		go wait.UntilWithContext(ctx, func(ctx context.Context) {
+			err := sleeper.SleepIfInactive(ctx, afterInactivity)
+			if err != nil {
+				logger.Error(err, "auto sleep")
+			}
+		}, checkInterval)
+	}
+
+	if autoSleep.Schedule == "" {
+		return nil
+	}
+
+	cronScheduler := cron.New(cron.WithLocation(location))
+	_, err = cronScheduler.AddFunc(autoSleep.Schedule, func() {
+		err := sleeper.Sleep(ctx, "schedule")
+		if err != nil {
+			logger.Error(err, "scheduled sleep")
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("parse sleep mode schedule %q: %w", autoSleep.Schedule, err)
+	}
+
+	logger.Info("Start scheduled sleep", "schedule", autoSleep.Schedule, "timeZone", location.String())
+	cronScheduler.Start()
+	go func() {
+		<-ctx.Done()
+		<-cronScheduler.Stop().Done()
+	}()
+
+	return nil
+}
+
+// SleepIfInactive puts the vCluster to sleep if there was no activity within the given duration
+func (s *Sleeper) SleepIfInactive(ctx context.Context, afterInactivity time.Duration) error {
+	lastActivity, err := s.LastActivity(ctx)
+	if err != nil {
+		return err
+	} else if lastActivity.IsZero() || s.Now().Sub(lastActivity) < afterInactivity {
+		return nil
+	}
+
+	return s.Sleep(ctx, "inactive since "+lastActivity.UTC().Format(time.RFC3339))
+}
+
+// LastActivity returns the last activity recorded on any of the vCluster pods. The start of a pod counts as
+// activity as well, so a vCluster that was just woken up is not put to sleep right away.
+func (s *Sleeper) LastActivity(ctx context.Context) (time.Time, error) {
+	pods, err := s.KubeClient.CoreV1().Pods(s.Namespace).List(ctx, metav1.ListOptions{LabelSelector: "app=vcluster,release=" + s.Name})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("list vcluster pods: %w", err)
+	}
+
+	lastActivity := time.Time{}
+	for _, pod := range pods.Items {
+		if pod.Status.StartTime != nil && pod.Status.StartTime.After(lastActivity) {
+			lastActivity = pod.Status.StartTime.Time
+		}
+
+		recorded, err := time.Parse(time.RFC3339, pod.Annotations[constants.LastActivityAnnotation])
+		if err == nil && recorded.After(lastActivity) {
+			lastActivity = recorded
+		}
+	}
+
+	return lastActivity, nil
+}
+
+// Sleep points the vCluster service to the wake-up proxy and scales down the vCluster. The wake-up proxy deletes
+// the workloads of the vCluster as soon as its control plane is gone.
+func (s *Sleeper) Sleep(ctx context.Context, reason string) error {
+	klog.FromContext(ctx).Info("Put vCluster to sleep", "reason", reason)
+
+	// the service is redirected first, as scaling down waits until the vCluster pods are gone, which includes the pod
+	// running this code. The wake-up proxy ignores the vCluster pods that were started before the redirect.
+	err := RedirectService(ctx, s.KubeClient, s.Namespace, s.Service, WakeupProxyLabels(s.Name))
+	if err != nil {
+		return fmt.Errorf("redirect service to wake-up proxy: %w", err)
+	}
+
+	return lifecycle.PauseVCluster(ctx, s.KubeClient, s.Name, s.Namespace, log.GetInstance())
+}
+
+// RedirectService points the given service to the pods with the given labels and remembers its original selector
+func RedirectService(ctx context.Context, kubeClient kubernetes.Interface, namespace, name string, selector map[string]string) error {
+	service, err := kubeClient.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	} else if service.Annotations[constants.SleepingSelectorAnnotation] != "" || len(service.Spec.Selector) == 0 {
+		return nil
+	}
+
+	originalSelector, err := json.Marshal(service.Spec.Selector)
+	if err != nil {
+		return err
+	}
+
+	if service.Annotations == nil {
+		service.Annotations = map[string]string{}
+	}
+	service.Annotations[constants.SleepingSelectorAnnotation] = string(originalSelector)
+	service.Annotations[constants.SleepingSinceAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	service.Spec.Selector = selector
+	_, err = kubeClient.CoreV1().Services(namespace).Update(ctx, service, metav1.UpdateOptions{})
+	return err
+}
+
+// RestoreService restores the original selector of a service that was redirected by RedirectService
+func RestoreService(ctx context.Context, kubeClient kubernetes.Interface, namespace, name string) error {
+	service, err := kubeClient.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	} else if service.Annotations[constants.SleepingSelectorAnnotation] == "" {
+		return nil
+	}
+
+	selector := map[string]string{}
+	err = json.Unmarshal([]byte(service.Annotations[constants.SleepingSelectorAnnotation]), &selector)
+	if err != nil {
+		return fmt.Errorf("parse original selector of service %s/%s: %w", namespace, name, err)
+	}
+
+	delete(service.Annotations, constants.SleepingSelectorAnnotation)
+	delete(service.Annotations, constants.SleepingSinceAnnotation)
+	service.Spec.Selector = selector
+	_, err = kubeClient.CoreV1().Services(namespace).Update(ctx, service, metav1.UpdateOptions{})
+	return err
+}
+
+// IsSleeping returns true if the given service currently points to the wake-up proxy
+func IsSleeping(ctx context.Context, kubeClient kubernetes.Interface, namespace, name string) (bool, error) {
+	service, err := kubeClient.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return service.Annotations[constants.SleepingSelectorAnnotation] != "", nil
+}
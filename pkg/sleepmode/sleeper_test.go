@@ -0,0 +1,318 @@
+package sleepmode
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/loft-sh/vcluster/pkg/constants"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"gotest.tools/v3/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+)
+
+var vClusterLabels = map[string]string{"app": "vcluster", "release": "my-vcluster"}
+
+func TestSleepIfInactive(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)
+	kubeClient := fake.NewSimpleClientset(
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-vcluster", Namespace: "test", Labels: vClusterLabels},
+			Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(int32(1))},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "my-vcluster-0",
+				Namespace:   "test",
+				Labels:      vClusterLabels,
+				Annotations: map[string]string{constants.LastActivityAnnotation: now.Add(-30 * time.Minute).Format(time.RFC3339)},
+			},
+			Status: corev1.PodStatus{StartTime: &metav1.Time{Time: now.Add(-2 * time.Hour)}},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-vcluster", Namespace: "test"},
+			Spec:       corev1.ServiceSpec{Selector: vClusterLabels},
+		},
+	)
+	sleeper := &Sleeper{KubeClient: kubeClient, Name: "my-vcluster", Namespace: "test", Service: "my-vcluster", Now: func() time.Time { return now }}
+
+	// active within the last hour
+	lastActivity, err := sleeper.LastActivity(ctx)
+	assert.NilError(t, err)
+	assert.Assert(t, lastActivity.Equal(now.Add(-30*time.Minute)))
+	assert.NilError(t, sleeper.SleepIfInactive(ctx, time.Hour))
+	sleeping, err := IsSleeping(ctx, kubeClient, "test", "my-vcluster")
+	assert.NilError(t, err)
+	assert.Assert(t, !sleeping)
+
+	// inactive for more than an hour
+	now = now.Add(time.Hour)
+	assert.NilError(t, sleeper.SleepIfInactive(ctx, time.Hour))
+	service, err := kubeClient.CoreV1().Services("test").Get(ctx, "my-vcluster", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, service.Spec.Selector, WakeupProxyLabels("my-vcluster"))
+	assert.Equal(t, service.Annotations[constants.SleepingSelectorAnnotation], `{"app":"vcluster","release":"my-vcluster"}`)
+	assert.Assert(t, service.Annotations[constants.SleepingSinceAnnotation] != "")
+	statefulSet, err := kubeClient.AppsV1().StatefulSets("test").Get(ctx, "my-vcluster", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, *statefulSet.Spec.Replicas, int32(0))
+	assert.Equal(t, statefulSet.Annotations[constants.PausedAnnotation], "true")
+
+	// restoring the service brings back the original selector
+	assert.NilError(t, RestoreService(ctx, kubeClient, "test", "my-vcluster"))
+	service, err = kubeClient.CoreV1().Services("test").Get(ctx, "my-vcluster", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, service.Spec.Selector, vClusterLabels)
+	_, found := service.Annotations[constants.SleepingSelectorAnnotation]
+	assert.Assert(t, !found)
+	_, found = service.Annotations[constants.SleepingSinceAnnotation]
+	assert.Assert(t, !found)
+}
+
+func TestWakeUp(t *testing.T) {
+	ctx := context.Background()
+	kubeClient := fake.NewSimpleClientset(
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "my-vcluster",
+				Namespace:   "test",
+				Labels:      vClusterLabels,
+				Annotations: map[string]string{constants.PausedAnnotation: "true", constants.PausedReplicasAnnotation: "1"},
+			},
+			Spec: appsv1.StatefulSetSpec{Replicas: ptr.To(int32(0))},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "my-vcluster",
+				Namespace:   "test",
+				Annotations: map[string]string{constants.SleepingSelectorAnnotation: `{"app":"vcluster","release":"my-vcluster"}`},
+			},
+			Spec: corev1.ServiceSpec{Selector: WakeupProxyLabels("my-vcluster")},
+		},
+	)
+
+	// the vCluster pod becomes ready as soon as the stateful set is scaled up
+	kubeClient.PrependReactor("patch", "statefulsets", func(clienttesting.Action) (bool, runtime.Object, error) {
+		err := kubeClient.Tracker().Add(readyPod("my-vcluster-0", "127.0.0.1"))
+		if kerrors.IsAlreadyExists(err) {
+			err = nil
+		}
+		return false, nil, err
+	})
+
+	proxy := &WakeupProxy{KubeClient: kubeClient, Name: "my-vcluster", Namespace: "test", Service: "my-vcluster", TargetPort: 8443, Timeout: time.Minute}
+	address, err := proxy.WakeUp(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, address, "127.0.0.1:8443")
+
+	statefulSet, err := kubeClient.AppsV1().StatefulSets("test").Get(ctx, "my-vcluster", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, *statefulSet.Spec.Replicas, int32(1))
+	sleeping, err := IsSleeping(ctx, kubeClient, "test", "my-vcluster")
+	assert.NilError(t, err)
+	assert.Assert(t, !sleeping)
+}
+
+func TestWakeUpWhileFallingAsleep(t *testing.T) {
+	ctx := context.Background()
+	sleepingSince := time.Now().UTC().Truncate(time.Second)
+	stalePod := readyPod("my-vcluster-0", "127.0.0.2")
+	stalePod.Status.StartTime = &metav1.Time{Time: sleepingSince.Add(-time.Hour)}
+	kubeClient := fake.NewSimpleClientset(
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "my-vcluster",
+				Namespace:   "test",
+				Labels:      vClusterLabels,
+				Annotations: map[string]string{constants.PausedAnnotation: "true", constants.PausedReplicasAnnotation: "1"},
+			},
+			Spec: appsv1.StatefulSetSpec{Replicas: ptr.To(int32(0))},
+		},
+		stalePod,
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-vcluster",
+				Namespace: "test",
+				Annotations: map[string]string{
+					constants.SleepingSelectorAnnotation: `{"app":"vcluster","release":"my-vcluster"}`,
+					constants.SleepingSinceAnnotation:    sleepingSince.Format(time.RFC3339),
+				},
+			},
+			Spec: corev1.ServiceSpec{Selector: WakeupProxyLabels("my-vcluster")},
+		},
+	)
+	proxy := &WakeupProxy{KubeClient: kubeClient, Name: "my-vcluster", Namespace: "test", Service: "my-vcluster", TargetPort: 8443, Timeout: time.Minute}
+
+	// the pod that is still terminating is ignored
+	pod, err := proxy.readyPod(ctx)
+	assert.NilError(t, err)
+	assert.Assert(t, pod == nil)
+
+	// the pod started after the vCluster was resumed is used
+	kubeClient.PrependReactor("patch", "statefulsets", func(clienttesting.Action) (bool, runtime.Object, error) {
+		resumedPod := readyPod("my-vcluster-0", "127.0.0.1")
+		resumedPod.Status.StartTime = &metav1.Time{Time: sleepingSince.Add(time.Minute)}
+		return false, nil, kubeClient.Tracker().Update(corev1.SchemeGroupVersion.WithResource("pods"), resumedPod, "test")
+	})
+	address, err := proxy.WakeUp(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, address, "127.0.0.1:8443")
+}
+
+func TestDeleteWorkloads(t *testing.T) {
+	ctx := context.Background()
+	workload := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "nginx-x-default-x-my-vcluster", Namespace: "test", Labels: map[string]string{translate.MarkerLabel: "my-vcluster"}}}
+	kubeClient := fake.NewSimpleClientset(
+		workload,
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "my-vcluster-0", Namespace: "test", Labels: vClusterLabels}},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "my-vcluster",
+				Namespace:   "test",
+				Annotations: map[string]string{constants.SleepingSelectorAnnotation: `{"app":"vcluster","release":"my-vcluster"}`},
+			},
+		},
+	)
+	proxy := &WakeupProxy{KubeClient: kubeClient, Name: "my-vcluster", Namespace: "test", Service: "my-vcluster"}
+
+	// the control plane is still running
+	assert.NilError(t, proxy.DeleteWorkloads(ctx))
+	_, err := kubeClient.CoreV1().Pods("test").Get(ctx, workload.Name, metav1.GetOptions{})
+	assert.NilError(t, err)
+
+	// the control plane is gone
+	assert.NilError(t, kubeClient.CoreV1().Pods("test").Delete(ctx, "my-vcluster-0", metav1.DeleteOptions{}))
+	assert.NilError(t, proxy.DeleteWorkloads(ctx))
+	_, err = kubeClient.CoreV1().Pods("test").Get(ctx, workload.Name, metav1.GetOptions{})
+	assert.Assert(t, kerrors.IsNotFound(err))
+}
+
+func TestWakeupProxyServe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the vCluster echoes everything it receives
+	vCluster, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	defer vCluster.Close()
+	go func() {
+		conn, err := vCluster.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = io.Copy(conn, conn)
+	}()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	proxy := &WakeupProxy{
+		KubeClient: fake.NewSimpleClientset(readyPod("my-vcluster-0", "127.0.0.1")),
+		Name:       "my-vcluster",
+		Namespace:  "test",
+		Service:    "my-vcluster",
+		TargetPort: vCluster.Addr().(*net.TCPAddr).Port,
+		Timeout:    time.Minute,
+	}
+	go func() {
+		_ = proxy.Serve(ctx, listener)
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	assert.NilError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("hello"))
+	assert.NilError(t, err)
+	response := make([]byte, 5)
+	_, err = io.ReadFull(conn, response)
+	assert.NilError(t, err)
+	assert.Equal(t, string(response), "hello")
+}
+
+func TestWakeupProxyIgnoresBareConnects(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	kubeClient := fake.NewSimpleClientset(&appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-vcluster",
+			Namespace:   "test",
+			Labels:      vClusterLabels,
+			Annotations: map[string]string{constants.PausedAnnotation: "true", constants.PausedReplicasAnnotation: "1"},
+		},
+		Spec: appsv1.StatefulSetSpec{Replicas: ptr.To(int32(0))},
+	})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	proxy := &WakeupProxy{KubeClient: kubeClient, Name: "my-vcluster", Namespace: "test", Service: "my-vcluster", TargetPort: 8443, Timeout: time.Minute}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = proxy.Serve(ctx, listener)
+	}()
+
+	// connect and close, and a tls record header without the ClientHello
+	for _, payload := range [][]byte{nil, {0x16, 0x03, 0x01, 0x00, 0x50}} {
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		assert.NilError(t, err)
+		_, err = conn.Write(payload)
+		assert.NilError(t, err)
+		assert.NilError(t, conn.Close())
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	cancel()
+	<-done
+	for _, action := range kubeClient.Actions() {
+		assert.Assert(t, action.GetVerb() != "patch", "unexpected %s of %s", action.GetVerb(), action.GetResource().Resource)
+	}
+	statefulSet, err := kubeClient.AppsV1().StatefulSets("test").Get(ctx, "my-vcluster", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, *statefulSet.Spec.Replicas, int32(0))
+}
+
+func TestReadFirstBytes(t *testing.T) {
+	clientHello := append([]byte{0x16, 0x03, 0x01, 0x00, 0x03}, 0x01, 0x02, 0x03)
+	for name, payload := range map[string][]byte{
+		"plain request": []byte("GET / HTTP/1.1\r\n"),
+		"tls":           clientHello,
+	} {
+		t.Run(name, func(t *testing.T) {
+			client, server := net.Pipe()
+			defer server.Close()
+			go func() {
+				_, _ = client.Write(append(payload, []byte("rest")...))
+				_ = client.Close()
+			}()
+
+			reader := bufio.NewReaderSize(server, tlsRecordHeaderSize+tlsMaxRecordSize)
+			assert.NilError(t, readFirstBytes(server, reader))
+
+			// the first bytes are kept for forwarding
+			out, err := io.ReadAll(reader)
+			assert.NilError(t, err)
+			assert.Equal(t, string(out), string(payload)+"rest")
+		})
+	}
+}
+
+func readyPod(name, ip string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test", Labels: vClusterLabels},
+		Status: corev1.PodStatus{
+			PodIP:      ip,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
@@ -0,0 +1,310 @@
+package sleepmode
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/vcluster/pkg/constants"
+	"github.com/loft-sh/vcluster/pkg/lifecycle"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// cleanupInterval is the interval in which the wake-up proxy checks if the workloads of a sleeping vCluster need to be deleted
+	cleanupInterval = 30 * time.Second
+
+	// firstBytesTimeout is how long the wake-up proxy waits for the first bytes of a connection
+	firstBytesTimeout = 30 * time.Second
+
+	// tlsRecordHeaderSize is the size of a tls record header, tlsMaxRecordSize the maximum size of a plaintext record
+	tlsRecordHeaderSize = 5
+	tlsMaxRecordSize    = 1 << 14
+
+	// tlsRecordTypeHandshake is the record type of the tls ClientHello
+	tlsRecordTypeHandshake = 0x16
+)
+
+// WakeupProxy receives the traffic of the vCluster service while the vCluster is sleeping. It holds incoming
+// connections, resumes the vCluster and forwards the connections to it as soon as it is ready.
+type WakeupProxy struct {
+	KubeClient kubernetes.Interface
+	Name       string
+	Namespace  string
+	Service    string
+
+	// TargetPort is the port of the vCluster pods connections are forwarded to
+	TargetPort int
+	// Timeout is how long to wait for the vCluster to become ready
+	Timeout time.Duration
+	// MultiNamespace defines if the workloads of the vCluster are spread across multiple host namespaces
+	MultiNamespace bool
+
+	wakeupMutex sync.Mutex
+}
+
+// Serve accepts connections on the given listener until the context is done
+func (w *WakeupProxy) Serve(ctx context.Context, listener net.Listener) error {
+	logger := klog.FromContext(ctx)
+	go func() {
+		<-ctx.Done()
+		_ = listener.Close()
+	}()
+	go wait.UntilWithContext(ctx, func(ctx context.Context) {
+		err := w.DeleteWorkloads(ctx)
+		if err != nil {
+			logger.Error(err, "delete workloads of sleeping vCluster")
+		}
+	}, cleanupInterval)
+
+	logger.Info("Start wake-up proxy", "address", listener.Addr().String(), "vcluster", w.Name)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return err
+		}
+
+		go w.handle(ctx, conn)
+	}
+}
+
+func (w *WakeupProxy) handle(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+
+	// only wake up the vCluster for actual requests and not for bare tcp connects, e.g. from port scanners or probes
+	logger := klog.FromContext(ctx)
+	reader := bufio.NewReaderSize(conn, tlsRecordHeaderSize+tlsMaxRecordSize)
+	err := readFirstBytes(conn, reader)
+	if err != nil {
+		logger.V(1).Info("Ignore connection without request", "remote", conn.RemoteAddr().String(), "error", err.Error())
+		return
+	}
+
+	address, err := w.WakeUp(ctx)
+	if err != nil {
+		logger.Error(err, "wake up vCluster", "remote", conn.RemoteAddr().String())
+		return
+	}
+
+	target, err := net.DialTimeout("tcp", address, 10*time.Second)
+	if err != nil {
+		logger.Error(err, "connect to vCluster", "address", address)
+		return
+	}
+	defer target.Close()
+
+	errChan := make(chan error, 2)
+	go func() {
+		_, err := io.Copy(target, reader)
+		errChan <- err
+	}()
+	go func() {
+		_, err := io.Copy(conn, target)
+		errChan <- err
+	}()
+	<-errChan
+}
+
+// readFirstBytes waits until the client sent the first bytes of the connection. For tls connections it waits
+// until the first record, which contains the ClientHello, is complete. The bytes stay buffered in the reader.
+func readFirstBytes(conn net.Conn, reader *bufio.Reader) error {
+	err := conn.SetReadDeadline(time.Now().Add(firstBytesTimeout))
+	if err != nil {
+		return err
+	}
+
+	first, err := reader.Peek(1)
+	if err != nil {
+		return err
+	} else if first[0] == tlsRecordTypeHandshake {
+		header, err := reader.Peek(tlsRecordHeaderSize)
+		if err != nil {
+			return fmt.Errorf("read tls record header: %w", err)
+		}
+
+		length := int(binary.BigEndian.Uint16(header[3:]))
+		if length > tlsMaxRecordSize {
+			return fmt.Errorf("tls record of %d bytes exceeds the maximum record size", length)
+		}
+
+		_, err = reader.Peek(tlsRecordHeaderSize + length)
+		if err != nil {
+			return fmt.Errorf("read tls client hello: %w", err)
+		}
+	}
+
+	return conn.SetReadDeadline(time.Time{})
+}
+
+// WakeUp resumes the vCluster if it is sleeping, waits until one of its pods is ready, points the vCluster
+// service back to the vCluster and returns the address of the ready pod.
+func (w *WakeupProxy) WakeUp(ctx context.Context) (string, error) {
+	w.wakeupMutex.Lock()
+	defer w.wakeupMutex.Unlock()
+
+	pod, err := w.readyPod(ctx)
+	if err != nil {
+		return "", err
+	} else if pod == nil {
+		klog.FromContext(ctx).Info("Wake up vCluster", "vcluster", w.Name)
+		err = wait.PollUntilContextTimeout(ctx, 2*time.Second, w.Timeout, true, func(ctx context.Context) (bool, error) {
+			// the vCluster might not be scaled down by the sleeper yet, so it is resumed as soon as it is paused
+			paused, err := w.paused(ctx)
+			if err != nil {
+				return false, err
+			} else if paused {
+				err = lifecycle.ResumeVCluster(ctx, w.KubeClient, w.Name, w.Namespace, log.GetInstance())
+				if err != nil {
+					// the vCluster might have been resumed already, so we wait for it to become ready anyways
+					klog.FromContext(ctx).Info("Resume vCluster", "error", err.Error())
+				}
+			}
+
+			pod, err = w.readyPod(ctx)
+			return pod != nil, err
+		})
+		if err != nil {
+			return "", fmt.Errorf("wait for vcluster %s/%s to become ready: %w", w.Namespace, w.Name, err)
+		}
+	}
+
+	err = RestoreService(ctx, w.KubeClient, w.Namespace, w.Service)
+	if err != nil {
+		return "", fmt.Errorf("restore service %s/%s: %w", w.Namespace, w.Service, err)
+	}
+
+	return net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(w.TargetPort)), nil
+}
+
+// DeleteWorkloads deletes the workloads of the vCluster once it is sleeping and its control plane is gone.
+// The vCluster cannot do this itself, as it would sync the deleted pods while it is still running.
+func (w *WakeupProxy) DeleteWorkloads(ctx context.Context) error {
+	// don't interfere with a vCluster that is waking up
+	if !w.wakeupMutex.TryLock() {
+		return nil
+	}
+	defer w.wakeupMutex.Unlock()
+
+	sleeping, err := IsSleeping(ctx, w.KubeClient, w.Namespace, w.Service)
+	if err != nil || !sleeping {
+		return err
+	}
+
+	pods, err := w.KubeClient.CoreV1().Pods(w.Namespace).List(ctx, metav1.ListOptions{LabelSelector: "app=vcluster,release=" + w.Name})
+	if err != nil {
+		return fmt.Errorf("list vcluster pods: %w", err)
+	} else if len(pods.Items) > 0 {
+		return nil
+	}
+
+	err = lifecycle.DeletePods(ctx, w.KubeClient, translate.MarkerLabel+"="+w.Name, w.Namespace, log.GetInstance())
+	if err != nil {
+		return fmt.Errorf("delete vcluster workloads: %w", err)
+	}
+	if w.MultiNamespace {
+		err = lifecycle.DeleteMultiNamespaceVClusterWorkloads(ctx, w.KubeClient, w.Name, w.Namespace, log.GetInstance())
+		if err != nil {
+			return fmt.Errorf("delete vcluster multinamespace workloads: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// readyPod returns a ready pod of the vCluster. Pods of a paused vCluster and pods that were started before the vCluster
+// service was redirected are ignored, as they are about to be scaled down.
+func (w *WakeupProxy) readyPod(ctx context.Context) (*corev1.Pod, error) {
+	paused, err := w.paused(ctx)
+	if err != nil || paused {
+		return nil, err
+	}
+
+	sleepingSince, err := w.sleepingSince(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := w.KubeClient.CoreV1().Pods(w.Namespace).List(ctx, metav1.ListOptions{LabelSelector: "app=vcluster,release=" + w.Name})
+	if err != nil {
+		return nil, fmt.Errorf("list vcluster pods: %w", err)
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.DeletionTimestamp != nil || pod.Status.PodIP == "" {
+			continue
+		} else if !sleepingSince.IsZero() && (pod.Status.StartTime == nil || !pod.Status.StartTime.After(sleepingSince)) {
+			continue
+		}
+
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+				return pod, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// paused checks if the vCluster is scaled down, either by the sleeper or because it was paused
+func (w *WakeupProxy) paused(ctx context.Context) (bool, error) {
+	listOptions := metav1.ListOptions{LabelSelector: "app=vcluster,release=" + w.Name}
+	statefulSets, err := w.KubeClient.AppsV1().StatefulSets(w.Namespace).List(ctx, listOptions)
+	if err != nil {
+		return false, fmt.Errorf("list vcluster statefulsets: %w", err)
+	}
+	for _, statefulSet := range statefulSets.Items {
+		if statefulSet.Annotations[constants.PausedAnnotation] == "true" || (statefulSet.Spec.Replicas != nil && *statefulSet.Spec.Replicas == 0) {
+			return true, nil
+		}
+	}
+
+	deployments, err := w.KubeClient.AppsV1().Deployments(w.Namespace).List(ctx, listOptions)
+	if err != nil {
+		return false, fmt.Errorf("list vcluster deployments: %w", err)
+	}
+	for _, deployment := range deployments.Items {
+		if deployment.Annotations[constants.PausedAnnotation] == "true" || (deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == 0) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// sleepingSince returns the time the vCluster service was redirected to the wake-up proxy or zero if it isn't redirected
+func (w *WakeupProxy) sleepingSince(ctx context.Context) (time.Time, error) {
+	service, err := w.KubeClient.CoreV1().Services(w.Namespace).Get(ctx, w.Service, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, fmt.Errorf("get service %s/%s: %w", w.Namespace, w.Service, err)
+	} else if service.Annotations[constants.SleepingSinceAnnotation] == "" {
+		return time.Time{}, nil
+	}
+
+	sleepingSince, err := time.Parse(time.RFC3339, service.Annotations[constants.SleepingSinceAnnotation])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse sleeping since of service %s/%s: %w", w.Namespace, w.Service, err)
+	}
+
+	return sleepingSince, nil
+}
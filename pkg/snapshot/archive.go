@@ -0,0 +1,115 @@
+package snapshot
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// SecretType identifies the vCluster secrets that are stored within a snapshot
+type SecretType string
+
+const (
+	// SecretTypeConfig is the vCluster config secret vc-config-<name>
+	SecretTypeConfig SecretType = "Config"
+	// SecretTypeCertificates is the vCluster certificates secret <name>-certs
+	SecretTypeCertificates SecretType = "Certificates"
+)
+
+// Metadata describes the virtual cluster a snapshot was taken from
+type Metadata struct {
+	Name         string    `json:"name"`
+	Namespace    string    `json:"namespace"`
+	Distro       string    `json:"distro"`
+	BackingStore string    `json:"backingStore"`
+	Created      time.Time `json:"created"`
+}
+
+// Secret is a vCluster secret stored within a snapshot
+type Secret struct {
+	Type   SecretType     `json:"type"`
+	Secret *corev1.Secret `json:"secret"`
+}
+
+// KeyValue is a single key of the backing store
+type KeyValue struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// Record is a single entry within a snapshot archive. Exactly one of the fields is set.
+type Record struct {
+	Metadata *Metadata `json:"metadata,omitempty"`
+	Secret   *Secret   `json:"secret,omitempty"`
+	KeyValue *KeyValue `json:"keyValue,omitempty"`
+}
+
+// Writer writes a snapshot archive, which is a gzip compressed stream of json encoded records
+type Writer struct {
+	gzipWriter *gzip.Writer
+	encoder    *json.Encoder
+}
+
+// NewWriter creates a new archive writer
+func NewWriter(w io.Writer) *Writer {
+	gzipWriter := gzip.NewWriter(w)
+	return &Writer{
+		gzipWriter: gzipWriter,
+		encoder:    json.NewEncoder(gzipWriter),
+	}
+}
+
+// Write writes a single record to the archive
+func (w *Writer) Write(record *Record) error {
+	return w.encoder.Encode(record)
+}
+
+// Close flushes the archive, it doesn't close the underlying writer
+func (w *Writer) Close() error {
+	return w.gzipWriter.Close()
+}
+
+// Reader reads a snapshot archive written by Writer
+type Reader struct {
+	gzipReader *gzip.Reader
+	decoder    *json.Decoder
+}
+
+// NewReader creates a new archive reader
+func NewReader(r io.Reader) (*Reader, error) {
+	gzipReader, err := gzip.NewReader(bufio.NewReader(r))
+	if err != nil {
+		return nil, fmt.Errorf("open snapshot archive: %w", err)
+	}
+
+	return &Reader{
+		gzipReader: gzipReader,
+		decoder:    json.NewDecoder(gzipReader),
+	}, nil
+}
+
+// Next returns the next record of the archive or io.EOF if there are no more records
+func (r *Reader) Next() (*Record, error) {
+	record := &Record{}
+	err := r.decoder.Decode(record)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+
+		return nil, fmt.Errorf("read snapshot archive: %w", err)
+	}
+
+	return record, nil
+}
+
+// Close closes the archive, it doesn't close the underlying reader
+func (r *Reader) Close() error {
+	return r.gzipReader.Close()
+}
@@ -0,0 +1,94 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/config"
+	"github.com/loft-sh/vcluster/pkg/constants"
+	"github.com/loft-sh/vcluster/pkg/etcd"
+	"github.com/loft-sh/vcluster/pkg/k8s"
+	"github.com/loft-sh/vcluster/pkg/pro"
+)
+
+// StartBackingStore starts the backing store of a paused vCluster, so that a snapshot can be restored
+// into it, and returns a client to it. Deployed etcd and external databases are expected to be running already.
+func StartBackingStore(ctx context.Context, vConfig *config.VirtualClusterConfig) (etcd.Client, error) {
+	backingStore := vConfig.ControlPlane.BackingStore
+	distro := vConfig.Distro()
+
+	// deployed etcd is not paused together with the vCluster
+	if backingStore.Etcd.Deploy.Enabled {
+		return etcd.NewFromConfig(ctx, vConfig)
+	}
+
+	// embedded etcd
+	if backingStore.Etcd.Embedded.Enabled {
+		certificatesDir := "/data/pki"
+		if distro == vclusterconfig.K0SDistro {
+			certificatesDir = "/data/k0s/pki"
+		}
+
+		err := pro.StartEmbeddedEtcd(ctx, vConfig.Name, vConfig.ControlPlaneNamespace, certificatesDir, 1, "")
+		if err != nil {
+			return nil, fmt.Errorf("start embedded etcd: %w", err)
+		}
+
+		return etcd.NewFromConfig(ctx, vConfig)
+	}
+
+	// external database
+	if backingStore.Database.External.Enabled && distro == vclusterconfig.K8SDistro {
+		endpoint, certificates, err := pro.ConfigureExternalDatabase(ctx, vConfig)
+		if err != nil {
+			return nil, fmt.Errorf("configure external database: %w", err)
+		}
+
+		return etcd.New(ctx, certificates, endpoint)
+	}
+
+	// start kine ourselves for the other distros and the embedded database
+	dataSource, certificates, err := kineDataSource(vConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	k8s.StartKine(ctx, dataSource, constants.K8sKineEndpoint, certificates)
+	return etcd.New(ctx, nil, constants.K8sKineEndpoint)
+}
+
+// kineDataSource returns the data source the distro uses, see k8s.StartK8S and k3s.StartK3S
+func kineDataSource(vConfig *config.VirtualClusterConfig) (string, *etcd.Certificates, error) {
+	database := vConfig.ControlPlane.BackingStore.Database
+	switch vConfig.Distro() {
+	case vclusterconfig.K8SDistro, vclusterconfig.EKSDistro:
+		if database.External.DataSource != "" {
+			return database.External.DataSource, &etcd.Certificates{
+				CaCert:     database.External.CaFile,
+				ServerCert: database.External.CertFile,
+				ServerKey:  database.External.KeyFile,
+			}, nil
+		}
+
+		return "sqlite:///data/state.db?_journal=WAL&cache=shared&_busy_timeout=30000", nil, nil
+	case vclusterconfig.K3SDistro, vclusterconfig.K0SDistro:
+		if database.Embedded.DataSource != "" {
+			return database.Embedded.DataSource, &etcd.Certificates{
+				CaCert:     database.Embedded.CaFile,
+				ServerCert: database.Embedded.CertFile,
+				ServerKey:  database.Embedded.KeyFile,
+			}, nil
+		} else if database.External.Enabled {
+			return database.External.DataSource, &etcd.Certificates{
+				CaCert:     database.External.CaFile,
+				ServerCert: database.External.CertFile,
+				ServerKey:  database.External.KeyFile,
+			}, nil
+		} else if vConfig.Distro() == vclusterconfig.K3SDistro {
+			return "sqlite:///data/server/db/state.db?_journal=WAL&cache=shared&_busy_timeout=30000", nil, nil
+		}
+	}
+
+	return "", nil, fmt.Errorf("restoring the default backing store of distro %s is not supported", vConfig.Distro())
+}
@@ -0,0 +1,275 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	snapshotSuffix     = ".snapshot.gz"
+	snapshotTimeFormat = "20060102-150405"
+)
+
+// Destination is a directory or bucket prefix that holds multiple snapshots
+type Destination interface {
+	// Store returns the store for the snapshot with the given name
+	Store(name string) Store
+
+	// List returns the names of the snapshots within the destination
+	List(ctx context.Context) ([]string, error)
+
+	// Delete deletes the snapshot with the given name
+	Delete(ctx context.Context, name string) error
+
+	// String returns a human-readable representation of the destination
+	String() string
+}
+
+// NewDestination parses the given url and returns the corresponding destination. Supported are
+// file:///path/to/dir and s3://bucket/prefix urls.
+func NewDestination(rawURL string) (Destination, error) {
+	scheme, rest, found := strings.Cut(rawURL, "://")
+	if !found {
+		return nil, fmt.Errorf("invalid snapshot destination %q, expected file://path or s3://bucket/prefix", rawURL)
+	}
+
+	switch scheme {
+	case "file":
+		if rest == "" {
+			return nil, fmt.Errorf("invalid snapshot destination %q, path is missing", rawURL)
+		}
+
+		return &fileDestination{dir: rest}, nil
+	case "s3":
+		parsedURL, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, fmt.Errorf("parse snapshot destination: %w", err)
+		}
+
+		client, prefix, err := newS3Client(parsedURL)
+		if err != nil {
+			return nil, err
+		}
+		if prefix != "" && !strings.HasSuffix(prefix, "/") {
+			prefix += "/"
+		}
+
+		return &s3Destination{s3Client: client, prefix: prefix}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported snapshot destination %q, expected file://path or s3://bucket/prefix", rawURL)
+}
+
+// SnapshotName returns the name of a scheduled snapshot of the given vCluster
+func SnapshotName(vClusterName string, created time.Time) string {
+	return vClusterName + "-" + created.UTC().Format(snapshotTimeFormat) + snapshotSuffix
+}
+
+// SnapshotTime parses the creation time from a snapshot name created by SnapshotName. It returns false
+// if the name doesn't belong to a snapshot of the given vCluster.
+func SnapshotTime(vClusterName, name string) (time.Time, bool) {
+	timestamp, found := strings.CutPrefix(name, vClusterName+"-")
+	if !found {
+		return time.Time{}, false
+	}
+	timestamp, found = strings.CutSuffix(timestamp, snapshotSuffix)
+	if !found {
+		return time.Time{}, false
+	}
+
+	created, err := time.Parse(snapshotTimeFormat, timestamp)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return created, true
+}
+
+// Prune deletes the snapshots of the given vCluster that exceed the retention count or are older than maxAge.
+// A count or maxAge of zero disables the respective limit. The deleted snapshot names are returned.
+func Prune(ctx context.Context, destination Destination, vClusterName string, count int, maxAge time.Duration, now time.Time) ([]string, error) {
+	names, err := destination.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the newest snapshots come first
+	type entry struct {
+		name    string
+		created time.Time
+	}
+	snapshots := []entry{}
+	for _, name := range names {
+		created, ok := SnapshotTime(vClusterName, name)
+		if ok {
+			snapshots = append(snapshots, entry{name: name, created: created})
+		}
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].created.After(snapshots[j].created)
+	})
+
+	deleted := []string{}
+	for i, snapshot := range snapshots {
+		if (count <= 0 || i < count) && (maxAge <= 0 || now.Sub(snapshot.created) <= maxAge) {
+			continue
+		}
+
+		err = destination.Delete(ctx, snapshot.name)
+		if err != nil {
+			return deleted, fmt.Errorf("delete snapshot %s: %w", snapshot.name, err)
+		}
+		deleted = append(deleted, snapshot.name)
+	}
+
+	return deleted, nil
+}
+
+type fileDestination struct {
+	dir string
+}
+
+func (f *fileDestination) Store(name string) Store {
+	return &fileStore{path: filepath.Join(f.dir, name)}
+}
+
+func (f *fileDestination) List(_ context.Context) ([]string, error) {
+	entries, err := os.ReadDir(f.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("list snapshots: %w", err)
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+
+	return names, nil
+}
+
+func (f *fileDestination) Delete(_ context.Context, name string) error {
+	err := os.Remove(filepath.Join(f.dir, name))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (f *fileDestination) String() string {
+	return "file://" + f.dir
+}
+
+type s3Destination struct {
+	*s3Client
+
+	prefix string
+}
+
+// s3ListResult is the response of the ListObjectsV2 api
+type s3ListResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (s *s3Destination) Store(name string) Store {
+	return &s3Store{s3Client: s.s3Client, key: s.prefix + name}
+}
+
+func (s *s3Destination) List(ctx context.Context) ([]string, error) {
+	names := []string{}
+	continuationToken := ""
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("prefix", s.prefix)
+		if continuationToken != "" {
+			query.Set("continuation-token", continuationToken)
+		}
+
+		req, err := s.newRequest(ctx, http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		s.sign(req)
+
+		result, err := s.list(req)
+		if err != nil {
+			return nil, err
+		}
+		for _, content := range result.Contents {
+			// skip objects in sub directories
+			name := strings.TrimPrefix(content.Key, s.prefix)
+			if name != "" && !strings.Contains(name, "/") {
+				names = append(names, name)
+			}
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return names, nil
+		}
+
+		continuationToken = result.NextContinuationToken
+	}
+}
+
+func (s *s3Destination) list(req *http.Request) (*s3ListResult, error) {
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("list snapshots: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("list snapshots: %s", responseError(resp))
+	}
+
+	result := &s3ListResult{}
+	err = xml.NewDecoder(resp.Body).Decode(result)
+	if err != nil {
+		return nil, fmt.Errorf("decode snapshot list: %w", err)
+	}
+
+	return result, nil
+}
+
+func (s *s3Destination) Delete(ctx context.Context, name string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, s.prefix+name, nil, nil)
+	if err != nil {
+		return err
+	}
+	s.sign(req)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s", responseError(resp))
+	}
+
+	return nil
+}
+
+func (s *s3Destination) String() string {
+	return "s3://" + path.Join(s.bucket, s.prefix)
+}
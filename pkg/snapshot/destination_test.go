@@ -0,0 +1,169 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestSnapshotName(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	name := SnapshotName("my-vcluster", created)
+	assert.Equal(t, name, "my-vcluster-20240102-030405.snapshot.gz")
+
+	parsed, ok := SnapshotTime("my-vcluster", name)
+	assert.Assert(t, ok)
+	assert.Assert(t, parsed.Equal(created))
+
+	_, ok = SnapshotTime("my", name)
+	assert.Assert(t, !ok, "snapshot of other vCluster with same prefix")
+	_, ok = SnapshotTime("other", name)
+	assert.Assert(t, !ok)
+	_, ok = SnapshotTime("my-vcluster", "my-vcluster-latest.snapshot.gz")
+	assert.Assert(t, !ok)
+}
+
+func TestPrune(t *testing.T) {
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		Name            string
+		Count           int
+		MaxAge          time.Duration
+		ExpectedDeleted []string
+	}{
+		{
+			Name: "no retention",
+		},
+		{
+			Name:  "count",
+			Count: 2,
+			ExpectedDeleted: []string{
+				SnapshotName("test", now.Add(-72*time.Hour)),
+				SnapshotName("test", now.Add(-96*time.Hour)),
+			},
+		},
+		{
+			Name:   "max age",
+			MaxAge: 80 * time.Hour,
+			ExpectedDeleted: []string{
+				SnapshotName("test", now.Add(-96*time.Hour)),
+			},
+		},
+		{
+			Name:   "count and max age",
+			Count:  1,
+			MaxAge: 80 * time.Hour,
+			ExpectedDeleted: []string{
+				SnapshotName("test", now.Add(-24*time.Hour)),
+				SnapshotName("test", now.Add(-72*time.Hour)),
+				SnapshotName("test", now.Add(-96*time.Hour)),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			dir := t.TempDir()
+			files := []string{
+				SnapshotName("test", now.Add(-72*time.Hour)),
+				SnapshotName("test", now),
+				SnapshotName("test", now.Add(-96*time.Hour)),
+				SnapshotName("test", now.Add(-24*time.Hour)),
+				SnapshotName("other", now.Add(-96*time.Hour)),
+				"manual.snapshot.gz",
+			}
+			for _, file := range files {
+				assert.NilError(t, os.WriteFile(filepath.Join(dir, file), []byte("snapshot"), 0644))
+			}
+
+			destination, err := NewDestination("file://" + dir)
+			assert.NilError(t, err)
+			deleted, err := Prune(context.Background(), destination, "test", testCase.Count, testCase.MaxAge, now)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, deleted, append([]string{}, testCase.ExpectedDeleted...))
+
+			remaining, err := destination.List(context.Background())
+			assert.NilError(t, err)
+			assert.Equal(t, len(remaining), len(files)-len(testCase.ExpectedDeleted))
+		})
+	}
+}
+
+func TestS3Destination(t *testing.T) {
+	ctx := context.Background()
+	objects := map[string]bool{
+		"snapshots/test-20240101-000000.snapshot.gz": true,
+		"snapshots/test-20240102-000000.snapshot.gz": true,
+		"snapshots/nested/test.snapshot.gz":          true,
+		"other/test-20240101-000000.snapshot.gz":     true,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/bucket":
+			// return a single key per page to test pagination
+			keys := []string{}
+			for key := range objects {
+				if strings.HasPrefix(key, r.URL.Query().Get("prefix")) && key > r.URL.Query().Get("continuation-token") {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+			if len(keys) == 0 {
+				_, _ = fmt.Fprint(w, `<ListBucketResult><IsTruncated>false</IsTruncated></ListBucketResult>`)
+				return
+			}
+			_, _ = fmt.Fprintf(w, `<ListBucketResult><Contents><Key>%s</Key></Contents><IsTruncated>%t</IsTruncated><NextContinuationToken>%s</NextContinuationToken></ListBucketResult>`, keys[0], len(keys) > 1, keys[0])
+		case r.Method == http.MethodPut:
+			_, _ = io.Copy(io.Discard, r.Body)
+			objects[strings.TrimPrefix(r.URL.Path, "/bucket/")] = true
+		case r.Method == http.MethodDelete:
+			delete(objects, strings.TrimPrefix(r.URL.Path, "/bucket/"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "access")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	destination, err := NewDestination("s3://bucket/snapshots?endpoint=" + server.URL)
+	assert.NilError(t, err)
+	assert.Equal(t, destination.String(), "s3://bucket/snapshots")
+
+	err = destination.Store("test-20240103-000000.snapshot.gz").Write(ctx, func(w io.Writer) error {
+		_, err := w.Write([]byte("snapshot"))
+		return err
+	})
+	assert.NilError(t, err)
+
+	names, err := destination.List(ctx)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, names, []string{
+		"test-20240101-000000.snapshot.gz",
+		"test-20240102-000000.snapshot.gz",
+		"test-20240103-000000.snapshot.gz",
+	})
+
+	deleted, err := Prune(ctx, destination, "test", 1, 0, time.Now())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, deleted, []string{
+		"test-20240102-000000.snapshot.gz",
+		"test-20240101-000000.snapshot.gz",
+	})
+	assert.DeepEqual(t, objects, map[string]bool{
+		"snapshots/test-20240103-000000.snapshot.gz": true,
+		"snapshots/nested/test.snapshot.gz":          true,
+		"other/test-20240101-000000.snapshot.gz":     true,
+	})
+}
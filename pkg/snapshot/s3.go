@@ -0,0 +1,267 @@
+package snapshot
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3TimeFormat      = "20060102T150405Z"
+	s3DateFormat      = "20060102"
+)
+
+// s3Client is a minimal client for an S3 compatible object store. Credentials are read from the
+// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables. The region and
+// endpoint can be set through the url, e.g. s3://bucket/key?region=eu-west-1&endpoint=http://minio:9000
+type s3Client struct {
+	bucket   string
+	region   string
+	endpoint *url.URL
+
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// s3Store stores a single snapshot in an S3 compatible object store
+type s3Store struct {
+	*s3Client
+
+	key string
+}
+
+// newS3Client parses the given s3://bucket/key url and returns a client for the bucket and the key
+func newS3Client(u *url.URL) (*s3Client, string, error) {
+	bucket := u.Host
+	key := strings.TrimPrefix(u.Path, "/")
+	if bucket == "" {
+		return nil, "", fmt.Errorf("invalid snapshot location %q, expected s3://bucket/key", u.String())
+	}
+
+	query := u.Query()
+	region := firstNonEmpty(query.Get("region"), os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION"), "us-east-1")
+	endpoint := firstNonEmpty(query.Get("endpoint"), os.Getenv("AWS_ENDPOINT_URL_S3"), os.Getenv("AWS_ENDPOINT_URL"), "https://s3."+region+".amazonaws.com")
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, "", fmt.Errorf("parse s3 endpoint: %w", err)
+	} else if endpointURL.Scheme != "http" && endpointURL.Scheme != "https" {
+		return nil, "", fmt.Errorf("invalid s3 endpoint %q, expected http:// or https://", endpoint)
+	}
+
+	accessKeyID := os.Getenv("AWS_ACCESS_KEY_ID")
+	secretAccessKey := os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if accessKeyID == "" || secretAccessKey == "" {
+		return nil, "", fmt.Errorf("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY need to be set to use an s3 snapshot location")
+	}
+
+	return &s3Client{
+		bucket:   bucket,
+		region:   region,
+		endpoint: endpointURL,
+
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+		sessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+
+		httpClient: http.DefaultClient,
+		now:        time.Now,
+	}, key, nil
+}
+
+func newS3Store(u *url.URL) (*s3Store, error) {
+	client, key, err := newS3Client(u)
+	if err != nil {
+		return nil, err
+	} else if key == "" {
+		return nil, fmt.Errorf("invalid snapshot location %q, expected s3://bucket/key", u.String())
+	}
+
+	return &s3Store{s3Client: client, key: key}, nil
+}
+
+func (s *s3Store) Write(ctx context.Context, write func(w io.Writer) error) error {
+	// s3 requires the content length upfront, so we buffer the snapshot in a temporary file
+	tempFile, err := writeTempFile("", write)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile)
+
+	file, err := os.Open(tempFile)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	req, err := s.newRequest(ctx, http.MethodPut, s.key, nil, file)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = stat.Size()
+	req.Header.Set("Content-Type", "application/gzip")
+	s.sign(req)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("upload snapshot: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("upload snapshot: %s", responseError(resp))
+	}
+
+	return nil
+}
+
+func (s *s3Store) Reader(ctx context.Context) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, s.key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	s.sign(req)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download snapshot: %w", err)
+	} else if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, fmt.Errorf("download snapshot: %s", responseError(resp))
+	}
+
+	return resp.Body, nil
+}
+
+func (s *s3Store) String() string {
+	return "s3://" + s.bucket + "/" + s.key
+}
+
+// newRequest creates a path style request for the object, which is supported by AWS and MinIO
+func (s *s3Client) newRequest(ctx context.Context, method, key string, query url.Values, body io.Reader) (*http.Request, error) {
+	objectURL := *s.endpoint
+	objectURL.Path = strings.TrimSuffix(objectURL.Path, "/") + "/" + s.bucket
+	if key != "" {
+		objectURL.Path += "/" + key
+	}
+	objectURL.RawPath = uriEncode(objectURL.Path)
+	objectURL.RawQuery = encodeQuery(query)
+	return http.NewRequestWithContext(ctx, method, objectURL.String(), body)
+}
+
+// sign signs the request with AWS signature version 4
+func (s *s3Client) sign(req *http.Request) {
+	now := s.now().UTC()
+	req.Header.Set("X-Amz-Date", now.Format(s3TimeFormat))
+	req.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+	if s.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.sessionToken)
+	}
+
+	// canonical headers
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if name == "content-type" || strings.HasPrefix(name, "x-amz-") {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	headerNames := make([]string, 0, len(headers))
+	for name := range headers {
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+	canonicalHeaders := &strings.Builder{}
+	for _, name := range headerNames {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		encodeQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		s3UnsignedPayload,
+	}, "\n")
+
+	scope := now.Format(s3DateFormat) + "/" + s.region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		now.Format(s3TimeFormat),
+		scope,
+		hexSHA256([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.secretAccessKey), now.Format(s3DateFormat))
+	signingKey = hmacSHA256(signingKey, s.region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", s.accessKeyID, scope, signedHeaders, signature))
+}
+
+func responseError(resp *http.Response) string {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	return fmt.Sprintf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// uriEncode encodes the path the way AWS expects it, every character except unreserved ones and slashes is encoded
+func uriEncode(path string) string {
+	encoded := &strings.Builder{}
+	for _, b := range []byte(path) {
+		if (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9') || b == '-' || b == '_' || b == '.' || b == '~' || b == '/' {
+			encoded.WriteByte(b)
+		} else {
+			fmt.Fprintf(encoded, "%%%02X", b)
+		}
+	}
+
+	return encoded.String()
+}
+
+// encodeQuery encodes the query the way AWS expects it, spaces are encoded as %20
+func encodeQuery(query url.Values) string {
+	return strings.ReplaceAll(query.Encode(), "+", "%20")
+}
+
+func hexSHA256(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,244 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/config"
+	"github.com/loft-sh/vcluster/pkg/etcd"
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	clientv1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// StatusLastSnapshot is the status config map key that holds the name of the last snapshot
+	StatusLastSnapshot = "lastSnapshot"
+	// StatusLastSnapshotTime is the status config map key that holds the time of the last snapshot
+	StatusLastSnapshotTime = "lastSnapshotTime"
+	// StatusLastSnapshotResult is the status config map key that holds the result of the last snapshot, either Succeeded or Failed
+	StatusLastSnapshotResult = "lastSnapshotResult"
+	// StatusLastSnapshotError is the status config map key that holds the error of the last failed snapshot
+	StatusLastSnapshotError = "lastSnapshotError"
+	// StatusLastSuccessfulSnapshot is the status config map key that holds the name of the last successful snapshot
+	StatusLastSuccessfulSnapshot = "lastSuccessfulSnapshot"
+	// StatusLastSuccessfulSnapshotTime is the status config map key that holds the time of the last successful snapshot
+	StatusLastSuccessfulSnapshotTime = "lastSuccessfulSnapshotTime"
+	// StatusConsecutiveFailures is the status config map key that holds the number of failed snapshots since the last success
+	StatusConsecutiveFailures = "consecutiveFailures"
+
+	ResultSucceeded = "Succeeded"
+	ResultFailed    = "Failed"
+)
+
+// StatusConfigMapName returns the name of the config map that holds the status of the scheduled snapshots
+func StatusConfigMapName(vClusterName string) string {
+	return "vc-snapshots-" + vClusterName
+}
+
+// Scheduler creates snapshots on a cron schedule and prunes old snapshots according to the retention
+type Scheduler struct {
+	Options     Options
+	Snapshots   vclusterconfig.BackingStoreSnapshots
+	Destination Destination
+	ServiceName string
+
+	NewEtcdClient func(ctx context.Context) (etcd.Client, error)
+	KubeClient    kubernetes.Interface
+	Recorder      record.EventRecorder
+
+	Now func() time.Time
+}
+
+// StartScheduler starts the scheduled snapshots of the vCluster. This should only be called by the leader.
+func StartScheduler(ctx *config.ControllerContext) error {
+	snapshots := ctx.Config.ControlPlane.BackingStore.Snapshots
+	destination, err := NewDestination(snapshots.Destination)
+	if err != nil {
+		return err
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(ctx.Config.ControlPlaneConfig)
+	if err != nil {
+		return fmt.Errorf("create kubernetes client: %w", err)
+	}
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(&clientv1.EventSinkImpl{Interface: kubeClient.CoreV1().Events(ctx.Config.ControlPlaneNamespace)})
+	go func() {
+		<-ctx.Done()
+		eventBroadcaster.Shutdown()
+	}()
+
+	scheduler := &Scheduler{
+		Options:     OptionsFromConfig(ctx.Config),
+		Snapshots:   snapshots,
+		Destination: destination,
+		ServiceName: ctx.Config.ControlPlaneService,
+		NewEtcdClient: func(etcdCtx context.Context) (etcd.Client, error) {
+			return etcd.NewFromConfig(etcdCtx, ctx.Config)
+		},
+		KubeClient: kubeClient,
+		Recorder:   eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "vcluster-snapshot"}),
+		Now:        time.Now,
+	}
+
+	return scheduler.Start(ctx)
+}
+
+// Start runs the scheduler in the background until the context is done
+func (s *Scheduler) Start(ctx context.Context) error {
+	logger := klog.FromContext(ctx).WithName("snapshot-scheduler")
+	cronScheduler := cron.New(cron.WithChain(cron.SkipIfStillRunning(cronLogger{logger: logger})))
+	_, err := cronScheduler.AddFunc(s.Snapshots.Schedule, func() {
+		_ = s.Run(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("parse snapshot schedule %q: %w", s.Snapshots.Schedule, err)
+	}
+
+	logger.Info("Start scheduled snapshots", "schedule", s.Snapshots.Schedule, "destination", s.Destination.String())
+	cronScheduler.Start()
+	go func() {
+		<-ctx.Done()
+		<-cronScheduler.Stop().Done()
+	}()
+
+	return nil
+}
+
+// Run creates a single snapshot, prunes old snapshots and reports the result
+func (s *Scheduler) Run(ctx context.Context) error {
+	logger := klog.FromContext(ctx).WithName("snapshot-scheduler")
+	now := s.Now()
+	name := SnapshotName(s.Options.Name, now)
+
+	err := s.create(ctx, name)
+	if err != nil {
+		logger.Error(err, "Error creating snapshot", "snapshot", name)
+		s.report(ctx, name, now, err)
+		return err
+	}
+
+	// prune old snapshots, this is not considered a failure of the snapshot itself
+	maxAge, _ := time.ParseDuration(s.Snapshots.Retention.MaxAge)
+	deleted, err := Prune(ctx, s.Destination, s.Options.Name, s.Snapshots.Retention.Count, maxAge, now)
+	if err != nil {
+		logger.Error(err, "Error pruning snapshots")
+		s.event(ctx, corev1.EventTypeWarning, "SnapshotPruneFailed", fmt.Sprintf("Error pruning snapshots in %s: %v", s.Destination.String(), err))
+	} else if len(deleted) > 0 {
+		logger.Info("Pruned snapshots", "snapshots", deleted)
+	}
+
+	logger.Info("Created snapshot", "snapshot", name, "destination", s.Destination.String())
+	s.report(ctx, name, now, nil)
+	return nil
+}
+
+func (s *Scheduler) create(ctx context.Context, name string) error {
+	etcdClient, err := s.NewEtcdClient(ctx)
+	if err != nil {
+		return fmt.Errorf("create etcd client: %w", err)
+	}
+	defer etcdClient.Close()
+
+	return s.Destination.Store(name).Write(ctx, func(w io.Writer) error {
+		return Create(ctx, s.Options, etcdClient, s.KubeClient, w)
+	})
+}
+
+// report records an event on the vCluster service and updates the status config map
+func (s *Scheduler) report(ctx context.Context, name string, now time.Time, snapshotErr error) {
+	if snapshotErr != nil {
+		s.event(ctx, corev1.EventTypeWarning, "SnapshotFailed", fmt.Sprintf("Error creating snapshot %s in %s: %v", name, s.Destination.String(), snapshotErr))
+	} else {
+		s.event(ctx, corev1.EventTypeNormal, "SnapshotCreated", fmt.Sprintf("Created snapshot %s in %s", name, s.Destination.String()))
+	}
+
+	err := s.updateStatus(ctx, name, now, snapshotErr)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "Error updating snapshot status", "configMap", StatusConfigMapName(s.Options.Name))
+	}
+}
+
+func (s *Scheduler) event(ctx context.Context, eventType, reason, message string) {
+	service, err := s.KubeClient.CoreV1().Services(s.Options.Namespace).Get(ctx, s.ServiceName, metav1.GetOptions{})
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "Error getting vCluster service to record snapshot event", "reason", reason)
+		return
+	}
+
+	s.Recorder.Event(service, eventType, reason, message)
+}
+
+func (s *Scheduler) updateStatus(ctx context.Context, name string, now time.Time, snapshotErr error) error {
+	configMaps := s.KubeClient.CoreV1().ConfigMaps(s.Options.Namespace)
+	configMap, err := configMaps.Get(ctx, StatusConfigMapName(s.Options.Name), metav1.GetOptions{})
+	exists := err == nil
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return err
+		}
+
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      StatusConfigMapName(s.Options.Name),
+				Namespace: s.Options.Namespace,
+				Labels: map[string]string{
+					"app":     "vcluster",
+					"release": s.Options.Name,
+				},
+			},
+		}
+	}
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+
+	configMap.Data[StatusLastSnapshot] = name
+	configMap.Data[StatusLastSnapshotTime] = now.UTC().Format(time.RFC3339)
+	if snapshotErr != nil {
+		failures, _ := strconv.Atoi(configMap.Data[StatusConsecutiveFailures])
+		configMap.Data[StatusLastSnapshotResult] = ResultFailed
+		configMap.Data[StatusLastSnapshotError] = strings.TrimSpace(snapshotErr.Error())
+		configMap.Data[StatusConsecutiveFailures] = strconv.Itoa(failures + 1)
+	} else {
+		configMap.Data[StatusLastSnapshotResult] = ResultSucceeded
+		configMap.Data[StatusLastSuccessfulSnapshot] = s.Destination.String() + "/" + name
+		configMap.Data[StatusLastSuccessfulSnapshotTime] = now.UTC().Format(time.RFC3339)
+		configMap.Data[StatusConsecutiveFailures] = "0"
+		delete(configMap.Data, StatusLastSnapshotError)
+	}
+
+	if !exists {
+		_, err = configMaps.Create(ctx, configMap, metav1.CreateOptions{})
+	} else {
+		_, err = configMaps.Update(ctx, configMap, metav1.UpdateOptions{})
+	}
+	return err
+}
+
+// cronLogger adapts logr to the cron logger interface
+type cronLogger struct {
+	logger logr.Logger
+}
+
+func (c cronLogger) Info(msg string, keysAndValues ...interface{}) {
+	c.logger.V(1).Info(msg, keysAndValues...)
+}
+
+func (c cronLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	c.logger.Error(err, msg, keysAndValues...)
+}
@@ -0,0 +1,101 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/etcd"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestSchedulerRun(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	destination, err := NewDestination("file://" + dir)
+	assert.NilError(t, err)
+
+	now := time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)
+	kubeClient := fake.NewSimpleClientset(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test-ns"},
+	})
+	recorder := record.NewFakeRecorder(10)
+	etcdErr := errors.New("connection refused")
+	var newEtcdClientErr error
+	scheduler := &Scheduler{
+		Options: Options{Name: "test", Namespace: "test-ns", Distro: "k8s"},
+		Snapshots: vclusterconfig.BackingStoreSnapshots{
+			Enabled:   true,
+			Schedule:  "@hourly",
+			Retention: vclusterconfig.BackingStoreSnapshotsRetention{Count: 2},
+		},
+		Destination: destination,
+		ServiceName: "test",
+		NewEtcdClient: func(context.Context) (etcd.Client, error) {
+			if newEtcdClientErr != nil {
+				return nil, newEtcdClientErr
+			}
+
+			return newFakeEtcdClient(map[string]string{"/registry/pods/default/a": "pod-a"}), nil
+		},
+		KubeClient: kubeClient,
+		Recorder:   recorder,
+		Now:        func() time.Time { return now },
+	}
+
+	// create three snapshots, the oldest one should be pruned
+	for i := 0; i < 3; i++ {
+		now = now.Add(time.Hour)
+		assert.NilError(t, scheduler.Run(ctx))
+		assert.Equal(t, <-recorder.Events, "Normal SnapshotCreated Created snapshot "+SnapshotName("test", now)+" in file://"+dir)
+	}
+	names, err := destination.List(ctx)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, names, []string{SnapshotName("test", now.Add(-time.Hour)), SnapshotName("test", now)})
+
+	// check the snapshot can be read
+	file, err := os.Open(filepath.Join(dir, SnapshotName("test", now)))
+	assert.NilError(t, err)
+	defer file.Close()
+	target := newFakeEtcdClient(nil)
+	assert.NilError(t, Restore(ctx, scheduler.Options, target, file))
+	assert.DeepEqual(t, target.data(), map[string]string{"/registry/pods/default/a": "pod-a"})
+
+	status, err := kubeClient.CoreV1().ConfigMaps("test-ns").Get(ctx, StatusConfigMapName("test"), metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, status.Data[StatusLastSnapshotResult], ResultSucceeded)
+	assert.Equal(t, status.Data[StatusLastSuccessfulSnapshot], "file://"+dir+"/"+SnapshotName("test", now))
+	assert.Equal(t, status.Data[StatusLastSuccessfulSnapshotTime], "2024-01-02T06:00:00Z")
+
+	// failed snapshots are reported and don't remove existing snapshots
+	newEtcdClientErr = etcdErr
+	for i := 0; i < 2; i++ {
+		now = now.Add(time.Hour)
+		err = scheduler.Run(ctx)
+		assert.ErrorIs(t, err, etcdErr)
+		assert.Equal(t, <-recorder.Events, "Warning SnapshotFailed Error creating snapshot "+SnapshotName("test", now)+" in file://"+dir+": create etcd client: connection refused")
+	}
+	names, err = destination.List(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, len(names), 2)
+
+	status, err = kubeClient.CoreV1().ConfigMaps("test-ns").Get(ctx, StatusConfigMapName("test"), metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, status.Data, map[string]string{
+		StatusLastSnapshot:               SnapshotName("test", now),
+		StatusLastSnapshotTime:           "2024-01-02T08:00:00Z",
+		StatusLastSnapshotResult:         ResultFailed,
+		StatusLastSnapshotError:          "create etcd client: connection refused",
+		StatusLastSuccessfulSnapshot:     "file://" + dir + "/" + SnapshotName("test", now.Add(-2*time.Hour)),
+		StatusLastSuccessfulSnapshotTime: "2024-01-02T06:00:00Z",
+		StatusConsecutiveFailures:        "2",
+	})
+}
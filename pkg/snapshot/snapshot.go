@@ -0,0 +1,241 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/loft-sh/vcluster/pkg/config"
+	"github.com/loft-sh/vcluster/pkg/etcd"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// keyPrefix is the prefix of all backing store keys that are part of a snapshot
+const keyPrefix = "/"
+
+// Options identify the virtual cluster to snapshot or restore
+type Options struct {
+	Name         string
+	Namespace    string
+	Distro       string
+	BackingStore string
+}
+
+// OptionsFromConfig returns the snapshot options for the given vCluster config
+func OptionsFromConfig(vConfig *config.VirtualClusterConfig) Options {
+	return Options{
+		Name:         vConfig.Name,
+		Namespace:    vConfig.ControlPlaneNamespace,
+		Distro:       vConfig.Distro(),
+		BackingStore: string(vConfig.BackingStoreType()),
+	}
+}
+
+// SecretName returns the name of the vCluster secret of the given type
+func SecretName(secretType SecretType, vClusterName string) string {
+	if secretType == SecretTypeCertificates {
+		return vClusterName + "-certs"
+	}
+
+	return "vc-config-" + vClusterName
+}
+
+// Create writes a snapshot of the vCluster secrets and all backing store keys to w
+func Create(ctx context.Context, options Options, etcdClient etcd.Client, kubeClient kubernetes.Interface, w io.Writer) error {
+	writer := NewWriter(w)
+	err := writer.Write(&Record{Metadata: &Metadata{
+		Name:         options.Name,
+		Namespace:    options.Namespace,
+		Distro:       options.Distro,
+		BackingStore: options.BackingStore,
+		Created:      time.Now().UTC(),
+	}})
+	if err != nil {
+		return err
+	}
+
+	// save the vCluster secrets
+	for _, secretType := range []SecretType{SecretTypeConfig, SecretTypeCertificates} {
+		secret, err := kubeClient.CoreV1().Secrets(options.Namespace).Get(ctx, SecretName(secretType, options.Name), metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				klog.FromContext(ctx).Info("Skip vCluster secret as it doesn't exist", "secret", SecretName(secretType, options.Name))
+				continue
+			}
+
+			return fmt.Errorf("get secret %s: %w", SecretName(secretType, options.Name), err)
+		}
+
+		err = writer.Write(&Record{Secret: &Secret{
+			Type:   secretType,
+			Secret: cleanSecret(secret),
+		}})
+		if err != nil {
+			return err
+		}
+	}
+
+	// save the backing store keys
+	values, err := etcdClient.List(ctx, keyPrefix, 0)
+	if err != nil {
+		return fmt.Errorf("list backing store keys: %w", err)
+	}
+	for _, value := range values {
+		err = writer.Write(&Record{KeyValue: &KeyValue{
+			Key:   string(value.Key),
+			Value: value.Data,
+		}})
+		if err != nil {
+			return err
+		}
+	}
+
+	klog.FromContext(ctx).Info("Created snapshot", "keys", len(values))
+	return writer.Close()
+}
+
+// Restore replaces all keys in the backing store with the keys of the snapshot. Secrets
+// within the snapshot are skipped, use RestoreSecrets to restore them.
+func Restore(ctx context.Context, options Options, etcdClient etcd.Client, r io.Reader) error {
+	reader, err := NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	// check the metadata first
+	record, err := reader.Next()
+	if err != nil {
+		return err
+	} else if record.Metadata == nil {
+		return fmt.Errorf("snapshot is missing metadata")
+	} else if record.Metadata.Distro != options.Distro {
+		return fmt.Errorf("cannot restore snapshot of distro %s into vCluster with distro %s", record.Metadata.Distro, options.Distro)
+	}
+
+	// delete the existing keys
+	existing, err := etcdClient.List(ctx, keyPrefix, 0)
+	if err != nil {
+		return fmt.Errorf("list backing store keys: %w", err)
+	}
+	for _, value := range existing {
+		err = etcdClient.Delete(ctx, string(value.Key), value.Revision)
+		if err != nil {
+			return fmt.Errorf("delete key %s: %w", string(value.Key), err)
+		}
+	}
+
+	// restore the keys from the snapshot
+	restored := 0
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return err
+		} else if record.KeyValue == nil {
+			continue
+		}
+
+		err = etcdClient.Create(ctx, record.KeyValue.Key, record.KeyValue.Value)
+		if err != nil {
+			return fmt.Errorf("restore key %s: %w", record.KeyValue.Key, err)
+		}
+		restored++
+	}
+
+	klog.FromContext(ctx).Info("Restored snapshot", "deletedKeys", len(existing), "restoredKeys", restored)
+	return nil
+}
+
+// RestoreSecrets restores the vCluster secrets from the snapshot into the given vCluster and returns the snapshot metadata
+func RestoreSecrets(ctx context.Context, vClusterName, vClusterNamespace string, kubeClient kubernetes.Interface, r io.Reader) (*Metadata, error) {
+	reader, err := NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var metadata *Metadata
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		} else if record.Metadata != nil {
+			metadata = record.Metadata
+			continue
+		} else if record.KeyValue != nil {
+			// secrets are always written before the keys
+			break
+		} else if record.Secret == nil {
+			continue
+		}
+
+		err = restoreSecret(ctx, kubeClient, record.Secret, vClusterName, vClusterNamespace)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if metadata == nil {
+		return nil, fmt.Errorf("snapshot is missing metadata")
+	}
+
+	return metadata, nil
+}
+
+func restoreSecret(ctx context.Context, kubeClient kubernetes.Interface, snapshotSecret *Secret, vClusterName, vClusterNamespace string) error {
+	secret := snapshotSecret.Secret.DeepCopy()
+	secret.Name = SecretName(snapshotSecret.Type, vClusterName)
+	secret.Namespace = vClusterNamespace
+
+	existing, err := kubeClient.CoreV1().Secrets(vClusterNamespace).Get(ctx, secret.Name, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return fmt.Errorf("get secret %s: %w", secret.Name, err)
+		}
+
+		_, err = kubeClient.CoreV1().Secrets(vClusterNamespace).Create(ctx, secret, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("create secret %s: %w", secret.Name, err)
+		}
+
+		return nil
+	}
+
+	// keep the ownership of the existing secret, e.g. the helm release
+	existing.Data = secret.Data
+	existing.Type = secret.Type
+	if existing.Annotations == nil {
+		existing.Annotations = map[string]string{}
+	}
+	for k, v := range secret.Annotations {
+		existing.Annotations[k] = v
+	}
+	_, err = kubeClient.CoreV1().Secrets(vClusterNamespace).Update(ctx, existing, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("update secret %s: %w", secret.Name, err)
+	}
+
+	return nil
+}
+
+// cleanSecret removes all fields of the secret that are set by the api server
+func cleanSecret(secret *corev1.Secret) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secret.Name,
+			Labels:      secret.Labels,
+			Annotations: secret.Annotations,
+		},
+		Type: secret.Type,
+		Data: secret.Data,
+	}
+}
@@ -0,0 +1,316 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/loft-sh/vcluster/pkg/etcd"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeEtcdClient struct {
+	revision int64
+	values   map[string]etcd.Value
+}
+
+func newFakeEtcdClient(values map[string]string) *fakeEtcdClient {
+	client := &fakeEtcdClient{values: map[string]etcd.Value{}}
+	for k, v := range values {
+		_ = client.Create(context.Background(), k, []byte(v))
+	}
+
+	return client
+}
+
+func (f *fakeEtcdClient) List(_ context.Context, key string, _ int) ([]etcd.Value, error) {
+	values := []etcd.Value{}
+	for k, v := range f.values {
+		if strings.HasPrefix(k, key) {
+			values = append(values, v)
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return string(values[i].Key) < string(values[j].Key) })
+	return values, nil
+}
+
+func (f *fakeEtcdClient) Get(_ context.Context, key string) (etcd.Value, error) {
+	value, ok := f.values[key]
+	if !ok {
+		return etcd.Value{}, etcd.ErrNotFound
+	}
+
+	return value, nil
+}
+
+func (f *fakeEtcdClient) Put(_ context.Context, key string, value []byte) error {
+	f.revision++
+	f.values[key] = etcd.Value{Key: []byte(key), Data: value, Revision: f.revision}
+	return nil
+}
+
+func (f *fakeEtcdClient) Create(ctx context.Context, key string, value []byte) error {
+	return f.Put(ctx, key, value)
+}
+
+func (f *fakeEtcdClient) Update(ctx context.Context, key string, _ int64, value []byte) error {
+	return f.Put(ctx, key, value)
+}
+
+func (f *fakeEtcdClient) Delete(_ context.Context, key string, _ int64) error {
+	delete(f.values, key)
+	return nil
+}
+
+func (f *fakeEtcdClient) Compact(_ context.Context, revision int64) (int64, error) {
+	return revision, nil
+}
+
+func (f *fakeEtcdClient) Close() error {
+	return nil
+}
+
+func (f *fakeEtcdClient) data() map[string]string {
+	data := map[string]string{}
+	for k, v := range f.values {
+		data[k] = string(v.Data)
+	}
+
+	return data
+}
+
+func TestCreateAndRestore(t *testing.T) {
+	ctx := context.Background()
+	options := Options{Name: "source", Namespace: "source-ns", Distro: "k8s", BackingStore: "database"}
+	kubeClient := fake.NewSimpleClientset(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "vc-config-source", Namespace: "source-ns", ResourceVersion: "12"},
+			Data:       map[string][]byte{"config.yaml": []byte("sync: {}")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "source-certs", Namespace: "source-ns"},
+			Data:       map[string][]byte{"ca.crt": []byte("ca")},
+		},
+	)
+	source := newFakeEtcdClient(map[string]string{
+		"/registry/pods/default/a":     "pod-a",
+		"/registry/services/default/b": "service-b",
+	})
+
+	// create the snapshot
+	buffer := &bytes.Buffer{}
+	err := Create(ctx, options, source, kubeClient, buffer)
+	assert.NilError(t, err)
+
+	// check the archive contents
+	reader, err := NewReader(bytes.NewReader(buffer.Bytes()))
+	assert.NilError(t, err)
+	records := []*Record{}
+	for {
+		record, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NilError(t, err)
+		records = append(records, record)
+	}
+	assert.Equal(t, len(records), 5)
+	assert.Equal(t, records[0].Metadata.Name, "source")
+	assert.Equal(t, records[1].Secret.Type, SecretTypeConfig)
+	assert.Equal(t, records[1].Secret.Secret.ResourceVersion, "")
+	assert.Equal(t, records[2].Secret.Type, SecretTypeCertificates)
+	assert.Equal(t, records[3].KeyValue.Key, "/registry/pods/default/a")
+
+	// restore into a backing store with different keys
+	target := newFakeEtcdClient(map[string]string{
+		"/registry/pods/default/a": "old-pod-a",
+		"/registry/pods/default/c": "pod-c",
+	})
+	err = Restore(ctx, options, target, bytes.NewReader(buffer.Bytes()))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, target.data(), source.data())
+
+	// restoring into another distro is not allowed
+	err = Restore(ctx, Options{Distro: "k3s"}, target, bytes.NewReader(buffer.Bytes()))
+	assert.ErrorContains(t, err, "cannot restore snapshot of distro k8s into vCluster with distro k3s")
+}
+
+func TestRestoreSecrets(t *testing.T) {
+	ctx := context.Background()
+	buffer := &bytes.Buffer{}
+	writer := NewWriter(buffer)
+	assert.NilError(t, writer.Write(&Record{Metadata: &Metadata{Name: "source", Namespace: "source-ns", Distro: "k8s"}}))
+	assert.NilError(t, writer.Write(&Record{Secret: &Secret{Type: SecretTypeConfig, Secret: &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vc-config-source", Annotations: map[string]string{"restored": "true"}},
+		Data:       map[string][]byte{"config.yaml": []byte("new")},
+	}}}))
+	assert.NilError(t, writer.Write(&Record{Secret: &Secret{Type: SecretTypeCertificates, Secret: &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "source-certs"},
+		Data:       map[string][]byte{"ca.crt": []byte("ca")},
+	}}}))
+	assert.NilError(t, writer.Write(&Record{KeyValue: &KeyValue{Key: "/registry/a", Value: []byte("a")}}))
+	assert.NilError(t, writer.Close())
+
+	kubeClient := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "vc-config-target",
+			Namespace:   "target-ns",
+			Labels:      map[string]string{"app.kubernetes.io/managed-by": "Helm"},
+			Annotations: map[string]string{"existing": "true"},
+		},
+		Data: map[string][]byte{"config.yaml": []byte("old")},
+	})
+	metadata, err := RestoreSecrets(ctx, "target", "target-ns", kubeClient, buffer)
+	assert.NilError(t, err)
+	assert.Equal(t, metadata.Name, "source")
+
+	// existing secrets are updated and keep their labels
+	configSecret, err := kubeClient.CoreV1().Secrets("target-ns").Get(ctx, "vc-config-target", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, string(configSecret.Data["config.yaml"]), "new")
+	assert.Equal(t, configSecret.Labels["app.kubernetes.io/managed-by"], "Helm")
+	assert.DeepEqual(t, configSecret.Annotations, map[string]string{"existing": "true", "restored": "true"})
+
+	// missing secrets are created with the name of the target vCluster
+	certsSecret, err := kubeClient.CoreV1().Secrets("target-ns").Get(ctx, "target-certs", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, string(certsSecret.Data["ca.crt"]), "ca")
+}
+
+func TestNewStore(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "access")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	testCases := []struct {
+		Name          string
+		URL           string
+		ExpectedStore string
+		ExpectedError string
+	}{
+		{
+			Name:          "file",
+			URL:           "file://./snapshots/test.gz",
+			ExpectedStore: "file://./snapshots/test.gz",
+		},
+		{
+			Name:          "s3",
+			URL:           "s3://bucket/path/test.gz?region=eu-west-1",
+			ExpectedStore: "s3://bucket/path/test.gz",
+		},
+		{
+			Name:          "missing scheme",
+			URL:           "./test.gz",
+			ExpectedError: "expected file://path or s3://bucket/key",
+		},
+		{
+			Name:          "unsupported scheme",
+			URL:           "gs://bucket/test.gz",
+			ExpectedError: "unsupported snapshot location",
+		},
+		{
+			Name:          "s3 without key",
+			URL:           "s3://bucket",
+			ExpectedError: "expected s3://bucket/key",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			store, err := NewStore(testCase.URL)
+			if testCase.ExpectedError != "" {
+				assert.ErrorContains(t, err, testCase.ExpectedError)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, store.String(), testCase.ExpectedStore)
+		})
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewStore("file://" + filepath.Join(t.TempDir(), "snapshots", "test.gz"))
+	assert.NilError(t, err)
+
+	// failed writes don't leave a file behind
+	err = store.Write(ctx, func(w io.Writer) error {
+		_, _ = w.Write([]byte("partial"))
+		return io.ErrUnexpectedEOF
+	})
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	_, err = store.Reader(ctx)
+	assert.ErrorContains(t, err, "open snapshot file")
+
+	err = store.Write(ctx, func(w io.Writer) error {
+		_, err := w.Write([]byte("snapshot"))
+		return err
+	})
+	assert.NilError(t, err)
+
+	reader, err := store.Reader(ctx)
+	assert.NilError(t, err)
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "snapshot")
+}
+
+func TestS3Store(t *testing.T) {
+	ctx := context.Background()
+	objects := map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/20240102/eu-west-1/s3/aws4_request, SignedHeaders=") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPut:
+			data, _ := io.ReadAll(r.Body)
+			objects[r.URL.Path] = data
+		case http.MethodGet:
+			data, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte("NoSuchKey"))
+				return
+			}
+			_, _ = w.Write(data)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "access")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	store, err := NewStore("s3://bucket/snapshots/test.gz?region=eu-west-1&endpoint=" + server.URL)
+	assert.NilError(t, err)
+	store.(*s3Store).now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	_, err = store.Reader(ctx)
+	assert.ErrorContains(t, err, "unexpected status code 404: NoSuchKey")
+
+	err = store.Write(ctx, func(w io.Writer) error {
+		_, err := w.Write([]byte("snapshot"))
+		return err
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, string(objects["/bucket/snapshots/test.gz"]), "snapshot")
+
+	reader, err := store.Reader(ctx)
+	assert.NilError(t, err)
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "snapshot")
+}
@@ -0,0 +1,107 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Store is a location a snapshot can be written to and read from
+type Store interface {
+	// Write calls write with a writer for the snapshot. The snapshot is only stored if write returns no error.
+	Write(ctx context.Context, write func(w io.Writer) error) error
+
+	// Reader returns a reader for the snapshot
+	Reader(ctx context.Context) (io.ReadCloser, error)
+
+	// String returns a human-readable representation of the location
+	String() string
+}
+
+// NewStore parses the given url and returns the corresponding store. Supported are
+// file://path/to/snapshot.tar.gz and s3://bucket/key urls.
+func NewStore(rawURL string) (Store, error) {
+	if !strings.Contains(rawURL, "://") {
+		return nil, fmt.Errorf("invalid snapshot location %q, expected file://path or s3://bucket/key", rawURL)
+	}
+
+	scheme, rest, _ := strings.Cut(rawURL, "://")
+	switch scheme {
+	case "file":
+		if rest == "" {
+			return nil, fmt.Errorf("invalid snapshot location %q, path is missing", rawURL)
+		}
+
+		return &fileStore{path: rest}, nil
+	case "s3":
+		parsedURL, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, fmt.Errorf("parse snapshot location: %w", err)
+		}
+
+		return newS3Store(parsedURL)
+	}
+
+	return nil, fmt.Errorf("unsupported snapshot location %q, expected file://path or s3://bucket/key", rawURL)
+}
+
+type fileStore struct {
+	path string
+}
+
+func (f *fileStore) Write(_ context.Context, write func(w io.Writer) error) error {
+	err := os.MkdirAll(filepath.Dir(f.path), 0755)
+	if err != nil {
+		return fmt.Errorf("create snapshot directory: %w", err)
+	}
+
+	// write to a temporary file first, so that we never leave a partial snapshot behind
+	file, err := writeTempFile(filepath.Dir(f.path), write)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(file, f.path)
+	if err != nil {
+		_ = os.Remove(file)
+		return fmt.Errorf("move snapshot file: %w", err)
+	}
+
+	return nil
+}
+
+func (f *fileStore) Reader(_ context.Context) (io.ReadCloser, error) {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("open snapshot file: %w", err)
+	}
+
+	return file, nil
+}
+
+func (f *fileStore) String() string {
+	return "file://" + f.path
+}
+
+// writeTempFile calls write with a temporary file in dir and returns its path. The file is removed if write fails.
+func writeTempFile(dir string, write func(w io.Writer) error) (string, error) {
+	file, err := os.CreateTemp(dir, ".vcluster-snapshot-*")
+	if err != nil {
+		return "", fmt.Errorf("create snapshot file: %w", err)
+	}
+
+	err = write(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return "", err
+	}
+
+	return file.Name(), nil
+}
@@ -12,8 +12,10 @@ import (
 
 	authenticationv1 "k8s.io/api/authentication/v1"
 	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -98,6 +100,27 @@ func GetByIndex(ctx context.Context, c client.Client, obj runtime.Object, index,
 	return nil
 }
 
+// EnsureNamespace creates the given namespace if it does not exist yet
+func EnsureNamespace(ctx context.Context, c client.Client, name string) error {
+	err := c.Get(ctx, client.ObjectKey{Name: name}, &corev1.Namespace{})
+	if err == nil {
+		return nil
+	} else if !kerrors.IsNotFound(err) {
+		return err
+	}
+
+	err = c.Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	})
+	if err != nil && !kerrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	return nil
+}
+
 func GVKFrom(obj runtime.Object, scheme *runtime.Scheme) (schema.GroupVersionKind, error) {
 	gvks, _, err := scheme.ObjectKinds(obj)
 	if err != nil {
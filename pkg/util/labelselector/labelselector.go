@@ -0,0 +1,21 @@
+package labelselector
+
+import (
+	"github.com/loft-sh/vcluster/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// FromConfig converts the label selector and match expressions of the given sync.toHost selector into a labels.Selector
+func FromConfig(selector config.SyncToHostSelector) (labels.Selector, error) {
+	labelSelector := &metav1.LabelSelector{MatchLabels: selector.LabelSelector}
+	for _, requirement := range selector.MatchExpressions {
+		labelSelector.MatchExpressions = append(labelSelector.MatchExpressions, metav1.LabelSelectorRequirement{
+			Key:      requirement.Key,
+			Operator: metav1.LabelSelectorOperator(requirement.Operator),
+			Values:   requirement.Values,
+		})
+	}
+
+	return metav1.LabelSelectorAsSelector(labelSelector)
+}
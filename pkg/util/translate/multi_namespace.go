@@ -9,10 +9,13 @@ import (
 	"strings"
 
 	"github.com/loft-sh/vcluster/pkg/scheme"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
@@ -20,13 +23,36 @@ import (
 var _ Translator = &multiNamespace{}
 
 func NewMultiNamespaceTranslator(currentNamespace string) Translator {
+	return NewMultiNamespaceTranslatorWithNamespaceTemplate(currentNamespace, nil)
+}
+
+// NewMultiNamespaceTranslatorWithNamespaceTemplate creates a new multi namespace translator that uses the given template
+// to translate the names of virtual namespaces. If the template is nil, host namespaces are named vcluster-<hash>-<hash>.
+func NewMultiNamespaceTranslatorWithNamespaceTemplate(currentNamespace string, namespaceTemplate *NamespaceTemplate) Translator {
 	return &multiNamespace{
-		currentNamespace: currentNamespace,
+		currentNamespace:  currentNamespace,
+		namespaceTemplate: namespaceTemplate,
 	}
 }
 
 type multiNamespace struct {
-	currentNamespace string
+	currentNamespace  string
+	namespaceTemplate *NamespaceTemplate
+
+	// hostNamespaces is used to look up the marker label of host namespaces that match the namespace template
+	hostNamespaces client.Reader
+}
+
+// HostNamespaceReaderSetter is implemented by translators that look up host namespaces to check if they
+// belong to the vCluster
+type HostNamespaceReaderSetter interface {
+	SetHostNamespaceReader(reader client.Reader)
+}
+
+// SetHostNamespaceReader sets the reader, usually the host cache, that is used to check the marker label of host
+// namespaces that match the namespace name template. Without a reader, only the name of the namespace is checked.
+func (s *multiNamespace) SetHostNamespaceReader(reader client.Reader) {
+	s.hostNamespaces = reader
 }
 
 func (s *multiNamespace) SingleNamespaceTarget() bool {
@@ -73,9 +99,33 @@ func (s *multiNamespace) IsManaged(_ context.Context, pObj client.Object) bool {
 }
 
 func (s *multiNamespace) IsTargetedNamespace(ns string) bool {
+	if s.namespaceTemplate != nil {
+		return s.namespaceTemplate.IsTargetedNamespace(ns) && s.hasMarkerLabel(ns)
+	}
+
 	return strings.HasPrefix(ns, s.getNamespacePrefix()) && strings.HasSuffix(ns, getNamespaceSuffix(s.currentNamespace, VClusterName))
 }
 
+// hasMarkerLabel checks if the host namespace carries the marker label of this vCluster, as the host namespaces
+// of a namespace name template can't be told apart from the ones of another vCluster with an overlapping template
+func (s *multiNamespace) hasMarkerLabel(ns string) bool {
+	if s.hostNamespaces == nil {
+		return true
+	}
+
+	namespace := &corev1.Namespace{}
+	err := s.hostNamespaces.Get(context.TODO(), types.NamespacedName{Name: ns}, namespace)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			klog.Errorf("Error getting host namespace %s: %v", ns, err)
+		}
+
+		return false
+	}
+
+	return namespace.Labels[MarkerLabel] == SafeConcatName(s.currentNamespace, "x", VClusterName)
+}
+
 func (s *multiNamespace) convertLabelKey(key string) string {
 	digest := sha256.Sum256([]byte(key))
 	return SafeConcatName(LabelPrefix, s.currentNamespace, "x", VClusterName, "x", hex.EncodeToString(digest[0:])[0:10])
@@ -86,6 +136,10 @@ func (s *multiNamespace) getNamespacePrefix() string {
 }
 
 func (s *multiNamespace) PhysicalNamespace(vNamespace string) string {
+	if s.namespaceTemplate != nil {
+		return s.namespaceTemplate.PhysicalNamespace(vNamespace)
+	}
+
 	return PhysicalNamespace(s.currentNamespace, vNamespace, s.getNamespacePrefix(), VClusterName)
 }
 
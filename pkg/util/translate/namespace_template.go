@@ -0,0 +1,112 @@
+package translate
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// namespaceNamePlaceholder is rendered in place of the virtual namespace name to split the template into
+// a prefix and suffix. It is upper case, so it can't be produced by any of the other template fields.
+const namespaceNamePlaceholder = "VCLUSTER_NAMESPACE_NAME"
+
+// vClusterNamePlaceholder is rendered in place of the vCluster name to check that the template uses it
+const vClusterNamePlaceholder = "VCLUSTERNAME"
+
+// NamespaceTemplate translates virtual namespaces into host namespaces by a go template. As the template
+// uses the virtual namespace name exactly once, host namespaces of the vCluster are recognized by the
+// fixed prefix and suffix around it. Templates of different vClusters can still overlap, e.g. "a-" and
+// "a-b-", so the translator additionally checks the marker label of the host namespace.
+type NamespaceTemplate struct {
+	prefix string
+	suffix string
+}
+
+type namespaceTemplateData struct {
+	Name              string
+	VClusterName      string
+	VClusterNamespace string
+}
+
+// NewNamespaceTemplate parses and validates the given host namespace name template
+func NewNamespaceTemplate(nameTemplate, vClusterName, vClusterNamespace string) (*NamespaceTemplate, error) {
+	t, err := template.New("namespace").Option("missingkey=error").Parse(nameTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("parse namespace name template: %w", err)
+	}
+
+	buf := &bytes.Buffer{}
+	err = t.Execute(buf, namespaceTemplateData{
+		Name:              namespaceNamePlaceholder,
+		VClusterName:      vClusterName,
+		VClusterNamespace: vClusterNamespace,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("execute namespace name template: %w", err)
+	}
+
+	parts := strings.Split(strings.TrimSpace(buf.String()), namespaceNamePlaceholder)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("namespace name template must use .Name exactly once")
+	}
+
+	namespaceTemplate := &NamespaceTemplate{
+		prefix: strings.ToLower(parts[0]),
+		suffix: strings.ToLower(parts[1]),
+	}
+	if namespaceTemplate.prefix == "" && namespaceTemplate.suffix == "" {
+		return nil, fmt.Errorf("namespace name template needs a prefix or suffix around .Name to distinguish the host namespaces of the vCluster")
+	}
+
+	// the host namespaces of different vClusters need to be distinguishable by their name
+	buf.Reset()
+	err = t.Execute(buf, namespaceTemplateData{
+		Name:              namespaceNamePlaceholder,
+		VClusterName:      vClusterNamePlaceholder,
+		VClusterNamespace: vClusterNamespace,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("execute namespace name template: %w", err)
+	} else if !strings.Contains(buf.String(), vClusterNamePlaceholder) {
+		return nil, fmt.Errorf("namespace name template must use .VClusterName to distinguish the host namespaces of different vClusters")
+	}
+
+	// check the shortest and the longest possible host namespace
+	for _, vNamespace := range []string{"a", strings.Repeat("a", validation.DNS1123LabelMaxLength)} {
+		pNamespace := namespaceTemplate.PhysicalNamespace(vNamespace)
+		if errs := validation.IsDNS1123Label(pNamespace); len(errs) > 0 {
+			return nil, fmt.Errorf("namespace name template produces invalid namespace %q: %s", pNamespace, strings.Join(errs, ", "))
+		}
+	}
+
+	return namespaceTemplate, nil
+}
+
+// PhysicalNamespace returns the host namespace for the given virtual namespace. If the name is too long,
+// the virtual namespace part is shortened and a hash is appended, so the prefix and suffix are kept.
+func (n *NamespaceTemplate) PhysicalNamespace(vNamespace string) string {
+	name := n.prefix + vNamespace + n.suffix
+	if len(name) <= validation.DNS1123LabelMaxLength {
+		return name
+	}
+
+	digest := sha256.Sum256([]byte(vNamespace))
+	hash := hex.EncodeToString(digest[0:])[0:8]
+	maxLength := validation.DNS1123LabelMaxLength - len(n.prefix) - len(n.suffix) - len(hash) - 1
+	if maxLength < 0 {
+		maxLength = 0
+	}
+
+	return n.prefix + strings.TrimSuffix(vNamespace[0:maxLength], "-") + "-" + hash + n.suffix
+}
+
+// IsTargetedNamespace checks if the host namespace name was created by the template. As templates of different
+// vClusters can overlap, this only checks the name.
+func (n *NamespaceTemplate) IsTargetedNamespace(ns string) bool {
+	return len(ns) > len(n.prefix)+len(n.suffix) && strings.HasPrefix(ns, n.prefix) && strings.HasSuffix(ns, n.suffix)
+}
@@ -0,0 +1,69 @@
+package translate
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNamespaceTemplate(t *testing.T) {
+	namespaceTemplate, err := NewNamespaceTemplate("{{ .VClusterName }}-{{ .Name }}", "my-vcluster", "vcluster-my-vcluster")
+	assert.NilError(t, err)
+
+	assert.Equal(t, namespaceTemplate.PhysicalNamespace("team-a"), "my-vcluster-team-a")
+	assert.Assert(t, namespaceTemplate.IsTargetedNamespace("my-vcluster-team-a"))
+	assert.Assert(t, !namespaceTemplate.IsTargetedNamespace("other-team-a"))
+	assert.Assert(t, !namespaceTemplate.IsTargetedNamespace("my-vcluster-"))
+
+	// long names are shortened and keep the prefix
+	longNamespace := namespaceTemplate.PhysicalNamespace(strings.Repeat("a", 63))
+	assert.Equal(t, len(longNamespace), 63)
+	assert.Assert(t, namespaceTemplate.IsTargetedNamespace(longNamespace))
+	assert.Assert(t, longNamespace != namespaceTemplate.PhysicalNamespace(strings.Repeat("a", 62)+"b"))
+
+	namespaceTemplate, err = NewNamespaceTemplate("{{ .Name }}-{{ .VClusterName }}-{{ .VClusterNamespace }}", "my-vcluster", "Team")
+	assert.NilError(t, err)
+	assert.Equal(t, namespaceTemplate.PhysicalNamespace("default"), "default-my-vcluster-team")
+
+	_, err = NewNamespaceTemplate("{{ .Name }}-{{ .VClusterNamespace }}", "my-vcluster", "team")
+	assert.Error(t, err, "namespace name template must use .VClusterName to distinguish the host namespaces of different vClusters")
+
+	_, err = NewNamespaceTemplate("{{ .Name }}-{{ .Name }}", "my-vcluster", "vcluster-my-vcluster")
+	assert.Error(t, err, "namespace name template must use .Name exactly once")
+
+	_, err = NewNamespaceTemplate("{{ .Name }}_{{ .VClusterName }}", "my-vcluster", "vcluster-my-vcluster")
+	assert.ErrorContains(t, err, `namespace name template produces invalid namespace "a_my-vcluster"`)
+}
+
+func TestMultiNamespaceTemplateMarkerLabel(t *testing.T) {
+	defer func(vClusterName string) {
+		VClusterName = vClusterName
+	}(VClusterName)
+	VClusterName = "a"
+
+	// the template of vCluster a overlaps with the one of vCluster a-b
+	namespaceTemplate, err := NewNamespaceTemplate("{{ .VClusterName }}-{{ .Name }}", "a", "test")
+	assert.NilError(t, err)
+	hostNamespaces := fake.NewClientBuilder().WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "a-team", Labels: map[string]string{MarkerLabel: SafeConcatName("test", "x", "a")}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "a-b-team", Labels: map[string]string{MarkerLabel: SafeConcatName("test", "x", "a-b")}}},
+	).Build()
+
+	translator := NewMultiNamespaceTranslatorWithNamespaceTemplate("test", namespaceTemplate)
+	assert.Assert(t, translator.IsTargetedNamespace("a-b-team"))
+
+	translator.(HostNamespaceReaderSetter).SetHostNamespaceReader(hostNamespaces)
+	assert.Assert(t, translator.IsTargetedNamespace("a-team"))
+	assert.Assert(t, !translator.IsTargetedNamespace("a-b-team"))
+	assert.Assert(t, !translator.IsTargetedNamespace("a-missing"))
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "a-b-team", Annotations: map[string]string{NameAnnotation: "pod"}}}
+	assert.Assert(t, !translator.IsManaged(context.Background(), pod))
+	pod.Namespace = "a-team"
+	assert.Assert(t, translator.IsManaged(context.Background(), pod))
+}
@@ -0,0 +1,178 @@
+package translate
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/loft-sh/vcluster/config"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// NamingPolicy translates the name of a namespaced virtual object into the name of the host object
+type NamingPolicy interface {
+	// PhysicalName returns the host name for the given virtual name and namespace
+	PhysicalName(vName, vNamespace string) string
+}
+
+// NewNamingPolicy creates the naming policy for the given config
+func NewNamingPolicy(naming config.ExperimentalSyncSettingsNaming) (NamingPolicy, error) {
+	switch naming.Policy {
+	case "", config.NamingPolicyHashed:
+		return &hashedNamingPolicy{}, nil
+	case config.NamingPolicyPrefix:
+		return &prefixNamingPolicy{}, nil
+	case config.NamingPolicyTemplate:
+		return NewTemplateNamingPolicy(naming.Template)
+	}
+
+	return nil, fmt.Errorf("unsupported naming policy %q, must be one of %s, %s or %s", naming.Policy, config.NamingPolicyHashed, config.NamingPolicyPrefix, config.NamingPolicyTemplate)
+}
+
+// hashedNamingPolicy translates names into <name>-x-<namespace>-x-<vcluster>
+type hashedNamingPolicy struct{}
+
+func (h *hashedNamingPolicy) PhysicalName(vName, vNamespace string) string {
+	return SingleNamespacePhysicalName(vName, vNamespace, VClusterName)
+}
+
+// prefixNamingPolicy translates names into <vcluster>-<name>. Objects with the same name
+// in different virtual namespaces collide, which is detected by the syncer.
+type prefixNamingPolicy struct{}
+
+func (p *prefixNamingPolicy) PhysicalName(vName, _ string) string {
+	return SafeConcatName(VClusterName, vName)
+}
+
+// NewTemplateNamingPolicy parses the given go template and returns a naming policy that uses it
+func NewTemplateNamingPolicy(nameTemplate string) (NamingPolicy, error) {
+	if nameTemplate == "" {
+		return nil, fmt.Errorf("template is required for naming policy %s", config.NamingPolicyTemplate)
+	}
+
+	t, err := template.New("naming").Option("missingkey=zero").Parse(nameTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("parse naming template: %w", err)
+	}
+
+	err = validateTemplateFields(t.Tree.Root)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &templateNamingPolicy{template: t}
+	testName, err := policy.execute("name", "namespace")
+	if err != nil {
+		return nil, err
+	} else if errs := validation.IsDNS1123Subdomain(testName); len(errs) > 0 {
+		return nil, fmt.Errorf("naming template produces invalid name %q: %s", testName, strings.Join(errs, ", "))
+	}
+
+	return policy, nil
+}
+
+type templateNamingPolicy struct {
+	template *template.Template
+}
+
+// templateNamingData only holds fields that cannot change during the lifetime of a virtual object,
+// as a changed host name would orphan the previously synced host object. This is also why labels
+// and annotations are not available.
+type templateNamingData struct {
+	Name         string
+	Namespace    string
+	VClusterName string
+}
+
+// templateNamingFields are the fields of templateNamingData
+var templateNamingFields = map[string]bool{"Name": true, "Namespace": true, "VClusterName": true}
+
+// validateTemplateFields checks that the template only uses the fields of templateNamingData. Fields within
+// branches that are not executed by the test name are otherwise only noticed when a virtual object uses them.
+func validateTemplateFields(node parse.Node) error {
+	switch n := node.(type) {
+	case *parse.FieldNode:
+		if !templateNamingFields[n.Ident[0]] {
+			return fmt.Errorf("naming template uses unsupported field .%s, only .Name, .Namespace and .VClusterName are supported. Labels are not supported, as they can change after an object was synced", n.Ident[0])
+		}
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := validateTemplateFields(child); err != nil {
+				return err
+			}
+		}
+	case *parse.ActionNode:
+		return validateTemplateFields(n.Pipe)
+	case *parse.PipeNode:
+		if n == nil {
+			return nil
+		}
+		for _, cmd := range n.Cmds {
+			if err := validateTemplateFields(cmd); err != nil {
+				return err
+			}
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			if err := validateTemplateFields(arg); err != nil {
+				return err
+			}
+		}
+	case *parse.ChainNode:
+		return validateTemplateFields(n.Node)
+	case *parse.IfNode:
+		return validateBranchFields(&n.BranchNode)
+	case *parse.RangeNode:
+		return validateBranchFields(&n.BranchNode)
+	case *parse.WithNode:
+		return validateBranchFields(&n.BranchNode)
+	case *parse.TemplateNode:
+		return validateTemplateFields(n.Pipe)
+	}
+
+	return nil
+}
+
+func validateBranchFields(n *parse.BranchNode) error {
+	for _, child := range []parse.Node{n.Pipe, n.List, n.ElseList} {
+		if err := validateTemplateFields(child); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *templateNamingPolicy) PhysicalName(vName, vNamespace string) string {
+	name, err := t.execute(vName, vNamespace)
+	if err != nil {
+		// fallback to the hashed name, the template was already validated during start
+		return SingleNamespacePhysicalName(vName, vNamespace, VClusterName)
+	}
+
+	return name
+}
+
+func (t *templateNamingPolicy) execute(vName, vNamespace string) (string, error) {
+	buf := &bytes.Buffer{}
+	err := t.template.Execute(buf, templateNamingData{
+		Name:         vName,
+		Namespace:    vNamespace,
+		VClusterName: VClusterName,
+	})
+	if err != nil {
+		return "", fmt.Errorf("execute naming template: %w", err)
+	}
+
+	name := strings.ToLower(strings.TrimSpace(buf.String()))
+	if name == "" {
+		return "", fmt.Errorf("naming template produced an empty name")
+	}
+
+	return SafeConcatName(name), nil
+}
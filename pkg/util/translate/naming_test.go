@@ -0,0 +1,116 @@
+package translate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/vcluster/config"
+	"gotest.tools/v3/assert"
+)
+
+func TestNamingPolicy(t *testing.T) {
+	defer func(vClusterName string) {
+		VClusterName = vClusterName
+	}(VClusterName)
+	VClusterName = "my-vcluster"
+
+	testCases := []struct {
+		name      string
+		naming    config.ExperimentalSyncSettingsNaming
+		vName     string
+		vNs       string
+		expected  string
+		expectErr string
+	}{
+		{
+			name:     "default",
+			vName:    "test",
+			vNs:      "default",
+			expected: "test-x-default-x-my-vcluster",
+		},
+		{
+			name:     "hashed",
+			naming:   config.ExperimentalSyncSettingsNaming{Policy: config.NamingPolicyHashed},
+			vName:    "test",
+			vNs:      "default",
+			expected: "test-x-default-x-my-vcluster",
+		},
+		{
+			name:     "prefix",
+			naming:   config.ExperimentalSyncSettingsNaming{Policy: config.NamingPolicyPrefix},
+			vName:    "test",
+			vNs:      "default",
+			expected: "my-vcluster-test",
+		},
+		{
+			name: "template with unknown function",
+			naming: config.ExperimentalSyncSettingsNaming{
+				Policy:   config.NamingPolicyTemplate,
+				Template: `{{ .Namespace | default "shared" }}-{{ .Name }}`,
+			},
+			expectErr: `function "default" not defined`,
+		},
+		{
+			name: "template",
+			naming: config.ExperimentalSyncSettingsNaming{
+				Policy:   config.NamingPolicyTemplate,
+				Template: `{{ .VClusterName }}-{{ if eq .Namespace "default" }}shared{{ else }}{{ .Namespace }}{{ end }}-{{ .Name }}`,
+			},
+			vName:    "Test",
+			vNs:      "team-a",
+			expected: "my-vcluster-team-a-test",
+		},
+		{
+			name: "template with namespace labels",
+			naming: config.ExperimentalSyncSettingsNaming{
+				Policy:   config.NamingPolicyTemplate,
+				Template: `{{ .VClusterName }}-{{ index .NamespaceLabels "team" }}-{{ .Name }}`,
+			},
+			expectErr: "naming template uses unsupported field .NamespaceLabels",
+		},
+		{
+			name: "template with labels in a branch",
+			naming: config.ExperimentalSyncSettingsNaming{
+				Policy:   config.NamingPolicyTemplate,
+				Template: `{{ if eq .Namespace "team-a" }}{{ .Labels.team }}{{ else }}{{ .Namespace }}{{ end }}-{{ .Name }}`,
+			},
+			expectErr: "Labels are not supported",
+		},
+		{
+			name: "template with long name",
+			naming: config.ExperimentalSyncSettingsNaming{
+				Policy:   config.NamingPolicyTemplate,
+				Template: `{{ .Namespace }}-{{ .Name }}`,
+			},
+			vName:    strings.Repeat("a", 63),
+			vNs:      "default",
+			expected: SafeConcatName("default", strings.Repeat("a", 63)),
+		},
+		{
+			name: "template producing invalid names",
+			naming: config.ExperimentalSyncSettingsNaming{
+				Policy:   config.NamingPolicyTemplate,
+				Template: `{{ .Namespace }}_{{ .Name }}`,
+			},
+			expectErr: "naming template produces invalid name",
+		},
+		{
+			name:      "unknown policy",
+			naming:    config.ExperimentalSyncSettingsNaming{Policy: "unknown"},
+			expectErr: `unsupported naming policy "unknown"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		namingPolicy, err := NewNamingPolicy(testCase.naming)
+		if testCase.expectErr != "" {
+			assert.ErrorContains(t, err, testCase.expectErr, testCase.name)
+			continue
+		}
+		assert.NilError(t, err, testCase.name)
+
+		translator := NewSingleNamespaceTranslatorWithNamingPolicy("host", namingPolicy)
+		assert.Equal(t, translator.PhysicalName(testCase.vName, testCase.vNs), testCase.expected, testCase.name)
+		assert.Equal(t, translator.PhysicalName("", testCase.vNs), "", testCase.name)
+	}
+}
@@ -22,13 +22,21 @@ import (
 var _ Translator = &singleNamespace{}
 
 func NewSingleNamespaceTranslator(targetNamespace string) Translator {
+	return NewSingleNamespaceTranslatorWithNamingPolicy(targetNamespace, &hashedNamingPolicy{})
+}
+
+// NewSingleNamespaceTranslatorWithNamingPolicy creates a new single namespace translator that uses the given naming policy
+// to translate the names of namespaced objects
+func NewSingleNamespaceTranslatorWithNamingPolicy(targetNamespace string, namingPolicy NamingPolicy) Translator {
 	return &singleNamespace{
 		targetNamespace: targetNamespace,
+		namingPolicy:    namingPolicy,
 	}
 }
 
 type singleNamespace struct {
 	targetNamespace string
+	namingPolicy    NamingPolicy
 }
 
 func (s *singleNamespace) SingleNamespaceTarget() bool {
@@ -37,7 +45,13 @@ func (s *singleNamespace) SingleNamespaceTarget() bool {
 
 // PhysicalName returns the physical name of the name / namespace resource
 func (s *singleNamespace) PhysicalName(name, namespace string) string {
-	return SingleNamespacePhysicalName(name, namespace, VClusterName)
+	if name == "" {
+		return ""
+	} else if s.namingPolicy == nil {
+		return SingleNamespacePhysicalName(name, namespace, VClusterName)
+	}
+
+	return s.namingPolicy.PhysicalName(name, namespace)
 }
 
 // PhysicalNameShort returns the short physical name of the name / namespace resource
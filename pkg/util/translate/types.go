@@ -13,6 +13,9 @@ var (
 	NameAnnotation      = "vcluster.loft.sh/object-name"
 	UIDAnnotation       = "vcluster.loft.sh/object-uid"
 	KindAnnotation      = "vcluster.loft.sh/object-kind"
+
+	HostNamespaceAnnotation = "vcluster.loft.sh/host-namespace"
+	HostNameAnnotation      = "vcluster.loft.sh/host-name"
 )
 
 var Default Translator = &singleNamespace{}